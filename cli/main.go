@@ -10,6 +10,7 @@ import (
 	_ "github.com/33cn/chain33/system"
 	"github.com/33cn/plugin/cli/buildflags"
 	_ "github.com/33cn/plugin/plugin"
+	_ "github.com/33cn/plugin/plugin/store/bench" //store 性能测试只在命令行中注册
 
 	"github.com/33cn/chain33/util/cli"
 )
@@ -0,0 +1,26 @@
+// Package rpcplugin 注册没有执行器的插件, 插件只提供 rpc 接口和命令行
+package rpcplugin
+
+import (
+	"github.com/33cn/chain33/pluginmgr"
+	rpctypes "github.com/33cn/chain33/rpc/types"
+	"github.com/33cn/chain33/types"
+	wcom "github.com/33cn/chain33/wallet/common"
+	"github.com/spf13/cobra"
+)
+
+// Register 注册只有 rpc 接口和命令行的插件, rpc 和 cmd 可以为空。
+// 插件必须有执行器名称, 这里用系统的 none 执行器, 执行器升级和别名检查的时候把它当作已经存在的执行器
+func Register(name string, rpc func(s rpctypes.RPCServer), cmd func() *cobra.Command) {
+	p := &pluginmgr.PluginBase{
+		Name:     name,
+		ExecName: "none",
+		Exec:     func(name string, cfg *types.Chain33Config, sub []byte) {},
+		Wallet:   func(walletBiz wcom.WalletOperate, sub []byte) {},
+		Cmd:      cmd,
+	}
+	if rpc != nil {
+		p.RPC = func(name string, s rpctypes.RPCServer) { rpc(s) }
+	}
+	pluginmgr.Register(p)
+}
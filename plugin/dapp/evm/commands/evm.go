@@ -47,6 +47,7 @@ func EvmCmd() *cobra.Command {
 		estimateContractCmd(),
 		checkContractAddrCmd(),
 		evmDebugCmd(),
+		traceTxCmd(),
 		evmTransferCmd(),
 		evmWithdrawCmd(),
 		getEvmBalanceCmd(),
@@ -624,6 +625,38 @@ func checkContractAddr(cmd *cobra.Command, args []string) {
 	}
 }
 
+// 重放交易并输出调用树或交易执行前的账户状态
+func traceTxCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trace",
+		Short: "Replay a transaction and show its call tree or prestate",
+		Run:   traceTx,
+	}
+	cmd.Flags().StringP("hash", "s", "", "transaction hash")
+	cmd.MarkFlagRequired("hash")
+	cmd.Flags().StringP("tracer", "t", evmtypes.CallTracer, "tracer type, callTracer or prestateTracer")
+	return cmd
+}
+
+func traceTx(cmd *cobra.Command, args []string) {
+	hash, _ := cmd.Flags().GetString("hash")
+	tracer, _ := cmd.Flags().GetString("tracer")
+
+	var req = evmtypes.EvmTraceTxReq{Hash: hash, Tracer: tracer}
+	var resp evmtypes.EvmTraceTxResp
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	query := sendQuery(rpcLaddr, "TraceTx", &req, &resp)
+
+	if query {
+		data, err := json.MarshalIndent(&resp, "", "  ")
+		if err != nil {
+			fmt.Println(resp.String())
+		} else {
+			fmt.Println(string(data))
+		}
+	}
+}
+
 // 查询或设置EVM调试开关
 func evmDebugCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -0,0 +1,131 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+以太坊格式签名交易的验签实现
+
+签名数据为RLP编码的以太坊签名交易，公钥为从签名中恢复出的压缩格式公钥，
+验签时同时检查chain33交易内容与以太坊交易一致。此签名只能用于验证，不能用于生成签名。
+*/
+
+package crypto
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/types"
+	evmtypes "github.com/33cn/plugin/plugin/dapp/evm/types"
+)
+
+const pubKeyLength = 33
+
+func init() {
+	crypto.Register(evmtypes.SignNameEthSecp256k1, &Driver{}, false)
+}
+
+// Driver 以太坊签名驱动
+type Driver struct{}
+
+// GenKey 不支持生成私钥，以太坊交易需要在外部钱包中签名
+func (d Driver) GenKey() (crypto.PrivKey, error) {
+	return nil, types.ErrNotSupport
+}
+
+// PrivKeyFromBytes 不支持导入私钥
+func (d Driver) PrivKeyFromBytes(b []byte) (crypto.PrivKey, error) {
+	return nil, types.ErrNotSupport
+}
+
+// PubKeyFromBytes create public key from bytes
+func (d Driver) PubKeyFromBytes(b []byte) (crypto.PubKey, error) {
+	if len(b) != pubKeyLength {
+		return nil, errors.New("invalid pub key byte")
+	}
+	pubKeyBytes := new([pubKeyLength]byte)
+	copy(pubKeyBytes[:], b)
+	return PubKeyEth(*pubKeyBytes), nil
+}
+
+// SignatureFromBytes create signature from bytes
+func (d Driver) SignatureFromBytes(b []byte) (crypto.Signature, error) {
+	if len(b) == 0 {
+		return nil, errors.New("invalid signature byte")
+	}
+	return SignatureEth(b), nil
+}
+
+// PubKeyEth 压缩格式的secp256k1公钥
+type PubKeyEth [pubKeyLength]byte
+
+// Bytes convert to bytes
+func (pubKey PubKeyEth) Bytes() []byte {
+	s := make([]byte, pubKeyLength)
+	copy(s, pubKey[:])
+	return s
+}
+
+// VerifyBytes 校验以太坊交易的签名以及msg（编码后的chain33交易）与以太坊交易的一致性
+func (pubKey PubKeyEth) VerifyBytes(msg []byte, sig crypto.Signature) bool {
+	sigEth, ok := sig.(SignatureEth)
+	if !ok {
+		return false
+	}
+	var tx types.Transaction
+	if err := types.Decode(msg, &tx); err != nil {
+		return false
+	}
+	pub, err := evmtypes.VerifyEthTx(&tx, sigEth)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(pub, pubKey[:])
+}
+
+// String convert to string
+func (pubKey PubKeyEth) String() string {
+	return fmt.Sprintf("PubKeyEth{%X}", pubKey[:])
+}
+
+// KeyString Must return the full bytes in hex.
+// Used for map keying, etc.
+func (pubKey PubKeyEth) KeyString() string {
+	return fmt.Sprintf("%X", pubKey[:])
+}
+
+// Equals check public key is equal
+func (pubKey PubKeyEth) Equals(other crypto.PubKey) bool {
+	if otherEth, ok := other.(PubKeyEth); ok {
+		return bytes.Equal(pubKey[:], otherEth[:])
+	}
+	return false
+}
+
+// SignatureEth RLP编码的以太坊签名交易
+type SignatureEth []byte
+
+// Bytes convert signature to bytes
+func (sig SignatureEth) Bytes() []byte {
+	s := make([]byte, len(sig))
+	copy(s, sig)
+	return s
+}
+
+// IsZero check signature is zero
+func (sig SignatureEth) IsZero() bool { return len(sig) == 0 }
+
+// String convert signature to string
+func (sig SignatureEth) String() string {
+	return fmt.Sprintf("SignatureEth{%X}", sig.Bytes())
+}
+
+// Equals check signature equals
+func (sig SignatureEth) Equals(other crypto.Signature) bool {
+	if otherEth, ok := other.(SignatureEth); ok {
+		return bytes.Equal(sig, otherEth)
+	}
+	return false
+}
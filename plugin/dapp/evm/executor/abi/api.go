@@ -112,6 +112,52 @@ func Unpack(data []byte, methodName, abiData string) (output string, err error)
 	return string(jsondata), err
 }
 
+// UnpackError 按照ABI中定义的自定义错误解析合约revert时返回的数据
+// data 合约revert时的返回数据
+// abiData 完整的ABI定义
+// 返回格式： Name(param1: value1, param2: value2)
+func UnpackError(data []byte, abiData string) (string, error) {
+	if len(data) < 4 {
+		return "", errors.New("invalid data for unpacking")
+	}
+	abi, err := JSON(strings.NewReader(abiData))
+	if err != nil {
+		return "", err
+	}
+	var id [4]byte
+	copy(id[:], data[:4])
+	errABI, err := abi.ErrorByID(id)
+	if err != nil {
+		return "", err
+	}
+	values, err := errABI.Unpack(data)
+	if err != nil {
+		return "", err
+	}
+	params := make([]string, len(values))
+	for i, v := range values {
+		params[i] = fmt.Sprintf("%s: %s", errABI.Inputs[i].Name, formatErrorValue(v))
+	}
+	return fmt.Sprintf("%s(%s)", errABI.Name, strings.Join(params, ", ")), nil
+}
+
+// 字节类型的参数以十六进制显示，其它类型使用默认格式
+func formatErrorValue(v interface{}) string {
+	val := reflect.ValueOf(v)
+	switch {
+	case val.Kind() == reflect.Slice && val.Type().Elem().Kind() == reflect.Uint8:
+		return common.Bytes2Hex(val.Bytes())
+	case val.Kind() == reflect.Array && val.Type().Elem().Kind() == reflect.Uint8:
+		if _, ok := v.(fmt.Stringer); ok {
+			return fmt.Sprintf("%v", v)
+		}
+		b := make([]byte, val.Len())
+		reflect.Copy(reflect.ValueOf(b), val)
+		return common.Bytes2Hex(b)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
 // Param 返回值参数结构定义
 type Param struct {
 	// Name 参数名称
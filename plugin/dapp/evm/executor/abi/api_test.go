@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/common"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/common/crypto"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -81,6 +82,33 @@ func TestABI_Unpack(t *testing.T) {
 	}
 }
 
+func TestABI_UnpackError(t *testing.T) {
+	abiData := `[{"inputs":[{"name":"allowance","type":"uint256"},{"name":"needed","type":"uint256"}],"name":"InsufficientAllowance","type":"error"},{"inputs":[{"name":"","type":"bytes4"}],"name":"Unsupported","type":"error"}]`
+
+	for _, test := range []struct {
+		input  string
+		output string
+	}{
+		{
+			"0x" + common.Bytes2Hex(crypto.Keccak256([]byte("InsufficientAllowance(uint256,uint256)"))[:4])[2:] +
+				"0000000000000000000000000000000000000000000000000000000000000005000000000000000000000000000000000000000000000000000000000000000a",
+			"InsufficientAllowance(allowance: 5, needed: 10)",
+		},
+		{
+			"0x" + common.Bytes2Hex(crypto.Keccak256([]byte("Unsupported(bytes4)"))[:4])[2:] +
+				"1234567800000000000000000000000000000000000000000000000000000000",
+			"Unsupported(arg0: 0x12345678)",
+		},
+	} {
+		reason, err := UnpackError(common.FromHex(test.input), abiData)
+		assert.NoError(t, err)
+		assert.Equal(t, test.output, reason)
+	}
+
+	_, err := UnpackError(common.FromHex("0x08c379a0"), abiData)
+	assert.Error(t, err)
+}
+
 func TestProcFuncCall(t *testing.T) {
 	for _, test := range []struct {
 		input    string
@@ -143,6 +143,13 @@ func (evm *EVMExecutor) getNewAddr(txHash []byte) common.Address {
 
 // CheckTx 校验交易
 func (evm *EVMExecutor) CheckTx(tx *types.Transaction, index int) error {
+	// 以太坊格式的交易只能执行一次
+	if tx.GetSignature().GetTy() == evmtypes.EthSecp256k1 {
+		_, err := evm.GetStateDB().Get(getEthTxKey(tx.GetSignature().GetSignature()))
+		if err == nil {
+			return evmtypes.ErrEthTxDup
+		}
+	}
 	return nil
 }
 
@@ -178,5 +185,8 @@ func (evm *EVMExecutor) NewEVMContext(msg *common.Message) runtime.Context {
 		Difficulty:  new(big.Int).SetUint64(evm.GetDifficulty()),
 		GasLimit:    msg.GasLimit(),
 		GasPrice:    msg.GasPrice(),
+		ChainID:     big.NewInt(int64(evm.GetAPI().GetConfig().GetChainID())),
+		// chain33中没有EIP-1559的动态基础费用，使用最低gasPrice作为基础费用
+		BaseFee: big.NewInt(1),
 	}
 }
@@ -28,7 +28,18 @@ func (evm *EVMExecutor) Exec(tx *types.Transaction, index int) (*types.Receipt,
 		return nil, err
 	}
 
-	return evm.innerExec(msg, tx.Hash(), index, evm.GetTxFee(tx, index), false)
+	receipt, err := evm.innerExec(msg, tx.Hash(), index, evm.GetTxFee(tx, index), false)
+	if err != nil {
+		return nil, err
+	}
+	// 记录已经执行的以太坊交易，CheckTx中拒绝再次执行
+	if tx.GetSignature().GetTy() == evmtypes.EthSecp256k1 {
+		if receipt == nil {
+			receipt = &types.Receipt{Ty: types.ExecOk}
+		}
+		receipt.KV = append(receipt.KV, &types.KeyValue{Key: getEthTxKey(tx.GetSignature().GetSignature()), Value: tx.Hash()})
+	}
+	return receipt, nil
 }
 
 // 通用的EVM合约执行逻辑封装
@@ -65,21 +76,34 @@ func (evm *EVMExecutor) innerExec(msg *common.Message, txHash []byte, index int,
 
 	// 状态机中设置当前交易状态
 	evm.mStateDB.Prepare(common.BytesToHash(txHash), index)
+	// 初始化当前交易的访问列表，伦敦分叉之后生效
+	env.PrepareAccessList(msg.From(), &contractAddr)
 
 	if isCreate {
 		// 如果携带ABI数据，则对数据合法性进行检查
 		if len(msg.ABI()) > 0 && cfg.IsDappFork(evm.GetHeight(), "evm", evmtypes.ForkEVMABI) {
-			_, err = abi.JSON(strings.NewReader(msg.ABI()))
+			contractABI, err := abi.JSON(strings.NewReader(msg.ABI()))
 			if err != nil {
 				return receipt, err
 			}
+			// 分叉之前不支持error类型的定义，保持与之前相同的执行结果
+			if len(contractABI.Errors) > 0 && !cfg.IsDappFork(evm.GetHeight(), "evm", evmtypes.ForkEVMABIError) {
+				return receipt, model.ErrABIErrorType
+			}
 		}
 		ret, snapshot, leftOverGas, vmerr = env.Create(runtime.AccountRef(msg.From()), contractAddr, msg.Data(), context.GasLimit, execName, msg.Alias(), msg.ABI())
 	} else {
 		inData := msg.Data()
 		// 在这里进行ABI和十六进制的调用参数转换
 		if len(msg.ABI()) > 0 && cfg.IsDappFork(evm.GetHeight(), "evm", evmtypes.ForkEVMABI) {
-			funcName, packData, err := abi.Pack(msg.ABI(), evm.mStateDB.GetAbi(msg.To().String()), readOnly)
+			abiData := evm.mStateDB.GetAbi(msg.To().String())
+			if !cfg.IsDappFork(evm.GetHeight(), "evm", evmtypes.ForkEVMABIError) {
+				contractABI, err := abi.JSON(strings.NewReader(abiData))
+				if err == nil && len(contractABI.Errors) > 0 {
+					return receipt, model.ErrABIErrorType
+				}
+			}
+			funcName, packData, err := abi.Pack(msg.ABI(), abiData, readOnly)
 			if err != nil {
 				return receipt, err
 			}
@@ -99,6 +123,16 @@ func (evm *EVMExecutor) innerExec(msg *common.Message, txHash []byte, index int,
 	curVer := evm.mStateDB.GetLastSnapshot()
 	if vmerr != nil {
 		log.Error("evm contract exec error", "error info", vmerr)
+		if vmerr == model.ErrExecutionReverted {
+			// 创建合约时ABI还未保存，直接使用交易中携带的ABI
+			abiData := msg.ABI()
+			if !isCreate {
+				abiData = evm.mStateDB.GetAbi(contractAddr.String())
+			}
+			if reason, ok := revertReason(ret, abiData); ok {
+				return receipt, model.NewRevertError(reason, ret)
+			}
+		}
 		return receipt, vmerr
 	}
 
@@ -135,6 +169,9 @@ func (evm *EVMExecutor) innerExec(msg *common.Message, txHash []byte, index int,
 	}
 	logs = append(logs, &types.ReceiptLog{Ty: evmtypes.TyLogCallContract, Log: types.Encode(contractReceipt)})
 	logs = append(logs, evm.mStateDB.GetReceiptLogs(contractAddr.String())...)
+	if cfg.IsDappFork(evm.GetHeight(), "evm", evmtypes.ForkEVMEventLog) {
+		logs = append(logs, evm.mStateDB.GetEventLogs()...)
+	}
 
 	if cfg.IsDappFork(evm.GetHeight(), "evm", evmtypes.ForkEVMKVHash) {
 		// 将执行时生成的合约状态数据变更信息也计算哈希并保存
@@ -159,6 +196,19 @@ func (evm *EVMExecutor) innerExec(msg *common.Message, txHash []byte, index int,
 	return receipt, nil
 }
 
+// 解析合约revert时的返回数据，优先解析Error(string)和Panic(uint256)，其次按照合约ABI中定义的自定义错误解析
+func revertReason(ret []byte, abiData string) (string, bool) {
+	if reason, ok := runtime.UnpackRevert(ret); ok {
+		return reason, true
+	}
+	if len(abiData) > 0 {
+		if reason, err := abi.UnpackError(ret, abiData); err == nil {
+			return reason, true
+		}
+	}
+	return "", false
+}
+
 // CheckInit 检查是否初始化数据库
 func (evm *EVMExecutor) CheckInit() {
 	if evm.mStateDB == nil {
@@ -246,8 +296,18 @@ func getDataHashKey(addr common.Address) []byte {
 	return []byte(fmt.Sprintf("mavl-%v-data-hash:%v", evmtypes.ExecutorName, addr))
 }
 
-// 从交易信息中获取交易发起人地址
+// 以太坊交易哈希到chain33交易哈希的映射
+func getEthTxKey(raw []byte) []byte {
+	return []byte(fmt.Sprintf("mavl-%v-ethtx:%v", evmtypes.ExecutorName, common.Bytes2Hex(evmtypes.EthTxHash(raw))))
+}
+
+// 从交易信息中获取交易发起人地址，以太坊格式的交易使用以太坊方式从公钥生成的地址，与eth_*接口中的地址一致
 func getCaller(tx *types.Transaction) common.Address {
+	if tx.GetSignature().GetTy() == evmtypes.EthSecp256k1 {
+		if addr, err := evmtypes.EthAddress(tx.GetSignature().GetPubkey()); err == nil {
+			return common.BytesToAddress(addr)
+		}
+	}
 	return *common.StringToAddress(tx.From())
 }
 
@@ -19,7 +19,7 @@ func (evm *EVMExecutor) ExecDelLocal(tx *types.Transaction, receipt *types.Recei
 		return set, nil
 	}
 	cfg := evm.GetAPI().GetConfig()
-	if cfg.IsDappFork(evm.GetHeight(), "evm", evmtypes.ForkEVMState) {
+	if cfg.IsDappFork(evm.GetHeight(), "evm", evmtypes.ForkEVMState) || cfg.IsDappFork(evm.GetHeight(), "evm", evmtypes.ForkEVMEventLog) {
 		kvs, err := evm.DelRollbackKV(tx, []byte(evmtypes.ExecutorName))
 		if err != nil {
 			return nil, err
@@ -43,6 +43,14 @@ func (evm *EVMExecutor) ExecLocal(tx *types.Transaction, receipt *types.ReceiptD
 			}
 		}
 	}
+	if cfg.IsDappFork(evm.GetHeight(), "evm", evmtypes.ForkEVMEventLog) {
+		// 为合约生成的事件日志建立按地址和主题查询的索引
+		kvs, err := evm.getLogIndexKVs(tx, receipt, index)
+		if err != nil {
+			return set, err
+		}
+		set.KV = append(set.KV, kvs...)
+	}
 	set.KV = evm.AddRollbackKV(tx, []byte(evmtypes.ExecutorName), set.KV)
 	return set, err
 }
@@ -0,0 +1,247 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/common"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/model"
+	evmtypes "github.com/33cn/plugin/plugin/dapp/evm/types"
+)
+
+// 事件日志在localdb中的索引
+// 日志数据：  LODB-evm-log:{height}:{txIndex}:{logIndex} -> EvmLogItem
+// 地址索引：  LODB-evm-logaddr:{address}:{height}:{txIndex}:{logIndex} -> 日志位置
+// 主题索引：  LODB-evm-logtopic{n}:{topic}:{height}:{txIndex}:{logIndex} -> 日志位置
+const (
+	logKeyPrefix      = "LODB-evm-log:"
+	logAddrKeyPrefix  = "LODB-evm-logaddr:"
+	logTopicKeyPrefix = "LODB-evm-logtopic"
+
+	// 日志位置中高度部分的长度
+	logHeightLength = 12
+	// 单次查询最多返回的日志数量，也是从索引中最多读取的日志位置数量
+	maxGetLogsCount = 10000
+	// 每次从localdb中读取的索引数量
+	logsListCount = 100
+	// 最多索引的主题数量，对应LOG4指令
+	maxLogTopics = 4
+)
+
+// 日志位置，按区块高度、交易序号、日志序号排序
+func logPosition(height, txIndex int64, logIndex int32) string {
+	return fmt.Sprintf("%0*d:%05d:%05d", logHeightLength, height, txIndex, logIndex)
+}
+
+func calcLogKey(pos string) []byte {
+	return []byte(logKeyPrefix + pos)
+}
+
+func calcLogAddrPrefix(addr string) string {
+	return logAddrKeyPrefix + addr + ":"
+}
+
+func calcLogTopicPrefix(n int, topic common.Hash) string {
+	return fmt.Sprintf("%s%d:%s:", logTopicKeyPrefix, n, topic.Hex())
+}
+
+// 根据交易回执中的事件日志生成索引
+func (evm *EVMExecutor) getLogIndexKVs(tx *types.Transaction, receipt *types.ReceiptData, index int) (kvs []*types.KeyValue, err error) {
+	var logIndex int32
+	for _, item := range receipt.Logs {
+		if item.Ty != evmtypes.TyLogEVMEventData {
+			continue
+		}
+		var evmLog evmtypes.EVMLog
+		err = types.Decode(item.Log, &evmLog)
+		if err != nil {
+			return nil, err
+		}
+		logItem := &evmtypes.EvmLogItem{
+			Address:  evmLog.Address,
+			Topics:   evmLog.Topics,
+			Data:     evmLog.Data,
+			Height:   evm.GetHeight(),
+			TxIndex:  int64(index),
+			LogIndex: logIndex,
+			TxHash:   tx.Hash(),
+		}
+		pos := logPosition(logItem.Height, logItem.TxIndex, logItem.LogIndex)
+		kvs = append(kvs, &types.KeyValue{Key: calcLogKey(pos), Value: types.Encode(logItem)})
+		kvs = append(kvs, &types.KeyValue{Key: []byte(calcLogAddrPrefix(evmLog.Address) + pos), Value: []byte(pos)})
+		for n, topic := range evmLog.Topics {
+			if n >= maxLogTopics {
+				break
+			}
+			kvs = append(kvs, &types.KeyValue{Key: []byte(calcLogTopicPrefix(n, common.BytesToHash(topic)) + pos), Value: []byte(pos)})
+		}
+		logIndex++
+	}
+	return kvs, nil
+}
+
+// 按照eth_getLogs的规则查询事件日志
+// 合约地址之间为或的关系，不同位置的主题之间为与的关系，同一位置的主题之间为或的关系
+func (evm *EVMExecutor) getLogs(in *evmtypes.EvmGetLogsReq) (*evmtypes.EvmGetLogsResp, error) {
+	if in.FromBlock < 0 || (in.ToBlock >= 0 && in.ToBlock < in.FromBlock) || len(in.Topics) > maxLogTopics {
+		return nil, types.ErrInvalidParam
+	}
+	topics := make([][]common.Hash, len(in.Topics))
+	for n, item := range in.Topics {
+		for _, topic := range item.Topics {
+			b, err := common.HexToBytes(topic)
+			if err != nil || len(b) != common.HashLength {
+				return nil, types.ErrInvalidParam
+			}
+			topics[n] = append(topics[n], common.BytesToHash(b))
+		}
+	}
+
+	// 优先使用地址索引，其次使用第一个指定了主题的位置的索引，都未指定时遍历全部日志
+	var prefixes []string
+	if len(in.Addresses) > 0 {
+		for _, addr := range in.Addresses {
+			if common.StringToAddress(addr) == nil {
+				return nil, types.ErrInvalidAddress
+			}
+			prefixes = append(prefixes, calcLogAddrPrefix(addr))
+		}
+	} else {
+		for n, hashes := range topics {
+			if len(hashes) == 0 {
+				continue
+			}
+			for _, hash := range hashes {
+				prefixes = append(prefixes, calcLogTopicPrefix(n, hash))
+			}
+			break
+		}
+	}
+	localdb := evm.GetLocalDB()
+	var positions []string
+	if len(prefixes) == 0 {
+		prefixes = append(prefixes, logKeyPrefix)
+	}
+	// 索引中符合条件的位置超过上限时直接返回错误，不把整个区块范围的位置都读到内存中再排序
+	for _, prefix := range prefixes {
+		list, err := listLogPositions(localdb, prefix, in.FromBlock, in.ToBlock, maxGetLogsCount-len(positions))
+		if err != nil {
+			return nil, err
+		}
+		positions = append(positions, list...)
+	}
+	sort.Strings(positions)
+
+	resp := &evmtypes.EvmGetLogsResp{}
+	for _, pos := range positions {
+		data, err := localdb.Get(calcLogKey(pos))
+		if err != nil {
+			return nil, err
+		}
+		var item evmtypes.EvmLogItem
+		err = types.Decode(data, &item)
+		if err != nil {
+			return nil, err
+		}
+		if !matchLog(&item, in.Addresses, topics) {
+			continue
+		}
+		if len(resp.Logs) >= maxGetLogsCount {
+			return nil, model.ErrTooManyLogs
+		}
+		resp.Logs = append(resp.Logs, &item)
+	}
+	return resp, nil
+}
+
+// 列出前缀下指定区块范围内的日志位置，toBlock小于0时不限制结束高度，位置数量超过limit时返回ErrTooManyLogs
+func listLogPositions(localdb dbm.KVDB, prefix string, fromBlock, toBlock int64, limit int) ([]string, error) {
+	var positions []string
+	// 找到起始高度之前的最后一个key，从它之后开始升序遍历
+	start := []byte(prefix + fmt.Sprintf("%0*d", logHeightLength, fromBlock))
+	values, err := localdb.List([]byte(prefix), start, 1, dbm.ListSeek)
+	if err != nil && err != types.ErrNotFound {
+		return nil, err
+	}
+	start = nil
+	if len(values) == 2 {
+		start = values[0]
+	}
+	for {
+		values, err = localdb.List([]byte(prefix), start, logsListCount, dbm.ListASC|dbm.ListWithKey)
+		if err == types.ErrNotFound {
+			return positions, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, value := range values {
+			var kv types.KeyValue
+			err = types.Decode(value, &kv)
+			if err != nil {
+				return nil, err
+			}
+			pos := string(kv.Key[len(prefix):])
+			height, err := strconv.ParseInt(pos[:logHeightLength], 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			if toBlock >= 0 && height > toBlock {
+				return positions, nil
+			}
+			start = kv.Key
+			if height < fromBlock {
+				continue
+			}
+			if len(positions) >= limit {
+				return nil, model.ErrTooManyLogs
+			}
+			positions = append(positions, pos)
+		}
+		if len(values) < logsListCount {
+			return positions, nil
+		}
+	}
+}
+
+func matchLog(item *evmtypes.EvmLogItem, addresses []string, topics [][]common.Hash) bool {
+	if len(addresses) > 0 {
+		found := false
+		for _, addr := range addresses {
+			if addr == item.Address {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(topics) > len(item.Topics) {
+		return false
+	}
+	for n, hashes := range topics {
+		if len(hashes) == 0 {
+			continue
+		}
+		topic := common.BytesToHash(item.Topics[n])
+		found := false
+		for _, hash := range hashes {
+			if hash == topic {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/33cn/chain33/types"
 	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/common"
 	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/model"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/runtime"
 	evmtypes "github.com/33cn/plugin/plugin/dapp/evm/types"
 )
 
@@ -154,7 +155,8 @@ func (evm *EVMExecutor) Query_Query(in *evmtypes.EvmQueryReq) (types.Message, er
 		caller = common.ExecAddress(cfg.ExecName(evmtypes.ExecutorName))
 	}
 
-	msg := common.NewMessage(caller, common.StringToAddress(in.Address), 0, 0, evmtypes.MaxGasLimit, 1, nil, "estimateGas", in.Input)
+	// 未指定ABI调用参数时，直接使用原始调用数据
+	msg := common.NewMessage(caller, common.StringToAddress(in.Address), 0, 0, evmtypes.MaxGasLimit, 1, in.Data, "estimateGas", in.Input)
 	txHash := common.BigToHash(big.NewInt(evmtypes.MaxGasLimit)).Bytes()
 
 	receipt, err := evm.innerExec(msg, txHash, 1, evmtypes.MaxGasLimit, true)
@@ -186,3 +188,95 @@ func (evm *EVMExecutor) Query_QueryABI(in *evmtypes.EvmQueryAbiReq) (types.Messa
 
 	return &evmtypes.EvmQueryAbiResp{Address: in.GetAddress(), Abi: abiData}, nil
 }
+
+// Query_GetCode 查询合约账户的代码，不修改原有执行器的状态数据
+func (evm *EVMExecutor) Query_GetCode(in *evmtypes.EvmGetCodeReq) (types.Message, error) {
+	evm.CheckInit()
+
+	addr := common.StringToAddress(in.GetAddr())
+	if addr == nil {
+		return nil, fmt.Errorf("invalid address: %v", in.GetAddr())
+	}
+
+	return &evmtypes.EvmGetCodeResp{Addr: in.GetAddr(), Code: evm.mStateDB.GetCode(addr.String())}, nil
+}
+
+// Query_GetStorageAt 查询合约账户指定位置的存储数据，不修改原有执行器的状态数据
+func (evm *EVMExecutor) Query_GetStorageAt(in *evmtypes.EvmGetStorageAtReq) (types.Message, error) {
+	evm.CheckInit()
+
+	addr := common.StringToAddress(in.GetAddr())
+	if addr == nil {
+		return nil, fmt.Errorf("invalid address: %v", in.GetAddr())
+	}
+	key, err := common.HexToBytes(in.GetKey())
+	if err != nil || len(key) > common.HashLength {
+		return nil, types.ErrInvalidParam
+	}
+
+	value := evm.mStateDB.GetState(addr.String(), common.BytesToHash(key))
+	return &evmtypes.EvmGetStorageAtResp{Addr: in.GetAddr(), Key: in.GetKey(), Value: value.Bytes()}, nil
+}
+
+// Query_GetLogs 按合约地址、主题和区块范围查询本地索引的事件日志
+func (evm *EVMExecutor) Query_GetLogs(in *evmtypes.EvmGetLogsReq) (types.Message, error) {
+	return evm.getLogs(in)
+}
+
+// Query_TraceTx 重放指定的历史交易，返回调用树或交易执行前被访问的账户状态，不修改原有执行器的状态数据
+// 重放使用交易所在区块的父区块状态，并先重放区块中之前的交易；重放结果和交易回执不一致时返回错误
+func (evm *EVMExecutor) Query_TraceTx(in *evmtypes.EvmTraceTxReq) (types.Message, error) {
+	hash, err := common.HexToBytes(in.GetHash())
+	if err != nil {
+		return nil, types.ErrInvalidParam
+	}
+	detail, err := evm.GetAPI().QueryTx(&types.ReqHash{Hash: hash})
+	if err != nil {
+		return nil, err
+	}
+	tx := detail.GetTx()
+	if string(types.GetRealExecName(tx.GetExecer())) != evmtypes.ExecutorName {
+		return nil, types.ErrNotSupport
+	}
+	err = evm.loadTxState(detail.GetHeight(), int(detail.GetIndex()))
+	if err != nil {
+		return nil, err
+	}
+	msg, err := evm.GetMessage(tx, int(detail.GetIndex()))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &evmtypes.EvmTraceTxResp{Hash: in.GetHash(), Tracer: in.GetTracer()}
+	var tracer runtime.Tracer
+	switch in.GetTracer() {
+	case "", evmtypes.CallTracer:
+		resp.Tracer = evmtypes.CallTracer
+		tracer = runtime.NewCallTracer()
+	case evmtypes.PrestateTracer:
+		tracer = runtime.NewPrestateTracer(evm.mStateDB)
+	default:
+		return nil, types.ErrInvalidParam
+	}
+
+	// 临时替换虚拟机配置，使用指定的跟踪器重放交易
+	vmCfg := evm.vmCfg
+	evm.vmCfg = &runtime.Config{Debug: true, Tracer: tracer}
+	defer func() { evm.vmCfg = vmCfg }()
+
+	// 交易执行失败时依然返回跟踪结果
+	receipt, err := evm.innerExec(msg, tx.Hash(), int(detail.GetIndex()), evm.GetTxFee(tx, int(detail.GetIndex())), false)
+	if !sameReceipt(detail.GetReceipt(), receipt, err) {
+		return nil, model.ErrTraceStateChanged
+	}
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	switch t := tracer.(type) {
+	case *runtime.CallTracer:
+		resp.CallTrace = t.Result()
+	case *runtime.PrestateTracer:
+		resp.Prestate = t.Result()
+	}
+	return resp, nil
+}
@@ -0,0 +1,87 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tests
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/common"
+)
+
+// local配置下所有分叉高度为0，伦敦和上海版本指令集生效
+var forkTestCfg = types.NewChain33Config(types.GetDefaultCfgstring())
+
+// CHAINID、BASEFEE、PUSH0指令
+func TestShanghaiOpcodes(t *testing.T) {
+	// CHAINID PUSH0 MSTORE BASEFEE PUSH1 0x20 MSTORE PUSH1 0x40 PUSH0 RETURN
+	deployCode, _ := hex.DecodeString("465f52486020526040" + "5ff3")
+
+	privKey := getPrivKey()
+	gas := uint64(210000)
+	tx := createTx(privKey, deployCode, gas, 0)
+	mdb := buildStateDB(getAddr(privKey).String(), 500000000)
+	ret, _, _, _, err := createContractWithCfg(forkTestCfg, mdb, tx, 0)
+
+	test := NewTester(t)
+	test.assertNil(err)
+	test.assertEqualsV(len(ret), 64)
+	test.assertEqualsV(int(new(big.Int).SetBytes(ret[:32]).Int64()), int(forkTestCfg.GetChainID()))
+	test.assertEqualsV(int(new(big.Int).SetBytes(ret[32:]).Int64()), 1)
+
+	// 分叉之前新指令无效
+	mdb = buildStateDB(getAddr(privKey).String(), 500000000)
+	ret, _, _, _, err = createContract(mdb, tx, 0)
+	test.assertNilB(ret)
+	test.assertNotNil(err)
+}
+
+// 伦敦版本开始EIP-2315的BEGINSUB、RETURNSUB、JUMPSUB指令无效
+func TestLondonSubroutineOpcodes(t *testing.T) {
+	privKey := getPrivKey()
+	gas := uint64(210000)
+	// PUSH1 0x04 JUMPSUB STOP BEGINSUB RETURNSUB
+	deployCode, _ := hex.DecodeString("60045e005c5d")
+	tx := createTx(privKey, deployCode, gas, 0)
+	mdb := buildStateDB(getAddr(privKey).String(), 500000000)
+	_, _, _, _, err := createContractWithCfg(forkTestCfg, mdb, tx, 0)
+
+	test := NewTester(t)
+	test.assertNotNil(err)
+}
+
+// EIP-2929 存储冷热访问计费
+func TestAccessListGas(t *testing.T) {
+	// PUSH0 SLOAD POP PUSH0 SLOAD POP STOP
+	deployCode, _ := hex.DecodeString("5f54505f545000")
+
+	privKey := getPrivKey()
+	gas := uint64(210000)
+	tx := createTx(privKey, deployCode, gas, 0)
+	mdb := buildStateDB(getAddr(privKey).String(), 500000000)
+	_, addr, leftGas, statedb, err := createContractWithCfg(forkTestCfg, mdb, tx, 0)
+
+	test := NewTester(t)
+	test.assertNil(err)
+	// 首次SLOAD为冷访问2100，再次访问为热访问100，其余指令共8
+	test.assertEqualsV(int(gas-leftGas), 2100+100+8)
+
+	_, slotOk := statedb.SlotInAccessList(addr.String(), common.Hash{})
+	if !slotOk {
+		t.Error("slot should be in access list")
+	}
+
+	// 回滚后访问列表中的槽位被删除
+	mdb = buildStateDB(getAddr(privKey).String(), 500000000)
+	tx = createTx(privKey, []byte{0x5f, 0x54, 0xfe}, gas, 0)
+	_, addr, _, statedb, err = createContractWithCfg(forkTestCfg, mdb, tx, 0)
+	test.assertNotNil(err)
+	_, slotOk = statedb.SlotInAccessList(addr.String(), common.Hash{})
+	if slotOk {
+		t.Error("slot should be removed from access list after revert")
+	}
+}
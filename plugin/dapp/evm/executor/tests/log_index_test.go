@@ -0,0 +1,170 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tests
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/33cn/chain33/client"
+	"github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/types"
+	evm "github.com/33cn/plugin/plugin/dapp/evm/executor"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/common"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/model"
+	evmtypes "github.com/33cn/plugin/plugin/dapp/evm/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type logTx struct {
+	height int64
+	tx     *types.Transaction
+	logs   []*evmtypes.EVMLog
+}
+
+func (l *logTx) receipt() *types.ReceiptData {
+	receipt := &types.ReceiptData{Ty: types.ExecOk}
+	for _, log := range l.logs {
+		receipt.Logs = append(receipt.Logs, &types.ReceiptLog{Ty: evmtypes.TyLogEVMEventData, Log: types.Encode(log)})
+	}
+	return receipt
+}
+
+func newLogExecutor(kvdb db.KVDB) *evm.EVMExecutor {
+	inst := evm.NewEVMExecutor()
+	q := queue.New("channel")
+	q.SetConfig(forkTestCfg)
+	api, _ := client.New(q.Client(), nil)
+	inst.SetAPI(api)
+	inst.SetLocalDB(kvdb)
+	return inst
+}
+
+func applyKVs(kvdb db.KVDB, kvs []*types.KeyValue) {
+	for _, kv := range kvs {
+		_ = kvdb.Set(kv.Key, kv.Value)
+	}
+}
+
+func getLogs(t *testing.T, inst *evm.EVMExecutor, req *evmtypes.EvmGetLogsReq) []*evmtypes.EvmLogItem {
+	msg, err := inst.Query_GetLogs(req)
+	require.Nil(t, err)
+	return msg.(*evmtypes.EvmGetLogsResp).Logs
+}
+
+func TestLogIndex(t *testing.T) {
+	mdb, _ := db.NewGoMemDB("test", "", 0)
+	kvdb := db.NewKVDB(mdb)
+	inst := newLogExecutor(kvdb)
+
+	contractA := getAddr(getPrivKey()).String()
+	contractB := getAddr(getPrivKey()).String()
+	transfer := common.BigToHash(big.NewInt(1)).Bytes()
+	other := common.BigToHash(big.NewInt(2)).Bytes()
+	from1 := common.BigToHash(big.NewInt(11)).Bytes()
+	from2 := common.BigToHash(big.NewInt(12)).Bytes()
+	topicHex := func(b []byte) string { return common.BytesToHash(b).Hex() }
+
+	txs := []*logTx{
+		{height: 1, logs: []*evmtypes.EVMLog{{Address: contractA, Topics: [][]byte{transfer, from1}}}},
+		{height: 2, logs: []*evmtypes.EVMLog{{Address: contractB, Topics: [][]byte{transfer}}}},
+		{height: 2, logs: []*evmtypes.EVMLog{{Address: contractA, Topics: [][]byte{other}}}},
+		{height: 3, logs: []*evmtypes.EVMLog{{Address: contractA, Topics: [][]byte{transfer, from2}}, {Address: contractA, Topics: [][]byte{transfer, from1}}}},
+	}
+	index := 0
+	for i, item := range txs {
+		if i > 0 && txs[i-1].height != item.height {
+			index = 0
+		}
+		item.tx = &types.Transaction{Execer: []byte("evm"), Nonce: int64(i)}
+		inst.SetEnv(item.height, 0, 0)
+		set, err := inst.ExecLocal(item.tx, item.receipt(), index)
+		require.Nil(t, err)
+		applyKVs(kvdb, set.KV)
+		index++
+	}
+
+	all := getLogs(t, inst, &evmtypes.EvmGetLogsReq{ToBlock: -1})
+	assert.Equal(t, 5, len(all))
+	assert.Equal(t, int64(3), all[4].Height)
+	assert.Equal(t, int32(1), all[4].LogIndex)
+	assert.Equal(t, txs[3].tx.Hash(), all[4].TxHash)
+
+	// 地址和主题同时过滤
+	logs := getLogs(t, inst, &evmtypes.EvmGetLogsReq{ToBlock: -1, Addresses: []string{contractA},
+		Topics: []*evmtypes.EvmLogTopics{{Topics: []string{topicHex(transfer)}}}})
+	assert.Equal(t, 3, len(logs))
+
+	// 只按主题过滤并限制区块范围
+	logs = getLogs(t, inst, &evmtypes.EvmGetLogsReq{FromBlock: 2, ToBlock: 2,
+		Topics: []*evmtypes.EvmLogTopics{{Topics: []string{topicHex(transfer)}}}})
+	require.Equal(t, 1, len(logs))
+	assert.Equal(t, contractB, logs[0].Address)
+
+	// 同一位置的主题为或的关系，未指定的位置匹配任意主题
+	logs = getLogs(t, inst, &evmtypes.EvmGetLogsReq{FromBlock: 3, ToBlock: -1,
+		Topics: []*evmtypes.EvmLogTopics{{}, {Topics: []string{topicHex(from1)}}}})
+	require.Equal(t, 1, len(logs))
+	assert.Equal(t, int32(1), logs[0].LogIndex)
+	logs = getLogs(t, inst, &evmtypes.EvmGetLogsReq{ToBlock: -1,
+		Topics: []*evmtypes.EvmLogTopics{{Topics: []string{topicHex(transfer), topicHex(other)}}, {Topics: []string{topicHex(from1)}}}})
+	assert.Equal(t, 2, len(logs))
+
+	logs = getLogs(t, inst, &evmtypes.EvmGetLogsReq{FromBlock: 2, ToBlock: -1, Addresses: []string{contractA}})
+	assert.Equal(t, 3, len(logs))
+	logs = getLogs(t, inst, &evmtypes.EvmGetLogsReq{FromBlock: 4, ToBlock: -1})
+	assert.Equal(t, 0, len(logs))
+
+	_, err := inst.Query_GetLogs(&evmtypes.EvmGetLogsReq{FromBlock: 3, ToBlock: 2})
+	assert.Equal(t, types.ErrInvalidParam, err)
+	_, err = inst.Query_GetLogs(&evmtypes.EvmGetLogsReq{ToBlock: -1, Topics: []*evmtypes.EvmLogTopics{{Topics: []string{"0x01"}}}})
+	assert.Equal(t, types.ErrInvalidParam, err)
+
+	// 回滚最后一个区块后对应的日志索引被删除
+	inst.SetEnv(3, 0, 0)
+	set, err := inst.ExecDelLocal(txs[3].tx, txs[3].receipt(), 0)
+	require.Nil(t, err)
+	applyKVs(kvdb, set.KV)
+	logs = getLogs(t, inst, &evmtypes.EvmGetLogsReq{ToBlock: -1, Addresses: []string{contractA},
+		Topics: []*evmtypes.EvmLogTopics{{Topics: []string{topicHex(transfer)}}}})
+	assert.Equal(t, 1, len(logs))
+	assert.Equal(t, 3, len(getLogs(t, inst, &evmtypes.EvmGetLogsReq{ToBlock: -1})))
+}
+
+type countListKVDB struct {
+	db.KVDB
+	listed int
+}
+
+func (c *countListKVDB) List(prefix, key []byte, count, direction int32) ([][]byte, error) {
+	values, err := c.KVDB.List(prefix, key, count, direction)
+	c.listed += len(values)
+	return values, err
+}
+
+func TestGetLogsTooMany(t *testing.T) {
+	mdb, _ := db.NewGoMemDB("test", "", 0)
+	kvdb := &countListKVDB{KVDB: db.NewKVDB(mdb)}
+	inst := newLogExecutor(kvdb)
+
+	// 直接写入超过上限的日志，查询在读到上限数量的日志位置时就返回错误
+	total := 30000
+	for i := 0; i < total; i++ {
+		item := &evmtypes.EvmLogItem{Height: int64(i/10 + 1), TxIndex: int64(i % 10)}
+		_ = kvdb.Set([]byte(fmt.Sprintf("LODB-evm-log:%012d:%05d:%05d", item.Height, item.TxIndex, 0)), types.Encode(item))
+	}
+	_, err := inst.Query_GetLogs(&evmtypes.EvmGetLogsReq{ToBlock: -1})
+	assert.Equal(t, model.ErrTooManyLogs, err)
+	assert.True(t, kvdb.listed < total/2)
+
+	// 区块范围内的日志数量没有超过上限时正常遍历
+	kvdb.listed = 0
+	logs := getLogs(t, inst, &evmtypes.EvmGetLogsReq{FromBlock: 2000, ToBlock: 2001})
+	assert.Equal(t, 20, len(logs))
+	assert.True(t, kvdb.listed < 200)
+}
@@ -0,0 +1,88 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tests
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/33cn/chain33/client"
+	"github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/types"
+	evm "github.com/33cn/plugin/plugin/dapp/evm/executor"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/common/crypto"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/model"
+	evmtypes "github.com/33cn/plugin/plugin/dapp/evm/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const allowanceABI = `[{"inputs":[{"name":"allowance","type":"uint256"},{"name":"needed","type":"uint256"}],"name":"InsufficientAllowance","type":"error"}]`
+
+// 部署代码直接revert，revert数据为选择器加上32字节对齐的参数
+func revertInitCode(selector []byte, args ...string) string {
+	// PUSH4 selector PUSH1 0xe0 SHL PUSH1 0 MSTORE
+	code := "63" + hex.EncodeToString(selector) + "60e01b600052"
+	for i, arg := range args {
+		// PUSH32 arg PUSH1 4+32*i MSTORE
+		code += "7f" + arg + "60" + hex.EncodeToString([]byte{byte(4 + 32*i)}) + "52"
+	}
+	// PUSH1 size PUSH1 0 REVERT
+	return code + "60" + hex.EncodeToString([]byte{byte(4 + 32*len(args))}) + "6000fd"
+}
+
+func newRevertExecutor(cfg *types.Chain33Config, height int64) *evm.EVMExecutor {
+	mdb, _ := db.NewGoMemDB("test", "", 0)
+	inst := evm.NewEVMExecutor()
+	q := queue.New("channel")
+	q.SetConfig(cfg)
+	api, _ := client.New(q.Client(), nil)
+	inst.SetAPI(api)
+	inst.SetStateDB(mdb)
+	inst.SetLocalDB(db.NewKVDB(mdb))
+	inst.SetEnv(height, 0, 0)
+	return inst
+}
+
+func TestRevertReason(t *testing.T) {
+	word := func(v byte) string { return hex.EncodeToString(append(make([]byte, 31), v)) }
+	errorString := crypto.Keccak256([]byte("Error(string)"))[:4]
+	panicCode := crypto.Keccak256([]byte("Panic(uint256)"))[:4]
+	allowance := crypto.Keccak256([]byte("InsufficientAllowance(uint256,uint256)"))[:4]
+	// 22字节的字符串补齐到32字节
+	reason := hex.EncodeToString(append([]byte("insufficient allowance"), make([]byte, 10)...))
+
+	for _, test := range []struct {
+		code   string
+		abi    string
+		reason string
+	}{
+		{revertInitCode(errorString, word(0x20), word(22), reason), "", "insufficient allowance"},
+		{revertInitCode(panicCode, word(0x11)), "", "panic: arithmetic underflow or overflow (0x11)"},
+		{revertInitCode(allowance, word(5), word(10)), allowanceABI, "InsufficientAllowance(allowance: 5, needed: 10)"},
+	} {
+		inst := newRevertExecutor(forkTestCfg, 10)
+		code, err := hex.DecodeString(test.code)
+		require.Nil(t, err)
+		_, err = inst.Query_EstimateGas(&evmtypes.EstimateEVMGasReq{Code: code, Abi: test.abi})
+		require.NotNil(t, err)
+		revertErr, ok := err.(*model.RevertError)
+		require.True(t, ok, err.Error())
+		assert.Equal(t, test.reason, revertErr.Reason)
+		assert.Equal(t, model.ErrExecutionReverted.Error()+": "+test.reason, err.Error())
+	}
+
+	// 无法解析的revert数据保持原有的错误
+	inst := newRevertExecutor(forkTestCfg, 10)
+	code, _ := hex.DecodeString(revertInitCode(allowance, word(5), word(10)))
+	_, err := inst.Query_EstimateGas(&evmtypes.EstimateEVMGasReq{Code: code})
+	assert.Equal(t, model.ErrExecutionReverted, err)
+
+	// 分叉之前不支持ABI中的error类型定义
+	inst = newRevertExecutor(chainTestCfg, 2000000)
+	_, err = inst.Query_EstimateGas(&evmtypes.EstimateEVMGasReq{Code: code, Abi: allowanceABI})
+	assert.Equal(t, model.ErrABIErrorType, err)
+}
@@ -0,0 +1,206 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tests
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/33cn/chain33/client/mocks"
+	"github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+	evm "github.com/33cn/plugin/plugin/dapp/evm/executor"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/common"
+	crypto2 "github.com/33cn/plugin/plugin/dapp/evm/executor/vm/common/crypto"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/model"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/runtime"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/state"
+	evmtypes "github.com/33cn/plugin/plugin/dapp/evm/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// 部署一个调用即revert的合约，revert数据为 Error("nope")
+func deployRevertContract(t *testing.T) (common.Address, *state.MemoryStateDB) {
+	// 部署代码：将运行时代码复制到内存并返回
+	initCode := "607080600b6000396000f3"
+	// 运行时代码：将代码中附带的revert数据复制到内存并revert
+	runtimeCode := "606460" + "0c" + "600039" + "60646000fd"
+	revertData := "08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000004" +
+		hex.EncodeToString([]byte("nope")) + "00000000000000000000000000000000000000000000000000000000"
+	deployCode, _ := hex.DecodeString(initCode + runtimeCode + revertData)
+
+	privKey := getPrivKey()
+	tx := createTx(privKey, deployCode, 2000000, 0)
+	mdb := buildStateDB(getAddr(privKey).String(), 500000000)
+	_, addr, _, statedb, err := createContract(mdb, tx, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return addr, statedb
+}
+
+// 使用跟踪器执行合约创建，部署代码中调用callee合约
+func traceCreate(statedb *state.MemoryStateDB, callee common.Address, tracer runtime.Tracer) error {
+	// BALANCE(callee) POP; SSTORE(1, 1); CALL(0xffff, callee, 0, 0, 0, 0, 0) POP STOP
+	code := "73" + hex.EncodeToString(callee.Bytes()) + "3150" +
+		"6001600155" +
+		"60006000600060006000" + "73" + hex.EncodeToString(callee.Bytes()) + "61fffff15000"
+	deployCode, _ := hex.DecodeString(code)
+	caller := common.StringToAddress(getAddr(getPrivKey()).String())
+
+	context := runtime.Context{
+		CanTransfer: evm.CanTransfer,
+		Transfer:    evm.Transfer,
+		Origin:      *caller,
+		BlockNumber: big.NewInt(10),
+		Time:        big.NewInt(0),
+		Difficulty:  big.NewInt(10),
+		GasLimit:    2000000,
+	}
+	env := runtime.NewEVM(context, statedb, runtime.Config{Debug: true, Tracer: tracer}, chainTestCfg)
+	addr := *crypto2.RandomContractAddress()
+	_, _, _, err := env.Create(runtime.AccountRef(*caller), addr, deployCode, 2000000, "user.evm.tracer", "", "")
+	return err
+}
+
+func TestCallTracer(t *testing.T) {
+	callee, statedb := deployRevertContract(t)
+	tracer := runtime.NewCallTracer()
+	err := traceCreate(statedb, callee, tracer)
+
+	test := NewTester(t)
+	test.assertNil(err)
+	root := tracer.Result()
+	test.assertEqualsS(root.Type, "CREATE")
+	test.assertEqualsV(len(root.Calls), 1)
+	call := root.Calls[0]
+	test.assertEqualsS(call.Type, "CALL")
+	test.assertEqualsS(call.To, callee.String())
+	test.assertEqualsS(call.Error, model.ErrExecutionReverted.Error())
+	test.assertEqualsS(call.RevertReason, "nope")
+	test.assertBigger(int(root.GasUsed), int(call.GasUsed))
+}
+
+func TestPrestateTracer(t *testing.T) {
+	callee, statedb := deployRevertContract(t)
+	tracer := runtime.NewPrestateTracer(statedb)
+	err := traceCreate(statedb, callee, tracer)
+
+	test := NewTester(t)
+	test.assertNil(err)
+	accounts := tracer.Result()
+	// 调用者、新建合约以及被调用合约
+	test.assertEqualsV(len(accounts), 3)
+	var storages int
+	for _, acc := range accounts {
+		if acc.Address == callee.String() {
+			test.assertNotEqualsV(len(acc.Code), 0)
+		}
+		if len(acc.Storage) > 0 {
+			storages++
+			// 新建合约的存储在交易执行前为空
+			test.assertEqualsS(acc.Storage[common.BigToHash(big.NewInt(1)).Hex()], common.Hash{}.Hex())
+		}
+	}
+	test.assertEqualsV(storages, 1)
+}
+
+// 每次调用将存储位置0的值加1的计数器合约
+// 部署代码：将运行时代码复制到内存并返回；运行时代码：SSTORE(0, SLOAD(0)+1) STOP
+const counterCode = "600a600c600039600a6000f3" + "60005460010160005500"
+
+func newTraceExecutor(api *mocks.QueueProtocolAPI, statedb *db.GoMemDB, height int64) *evm.EVMExecutor {
+	inst := evm.NewEVMExecutor()
+	inst.SetAPI(api)
+	inst.SetStateDB(statedb)
+	// 合约的存储数据保存在localdb中
+	inst.SetLocalDB(db.NewKVDB(statedb))
+	inst.SetEnv(height, 100+height, 0)
+	return inst
+}
+
+// 在最新状态上执行一个区块的交易，返回交易回执
+func execTraceBlock(t *testing.T, api *mocks.QueueProtocolAPI, statedb *db.GoMemDB, height int64, txs []*types.Transaction) []*types.ReceiptData {
+	var receipts []*types.ReceiptData
+	for i, tx := range txs {
+		receipt, err := newTraceExecutor(api, statedb, height).Exec(tx, i)
+		require.Nil(t, err)
+		for _, kv := range receipt.KV {
+			require.Nil(t, statedb.Set(kv.Key, kv.Value))
+		}
+		receipts = append(receipts, &types.ReceiptData{Ty: receipt.Ty, Logs: receipt.Logs})
+	}
+	return receipts
+}
+
+func TestTraceTxState(t *testing.T) {
+	privKey := getPrivKey()
+	caller := getAddr(privKey).String()
+	parent := buildStateDB(caller, 10*types.Coin)
+	latest := buildStateDB(caller, 10*types.Coin)
+	api := new(mocks.QueueProtocolAPI)
+	api.On("GetConfig").Return(forkTestCfg)
+
+	code, _ := hex.DecodeString(counterCode)
+	create := createTx(privKey, code, 2000000, 0)
+	block := &types.Block{Height: 10, BlockTime: 110, Txs: []*types.Transaction{&create}}
+	receipts := execTraceBlock(t, api, latest, 10, block.Txs)
+	var contract evmtypes.ReceiptEVMContract
+	for _, l := range receipts[0].Logs {
+		if l.Ty == evmtypes.TyLogCallContract {
+			require.Nil(t, types.Decode(l.Log, &contract))
+		}
+	}
+	var nonce int64
+	call := func() *types.Transaction {
+		nonce++
+		action := &evmtypes.EVMContractAction{}
+		tx := &types.Transaction{Execer: []byte("evm"), Payload: types.Encode(action), Fee: 2000000, To: contract.ContractAddr, Nonce: nonce}
+		tx.Sign(types.SECP256K1, privKey)
+		return tx
+	}
+	//同一个区块中的两次调用，以及之后区块中的一次调用
+	block.Txs = append(block.Txs, call(), call())
+	receipts = append(receipts, execTraceBlock(t, api, latest, 10, block.Txs[1:])...)
+	execTraceBlock(t, api, latest, 11, []*types.Transaction{call()})
+
+	parentHash := []byte("parent state")
+	api.On("GetBlocks", &types.ReqBlocks{Start: 9, End: 10, IsDetail: true}).Return(&types.BlockDetails{Items: []*types.BlockDetail{
+		{Block: &types.Block{Height: 9, StateHash: parentHash}},
+		{Block: block, Receipts: receipts},
+	}}, nil)
+	api.On("StoreGet", mock.Anything).Return(func(req *types.StoreGet) *types.StoreReplyValue {
+		require.Equal(t, parentHash, req.StateHash)
+		value, _ := parent.Get(req.Keys[0])
+		return &types.StoreReplyValue{Values: [][]byte{value}}
+	}, nil)
+	traced := block.Txs[2]
+	detail := &types.TransactionDetail{Tx: traced, Height: 10, Index: 2, Receipt: receipts[2]}
+	api.On("QueryTx", &types.ReqHash{Hash: traced.Hash()}).Return(detail, nil)
+
+	//在交易执行前的状态上重放，计数器的值是区块中前一次调用的结果，而不是最新状态的3
+	inst := newTraceExecutor(api, latest, 11)
+	msg, err := inst.Query_TraceTx(&evmtypes.EvmTraceTxReq{Hash: common.Bytes2Hex(traced.Hash()), Tracer: evmtypes.PrestateTracer})
+	require.Nil(t, err)
+	resp := msg.(*evmtypes.EvmTraceTxResp)
+	test := NewTester(t)
+	test.assertEqualsS(resp.Error, "")
+	var storage map[string]string
+	for _, acc := range resp.Prestate {
+		if acc.Address == contract.ContractAddr {
+			storage = acc.Storage
+		}
+	}
+	test.assertEqualsS(storage[common.Hash{}.Hex()], common.BigToHash(big.NewInt(1)).Hex())
+
+	//重放结果和交易回执不一致时返回错误
+	detail.Receipt = receipts[1]
+	_, err = newTraceExecutor(api, latest, 11).Query_TraceTx(&evmtypes.EvmTraceTxReq{Hash: common.Bytes2Hex(traced.Hash())})
+	test.assertEqualsE(model.ErrTraceStateChanged, err)
+}
@@ -258,9 +258,13 @@ func buildStateDB(addr string, balance int64) *db.GoMemDB {
 }
 
 func createContract(mdb *db.GoMemDB, tx types.Transaction, maxCodeSize int) (ret []byte, contractAddr common.Address, leftOverGas uint64, statedb *state.MemoryStateDB, err error) {
+	return createContractWithCfg(chainTestCfg, mdb, tx, maxCodeSize)
+}
+
+func createContractWithCfg(cfg *types.Chain33Config, mdb *db.GoMemDB, tx types.Transaction, maxCodeSize int) (ret []byte, contractAddr common.Address, leftOverGas uint64, statedb *state.MemoryStateDB, err error) {
 	inst := evm.NewEVMExecutor()
 	q := queue.New("channel")
-	q.SetConfig(chainTestCfg)
+	q.SetConfig(cfg)
 	api, _ := client.New(q.Client(), nil)
 	inst.SetAPI(api)
 	inst.CheckInit()
@@ -270,8 +274,9 @@ func createContract(mdb *db.GoMemDB, tx types.Transaction, maxCodeSize int) (ret
 	statedb = inst.GetMStateDB()
 
 	statedb.StateDB = mdb
+	statedb.LocalDB = db.NewKVDB(mdb)
 
-	statedb.CoinsAccount = account.NewCoinsAccount(chainTestCfg)
+	statedb.CoinsAccount = account.NewCoinsAccount(cfg)
 	statedb.CoinsAccount.SetDB(statedb.StateDB)
 
 	vmcfg := inst.GetVMConfig()
@@ -285,6 +290,7 @@ func createContract(mdb *db.GoMemDB, tx types.Transaction, maxCodeSize int) (ret
 	}
 
 	addr := *crypto2.RandomContractAddress()
+	env.PrepareAccessList(msg.From(), &addr)
 	ret, _, leftGas, err := env.Create(runtime.AccountRef(msg.From()), addr, msg.Data(), msg.GasLimit(), fmt.Sprintf("%s%s", evmtypes.EvmPrefix, common.BytesToHash(tx.Hash()).Hex()), "", "")
 
 	return ret, addr, leftGas, statedb, err
@@ -0,0 +1,195 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"bytes"
+
+	"github.com/33cn/chain33/client"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+	evmtypes "github.com/33cn/plugin/plugin/dapp/evm/types"
+)
+
+// historyStateDB 读取指定状态哈希下的状态数据，写入的数据只保存在内存中，用于在历史状态上重放交易
+type historyStateDB struct {
+	api       client.QueueProtocolAPI
+	stateHash []byte
+	cache     map[string][]byte
+}
+
+func newHistoryStateDB(api client.QueueProtocolAPI, stateHash []byte) *historyStateDB {
+	return &historyStateDB{api: api, stateHash: stateHash, cache: make(map[string][]byte)}
+}
+
+// Get 优先读取重放过程中写入的数据
+func (s *historyStateDB) Get(key []byte) ([]byte, error) {
+	if value, ok := s.cache[string(key)]; ok {
+		if len(value) == 0 {
+			return nil, types.ErrNotFound
+		}
+		return value, nil
+	}
+	reply, err := s.api.StoreGet(&types.StoreGet{StateHash: s.stateHash, Keys: [][]byte{key}})
+	if err != nil {
+		return nil, err
+	}
+	if len(reply.GetValues()) == 0 || len(reply.Values[0]) == 0 {
+		return nil, types.ErrNotFound
+	}
+	return reply.Values[0], nil
+}
+
+// Set 数据只写入内存
+func (s *historyStateDB) Set(key []byte, value []byte) error {
+	s.cache[string(key)] = value
+	return nil
+}
+
+// Begin 重放不需要回滚
+func (s *historyStateDB) Begin() {}
+
+// Commit 重放不需要提交
+func (s *historyStateDB) Commit() error { return nil }
+
+// Rollback 重放不需要回滚
+func (s *historyStateDB) Rollback() {}
+
+// historyLocalDB 合约的存储数据保存在localdb中，只有最新的值。
+// 在区块中被修改过的存储使用回执中第一次修改之前的值，其他数据读取最新的localdb，写入的数据只保存在内存中
+type historyLocalDB struct {
+	dbm.KVDB
+	cache map[string][]byte
+}
+
+func newHistoryLocalDB(local dbm.KVDB, detail *types.BlockDetail) *historyLocalDB {
+	db := &historyLocalDB{KVDB: local, cache: make(map[string][]byte)}
+	for _, receipt := range detail.GetReceipts() {
+		for _, l := range receipt.GetLogs() {
+			if l.Ty != evmtypes.TyLogEVMStateChangeItem {
+				continue
+			}
+			var item evmtypes.EVMStateChangeItem
+			if types.Decode(l.Log, &item) != nil {
+				continue
+			}
+			if _, ok := db.cache[item.Key]; !ok {
+				db.cache[item.Key] = item.PreValue
+			}
+		}
+	}
+	return db
+}
+
+// Get 优先读取区块执行前的值以及重放过程中写入的数据
+func (l *historyLocalDB) Get(key []byte) ([]byte, error) {
+	if value, ok := l.cache[string(key)]; ok {
+		if len(value) == 0 {
+			return nil, types.ErrNotFound
+		}
+		return value, nil
+	}
+	return l.KVDB.Get(key)
+}
+
+// Set 数据只写入内存
+func (l *historyLocalDB) Set(key []byte, value []byte) error {
+	l.cache[string(key)] = value
+	return nil
+}
+
+// loadTxState 还原区块中第index笔交易执行前的状态：在父区块的状态上扣除手续费，并依次重放区块中之前的evm交易。
+// 区块回执中只有日志没有状态数据，之前的非evm交易无法重放，之后的区块修改过的合约存储也无法还原，
+// 交易访问的状态是否还原由重放结果和回执比较确定
+func (evm *EVMExecutor) loadTxState(height int64, index int) error {
+	if height <= 0 {
+		return types.ErrInvalidParam
+	}
+	api := evm.GetAPI()
+	details, err := api.GetBlocks(&types.ReqBlocks{Start: height - 1, End: height, IsDetail: true})
+	if err != nil {
+		return err
+	}
+	if len(details.GetItems()) != 2 {
+		return types.ErrBlockNotFound
+	}
+	parent := details.Items[0].GetBlock()
+	detail := details.Items[1]
+	block := detail.GetBlock()
+	if index >= len(block.GetTxs()) || len(detail.GetReceipts()) != len(block.GetTxs()) {
+		return types.ErrInvalidParam
+	}
+	evm.SetStateDB(newHistoryStateDB(api, parent.GetStateHash()))
+	evm.SetLocalDB(newHistoryLocalDB(evm.GetLocalDB(), detail))
+	evm.SetEnv(block.Height, block.BlockTime, uint64(block.Difficulty))
+	evm.SetTxs(block.Txs)
+	for i := 0; i <= index; i++ {
+		tx, receipt := block.Txs[i], detail.Receipts[i]
+		err = evm.restoreFee(receipt)
+		if err != nil {
+			return err
+		}
+		if i == index {
+			break
+		}
+		if receipt.Ty != types.ExecOk || string(types.GetRealExecName(tx.GetExecer())) != evmtypes.ExecutorName {
+			continue
+		}
+		// 每笔交易使用新的状态机，和区块执行时一致
+		evm.mStateDB = nil
+		r, err := evm.Exec(tx, i)
+		if err != nil {
+			return err
+		}
+		for _, kv := range r.GetKV() {
+			_ = evm.GetStateDB().Set(kv.Key, kv.Value)
+		}
+	}
+	evm.mStateDB = nil
+	evm.CheckInit()
+	return nil
+}
+
+// restoreFee 根据回执中的手续费日志设置扣除手续费之后的账户
+func (evm *EVMExecutor) restoreFee(receipt *types.ReceiptData) error {
+	for _, l := range receipt.GetLogs() {
+		if l.Ty != types.TyLogFee {
+			continue
+		}
+		var transfer types.ReceiptAccountTransfer
+		err := types.Decode(l.Log, &transfer)
+		if err != nil {
+			return err
+		}
+		coins := evm.GetCoinsAccount()
+		coins.SaveKVSet(coins.GetKVSet(transfer.Current))
+	}
+	return nil
+}
+
+// sameReceipt 比较重放的结果和交易的回执，手续费和错误日志不是合约执行产生的，不参与比较
+func sameReceipt(origin *types.ReceiptData, receipt *types.Receipt, err error) bool {
+	if err != nil {
+		return origin.GetTy() == types.ExecPack
+	}
+	if origin.GetTy() != types.ExecOk {
+		return false
+	}
+	var logs []*types.ReceiptLog
+	for _, l := range origin.GetLogs() {
+		if l.Ty != types.TyLogFee && l.Ty != types.TyLogErr {
+			logs = append(logs, l)
+		}
+	}
+	if len(logs) != len(receipt.GetLogs()) {
+		return false
+	}
+	for i, l := range receipt.GetLogs() {
+		if l.Ty != logs[i].Ty || !bytes.Equal(l.Log, logs[i].Log) {
+			return false
+		}
+	}
+	return true
+}
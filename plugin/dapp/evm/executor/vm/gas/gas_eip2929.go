@@ -0,0 +1,127 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gas
+
+import (
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/common"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/mm"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/model"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/params"
+)
+
+// 本文件中定义EIP-2929中访问账户和存储时的Gas计算逻辑
+// 交易中首次访问某个地址或存储槽位为冷访问，之后为热访问，热访问价格在TableLondon中定义
+
+var (
+	// CallEIP2929 调用合约计费
+	CallEIP2929 = makeCallVariantGasEIP2929(Call)
+	// CallCodeEIP2929 调用合约代码计费
+	CallCodeEIP2929 = makeCallVariantGasEIP2929(CallCode)
+	// DelegateCallEIP2929 委托调用计费
+	DelegateCallEIP2929 = makeCallVariantGasEIP2929(DelegateCall)
+	// StaticCallEIP2929 静态调用计费
+	StaticCallEIP2929 = makeCallVariantGasEIP2929(StaticCall)
+)
+
+// 冷访问账户需要额外支付的Gas，同时将地址加入访问列表
+func accountAccessCost(evm *params.EVMParam, addr common.Address) uint64 {
+	if evm.StateDB.AddressInAccessList(addr.String()) {
+		return 0
+	}
+	evm.StateDB.AddAddressToAccessList(addr.String())
+	return params.ColdAccountAccessCostEIP2929 - params.WarmStorageReadCostEIP2929
+}
+
+// SLoadEIP2929 加载存储计费
+func SLoadEIP2929(gt Table, evm *params.EVMParam, contractGas *params.GasParam, stack *mm.Stack, mem *mm.Memory, memorySize uint64) (uint64, error) {
+	addr, slot := contractGas.Address.String(), common.Uint256ToHash(stack.Peek())
+	if _, slotOk := evm.StateDB.SlotInAccessList(addr, slot); slotOk {
+		return gt.SLoad, nil
+	}
+	evm.StateDB.AddSlotToAccessList(addr, slot)
+	return params.ColdSloadCostEIP2929, nil
+}
+
+// SStoreEIP2929 计算数据存储的价格，冷访问存储槽位需要额外支付ColdSloadCost，
+// 同时变更和删除值的价格降低为 SstoreResetGas - ColdSloadCost
+func SStoreEIP2929(gt Table, evm *params.EVMParam, contractGas *params.GasParam, stack *mm.Stack, mem *mm.Memory, memorySize uint64) (uint64, error) {
+	var cost uint64
+	addr, slot := contractGas.Address.String(), common.Uint256ToHash(stack.Back(0))
+	if _, slotOk := evm.StateDB.SlotInAccessList(addr, slot); !slotOk {
+		cost = params.ColdSloadCostEIP2929
+		evm.StateDB.AddSlotToAccessList(addr, slot)
+	}
+	gas, err := SStore(gt, evm, contractGas, stack, mem, memorySize)
+	if err != nil {
+		return 0, err
+	}
+	if gas != params.SstoreSetGas {
+		gas -= params.ColdSloadCostEIP2929
+	}
+	return cost + gas, nil
+}
+
+// BalanceEIP2929 获取余额计费
+func BalanceEIP2929(gt Table, evm *params.EVMParam, contractGas *params.GasParam, stack *mm.Stack, mem *mm.Memory, memorySize uint64) (uint64, error) {
+	return gt.Balance + accountAccessCost(evm, common.Uint256ToAddress(stack.Peek())), nil
+}
+
+// ExtCodeSizeEIP2929 获取代码大小计费
+func ExtCodeSizeEIP2929(gt Table, evm *params.EVMParam, contractGas *params.GasParam, stack *mm.Stack, mem *mm.Memory, memorySize uint64) (uint64, error) {
+	return gt.ExtcodeSize + accountAccessCost(evm, common.Uint256ToAddress(stack.Peek())), nil
+}
+
+// ExtCodeHashEIP2929 获取代码哈希计费
+func ExtCodeHashEIP2929(gt Table, evm *params.EVMParam, contractGas *params.GasParam, stack *mm.Stack, mem *mm.Memory, memorySize uint64) (uint64, error) {
+	return params.WarmStorageReadCostEIP2929 + accountAccessCost(evm, common.Uint256ToAddress(stack.Peek())), nil
+}
+
+// ExtCodeCopyEIP2929 扩展代码复制计费
+func ExtCodeCopyEIP2929(gt Table, evm *params.EVMParam, contractGas *params.GasParam, stack *mm.Stack, mem *mm.Memory, memorySize uint64) (uint64, error) {
+	gas, err := ExtCodeCopy(gt, evm, contractGas, stack, mem, memorySize)
+	if err != nil {
+		return 0, err
+	}
+	var overflow bool
+	if gas, overflow = common.SafeAdd(gas, accountAccessCost(evm, common.Uint256ToAddress(stack.Peek()))); overflow {
+		return 0, model.ErrGasUintOverflow
+	}
+	return gas, nil
+}
+
+// SuicideEIP2929 自杀操作计费，冷访问受益人地址需要支付ColdAccountAccessCost
+func SuicideEIP2929(gt Table, evm *params.EVMParam, contractGas *params.GasParam, stack *mm.Stack, mem *mm.Memory, memorySize uint64) (uint64, error) {
+	var gas uint64
+	addr := common.Uint256ToAddress(stack.Peek()).String()
+	if !evm.StateDB.AddressInAccessList(addr) {
+		evm.StateDB.AddAddressToAccessList(addr)
+		gas = params.ColdAccountAccessCostEIP2929
+	}
+	if !evm.StateDB.HasSuicided(contractGas.Address.String()) {
+		evm.StateDB.AddRefund(params.SelfdestructRefundGas)
+	}
+	return gas, nil
+}
+
+// 调用类指令在原有计费基础上增加冷访问的费用，
+// 冷访问的费用需要先从可用Gas中扣除，再计算传递给被调用合约的Gas（63/64规则）
+func makeCallVariantGasEIP2929(oldCalculator CalcGasFunc) CalcGasFunc {
+	return func(gt Table, evm *params.EVMParam, contractGas *params.GasParam, stack *mm.Stack, mem *mm.Memory, memorySize uint64) (uint64, error) {
+		coldCost := accountAccessCost(evm, common.Uint256ToAddress(stack.Back(1)))
+		if contractGas.Gas < coldCost {
+			return 0, model.ErrOutOfGas
+		}
+		available := &params.GasParam{Gas: contractGas.Gas - coldCost, Address: contractGas.Address}
+		gas, err := oldCalculator(gt, evm, available, stack, mem, memorySize)
+		if err != nil {
+			return 0, err
+		}
+		var overflow bool
+		if gas, overflow = common.SafeAdd(gas, coldCost); overflow {
+			return 0, model.ErrGasUintOverflow
+		}
+		return gas, nil
+	}
+}
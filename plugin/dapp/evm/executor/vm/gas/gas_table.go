@@ -48,6 +48,18 @@ var (
 		Suicide:     0,
 		ExpByte:     10,
 	}
+
+	// TableLondon 伦敦版本（EIP-2929）的Gas定价，访问账户和存储的价格为热访问价格，
+	// 冷访问需要额外支付的部分在对应的Gas计算方法中计算
+	TableLondon = Table{
+		ExtcodeSize: params.WarmStorageReadCostEIP2929,
+		ExtcodeCopy: params.WarmStorageReadCostEIP2929,
+		Balance:     params.WarmStorageReadCostEIP2929,
+		SLoad:       params.WarmStorageReadCostEIP2929,
+		Calls:       params.WarmStorageReadCostEIP2929,
+		Suicide:     0,
+		ExpByte:     10,
+	}
 )
 
 // 计算新开辟内存空间需要使用多少Gas
@@ -4,7 +4,10 @@
 
 package model //nolint
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	// ErrOutOfGas                 out of gas
@@ -49,4 +52,26 @@ var (
 	ErrInvalidJump = errors.New("invalid jump destination")
 	// ErrInvalidRetsub invalid retsub
 	ErrInvalidRetsub = errors.New("invalid retsub")
+	// ErrTooManyLogs 查询结果中的日志数量超过上限
+	ErrTooManyLogs = errors.New("query returned too many logs")
+	// ErrABIErrorType ABI中包含不支持的error类型定义
+	ErrABIErrorType = errors.New("abi: error type is not supported")
+	// ErrTraceStateChanged 重放交易的结果和交易回执不一致，交易访问的状态无法还原
+	ErrTraceStateChanged = errors.New("trace: state touched by the tx can not be restored")
 )
+
+// RevertError 合约执行revert时返回的错误，携带解析出的revert原因和原始返回数据
+type RevertError struct {
+	Reason string
+	Data   []byte
+}
+
+// NewRevertError 新建合约revert错误
+func NewRevertError(reason string, data []byte) *RevertError {
+	return &RevertError{Reason: reason, Data: data}
+}
+
+// Error 错误信息中包含revert原因
+func (e *RevertError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrExecutionReverted.Error(), e.Reason)
+}
@@ -0,0 +1,108 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package runtime
+
+import (
+	"time"
+
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/common"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/mm"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/model"
+	evmtypes "github.com/33cn/plugin/plugin/dapp/evm/types"
+)
+
+// CallTracer 调用树跟踪器，记录交易执行过程中的所有合约调用，生成嵌套的调用树
+type CallTracer struct {
+	// 当前尚未结束的调用，第一个元素为交易的顶层调用
+	callstack []*evmtypes.EvmCallFrame
+	root      *evmtypes.EvmCallFrame
+}
+
+// NewCallTracer 创建调用树跟踪器
+func NewCallTracer() *CallTracer {
+	return &CallTracer{}
+}
+
+// CaptureStart 记录交易的顶层调用
+func (t *CallTracer) CaptureStart(from common.Address, to common.Address, create bool, input []byte, gas uint64, value uint64) error {
+	typ := CALL
+	if create {
+		typ = CREATE
+	}
+	t.root = newCallFrame(typ, from, to, input, gas, value)
+	t.callstack = []*evmtypes.EvmCallFrame{t.root}
+	return nil
+}
+
+// CaptureState 调用树不需要记录指令级别的数据
+func (t *CallTracer) CaptureState(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *mm.Memory, stack *mm.Stack, rStack *mm.ReturnStack, rData []byte, contract *Contract, depth int, err error) error {
+	return nil
+}
+
+// CaptureFault 调用树不需要记录指令级别的数据
+func (t *CallTracer) CaptureFault(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *mm.Memory, stack *mm.Stack, rStack *mm.ReturnStack, contract *Contract, depth int, err error) error {
+	return nil
+}
+
+// CaptureEnd 记录顶层调用的执行结果
+func (t *CallTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	if t.root == nil {
+		return nil
+	}
+	fillCallFrame(t.root, output, gasUsed, err)
+	t.callstack = nil
+	return nil
+}
+
+// CaptureEnter 记录内部调用的开始
+func (t *CallTracer) CaptureEnter(typ OpCode, from common.Address, to common.Address, input []byte, gas uint64, value uint64) {
+	if len(t.callstack) == 0 {
+		return
+	}
+	t.callstack = append(t.callstack, newCallFrame(typ, from, to, input, gas, value))
+}
+
+// CaptureExit 记录内部调用的结果，并挂到上一层调用下
+func (t *CallTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	size := len(t.callstack)
+	if size <= 1 {
+		return
+	}
+	frame := t.callstack[size-1]
+	t.callstack = t.callstack[:size-1]
+	fillCallFrame(frame, output, gasUsed, err)
+	parent := t.callstack[size-2]
+	parent.Calls = append(parent.Calls, frame)
+}
+
+// Result 返回调用树，交易没有执行时返回nil
+func (t *CallTracer) Result() *evmtypes.EvmCallFrame {
+	return t.root
+}
+
+func newCallFrame(typ OpCode, from common.Address, to common.Address, input []byte, gas uint64, value uint64) *evmtypes.EvmCallFrame {
+	return &evmtypes.EvmCallFrame{
+		Type:  typ.String(),
+		From:  from.String(),
+		To:    to.String(),
+		Value: value,
+		Gas:   gas,
+		Input: common.Bytes2Hex(input),
+	}
+}
+
+func fillCallFrame(frame *evmtypes.EvmCallFrame, output []byte, gasUsed uint64, err error) {
+	frame.GasUsed = gasUsed
+	frame.Output = common.Bytes2Hex(output)
+	if err == nil {
+		return
+	}
+	frame.Error = err.Error()
+	if err == model.ErrExecutionReverted {
+		if reason, ok := UnpackRevert(output); ok {
+			frame.RevertReason = reason
+		}
+	}
+}
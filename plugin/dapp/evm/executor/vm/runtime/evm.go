@@ -34,13 +34,7 @@ type (
 // 依据合约地址判断是否为预编译合约，如果不是，则全部通过解释器解释执行
 func run(evm *EVM, contract *Contract, input []byte) (ret []byte, err error) {
 	if contract.CodeAddr != nil {
-		// 预编译合约以拜占庭分支为初始版本，后继如有分叉，需要在此处理
-		precompiles := PrecompiledContractsByzantium
-		//预编译分叉处理： chain33中目前只存在拜占庭和最新的黄皮书v1版本（兼容伊斯坦布尔版本）
-		if evm.cfg.IsDappFork(evm.StateDB.GetBlockHeight(), "evm", evmtypes.ForkEVMYoloV1) {
-			precompiles = PrecompiledContractsYoloV1
-		}
-		if p := precompiles[*contract.CodeAddr]; p != nil {
+		if p := evm.precompiles()[*contract.CodeAddr]; p != nil {
 			return RunPrecompiledContract(p, input, contract)
 		}
 	}
@@ -80,6 +74,10 @@ type Context struct {
 	Time *big.Int
 	// Difficulty 指令，当前区块难度
 	Difficulty *big.Int
+	// ChainID CHAINID 指令，当前链的chainID
+	ChainID *big.Int
+	// BaseFee BASEFEE 指令，当前区块的基础Gas价格
+	BaseFee *big.Int
 }
 
 // EVM 结构对象及其提供的操作方法，用于进行满足以太坊EVM黄皮书规范定义的智能合约代码的创建和执行
@@ -134,9 +132,48 @@ func NewEVM(ctx Context, statedb state.EVMStateDB, vmConfig Config, cfg *types.C
 // GasTable 返回不同操作消耗的Gas定价表
 // 接收区块高度作为参数，方便以后在这里作分叉处理
 func (evm *EVM) GasTable(num *big.Int) gas.Table {
+	if evm.IsLondon() {
+		return gas.TableLondon
+	}
 	return gas.TableHomestead
 }
 
+// IsLondon 是否启用伦敦版本的指令和Gas规则（EIP-2929）
+func (evm *EVM) IsLondon() bool {
+	return evm.cfg.IsDappFork(evm.StateDB.GetBlockHeight(), "evm", evmtypes.ForkEVMLondon)
+}
+
+// IsShanghai 是否启用上海版本的指令，上海版本以伦敦版本为基础
+func (evm *EVM) IsShanghai() bool {
+	return evm.IsLondon() && evm.cfg.IsDappFork(evm.StateDB.GetBlockHeight(), "evm", evmtypes.ForkEVMShanghai)
+}
+
+// 预编译分叉处理： 预编译合约以拜占庭分支为初始版本，黄皮书v1版本兼容伊斯坦布尔版本，
+// 伦敦及之后的版本沿用黄皮书v1版本的预编译合约
+func (evm *EVM) precompiles() map[common.Address]PrecompiledContract {
+	if evm.cfg.IsDappFork(evm.StateDB.GetBlockHeight(), "evm", evmtypes.ForkEVMYoloV1) {
+		return PrecompiledContractsYoloV1
+	}
+	return PrecompiledContractsByzantium
+}
+
+// PrepareAccessList 交易执行前初始化访问列表（EIP-2929），
+// 交易的发送方、接收方以及所有预编译合约地址默认处于热访问状态
+func (evm *EVM) PrepareAccessList(sender common.Address, dst *common.Address) {
+	if !evm.IsLondon() {
+		return
+	}
+	var to string
+	if dst != nil {
+		to = dst.String()
+	}
+	precompiles := make([]string, 0, len(evm.precompiles()))
+	for addr := range evm.precompiles() {
+		precompiles = append(precompiles, addr.String())
+	}
+	evm.StateDB.PrepareAccessList(sender.String(), to, precompiles)
+}
+
 // Cancel 调用此操作会在任意时刻取消此EVM的解释运行逻辑，支持重复调用
 func (evm *EVM) Cancel() {
 	atomic.StoreInt32(&evm.abort, 1)
@@ -184,12 +221,7 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 	}
 
 	if !evm.StateDB.Exist(addr.String()) {
-		//预编译分叉处理： chain33中目前只存在拜占庭和最新的黄皮书v1版本（兼容伊斯坦布尔版本）
-		precompiles := PrecompiledContractsByzantium
-		// 是否是黄皮书v1分叉
-		if evm.cfg.IsDappFork(evm.StateDB.GetBlockHeight(), "evm", evmtypes.ForkEVMYoloV1) {
-			precompiles = PrecompiledContractsYoloV1
-		}
+		precompiles := evm.precompiles()
 		// 合约地址在自定义合约和预编译合约中都不存在时，可能为外部账户
 		if precompiles[addr] == nil {
 			// 只有一种情况会走到这里来，就是合约账户向外部账户转账的情况
@@ -233,6 +265,12 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 		defer func() {
 			evm.VMConfig.Tracer.CaptureEnd(ret, gas-contract.Gas, types.Since(start), err)
 		}()
+	} else if evm.VMConfig.Debug {
+		evm.VMConfig.Tracer.CaptureEnter(CALL, caller.Address(), addr, input, gas, value)
+
+		defer func() {
+			evm.VMConfig.Tracer.CaptureExit(ret, gas-contract.Gas, err)
+		}()
 	}
 
 	// 从ForkV20EVMState开始，状态数据存储发生变更，需要做数据迁移
@@ -277,6 +315,14 @@ func (evm *EVM) CallCode(caller ContractRef, addr common.Address, input []byte,
 	// 正常从合约地址加载合约代码
 	contract.SetCallCode(&addr, evm.StateDB.GetCodeHash(addr.String()), evm.StateDB.GetCode(addr.String()))
 
+	// 调试模式下跟踪内部调用
+	if evm.VMConfig.Debug {
+		evm.VMConfig.Tracer.CaptureEnter(CALLCODE, caller.Address(), addr, input, gas, value)
+		defer func() {
+			evm.VMConfig.Tracer.CaptureExit(ret, gas-contract.Gas, err)
+		}()
+	}
+
 	ret, err = run(evm, contract, input)
 	if err != nil {
 		evm.StateDB.RevertToSnapshot(snapshot)
@@ -311,6 +357,14 @@ func (evm *EVM) DelegateCall(caller ContractRef, addr common.Address, input []by
 	contract := NewContract(caller, to, 0, gas).AsDelegate()
 	contract.SetCallCode(&addr, evm.StateDB.GetCodeHash(addr.String()), evm.StateDB.GetCode(addr.String()))
 
+	// 调试模式下跟踪内部调用
+	if evm.VMConfig.Debug {
+		evm.VMConfig.Tracer.CaptureEnter(DELEGATECALL, caller.Address(), addr, input, gas, 0)
+		defer func() {
+			evm.VMConfig.Tracer.CaptureExit(ret, gas-contract.Gas, err)
+		}()
+	}
+
 	// 其它逻辑同StaticCall
 	ret, err = run(evm, contract, input)
 	if err != nil {
@@ -352,6 +406,14 @@ func (evm *EVM) StaticCall(caller ContractRef, addr common.Address, input []byte
 	contract := NewContract(caller, to, 0, gas)
 	contract.SetCallCode(&addr, evm.StateDB.GetCodeHash(addr.String()), evm.StateDB.GetCode(addr.String()))
 
+	// 调试模式下跟踪内部调用
+	if evm.VMConfig.Debug {
+		evm.VMConfig.Tracer.CaptureEnter(STATICCALL, caller.Address(), addr, input, gas, 0)
+		defer func() {
+			evm.VMConfig.Tracer.CaptureExit(ret, gas-contract.Gas, err)
+		}()
+	}
+
 	// 执行合约指令时如果出错，需要进行回滚，并且扣除剩余的Gas
 	ret, err = run(evm, contract, input)
 	if err != nil {
@@ -383,10 +445,16 @@ func (evm *EVM) Create(caller ContractRef, contractAddr common.Address, code []b
 
 	// 创建一个新的账户对象（合约账户）
 	snapshot = evm.StateDB.Snapshot()
+	// 新创建的合约地址加入访问列表（EIP-2929）
+	if evm.IsLondon() {
+		evm.StateDB.AddAddressToAccessList(contractAddr.String())
+	}
 	evm.StateDB.CreateAccount(contractAddr.String(), contract.CallerAddress.String(), execName, alias)
 
 	if evm.VMConfig.Debug && evm.depth == 0 {
 		evm.VMConfig.Tracer.CaptureStart(caller.Address(), contractAddr, true, code, gas, 0)
+	} else if evm.VMConfig.Debug {
+		evm.VMConfig.Tracer.CaptureEnter(CREATE, caller.Address(), contractAddr, code, gas, 0)
 	}
 	start := types.Now()
 
@@ -430,6 +498,8 @@ func (evm *EVM) Create(caller ContractRef, contractAddr common.Address, code []b
 
 	if evm.VMConfig.Debug && evm.depth == 0 {
 		evm.VMConfig.Tracer.CaptureEnd(ret, gas-contract.Gas, types.Since(start), err)
+	} else if evm.VMConfig.Debug {
+		evm.VMConfig.Tracer.CaptureExit(ret, gas-contract.Gas, err)
 	}
 
 	return ret, snapshot, contract.Gas, err
@@ -901,9 +901,28 @@ func opSelfBalance(pc *uint64, evm *EVM, callContext *callCtx) ([]byte, error) {
 	return nil, nil
 }
 
-// opChainID implements CHAINID opcode
-//func opChainID(pc *uint64, evm *EVM, callContext *callCtx) ([]byte, error) {
-//	chainId, _ := uint256.FromBig(evm.ChainConfig.ChainID)
-//	callContext.stack.Push(chainId)
-//	return nil, nil
-//}
+// opChainID 获取当前链的chainID
+func opChainID(pc *uint64, evm *EVM, callContext *callCtx) ([]byte, error) {
+	chainID := new(uint256.Int)
+	if evm.ChainID != nil {
+		chainID, _ = uint256.FromBig(evm.ChainID)
+	}
+	callContext.stack.Push(chainID)
+	return nil, nil
+}
+
+// opBaseFee 获取当前区块的基础手续费价格
+func opBaseFee(pc *uint64, evm *EVM, callContext *callCtx) ([]byte, error) {
+	baseFee := new(uint256.Int)
+	if evm.BaseFee != nil {
+		baseFee, _ = uint256.FromBig(evm.BaseFee)
+	}
+	callContext.stack.Push(baseFee)
+	return nil, nil
+}
+
+// opPush0 将0压栈
+func opPush0(pc *uint64, evm *EVM, callContext *callCtx) ([]byte, error) {
+	callContext.stack.Push(new(uint256.Int))
+	return nil, nil
+}
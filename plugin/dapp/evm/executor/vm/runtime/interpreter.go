@@ -50,9 +50,15 @@ func NewInterpreter(evm *EVM, cfg Config) *Interpreter {
 	if !cfg.JumpTable[STOP].Valid {
 		cfg.JumpTable = ConstantinopleInstructionSet
 		if evm.cfg.IsDappFork(evm.StateDB.GetBlockHeight(), "evm", evmtypes.ForkEVMYoloV1) {
-			//这里需要替换为最新得指令集
 			cfg.JumpTable = YoloV1InstructionSet
 		}
+		if evm.IsLondon() {
+			cfg.JumpTable = LondonInstructionSet
+		}
+		if evm.IsShanghai() {
+			//这里需要替换为最新得指令集
+			cfg.JumpTable = ShanghaiInstructionSet
+		}
 	}
 
 	return &Interpreter{
@@ -49,11 +49,66 @@ var (
 	ConstantinopleInstructionSet = NewConstantinopleInstructionSet()
 	// YoloV1InstructionSet 黄皮书指令集
 	YoloV1InstructionSet = NewYoloV1InstructionSet()
+	// LondonInstructionSet 伦敦版本指令集
+	LondonInstructionSet = NewLondonInstructionSet()
+	// ShanghaiInstructionSet 上海版本指令集
+	ShanghaiInstructionSet = NewShanghaiInstructionSet()
 )
 
 // JumpTable contains the EVM opcodes supported at a given fork.
 type JumpTable [256]Operation
 
+// NewShanghaiInstructionSet 上海 版本支持的指令集
+func NewShanghaiInstructionSet() JumpTable {
+	instructionSet := NewLondonInstructionSet()
+	// New opcode EIP-3855
+	instructionSet[PUSH0] = Operation{
+		Execute:       opPush0,
+		GasCost:       gas.ConstGasFunc(gas.GasQuickStep),
+		ValidateStack: mm.MakeStackFunc(0, 1),
+		Valid:         true,
+	}
+	return instructionSet
+}
+
+// NewLondonInstructionSet 伦敦 版本支持的指令集，包含伊斯坦布尔版本的CHAINID、伦敦版本的BASEFEE，
+// 以及EIP-2929中访问账户和存储的冷热计费规则，不包含没有被以太坊采纳的EIP-2315子程序指令
+func NewLondonInstructionSet() JumpTable {
+	instructionSet := NewYoloV1InstructionSet()
+	// EIP-2315 BEGINSUB、RETURNSUB、JUMPSUB 指令无效
+	instructionSet[BEGINSUB] = Operation{}
+	instructionSet[RETURNSUB] = Operation{}
+	instructionSet[JUMPSUB] = Operation{}
+	// New opcode EIP-1344
+	instructionSet[CHAINID] = Operation{
+		Execute:       opChainID,
+		GasCost:       gas.ConstGasFunc(gas.GasQuickStep),
+		ValidateStack: mm.MakeStackFunc(0, 1),
+		Valid:         true,
+	}
+	// New opcode EIP-3198
+	instructionSet[BASEFEE] = Operation{
+		Execute:       opBaseFee,
+		GasCost:       gas.ConstGasFunc(gas.GasQuickStep),
+		ValidateStack: mm.MakeStackFunc(0, 1),
+		Valid:         true,
+	}
+
+	// EIP-2929 计费规则变更
+	instructionSet[SLOAD].GasCost = gas.SLoadEIP2929
+	instructionSet[SSTORE].GasCost = gas.SStoreEIP2929
+	instructionSet[BALANCE].GasCost = gas.BalanceEIP2929
+	instructionSet[EXTCODESIZE].GasCost = gas.ExtCodeSizeEIP2929
+	instructionSet[EXTCODECOPY].GasCost = gas.ExtCodeCopyEIP2929
+	instructionSet[EXTCODEHASH].GasCost = gas.ExtCodeHashEIP2929
+	instructionSet[CALL].GasCost = gas.CallEIP2929
+	instructionSet[CALLCODE].GasCost = gas.CallCodeEIP2929
+	instructionSet[DELEGATECALL].GasCost = gas.DelegateCallEIP2929
+	instructionSet[STATICCALL].GasCost = gas.StaticCallEIP2929
+	instructionSet[SELFDESTRUCT].GasCost = gas.SuicideEIP2929
+	return instructionSet
+}
+
 // NewYoloV1InstructionSet 黄皮书指令集
 func NewYoloV1InstructionSet() JumpTable {
 	instructionSet := NewConstantinopleInstructionSet()
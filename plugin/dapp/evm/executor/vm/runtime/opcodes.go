@@ -92,6 +92,7 @@ func initMap() {
 		GASLIMIT:    "GASLIMIT",
 		CHAINID:     "CHAINID",
 		SELFBALANCE: "SELFBALANCE",
+		BASEFEE:     "BASEFEE",
 
 		// 0x50 range - 'storage' and execution
 		POP: "POP",
@@ -112,6 +113,7 @@ func initMap() {
 		BEGINSUB:  "BEGINSUB",
 		JUMPSUB:   "JUMPSUB",
 		RETURNSUB: "RETURNSUB",
+		PUSH0:     "PUSH0",
 
 		// 0x60 range - push
 		PUSH1:  "PUSH1",
@@ -326,6 +328,8 @@ const (
 	CHAINID OpCode = 0x46
 	// SELFBALANCE op
 	SELFBALANCE OpCode = 0x47
+	// BASEFEE op
+	BASEFEE OpCode = 0x48
 )
 
 const (
@@ -359,6 +363,8 @@ const (
 	RETURNSUB
 	// JUMPSUB op
 	JUMPSUB
+	// PUSH0 op
+	PUSH0
 )
 
 const (
@@ -0,0 +1,117 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package runtime
+
+import (
+	"sort"
+	"time"
+
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/common"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/mm"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/state"
+	evmtypes "github.com/33cn/plugin/plugin/dapp/evm/types"
+)
+
+// PrestateTracer 交易执行前状态跟踪器，记录交易执行过程中访问过的账户和存储在交易执行前的状态
+type PrestateTracer struct {
+	statedb  state.EVMStateDB
+	accounts map[string]*evmtypes.EvmPrestateAccount
+}
+
+// NewPrestateTracer 创建交易执行前状态跟踪器
+func NewPrestateTracer(statedb state.EVMStateDB) *PrestateTracer {
+	return &PrestateTracer{
+		statedb:  statedb,
+		accounts: make(map[string]*evmtypes.EvmPrestateAccount),
+	}
+}
+
+// CaptureStart 记录交易双方账户，调用合约时转账已经发生，需要还原转账前的余额
+func (t *PrestateTracer) CaptureStart(from common.Address, to common.Address, create bool, input []byte, gas uint64, value uint64) error {
+	t.lookupAccount(from.String())
+	t.lookupAccount(to.String())
+	if !create && value > 0 {
+		t.accounts[from.String()].Balance += value
+		t.accounts[to.String()].Balance -= value
+	}
+	return nil
+}
+
+// CaptureState 在指令执行前记录指令将要访问的账户和存储
+func (t *PrestateTracer) CaptureState(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *mm.Memory, stack *mm.Stack, rStack *mm.ReturnStack, rData []byte, contract *Contract, depth int, err error) error {
+	if err != nil || stack.Len() == 0 {
+		return nil
+	}
+	switch op {
+	case SLOAD, SSTORE:
+		t.lookupStorage(contract.Address().String(), common.Uint256ToHash(stack.Back(0)))
+	case EXTCODECOPY, EXTCODEHASH, EXTCODESIZE, BALANCE, SELFDESTRUCT:
+		t.lookupAccount(common.Uint256ToAddress(stack.Back(0)).String())
+	case CALL, CALLCODE, DELEGATECALL, STATICCALL:
+		if stack.Len() > 1 {
+			t.lookupAccount(common.Uint256ToAddress(stack.Back(1)).String())
+		}
+	}
+	return nil
+}
+
+// CaptureFault 目前实现为空
+func (t *PrestateTracer) CaptureFault(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *mm.Memory, stack *mm.Stack, rStack *mm.ReturnStack, contract *Contract, depth int, err error) error {
+	return nil
+}
+
+// CaptureEnd 目前实现为空
+func (t *PrestateTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	return nil
+}
+
+// CaptureEnter 目前实现为空，被调用的地址已经在CaptureState中记录
+func (t *PrestateTracer) CaptureEnter(typ OpCode, from common.Address, to common.Address, input []byte, gas uint64, value uint64) {
+}
+
+// CaptureExit 目前实现为空
+func (t *PrestateTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+}
+
+// Result 返回按地址排序的账户状态列表
+func (t *PrestateTracer) Result() []*evmtypes.EvmPrestateAccount {
+	addrs := make([]string, 0, len(t.accounts))
+	for addr := range t.accounts {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+	accounts := make([]*evmtypes.EvmPrestateAccount, 0, len(addrs))
+	for _, addr := range addrs {
+		accounts = append(accounts, t.accounts[addr])
+	}
+	return accounts
+}
+
+// 只记录账户第一次被访问时的状态
+func (t *PrestateTracer) lookupAccount(addr string) {
+	if _, ok := t.accounts[addr]; ok {
+		return
+	}
+	acc := &evmtypes.EvmPrestateAccount{
+		Address: addr,
+		Balance: t.statedb.GetBalance(addr),
+		Nonce:   t.statedb.GetNonce(addr),
+		Storage: make(map[string]string),
+	}
+	if code := t.statedb.GetCode(addr); len(code) > 0 {
+		acc.Code = common.Bytes2Hex(code)
+	}
+	t.accounts[addr] = acc
+}
+
+// 只记录存储第一次被访问时的值
+func (t *PrestateTracer) lookupStorage(addr string, key common.Hash) {
+	t.lookupAccount(addr)
+	storage := t.accounts[addr].Storage
+	if _, ok := storage[key.Hex()]; ok {
+		return
+	}
+	storage[key.Hex()] = t.statedb.GetState(addr, key).Hex()
+}
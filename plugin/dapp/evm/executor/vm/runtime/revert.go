@@ -0,0 +1,77 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package runtime
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/common"
+)
+
+var (
+	// revertSelector Error(string) 的方法选择器
+	revertSelector = common.FromHex("0x08c379a0")
+	// panicSelector Panic(uint256) 的方法选择器
+	panicSelector = common.FromHex("0x4e487b71")
+)
+
+// solidity 0.8 之后由编译器插入的检查失败时返回的错误码
+var panicReasons = map[uint64]string{
+	0x00: "generic panic",
+	0x01: "assert(false)",
+	0x11: "arithmetic underflow or overflow",
+	0x12: "division or modulo by zero",
+	0x21: "enum overflow",
+	0x22: "invalid encoded storage byte array accessed",
+	0x31: "out-of-bounds array access; popping on an empty array",
+	0x32: "out-of-bounds access of an array or bytesN",
+	0x41: "out of memory",
+	0x51: "uninitialized function",
+}
+
+// UnpackRevert 解析合约revert时返回的 Error(string) 或 Panic(uint256) 数据，返回其中的错误信息
+func UnpackRevert(data []byte) (string, bool) {
+	if len(data) < 4 {
+		return "", false
+	}
+	switch {
+	case bytes.Equal(data[:4], revertSelector):
+		return unpackErrorString(data[4:])
+	case bytes.Equal(data[:4], panicSelector):
+		return unpackPanic(data[4:])
+	}
+	return "", false
+}
+
+func unpackErrorString(data []byte) (string, bool) {
+	if len(data) < 32+32 {
+		return "", false
+	}
+	offset := new(big.Int).SetBytes(data[:32])
+	if !offset.IsUint64() || offset.Uint64()+32 > uint64(len(data)) {
+		return "", false
+	}
+	start := offset.Uint64() + 32
+	size := new(big.Int).SetBytes(data[start-32 : start])
+	if !size.IsUint64() || start+size.Uint64() > uint64(len(data)) {
+		return "", false
+	}
+	return string(data[start : start+size.Uint64()]), true
+}
+
+func unpackPanic(data []byte) (string, bool) {
+	if len(data) != 32 {
+		return "", false
+	}
+	code := new(big.Int).SetBytes(data)
+	if code.IsUint64() {
+		if reason, ok := panicReasons[code.Uint64()]; ok {
+			return fmt.Sprintf("panic: %s (0x%x)", reason, code.Uint64()), true
+		}
+	}
+	return fmt.Sprintf("panic: unknown panic code 0x%x", code), true
+}
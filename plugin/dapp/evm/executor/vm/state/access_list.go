@@ -0,0 +1,79 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package state
+
+import (
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/common"
+)
+
+// accessList 交易执行过程中访问过的地址和存储槽位（EIP-2929）
+// 首次访问（冷访问）和再次访问（热访问）的Gas价格不同，此对象只在单个交易的生命周期内有效
+type accessList struct {
+	addresses map[string]struct{}
+	slots     map[string]map[common.Hash]struct{}
+}
+
+func newAccessList() *accessList {
+	return &accessList{
+		addresses: make(map[string]struct{}),
+		slots:     make(map[string]map[common.Hash]struct{}),
+	}
+}
+
+// ContainsAddress 地址是否在访问列表中
+func (al *accessList) ContainsAddress(addr string) bool {
+	_, ok := al.addresses[addr]
+	return ok
+}
+
+// Contains 地址和存储槽位是否在访问列表中
+func (al *accessList) Contains(addr string, slot common.Hash) (addressPresent bool, slotPresent bool) {
+	_, addressPresent = al.addresses[addr]
+	if slots, ok := al.slots[addr]; ok {
+		_, slotPresent = slots[slot]
+	}
+	return addressPresent, slotPresent
+}
+
+// AddAddress 添加地址，返回值表示是否为新增
+func (al *accessList) AddAddress(addr string) bool {
+	if _, ok := al.addresses[addr]; ok {
+		return false
+	}
+	al.addresses[addr] = struct{}{}
+	return true
+}
+
+// AddSlot 添加存储槽位，返回值分别表示地址和槽位是否为新增
+func (al *accessList) AddSlot(addr string, slot common.Hash) (addrChange bool, slotChange bool) {
+	addrChange = al.AddAddress(addr)
+	slots, ok := al.slots[addr]
+	if !ok {
+		slots = make(map[common.Hash]struct{})
+		al.slots[addr] = slots
+	}
+	if _, ok := slots[slot]; ok {
+		return addrChange, false
+	}
+	slots[slot] = struct{}{}
+	return addrChange, true
+}
+
+// DeleteAddress 删除地址，仅用于回滚
+func (al *accessList) DeleteAddress(addr string) {
+	delete(al.addresses, addr)
+}
+
+// DeleteSlot 删除存储槽位，仅用于回滚
+func (al *accessList) DeleteSlot(addr string, slot common.Hash) {
+	slots, ok := al.slots[addr]
+	if !ok {
+		return
+	}
+	delete(slots, slot)
+	if len(slots) == 0 {
+		delete(al.slots, addr)
+	}
+}
@@ -85,4 +85,15 @@ type EVMStateDB interface {
 
 	// GetConfig 获取系统配置
 	GetConfig() *types.Chain33Config
+
+	// PrepareAccessList 交易执行前重置访问列表（EIP-2929）
+	PrepareAccessList(sender string, dst string, precompiles []string)
+	// AddressInAccessList 地址是否在访问列表中
+	AddressInAccessList(addr string) bool
+	// SlotInAccessList 地址和存储槽位是否在访问列表中
+	SlotInAccessList(addr string, slot common.Hash) (addressOk bool, slotOk bool)
+	// AddAddressToAccessList 将地址加入访问列表
+	AddAddressToAccessList(addr string)
+	// AddSlotToAccessList 将地址和存储槽位加入访问列表
+	AddSlotToAccessList(addr string, slot common.Hash)
 }
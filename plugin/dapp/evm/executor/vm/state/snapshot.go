@@ -156,6 +156,19 @@ type (
 		baseChange
 		hash common.Hash
 	}
+
+	// 访问列表添加地址事件（EIP-2929）
+	accessListAddAccountChange struct {
+		baseChange
+		address string
+	}
+
+	// 访问列表添加存储槽位事件（EIP-2929）
+	accessListAddSlotChange struct {
+		baseChange
+		address string
+		slot    common.Hash
+	}
 )
 
 // 在baseChang中定义三个基本操作，子对象中只需要实现必要的操作
@@ -311,3 +324,12 @@ func (ch transferChange) getData(mdb *MemoryStateDB) []*types.KeyValue {
 func (ch transferChange) getLog(mdb *MemoryStateDB) []*types.ReceiptLog {
 	return ch.logs
 }
+
+// 访问列表只影响Gas计算，不产生状态数据，回滚时从列表中删除即可
+func (ch accessListAddAccountChange) revert(mdb *MemoryStateDB) {
+	mdb.accessList.DeleteAddress(ch.address)
+}
+
+func (ch accessListAddSlotChange) revert(mdb *MemoryStateDB) {
+	mdb.accessList.DeleteSlot(ch.address, ch.slot)
+}
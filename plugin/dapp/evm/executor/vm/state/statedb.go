@@ -63,6 +63,9 @@ type MemoryStateDB struct {
 	stateDirty map[string]interface{}
 	dataDirty  map[string]interface{}
 	api        client.QueueProtocolAPI
+
+	// 当前交易的访问列表（EIP-2929）
+	accessList *accessList
 }
 
 // NewMemoryStateDB 基于执行器框架的三个DB构建内存状态机对象
@@ -82,6 +85,7 @@ func NewMemoryStateDB(StateDB db.KV, LocalDB db.KVDB, CoinsAccount *account.DB,
 		refund:       0,
 		txIndex:      0,
 		api:          api,
+		accessList:   newAccessList(),
 	}
 	return mdb
 }
@@ -93,6 +97,47 @@ func (mdb *MemoryStateDB) Prepare(txHash common.Hash, txIndex int) {
 	mdb.txIndex = txIndex
 }
 
+// PrepareAccessList 每一个交易执行之前重置访问列表（EIP-2929）
+// 交易的发送方、接收方以及预编译合约地址默认处于热访问状态
+func (mdb *MemoryStateDB) PrepareAccessList(sender string, dst string, precompiles []string) {
+	mdb.accessList = newAccessList()
+	mdb.accessList.AddAddress(sender)
+	if dst != "" {
+		mdb.accessList.AddAddress(dst)
+	}
+	for _, addr := range precompiles {
+		mdb.accessList.AddAddress(addr)
+	}
+}
+
+// AddressInAccessList 地址是否在访问列表中
+func (mdb *MemoryStateDB) AddressInAccessList(addr string) bool {
+	return mdb.accessList.ContainsAddress(addr)
+}
+
+// SlotInAccessList 地址和存储槽位是否在访问列表中
+func (mdb *MemoryStateDB) SlotInAccessList(addr string, slot common.Hash) (addressOk bool, slotOk bool) {
+	return mdb.accessList.Contains(addr, slot)
+}
+
+// AddAddressToAccessList 将地址加入访问列表
+func (mdb *MemoryStateDB) AddAddressToAccessList(addr string) {
+	if mdb.accessList.AddAddress(addr) {
+		mdb.addChange(accessListAddAccountChange{address: addr})
+	}
+}
+
+// AddSlotToAccessList 将地址和存储槽位加入访问列表
+func (mdb *MemoryStateDB) AddSlotToAccessList(addr string, slot common.Hash) {
+	addrMod, slotMod := mdb.accessList.AddSlot(addr, slot)
+	if addrMod {
+		mdb.addChange(accessListAddAccountChange{address: addr})
+	}
+	if slotMod {
+		mdb.addChange(accessListAddSlotChange{address: addr, slot: slot})
+	}
+}
+
 // CreateAccount 创建一个新的合约账户对象
 func (mdb *MemoryStateDB) CreateAccount(addr, creator string, execName, alias string) {
 	acc := mdb.GetAccount(addr)
@@ -721,6 +766,18 @@ func (mdb *MemoryStateDB) PrintLogs() {
 	}
 }
 
+// GetEventLogs 将当前交易生成的合约日志转换为交易回执日志
+func (mdb *MemoryStateDB) GetEventLogs() (logs []*types.ReceiptLog) {
+	for _, item := range mdb.logs[mdb.txHash] {
+		evmLog := &evmtypes.EVMLog{Address: item.Address.String(), Data: item.Data}
+		for _, topic := range item.Topics {
+			evmLog.Topics = append(evmLog.Topics, topic.Bytes())
+		}
+		logs = append(logs, &types.ReceiptLog{Ty: evmtypes.TyLogEVMEventData, Log: types.Encode(evmLog)})
+	}
+	return logs
+}
+
 // WritePreimages 打印本区块内生成的preimages日志
 func (mdb *MemoryStateDB) WritePreimages(number int64) {
 	for k, v := range mdb.preimages {
@@ -0,0 +1,327 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/33cn/chain33/common/address"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/common"
+	evmtypes "github.com/33cn/plugin/plugin/dapp/evm/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// 日志索引中交易内日志序号的上限，用于生成区块内的日志序号
+const maxLogsPerTx = 100000
+
+var errBlockTag = errors.New("invalid block tag")
+
+// ethAPI 以太坊兼容的eth_*接口实现，所有查询都基于最新区块的状态
+type ethAPI struct {
+	cli *channelClient
+}
+
+// ethCallArgs eth_call和eth_estimateGas的调用参数
+type ethCallArgs struct {
+	From     string         `json:"from"`
+	To       string         `json:"to"`
+	Gas      hexutil.Uint64 `json:"gas"`
+	GasPrice *hexutil.Big   `json:"gasPrice"`
+	Value    *hexutil.Big   `json:"value"`
+	Data     hexutil.Bytes  `json:"data"`
+	Input    hexutil.Bytes  `json:"input"`
+}
+
+// ethFilter eth_getLogs的过滤条件
+type ethFilter struct {
+	FromBlock string            `json:"fromBlock"`
+	ToBlock   string            `json:"toBlock"`
+	Address   json.RawMessage   `json:"address"`
+	Topics    []json.RawMessage `json:"topics"`
+}
+
+// ethLog eth_getLogs返回的日志
+type ethLog struct {
+	Address          string         `json:"address"`
+	Topics           []string       `json:"topics"`
+	Data             hexutil.Bytes  `json:"data"`
+	BlockNumber      hexutil.Uint64 `json:"blockNumber"`
+	TransactionHash  string         `json:"transactionHash"`
+	TransactionIndex hexutil.Uint   `json:"transactionIndex"`
+	BlockHash        string         `json:"blockHash"`
+	LogIndex         hexutil.Uint   `json:"logIndex"`
+	Removed          bool           `json:"removed"`
+}
+
+// ChainID eth_chainId
+func (e *ethAPI) ChainID() (hexutil.Uint64, error) {
+	return hexutil.Uint64(e.cli.GetConfig().GetChainID()), nil
+}
+
+// BlockNumber eth_blockNumber
+func (e *ethAPI) BlockNumber() (hexutil.Uint64, error) {
+	header, err := e.cli.GetLastHeader()
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(header.Height), nil
+}
+
+// GasPrice eth_gasPrice，chain33中gasPrice固定为1，换算为wei
+func (e *ethAPI) GasPrice() (*hexutil.Big, error) {
+	return (*hexutil.Big)(big.NewInt(evmtypes.WeiPerAmount)), nil
+}
+
+// Call eth_call
+func (e *ethAPI) Call(args ethCallArgs) (hexutil.Bytes, error) {
+	to, err := toChain33Addr(args.To)
+	if err != nil || to == "" {
+		return nil, types.ErrInvalidAddress
+	}
+	caller, err := toChain33Addr(args.From)
+	if err != nil {
+		return nil, err
+	}
+	req := &evmtypes.EvmQueryReq{Address: to, Caller: caller, Data: args.data()}
+	msg, err := e.cli.Query(evmtypes.ExecutorName, "Query", req)
+	if err != nil {
+		return nil, err
+	}
+	resp := msg.(*evmtypes.EvmQueryResp)
+	// 执行失败时只返回错误信息
+	if resp.GetRawData() == "" && resp.GetJsonData() != "" {
+		return nil, errors.New(resp.GetJsonData())
+	}
+	return common.FromHex(resp.GetRawData()), nil
+}
+
+// EstimateGas eth_estimateGas，未指定to时估算创建合约消耗的gas
+func (e *ethAPI) EstimateGas(args ethCallArgs) (hexutil.Uint64, error) {
+	to, err := toChain33Addr(args.To)
+	if err != nil {
+		return 0, err
+	}
+	caller, err := toChain33Addr(args.From)
+	if err != nil {
+		return 0, err
+	}
+	var amount uint64
+	if args.Value != nil {
+		value := new(big.Int).Quo(args.Value.ToInt(), big.NewInt(evmtypes.WeiPerAmount))
+		if !value.IsUint64() {
+			return 0, types.ErrAmount
+		}
+		amount = value.Uint64()
+	}
+	req := &evmtypes.EstimateEVMGasReq{To: to, Code: args.data(), Caller: caller, Amount: amount}
+	msg, err := e.cli.Query(evmtypes.ExecutorName, "EstimateGas", req)
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(msg.(*evmtypes.EstimateEVMGasResp).GetGas()), nil
+}
+
+// GetCode eth_getCode
+func (e *ethAPI) GetCode(addr string) (hexutil.Bytes, error) {
+	contract, err := toChain33Addr(addr)
+	if err != nil || contract == "" {
+		return nil, types.ErrInvalidAddress
+	}
+	msg, err := e.cli.Query(evmtypes.ExecutorName, "GetCode", &evmtypes.EvmGetCodeReq{Addr: contract})
+	if err != nil {
+		return nil, err
+	}
+	return msg.(*evmtypes.EvmGetCodeResp).GetCode(), nil
+}
+
+// GetStorageAt eth_getStorageAt
+func (e *ethAPI) GetStorageAt(addr string, position *hexutil.Big) (hexutil.Bytes, error) {
+	contract, err := toChain33Addr(addr)
+	if err != nil || contract == "" {
+		return nil, types.ErrInvalidAddress
+	}
+	if position == nil || position.ToInt().Sign() < 0 || position.ToInt().BitLen() > 256 {
+		return nil, types.ErrInvalidParam
+	}
+	key := common.BigToHash(position.ToInt()).Hex()
+	msg, err := e.cli.Query(evmtypes.ExecutorName, "GetStorageAt", &evmtypes.EvmGetStorageAtReq{Addr: contract, Key: key})
+	if err != nil {
+		return nil, err
+	}
+	return common.BytesToHash(msg.(*evmtypes.EvmGetStorageAtResp).GetValue()).Bytes(), nil
+}
+
+// SendRawTransaction eth_sendRawTransaction，返回以太坊交易哈希，以太坊工具会校验返回的哈希
+func (e *ethAPI) SendRawTransaction(raw hexutil.Bytes) (string, error) {
+	tx, err := evmtypes.NewTxFromEthTx(e.cli.GetConfig(), raw)
+	if err != nil {
+		return "", err
+	}
+	reply, err := e.cli.SendTx(tx)
+	if err != nil {
+		return "", err
+	}
+	rlog.Debug("SendRawTransaction", "ethHash", common.Bytes2Hex(evmtypes.EthTxHash(raw)), "hash", common.Bytes2Hex(reply.GetMsg()))
+	return common.Bytes2Hex(evmtypes.EthTxHash(raw)), nil
+}
+
+// GetLogs eth_getLogs，使用执行器在localdb中建立的事件日志索引查询
+func (e *ethAPI) GetLogs(filter ethFilter) ([]*ethLog, error) {
+	header, err := e.cli.GetLastHeader()
+	if err != nil {
+		return nil, err
+	}
+	from, err := parseBlockTag(filter.FromBlock, header.Height)
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseBlockTag(filter.ToBlock, header.Height)
+	if err != nil {
+		return nil, err
+	}
+	if to > header.Height {
+		to = header.Height
+	}
+	logs := make([]*ethLog, 0)
+	if from > to {
+		return logs, nil
+	}
+	req, err := newGetLogsReq(filter, from, to)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := e.cli.Query(evmtypes.ExecutorName, "GetLogs", req)
+	if err != nil {
+		return nil, err
+	}
+
+	blockHashes := make(map[int64]string)
+	for _, item := range msg.(*evmtypes.EvmGetLogsResp).GetLogs() {
+		blockHash, ok := blockHashes[item.GetHeight()]
+		if !ok {
+			reply, err := e.cli.GetBlockHash(&types.ReqInt{Height: item.GetHeight()})
+			if err != nil {
+				return nil, err
+			}
+			blockHash = common.Bytes2Hex(reply.GetHash())
+			blockHashes[item.GetHeight()] = blockHash
+		}
+		out := &ethLog{
+			Address:          toEthAddr(item.GetAddress()),
+			Data:             item.GetData(),
+			BlockNumber:      hexutil.Uint64(item.GetHeight()),
+			TransactionHash:  common.Bytes2Hex(item.GetTxHash()),
+			TransactionIndex: hexutil.Uint(item.GetTxIndex()),
+			BlockHash:        blockHash,
+			// 索引中的日志序号是交易内的序号，按照交易序号展开，保证在区块内唯一且递增
+			LogIndex: hexutil.Uint(item.GetTxIndex()*maxLogsPerTx + int64(item.GetLogIndex())),
+		}
+		out.Topics = make([]string, 0, len(item.GetTopics()))
+		for _, topic := range item.GetTopics() {
+			out.Topics = append(out.Topics, common.BytesToHash(topic).Hex())
+		}
+		logs = append(logs, out)
+	}
+	return logs, nil
+}
+
+// 过滤条件转换为日志索引的查询请求，地址之间为或的关系，每个位置的主题之间为或的关系
+func newGetLogsReq(filter ethFilter, from, to int64) (*evmtypes.EvmGetLogsReq, error) {
+	req := &evmtypes.EvmGetLogsReq{FromBlock: from, ToBlock: to}
+	addrs, err := stringOrList(filter.Address)
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrs {
+		chain33Addr, err := toChain33Addr(addr)
+		if err != nil || chain33Addr == "" {
+			return nil, types.ErrInvalidAddress
+		}
+		req.Addresses = append(req.Addresses, chain33Addr)
+	}
+	for _, raw := range filter.Topics {
+		topics, err := stringOrList(raw)
+		if err != nil {
+			return nil, err
+		}
+		// 未指定的位置匹配任意主题
+		item := &evmtypes.EvmLogTopics{}
+		for _, topic := range topics {
+			item.Topics = append(item.Topics, common.BytesToHash(common.FromHex(topic)).Hex())
+		}
+		req.Topics = append(req.Topics, item)
+	}
+	return req, nil
+}
+
+// 解析null、单个字符串或字符串数组格式的参数
+func stringOrList(raw json.RawMessage) ([]string, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	var one string
+	if err := json.Unmarshal(raw, &one); err == nil {
+		return []string{one}, nil
+	}
+	var list []string
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, types.ErrInvalidParam
+	}
+	return list, nil
+}
+
+// 解析区块高度参数，支持十六进制高度以及earliest、latest、pending标签
+func parseBlockTag(tag string, latest int64) (int64, error) {
+	switch tag {
+	case "", "latest", "pending":
+		return latest, nil
+	case "earliest":
+		return 0, nil
+	}
+	height, err := hexutil.DecodeUint64(tag)
+	if err != nil || int64(height) < 0 {
+		return 0, errBlockTag
+	}
+	return int64(height), nil
+}
+
+// 以太坊格式的十六进制地址转换为chain33地址，同时兼容chain33格式的地址，空字符串返回空地址
+// 以太坊格式交易的合约调用者地址也是以太坊方式生成的20字节地址，eth_call中的from与链上执行时的msg.sender一致
+func toChain33Addr(addr string) (string, error) {
+	if addr == "" {
+		return "", nil
+	}
+	if strings.HasPrefix(addr, "0x") || strings.HasPrefix(addr, "0X") {
+		b, err := hexutil.Decode(addr)
+		if err != nil || len(b) != common.AddressLength {
+			return "", types.ErrInvalidAddress
+		}
+		return common.BytesToAddress(b).String(), nil
+	}
+	if err := address.CheckAddress(addr); err != nil {
+		return "", err
+	}
+	return addr, nil
+}
+
+// chain33地址转换为以太坊格式的十六进制地址
+func toEthAddr(addr string) string {
+	a, err := address.NewAddrFromString(addr)
+	if err != nil {
+		return addr
+	}
+	return common.Bytes2Hex(a.Hash160[:])
+}
+
+func (args *ethCallArgs) data() []byte {
+	if len(args.Input) > 0 {
+		return args.Input
+	}
+	return args.Data
+}
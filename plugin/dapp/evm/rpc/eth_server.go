@@ -0,0 +1,174 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/33cn/chain33/client"
+	rpctypes "github.com/33cn/chain33/rpc/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// JSON-RPC 2.0 错误码
+const (
+	ethErrParse          = -32700
+	ethErrInvalidRequest = -32600
+	ethErrMethodNotFound = -32601
+	ethErrInvalidParams  = -32602
+	ethErrServer         = -32000
+)
+
+// 单个HTTP请求体的最大长度，批量请求也受此限制
+const maxEthRequestSize = 5 * 1024 * 1024
+
+type ethRequest struct {
+	JSONRPC string            `json:"jsonrpc"`
+	ID      json.RawMessage   `json:"id"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params"`
+}
+
+type ethError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type ethResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *ethError       `json:"error,omitempty"`
+}
+
+// EthHandler 以太坊兼容的JSON-RPC 2.0服务，方法名为eth_*格式，参数按位置传递，支持批量请求
+type EthHandler struct {
+	api *ethAPI
+}
+
+// NewEthHandler 新建eth_*接口的HTTP处理对象
+func NewEthHandler(api client.QueueProtocolAPI) *EthHandler {
+	cli := &channelClient{ChannelClient: rpctypes.ChannelClient{QueueProtocolAPI: api}}
+	return &EthHandler{api: &ethAPI{cli: cli}}
+}
+
+// ServeHTTP 处理单个或批量的JSON-RPC请求
+func (h *EthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	data, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxEthRequestSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var resp interface{}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		var reqs []*ethRequest
+		if err := json.Unmarshal(trimmed, &reqs); err != nil {
+			resp = newEthError(nil, ethErrParse, err.Error())
+		} else {
+			resps := make([]*ethResponse, 0, len(reqs))
+			for _, req := range reqs {
+				resps = append(resps, h.handle(req))
+			}
+			resp = resps
+		}
+	} else {
+		var req ethRequest
+		if err := json.Unmarshal(trimmed, &req); err != nil {
+			resp = newEthError(nil, ethErrParse, err.Error())
+		} else {
+			resp = h.handle(&req)
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *EthHandler) handle(req *ethRequest) *ethResponse {
+	if req == nil || req.Method == "" {
+		return newEthError(nil, ethErrInvalidRequest, "invalid request")
+	}
+	var (
+		result interface{}
+		err    error
+	)
+	params := req.Params
+	switch req.Method {
+	case "eth_chainId":
+		result, err = h.api.ChainID()
+	case "eth_blockNumber":
+		result, err = h.api.BlockNumber()
+	case "eth_gasPrice":
+		result, err = h.api.GasPrice()
+	case "eth_call":
+		var args ethCallArgs
+		if !decodeParams(params, 1, &args) {
+			return newEthError(req.ID, ethErrInvalidParams, "invalid params")
+		}
+		result, err = h.api.Call(args)
+	case "eth_estimateGas":
+		var args ethCallArgs
+		if !decodeParams(params, 1, &args) {
+			return newEthError(req.ID, ethErrInvalidParams, "invalid params")
+		}
+		result, err = h.api.EstimateGas(args)
+	case "eth_getCode":
+		var addr string
+		if !decodeParams(params, 1, &addr) {
+			return newEthError(req.ID, ethErrInvalidParams, "invalid params")
+		}
+		result, err = h.api.GetCode(addr)
+	case "eth_getStorageAt":
+		var (
+			addr     string
+			position hexutil.Big
+		)
+		if !decodeParams(params, 2, &addr, &position) {
+			return newEthError(req.ID, ethErrInvalidParams, "invalid params")
+		}
+		result, err = h.api.GetStorageAt(addr, &position)
+	case "eth_getLogs":
+		var filter ethFilter
+		if !decodeParams(params, 1, &filter) {
+			return newEthError(req.ID, ethErrInvalidParams, "invalid params")
+		}
+		result, err = h.api.GetLogs(filter)
+	case "eth_sendRawTransaction":
+		var raw hexutil.Bytes
+		if !decodeParams(params, 1, &raw) {
+			return newEthError(req.ID, ethErrInvalidParams, "invalid params")
+		}
+		result, err = h.api.SendRawTransaction(raw)
+	default:
+		return newEthError(req.ID, ethErrMethodNotFound, "the method "+req.Method+" does not exist")
+	}
+	if err != nil {
+		return newEthError(req.ID, ethErrServer, err.Error())
+	}
+	return &ethResponse{JSONRPC: "2.0", ID: req.ID, Result: result}
+}
+
+// 按位置解析前required个参数，多余的参数（如区块标签）被忽略
+func decodeParams(params []json.RawMessage, required int, args ...interface{}) bool {
+	if len(params) < required {
+		return false
+	}
+	for i, arg := range args {
+		if err := json.Unmarshal(params[i], arg); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func newEthError(id json.RawMessage, code int, msg string) *ethResponse {
+	return &ethResponse{JSONRPC: "2.0", ID: id, Error: &ethError{Code: code, Message: msg}}
+}
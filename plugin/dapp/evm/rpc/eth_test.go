@@ -0,0 +1,220 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/33cn/chain33/common/address"
+	commonlog "github.com/33cn/chain33/common/log"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/chain33/util"
+	"github.com/33cn/chain33/util/testnode"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/common"
+	"github.com/33cn/plugin/plugin/dapp/evm/rpc"
+	evmtypes "github.com/33cn/plugin/plugin/dapp/evm/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	// 注册system和plugin 包
+	_ "github.com/33cn/chain33/system"
+	_ "github.com/33cn/plugin/plugin"
+)
+
+func init() {
+	commonlog.SetLogLevel("error")
+}
+
+// 合约创建时在存储位置0写入0x2a，调用时生成一条主题为0x07的日志，并返回存储位置0的值
+var (
+	initCode    = "602a6000556017601160003960176000f3"
+	runtimeCode = "602a600052600760206000a160005460005260206000f3"
+	eventTopic  = common.BigToHash(big.NewInt(7)).Hex()
+	slotValue   = common.BigToHash(big.NewInt(0x2a)).Hex()
+	// 调用时把调用者地址写入存储位置0，并返回调用者地址
+	callerCode = "600d600c600039600d6000f3" + "338060005560005260206000f3"
+)
+
+type ethResult struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func ethCall(t *testing.T, url, method string, params ...interface{}) json.RawMessage {
+	if params == nil {
+		params = []interface{}{}
+	}
+	body, _ := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+	resp, err := http.Post(url, "application/json", bytes.NewReader(body))
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	var res ethResult
+	require.Nil(t, json.NewDecoder(resp.Body).Decode(&res))
+	require.Nil(t, res.Error, "%s: %v", method, res.Error)
+	return res.Result
+}
+
+// 链ID为0时以太坊工具使用不带链ID的签名
+func ethSigner(chainID int64) ethtypes.Signer {
+	if chainID == 0 {
+		return ethtypes.HomesteadSigner{}
+	}
+	return ethtypes.NewEIP155Signer(big.NewInt(chainID))
+}
+
+func sendEthTx(t *testing.T, mocker *testnode.Chain33Mock, url string, key *ecdsa.PrivateKey, ethTx *ethtypes.Transaction) []byte {
+	signed, err := ethtypes.SignTx(ethTx, ethSigner(int64(mocker.GetAPI().GetConfig().GetChainID())), key)
+	require.Nil(t, err)
+	raw, err := rlp.EncodeToBytes(signed)
+	require.Nil(t, err)
+
+	// 返回以太坊交易哈希，按照chain33交易哈希等待交易执行
+	var hash string
+	require.Nil(t, json.Unmarshal(ethCall(t, url, "eth_sendRawTransaction", common.Bytes2Hex(raw)), &hash))
+	assert.Equal(t, signed.Hash().Hex(), hash)
+	tx, err := evmtypes.NewTxFromEthTx(mocker.GetAPI().GetConfig(), raw)
+	require.Nil(t, err)
+	txHash := tx.Hash()
+	detail, err := mocker.WaitTx(txHash)
+	require.Nil(t, err)
+	require.Equal(t, int32(types.ExecOk), detail.Receipt.Ty)
+	return txHash
+}
+
+func TestEthRPC(t *testing.T) {
+	mocker := testnode.New("--notset--", nil)
+	defer mocker.Close()
+	mocker.Listen()
+	cfg := mocker.GetAPI().GetConfig()
+
+	server := httptest.NewServer(rpc.NewEthHandler(mocker.GetAPI()))
+	defer server.Close()
+
+	var chainID string
+	require.Nil(t, json.Unmarshal(ethCall(t, server.URL, "eth_chainId"), &chainID))
+	assert.Equal(t, "0x"+big.NewInt(int64(cfg.GetChainID())).Text(16), chainID)
+
+	// 请求体超过长度限制
+	resp, err := http.Post(server.URL, "application/json", bytes.NewReader(make([]byte, 6*1024*1024)))
+	require.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// 以太坊私钥对应的chain33地址需要先有手续费
+	key, err := ethcrypto.GenerateKey()
+	require.Nil(t, err)
+	sender := address.PubKeyToAddr(ethcrypto.CompressPubkey(&key.PublicKey))
+	fund := util.CreateCoinsTx(cfg, mocker.GetGenesisKey(), sender, 10*types.Coin)
+	_, err = mocker.WaitTx(mocker.SendTx(fund))
+	require.Nil(t, err)
+
+	gasPrice := big.NewInt(evmtypes.WeiPerAmount)
+	createHash := sendEthTx(t, mocker, server.URL, key, ethtypes.NewContractCreation(0, big.NewInt(0), 1000000, gasPrice, common.FromHex(initCode+runtimeCode)))
+	contract := common.Bytes2Hex(common.NewAddress(cfg, createHash).Bytes())
+
+	var code string
+	require.Nil(t, json.Unmarshal(ethCall(t, server.URL, "eth_getCode", contract, "latest"), &code))
+	assert.Equal(t, "0x"+runtimeCode, code)
+
+	var storage string
+	require.Nil(t, json.Unmarshal(ethCall(t, server.URL, "eth_getStorageAt", contract, "0x0", "latest"), &storage))
+	assert.Equal(t, slotValue, storage)
+
+	callArgs := map[string]string{"from": ethcrypto.PubkeyToAddress(key.PublicKey).Hex(), "to": contract, "data": "0x"}
+	var ret string
+	require.Nil(t, json.Unmarshal(ethCall(t, server.URL, "eth_call", callArgs, "latest"), &ret))
+	assert.Equal(t, slotValue, ret)
+
+	var gas string
+	require.Nil(t, json.Unmarshal(ethCall(t, server.URL, "eth_estimateGas", callArgs), &gas))
+	assert.NotEqual(t, "0x0", gas)
+
+	to := ethcommon.HexToAddress(contract)
+	callTx := ethtypes.NewTransaction(1, to, big.NewInt(0), 1000000, gasPrice, nil)
+	callHash := sendEthTx(t, mocker, server.URL, key, callTx)
+
+	// 同一笔以太坊交易不能再次发送
+	signed, err := ethtypes.SignTx(callTx, ethSigner(int64(cfg.GetChainID())), key)
+	require.Nil(t, err)
+	raw, err := rlp.EncodeToBytes(signed)
+	require.Nil(t, err)
+	body, _ := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "method": "eth_sendRawTransaction", "params": []interface{}{common.Bytes2Hex(raw)}})
+	resp, err = http.Post(server.URL, "application/json", bytes.NewReader(body))
+	require.Nil(t, err)
+	var res ethResult
+	require.Nil(t, json.NewDecoder(resp.Body).Decode(&res))
+	resp.Body.Close()
+	assert.NotNil(t, res.Error)
+
+	var logs []map[string]interface{}
+	filter := map[string]interface{}{"fromBlock": "0x0", "address": contract, "topics": []interface{}{eventTopic}}
+	require.Nil(t, json.Unmarshal(ethCall(t, server.URL, "eth_getLogs", filter), &logs))
+	require.Equal(t, 1, len(logs))
+	assert.Equal(t, common.Bytes2Hex(callHash), logs[0]["transactionHash"])
+	assert.Equal(t, slotValue, logs[0]["data"])
+
+	filter["topics"] = []interface{}{nil, eventTopic}
+	require.Nil(t, json.Unmarshal(ethCall(t, server.URL, "eth_getLogs", filter), &logs))
+	assert.Equal(t, 0, len(logs))
+
+	// eth_call中的from与链上交易的msg.sender使用相同的以太坊地址
+	ethAddr := common.BytesToHash(ethcrypto.PubkeyToAddress(key.PublicKey).Bytes()).Hex()
+	callerHash := sendEthTx(t, mocker, server.URL, key, ethtypes.NewContractCreation(2, big.NewInt(0), 1000000, gasPrice, common.FromHex(callerCode)))
+	callerContract := common.Bytes2Hex(common.NewAddress(cfg, callerHash).Bytes())
+	callArgs["to"] = callerContract
+	require.Nil(t, json.Unmarshal(ethCall(t, server.URL, "eth_call", callArgs, "latest"), &ret))
+	assert.Equal(t, ethAddr, ret)
+	sendEthTx(t, mocker, server.URL, key, ethtypes.NewTransaction(3, ethcommon.HexToAddress(callerContract), big.NewInt(0), 1000000, gasPrice, nil))
+	require.Nil(t, json.Unmarshal(ethCall(t, server.URL, "eth_getStorageAt", callerContract, "0x0", "latest"), &storage))
+	assert.Equal(t, ethAddr, storage)
+}
+
+func TestEthTxSignature(t *testing.T) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	key, err := ethcrypto.GenerateKey()
+	require.Nil(t, err)
+	ethTx := ethtypes.NewContractCreation(0, big.NewInt(0), 100000, big.NewInt(evmtypes.WeiPerAmount), common.FromHex(initCode))
+	signed, err := ethtypes.SignTx(ethTx, ethSigner(int64(cfg.GetChainID())), key)
+	require.Nil(t, err)
+	raw, err := rlp.EncodeToBytes(signed)
+	require.Nil(t, err)
+
+	tx, err := evmtypes.NewTxFromEthTx(cfg, raw)
+	require.Nil(t, err)
+	assert.True(t, tx.CheckSign())
+	assert.Equal(t, address.PubKeyToAddr(ethcrypto.CompressPubkey(&key.PublicKey)), tx.From())
+
+	// 修改以太坊签名未覆盖的交易内容后验签失败
+	tx.Fee++
+	assert.False(t, tx.CheckSign())
+	tx.Fee--
+	// Expire不在以太坊签名的范围内，修改后可以生成新的交易哈希，必须为0
+	tx.Expire = 1
+	assert.False(t, tx.CheckSign())
+	tx.Expire = 0
+	tx.Execer = []byte("coins")
+	assert.False(t, tx.CheckSign())
+
+	// 链ID不一致的交易不被接受
+	signed, err = ethtypes.SignTx(ethTx, ethtypes.NewEIP155Signer(big.NewInt(int64(cfg.GetChainID())+1)), key)
+	require.Nil(t, err)
+	raw, err = rlp.EncodeToBytes(signed)
+	require.Nil(t, err)
+	_, err = evmtypes.NewTxFromEthTx(cfg, raw)
+	assert.Equal(t, evmtypes.ErrEthTxChainID, err)
+}
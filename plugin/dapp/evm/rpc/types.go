@@ -5,7 +5,22 @@
 package rpc
 
 import (
+	"net/http"
+	"time"
+
+	log "github.com/33cn/chain33/common/log/log15"
 	"github.com/33cn/chain33/rpc/types"
+	ctypes "github.com/33cn/chain33/types"
+	evmtypes "github.com/33cn/plugin/plugin/dapp/evm/types"
+)
+
+var rlog = log.New("module", "evm.rpc")
+
+// 以太坊兼容的JSON-RPC服务的超时时间
+const (
+	ethReadTimeout  = 10 * time.Second
+	ethWriteTimeout = 30 * time.Second
+	ethIdleTimeout  = 60 * time.Second
 )
 
 // Jrpc json rpc struct
@@ -22,9 +37,33 @@ type channelClient struct {
 	types.ChannelClient
 }
 
+// subConfig evm执行器的子配置，ethRpcBindAddr不为空时启动以太坊兼容的JSON-RPC服务
+type subConfig struct {
+	EthRPCBindAddr string `json:"ethRpcBindAddr"`
+}
+
 // Init init grpc param
 func Init(name string, s types.RPCServer) {
 	cli := &channelClient{}
 	grpc := &Grpc{channelClient: cli}
 	cli.Init(name, s, &Jrpc{cli: cli}, grpc)
+
+	var subcfg subConfig
+	if sub, ok := cli.GetConfig().GetSubConfig().Exec[evmtypes.ExecutorName]; ok {
+		ctypes.MustDecode(sub, &subcfg)
+	}
+	if subcfg.EthRPCBindAddr != "" {
+		server := &http.Server{
+			Addr:              subcfg.EthRPCBindAddr,
+			Handler:           &EthHandler{api: &ethAPI{cli: cli}},
+			ReadHeaderTimeout: ethReadTimeout,
+			ReadTimeout:       ethReadTimeout,
+			WriteTimeout:      ethWriteTimeout,
+			IdleTimeout:       ethIdleTimeout,
+		}
+		go func() {
+			err := server.ListenAndServe()
+			rlog.Error("eth rpc server stopped", "addr", subcfg.EthRPCBindAddr, "err", err)
+		}()
+	}
 }
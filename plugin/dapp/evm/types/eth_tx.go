@@ -0,0 +1,191 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package types
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"math/big"
+
+	"github.com/33cn/chain33/common/address"
+	"github.com/33cn/chain33/types"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// WeiPerAmount 以太坊金额单位wei与chain33最小金额单位的换算比例（1e18 wei = 1e8）
+const WeiPerAmount = 1e10
+
+var (
+	// ErrEthTxChainID 以太坊交易的链ID与本链不一致
+	ErrEthTxChainID = errors.New("ErrEthTxChainID")
+	// ErrEthTxValue 以太坊交易的金额或gasPrice无法换算为chain33的单位
+	ErrEthTxValue = errors.New("ErrEthTxValue")
+	// ErrEthTxMismatch chain33交易与其携带的以太坊交易不一致
+	ErrEthTxMismatch = errors.New("ErrEthTxMismatch")
+	// ErrEthTxDup 以太坊交易已经执行过
+	ErrEthTxDup = errors.New("ErrEthTxDup")
+)
+
+// DecodeEthTx 解析RLP编码的以太坊签名交易
+func DecodeEthTx(raw []byte) (*ethtypes.Transaction, error) {
+	ethTx := new(ethtypes.Transaction)
+	if err := rlp.DecodeBytes(raw, ethTx); err != nil {
+		return nil, err
+	}
+	return ethTx, nil
+}
+
+// EthTxHash 以太坊交易哈希，即RLP编码的签名交易的keccak256哈希
+func EthTxHash(raw []byte) []byte {
+	return ethcrypto.Keccak256(raw)
+}
+
+// EthAddress 压缩格式公钥对应的以太坊20字节地址
+func EthAddress(pub []byte) ([]byte, error) {
+	key, err := ethcrypto.DecompressPubkey(pub)
+	if err != nil {
+		return nil, err
+	}
+	return ethcrypto.PubkeyToAddress(*key).Bytes(), nil
+}
+
+// RecoverEthTxPubKey 从交易签名中恢复发送者的压缩格式公钥
+func RecoverEthTxPubKey(ethTx *ethtypes.Transaction) ([]byte, error) {
+	var signer ethtypes.Signer = ethtypes.HomesteadSigner{}
+	offset := big.NewInt(27)
+	if ethTx.Protected() {
+		signer = ethtypes.NewEIP155Signer(ethTx.ChainId())
+		offset = new(big.Int).Add(new(big.Int).Mul(ethTx.ChainId(), big.NewInt(2)), big.NewInt(35))
+	}
+	v, r, s := ethTx.RawSignatureValues()
+	recID := new(big.Int).Sub(v, offset)
+	if recID.Sign() < 0 || recID.BitLen() > 8 || !ethcrypto.ValidateSignatureValues(byte(recID.Uint64()), r, s, true) {
+		return nil, types.ErrSign
+	}
+	sig := make([]byte, 65)
+	rb, sb := r.Bytes(), s.Bytes()
+	copy(sig[32-len(rb):32], rb)
+	copy(sig[64-len(sb):64], sb)
+	sig[64] = byte(recID.Uint64())
+
+	hash := signer.Hash(ethTx)
+	pub, err := ethcrypto.SigToPub(hash[:], sig)
+	if err != nil {
+		return nil, err
+	}
+	return ethcrypto.CompressPubkey(pub), nil
+}
+
+// 以太坊交易签名对应的链ID，EIP-155无法表示链ID为0，未使用EIP-155签名的交易视为链ID为0
+func ethTxChainID(ethTx *ethtypes.Transaction) *big.Int {
+	if !ethTx.Protected() {
+		return new(big.Int)
+	}
+	return ethTx.ChainId()
+}
+
+// NewTxFromEthTx 将以太坊签名交易包装为chain33的evm交易
+// 交易的chain33发送者地址（支付手续费）为同一私钥对应的chain33地址，
+// 合约调用者（msg.sender）为以太坊方式生成的地址，与以太坊工具中的地址一致，原始以太坊交易作为签名数据保存在交易中
+// 以太坊交易的链ID必须与本链一致，以防止交易在不同的链上重放
+// Expire不在以太坊签名的范围内，固定为0，使chain33交易哈希完全由以太坊交易决定
+func NewTxFromEthTx(cfg *types.Chain33Config, raw []byte) (*types.Transaction, error) {
+	ethTx, err := DecodeEthTx(raw)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := RecoverEthTxPubKey(ethTx)
+	if err != nil {
+		return nil, err
+	}
+	if ethTxChainID(ethTx).Cmp(big.NewInt(int64(cfg.GetChainID()))) != 0 {
+		return nil, ErrEthTxChainID
+	}
+	action, fee, err := ethTxAction(ethTx)
+	if err != nil {
+		return nil, err
+	}
+
+	execer := cfg.ExecName(ExecutorName)
+	tx := &types.Transaction{Execer: []byte(execer), Payload: types.Encode(action), Fee: fee, To: address.ExecAddress(execer)}
+	if ethTx.To() != nil {
+		tx.To = ethAddrToString(ethTx.To().Bytes())
+	}
+	tx.Nonce = int64(ethTx.Nonce())
+	tx.ChainID = cfg.GetChainID()
+	tx.Signature = &types.Signature{Ty: EthSecp256k1, Pubkey: pub, Signature: raw}
+	return tx, nil
+}
+
+// VerifyEthTx 校验chain33交易（不含签名）与以太坊签名交易的内容一致，返回签名者的压缩格式公钥
+func VerifyEthTx(tx *types.Transaction, raw []byte) ([]byte, error) {
+	ethTx, err := DecodeEthTx(raw)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := RecoverEthTxPubKey(ethTx)
+	if err != nil {
+		return nil, err
+	}
+	if ethTxChainID(ethTx).Cmp(big.NewInt(int64(tx.ChainID))) != 0 {
+		return nil, ErrEthTxChainID
+	}
+	action, fee, err := ethTxAction(ethTx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 只允许调用evm执行器本身，合约地址由交易的目标地址指定
+	if !bytes.Equal(types.GetParaExecName(tx.Execer), ExecerEvm) {
+		return nil, ErrEthTxMismatch
+	}
+	to := address.ExecAddress(string(tx.Execer))
+	if ethTx.To() != nil {
+		to = ethAddrToString(ethTx.To().Bytes())
+	}
+	// Expire参与交易哈希的计算但不在以太坊签名的范围内，必须为0，否则修改Expire就可以重放同一笔以太坊交易
+	if tx.To != to || tx.Fee != fee || tx.Nonce != int64(ethTx.Nonce()) || tx.Expire != 0 || tx.GroupCount != 0 || len(tx.Header) != 0 || len(tx.Next) != 0 {
+		return nil, ErrEthTxMismatch
+	}
+	if !bytes.Equal(tx.Payload, types.Encode(action)) {
+		return nil, ErrEthTxMismatch
+	}
+	return pub, nil
+}
+
+// 根据以太坊交易构造合约动作，同时返回交易需要支付的手续费
+func ethTxAction(ethTx *ethtypes.Transaction) (*EVMContractAction, int64, error) {
+	unit := big.NewInt(WeiPerAmount)
+	amount, rem := new(big.Int).QuoRem(ethTx.Value(), unit, new(big.Int))
+	if rem.Sign() != 0 || !amount.IsUint64() {
+		return nil, 0, ErrEthTxValue
+	}
+	gasPrice, rem := new(big.Int).QuoRem(ethTx.GasPrice(), unit, new(big.Int))
+	if rem.Sign() != 0 || gasPrice.Sign() == 0 || gasPrice.Cmp(big.NewInt(math.MaxUint32)) > 0 {
+		return nil, 0, ErrEthTxValue
+	}
+	fee := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(ethTx.Gas()))
+	if !fee.IsInt64() || ethTx.Nonce() > math.MaxInt64 {
+		return nil, 0, ErrEthTxValue
+	}
+	action := &EVMContractAction{
+		Amount:   amount.Uint64(),
+		GasLimit: ethTx.Gas(),
+		GasPrice: uint32(gasPrice.Uint64()),
+		Code:     ethTx.Data(),
+	}
+	return action, fee.Int64(), nil
+}
+
+// 将以太坊20字节地址转换为chain33地址
+func ethAddrToString(b []byte) string {
+	addr := new(address.Address)
+	addr.Version = address.NormalVer
+	addr.SetBytes(b)
+	return addr.String()
+}
@@ -46,6 +46,14 @@ func InitFork(cfg *types.Chain33Config) {
 	cfg.RegisterDappFork(ExecutorName, ForkEVMYoloV1, 9500000)
 	// EVM合约支持交易组
 	cfg.RegisterDappFork(ExecutorName, ForkEVMTxGroup, 0)
+	// EVM 伦敦版本指令分叉高度
+	cfg.RegisterDappFork(ExecutorName, ForkEVMLondon, types.MaxHeight)
+	// EVM 上海版本指令分叉高度
+	cfg.RegisterDappFork(ExecutorName, ForkEVMShanghai, types.MaxHeight)
+	// EVM 事件日志保存到交易回执的分叉高度
+	cfg.RegisterDappFork(ExecutorName, ForkEVMEventLog, types.MaxHeight)
+	// EVM 合约ABI支持error类型定义的分叉高度
+	cfg.RegisterDappFork(ExecutorName, ForkEVMABIError, types.MaxHeight)
 }
 
 //InitExecutor ...
@@ -125,6 +133,22 @@ func (evm EvmType) CreateTx(action string, message json.RawMessage) (*types.Tran
 	return nil, types.ErrNotSupport
 }
 
+// GetCryptoDriver 获取本执行器支持的签名驱动
+func (evm *EvmType) GetCryptoDriver(ty int) (string, error) {
+	if ty == EthSecp256k1 {
+		return SignNameEthSecp256k1, nil
+	}
+	return "", types.ErrNotSupport
+}
+
+// GetCryptoType 获取本执行器支持的签名类型
+func (evm *EvmType) GetCryptoType(name string) (int, error) {
+	if name == SignNameEthSecp256k1 {
+		return EthSecp256k1, nil
+	}
+	return 0, types.ErrNotSupport
+}
+
 // GetLogMap 获取日志类型映射
 func (evm *EvmType) GetLogMap() map[int64]*types.LogInfo {
 	return logInfo
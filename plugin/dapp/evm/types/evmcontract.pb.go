@@ -463,6 +463,151 @@ func (m *EVMStateChangeItem) GetCurrentValue() []byte {
 	return nil
 }
 
+// 合约执行LOG0-4指令生成的事件日志 ForkEVMEventLog
+type EVMLog struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Topics               [][]byte `protobuf:"bytes,2,rep,name=topics,proto3" json:"topics,omitempty"`
+	Data                 []byte   `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EVMLog) Reset()         { *m = EVMLog{} }
+func (m *EVMLog) String() string { return proto.CompactTextString(m) }
+func (*EVMLog) ProtoMessage()    {}
+func (*EVMLog) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74353de561acd7c6, []int{6}
+}
+
+func (m *EVMLog) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EVMLog.Unmarshal(m, b)
+}
+func (m *EVMLog) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EVMLog.Marshal(b, m, deterministic)
+}
+func (m *EVMLog) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EVMLog.Merge(m, src)
+}
+func (m *EVMLog) XXX_Size() int {
+	return xxx_messageInfo_EVMLog.Size(m)
+}
+func (m *EVMLog) XXX_DiscardUnknown() {
+	xxx_messageInfo_EVMLog.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EVMLog proto.InternalMessageInfo
+
+func (m *EVMLog) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *EVMLog) GetTopics() [][]byte {
+	if m != nil {
+		return m.Topics
+	}
+	return nil
+}
+
+func (m *EVMLog) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// 本地数据库中索引的事件日志
+type EvmLogItem struct {
+	Address string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Topics  [][]byte `protobuf:"bytes,2,rep,name=topics,proto3" json:"topics,omitempty"`
+	Data    []byte   `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Height  int64    `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	TxIndex int64    `protobuf:"varint,5,opt,name=txIndex,proto3" json:"txIndex,omitempty"`
+	// 日志在交易中的序号
+	LogIndex             int32    `protobuf:"varint,6,opt,name=logIndex,proto3" json:"logIndex,omitempty"`
+	TxHash               []byte   `protobuf:"bytes,7,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EvmLogItem) Reset()         { *m = EvmLogItem{} }
+func (m *EvmLogItem) String() string { return proto.CompactTextString(m) }
+func (*EvmLogItem) ProtoMessage()    {}
+func (*EvmLogItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74353de561acd7c6, []int{7}
+}
+
+func (m *EvmLogItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvmLogItem.Unmarshal(m, b)
+}
+func (m *EvmLogItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvmLogItem.Marshal(b, m, deterministic)
+}
+func (m *EvmLogItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvmLogItem.Merge(m, src)
+}
+func (m *EvmLogItem) XXX_Size() int {
+	return xxx_messageInfo_EvmLogItem.Size(m)
+}
+func (m *EvmLogItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvmLogItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvmLogItem proto.InternalMessageInfo
+
+func (m *EvmLogItem) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *EvmLogItem) GetTopics() [][]byte {
+	if m != nil {
+		return m.Topics
+	}
+	return nil
+}
+
+func (m *EvmLogItem) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *EvmLogItem) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *EvmLogItem) GetTxIndex() int64 {
+	if m != nil {
+		return m.TxIndex
+	}
+	return 0
+}
+
+func (m *EvmLogItem) GetLogIndex() int32 {
+	if m != nil {
+		return m.LogIndex
+	}
+	return 0
+}
+
+func (m *EvmLogItem) GetTxHash() []byte {
+	if m != nil {
+		return m.TxHash
+	}
+	return nil
+}
+
 // 存放合约固定数据
 type EVMContractDataCmd struct {
 	Creator              string   `protobuf:"bytes,1,opt,name=creator,proto3" json:"creator,omitempty"`
@@ -480,7 +625,7 @@ func (m *EVMContractDataCmd) Reset()         { *m = EVMContractDataCmd{} }
 func (m *EVMContractDataCmd) String() string { return proto.CompactTextString(m) }
 func (*EVMContractDataCmd) ProtoMessage()    {}
 func (*EVMContractDataCmd) Descriptor() ([]byte, []int) {
-	return fileDescriptor_74353de561acd7c6, []int{6}
+	return fileDescriptor_74353de561acd7c6, []int{8}
 }
 
 func (m *EVMContractDataCmd) XXX_Unmarshal(b []byte) error {
@@ -558,7 +703,7 @@ func (m *EVMContractStateCmd) Reset()         { *m = EVMContractStateCmd{} }
 func (m *EVMContractStateCmd) String() string { return proto.CompactTextString(m) }
 func (*EVMContractStateCmd) ProtoMessage()    {}
 func (*EVMContractStateCmd) Descriptor() ([]byte, []int) {
-	return fileDescriptor_74353de561acd7c6, []int{7}
+	return fileDescriptor_74353de561acd7c6, []int{9}
 }
 
 func (m *EVMContractStateCmd) XXX_Unmarshal(b []byte) error {
@@ -625,7 +770,7 @@ func (m *ReceiptEVMContractCmd) Reset()         { *m = ReceiptEVMContractCmd{} }
 func (m *ReceiptEVMContractCmd) String() string { return proto.CompactTextString(m) }
 func (*ReceiptEVMContractCmd) ProtoMessage()    {}
 func (*ReceiptEVMContractCmd) Descriptor() ([]byte, []int) {
-	return fileDescriptor_74353de561acd7c6, []int{8}
+	return fileDescriptor_74353de561acd7c6, []int{10}
 }
 
 func (m *ReceiptEVMContractCmd) XXX_Unmarshal(b []byte) error {
@@ -692,7 +837,7 @@ func (m *CheckEVMAddrReq) Reset()         { *m = CheckEVMAddrReq{} }
 func (m *CheckEVMAddrReq) String() string { return proto.CompactTextString(m) }
 func (*CheckEVMAddrReq) ProtoMessage()    {}
 func (*CheckEVMAddrReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_74353de561acd7c6, []int{9}
+	return fileDescriptor_74353de561acd7c6, []int{11}
 }
 
 func (m *CheckEVMAddrReq) XXX_Unmarshal(b []byte) error {
@@ -734,7 +879,7 @@ func (m *CheckEVMAddrResp) Reset()         { *m = CheckEVMAddrResp{} }
 func (m *CheckEVMAddrResp) String() string { return proto.CompactTextString(m) }
 func (*CheckEVMAddrResp) ProtoMessage()    {}
 func (*CheckEVMAddrResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_74353de561acd7c6, []int{10}
+	return fileDescriptor_74353de561acd7c6, []int{12}
 }
 
 func (m *CheckEVMAddrResp) XXX_Unmarshal(b []byte) error {
@@ -798,7 +943,7 @@ func (m *EstimateEVMGasReq) Reset()         { *m = EstimateEVMGasReq{} }
 func (m *EstimateEVMGasReq) String() string { return proto.CompactTextString(m) }
 func (*EstimateEVMGasReq) ProtoMessage()    {}
 func (*EstimateEVMGasReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_74353de561acd7c6, []int{11}
+	return fileDescriptor_74353de561acd7c6, []int{13}
 }
 
 func (m *EstimateEVMGasReq) XXX_Unmarshal(b []byte) error {
@@ -865,7 +1010,7 @@ func (m *EstimateEVMGasResp) Reset()         { *m = EstimateEVMGasResp{} }
 func (m *EstimateEVMGasResp) String() string { return proto.CompactTextString(m) }
 func (*EstimateEVMGasResp) ProtoMessage()    {}
 func (*EstimateEVMGasResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_74353de561acd7c6, []int{12}
+	return fileDescriptor_74353de561acd7c6, []int{14}
 }
 
 func (m *EstimateEVMGasResp) XXX_Unmarshal(b []byte) error {
@@ -905,7 +1050,7 @@ func (m *EvmDebugReq) Reset()         { *m = EvmDebugReq{} }
 func (m *EvmDebugReq) String() string { return proto.CompactTextString(m) }
 func (*EvmDebugReq) ProtoMessage()    {}
 func (*EvmDebugReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_74353de561acd7c6, []int{13}
+	return fileDescriptor_74353de561acd7c6, []int{15}
 }
 
 func (m *EvmDebugReq) XXX_Unmarshal(b []byte) error {
@@ -944,7 +1089,7 @@ func (m *EvmDebugResp) Reset()         { *m = EvmDebugResp{} }
 func (m *EvmDebugResp) String() string { return proto.CompactTextString(m) }
 func (*EvmDebugResp) ProtoMessage()    {}
 func (*EvmDebugResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_74353de561acd7c6, []int{14}
+	return fileDescriptor_74353de561acd7c6, []int{16}
 }
 
 func (m *EvmDebugResp) XXX_Unmarshal(b []byte) error {
@@ -983,7 +1128,7 @@ func (m *EvmQueryAbiReq) Reset()         { *m = EvmQueryAbiReq{} }
 func (m *EvmQueryAbiReq) String() string { return proto.CompactTextString(m) }
 func (*EvmQueryAbiReq) ProtoMessage()    {}
 func (*EvmQueryAbiReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_74353de561acd7c6, []int{15}
+	return fileDescriptor_74353de561acd7c6, []int{17}
 }
 
 func (m *EvmQueryAbiReq) XXX_Unmarshal(b []byte) error {
@@ -1023,7 +1168,7 @@ func (m *EvmQueryAbiResp) Reset()         { *m = EvmQueryAbiResp{} }
 func (m *EvmQueryAbiResp) String() string { return proto.CompactTextString(m) }
 func (*EvmQueryAbiResp) ProtoMessage()    {}
 func (*EvmQueryAbiResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_74353de561acd7c6, []int{16}
+	return fileDescriptor_74353de561acd7c6, []int{18}
 }
 
 func (m *EvmQueryAbiResp) XXX_Unmarshal(b []byte) error {
@@ -1059,9 +1204,11 @@ func (m *EvmQueryAbiResp) GetAbi() string {
 }
 
 type EvmQueryReq struct {
-	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
-	Input                string   `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
-	Caller               string   `protobuf:"bytes,3,opt,name=caller,proto3" json:"caller,omitempty"`
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Input   string `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+	Caller  string `protobuf:"bytes,3,opt,name=caller,proto3" json:"caller,omitempty"`
+	// 原始调用数据，input为空时使用
+	Data                 []byte   `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1071,7 +1218,7 @@ func (m *EvmQueryReq) Reset()         { *m = EvmQueryReq{} }
 func (m *EvmQueryReq) String() string { return proto.CompactTextString(m) }
 func (*EvmQueryReq) ProtoMessage()    {}
 func (*EvmQueryReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_74353de561acd7c6, []int{17}
+	return fileDescriptor_74353de561acd7c6, []int{19}
 }
 
 func (m *EvmQueryReq) XXX_Unmarshal(b []byte) error {
@@ -1113,6 +1260,13 @@ func (m *EvmQueryReq) GetCaller() string {
 	return ""
 }
 
+func (m *EvmQueryReq) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
 type EvmQueryResp struct {
 	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
 	Input                string   `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
@@ -1128,7 +1282,7 @@ func (m *EvmQueryResp) Reset()         { *m = EvmQueryResp{} }
 func (m *EvmQueryResp) String() string { return proto.CompactTextString(m) }
 func (*EvmQueryResp) ProtoMessage()    {}
 func (*EvmQueryResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_74353de561acd7c6, []int{18}
+	return fileDescriptor_74353de561acd7c6, []int{20}
 }
 
 func (m *EvmQueryResp) XXX_Unmarshal(b []byte) error {
@@ -1202,7 +1356,7 @@ func (m *EvmContractCreateReq) Reset()         { *m = EvmContractCreateReq{} }
 func (m *EvmContractCreateReq) String() string { return proto.CompactTextString(m) }
 func (*EvmContractCreateReq) ProtoMessage()    {}
 func (*EvmContractCreateReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_74353de561acd7c6, []int{19}
+	return fileDescriptor_74353de561acd7c6, []int{21}
 }
 
 func (m *EvmContractCreateReq) XXX_Unmarshal(b []byte) error {
@@ -1298,7 +1452,7 @@ func (m *EvmContractCallReq) Reset()         { *m = EvmContractCallReq{} }
 func (m *EvmContractCallReq) String() string { return proto.CompactTextString(m) }
 func (*EvmContractCallReq) ProtoMessage()    {}
 func (*EvmContractCallReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_74353de561acd7c6, []int{20}
+	return fileDescriptor_74353de561acd7c6, []int{22}
 }
 
 func (m *EvmContractCallReq) XXX_Unmarshal(b []byte) error {
@@ -1398,7 +1552,7 @@ func (m *EvmContractTransferReq) Reset()         { *m = EvmContractTransferReq{}
 func (m *EvmContractTransferReq) String() string { return proto.CompactTextString(m) }
 func (*EvmContractTransferReq) ProtoMessage()    {}
 func (*EvmContractTransferReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_74353de561acd7c6, []int{21}
+	return fileDescriptor_74353de561acd7c6, []int{23}
 }
 
 func (m *EvmContractTransferReq) XXX_Unmarshal(b []byte) error {
@@ -1461,6 +1615,649 @@ func (m *EvmContractTransferReq) GetParaName() string {
 	return ""
 }
 
+type EvmTraceTxReq struct {
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// callTracer 或 prestateTracer，默认为callTracer
+	Tracer               string   `protobuf:"bytes,2,opt,name=tracer,proto3" json:"tracer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EvmTraceTxReq) Reset()         { *m = EvmTraceTxReq{} }
+func (m *EvmTraceTxReq) String() string { return proto.CompactTextString(m) }
+func (*EvmTraceTxReq) ProtoMessage()    {}
+func (*EvmTraceTxReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74353de561acd7c6, []int{24}
+}
+
+func (m *EvmTraceTxReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvmTraceTxReq.Unmarshal(m, b)
+}
+func (m *EvmTraceTxReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvmTraceTxReq.Marshal(b, m, deterministic)
+}
+func (m *EvmTraceTxReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvmTraceTxReq.Merge(m, src)
+}
+func (m *EvmTraceTxReq) XXX_Size() int {
+	return xxx_messageInfo_EvmTraceTxReq.Size(m)
+}
+func (m *EvmTraceTxReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvmTraceTxReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvmTraceTxReq proto.InternalMessageInfo
+
+func (m *EvmTraceTxReq) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *EvmTraceTxReq) GetTracer() string {
+	if m != nil {
+		return m.Tracer
+	}
+	return ""
+}
+
+// 合约调用树中的一次调用
+type EvmCallFrame struct {
+	Type                 string          `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	From                 string          `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To                   string          `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Value                uint64          `protobuf:"varint,4,opt,name=value,proto3" json:"value,omitempty"`
+	Gas                  uint64          `protobuf:"varint,5,opt,name=gas,proto3" json:"gas,omitempty"`
+	GasUsed              uint64          `protobuf:"varint,6,opt,name=gasUsed,proto3" json:"gasUsed,omitempty"`
+	Input                string          `protobuf:"bytes,7,opt,name=input,proto3" json:"input,omitempty"`
+	Output               string          `protobuf:"bytes,8,opt,name=output,proto3" json:"output,omitempty"`
+	Error                string          `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	RevertReason         string          `protobuf:"bytes,10,opt,name=revertReason,proto3" json:"revertReason,omitempty"`
+	Calls                []*EvmCallFrame `protobuf:"bytes,11,rep,name=calls,proto3" json:"calls,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *EvmCallFrame) Reset()         { *m = EvmCallFrame{} }
+func (m *EvmCallFrame) String() string { return proto.CompactTextString(m) }
+func (*EvmCallFrame) ProtoMessage()    {}
+func (*EvmCallFrame) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74353de561acd7c6, []int{25}
+}
+
+func (m *EvmCallFrame) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvmCallFrame.Unmarshal(m, b)
+}
+func (m *EvmCallFrame) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvmCallFrame.Marshal(b, m, deterministic)
+}
+func (m *EvmCallFrame) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvmCallFrame.Merge(m, src)
+}
+func (m *EvmCallFrame) XXX_Size() int {
+	return xxx_messageInfo_EvmCallFrame.Size(m)
+}
+func (m *EvmCallFrame) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvmCallFrame.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvmCallFrame proto.InternalMessageInfo
+
+func (m *EvmCallFrame) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *EvmCallFrame) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *EvmCallFrame) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *EvmCallFrame) GetValue() uint64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *EvmCallFrame) GetGas() uint64 {
+	if m != nil {
+		return m.Gas
+	}
+	return 0
+}
+
+func (m *EvmCallFrame) GetGasUsed() uint64 {
+	if m != nil {
+		return m.GasUsed
+	}
+	return 0
+}
+
+func (m *EvmCallFrame) GetInput() string {
+	if m != nil {
+		return m.Input
+	}
+	return ""
+}
+
+func (m *EvmCallFrame) GetOutput() string {
+	if m != nil {
+		return m.Output
+	}
+	return ""
+}
+
+func (m *EvmCallFrame) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *EvmCallFrame) GetRevertReason() string {
+	if m != nil {
+		return m.RevertReason
+	}
+	return ""
+}
+
+func (m *EvmCallFrame) GetCalls() []*EvmCallFrame {
+	if m != nil {
+		return m.Calls
+	}
+	return nil
+}
+
+// 交易执行前被访问的账户状态
+type EvmPrestateAccount struct {
+	Address              string            `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Balance              uint64            `protobuf:"varint,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	Nonce                uint64            `protobuf:"varint,3,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Code                 string            `protobuf:"bytes,4,opt,name=code,proto3" json:"code,omitempty"`
+	Storage              map[string]string `protobuf:"bytes,5,rep,name=storage,proto3" json:"storage,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *EvmPrestateAccount) Reset()         { *m = EvmPrestateAccount{} }
+func (m *EvmPrestateAccount) String() string { return proto.CompactTextString(m) }
+func (*EvmPrestateAccount) ProtoMessage()    {}
+func (*EvmPrestateAccount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74353de561acd7c6, []int{26}
+}
+
+func (m *EvmPrestateAccount) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvmPrestateAccount.Unmarshal(m, b)
+}
+func (m *EvmPrestateAccount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvmPrestateAccount.Marshal(b, m, deterministic)
+}
+func (m *EvmPrestateAccount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvmPrestateAccount.Merge(m, src)
+}
+func (m *EvmPrestateAccount) XXX_Size() int {
+	return xxx_messageInfo_EvmPrestateAccount.Size(m)
+}
+func (m *EvmPrestateAccount) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvmPrestateAccount.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvmPrestateAccount proto.InternalMessageInfo
+
+func (m *EvmPrestateAccount) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *EvmPrestateAccount) GetBalance() uint64 {
+	if m != nil {
+		return m.Balance
+	}
+	return 0
+}
+
+func (m *EvmPrestateAccount) GetNonce() uint64 {
+	if m != nil {
+		return m.Nonce
+	}
+	return 0
+}
+
+func (m *EvmPrestateAccount) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *EvmPrestateAccount) GetStorage() map[string]string {
+	if m != nil {
+		return m.Storage
+	}
+	return nil
+}
+
+type EvmTraceTxResp struct {
+	Hash                 string                `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Tracer               string                `protobuf:"bytes,2,opt,name=tracer,proto3" json:"tracer,omitempty"`
+	CallTrace            *EvmCallFrame         `protobuf:"bytes,3,opt,name=callTrace,proto3" json:"callTrace,omitempty"`
+	Prestate             []*EvmPrestateAccount `protobuf:"bytes,4,rep,name=prestate,proto3" json:"prestate,omitempty"`
+	Error                string                `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *EvmTraceTxResp) Reset()         { *m = EvmTraceTxResp{} }
+func (m *EvmTraceTxResp) String() string { return proto.CompactTextString(m) }
+func (*EvmTraceTxResp) ProtoMessage()    {}
+func (*EvmTraceTxResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74353de561acd7c6, []int{27}
+}
+
+func (m *EvmTraceTxResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvmTraceTxResp.Unmarshal(m, b)
+}
+func (m *EvmTraceTxResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvmTraceTxResp.Marshal(b, m, deterministic)
+}
+func (m *EvmTraceTxResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvmTraceTxResp.Merge(m, src)
+}
+func (m *EvmTraceTxResp) XXX_Size() int {
+	return xxx_messageInfo_EvmTraceTxResp.Size(m)
+}
+func (m *EvmTraceTxResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvmTraceTxResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvmTraceTxResp proto.InternalMessageInfo
+
+func (m *EvmTraceTxResp) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *EvmTraceTxResp) GetTracer() string {
+	if m != nil {
+		return m.Tracer
+	}
+	return ""
+}
+
+func (m *EvmTraceTxResp) GetCallTrace() *EvmCallFrame {
+	if m != nil {
+		return m.CallTrace
+	}
+	return nil
+}
+
+func (m *EvmTraceTxResp) GetPrestate() []*EvmPrestateAccount {
+	if m != nil {
+		return m.Prestate
+	}
+	return nil
+}
+
+func (m *EvmTraceTxResp) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type EvmGetCodeReq struct {
+	Addr                 string   `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EvmGetCodeReq) Reset()         { *m = EvmGetCodeReq{} }
+func (m *EvmGetCodeReq) String() string { return proto.CompactTextString(m) }
+func (*EvmGetCodeReq) ProtoMessage()    {}
+func (*EvmGetCodeReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74353de561acd7c6, []int{28}
+}
+
+func (m *EvmGetCodeReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvmGetCodeReq.Unmarshal(m, b)
+}
+func (m *EvmGetCodeReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvmGetCodeReq.Marshal(b, m, deterministic)
+}
+func (m *EvmGetCodeReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvmGetCodeReq.Merge(m, src)
+}
+func (m *EvmGetCodeReq) XXX_Size() int {
+	return xxx_messageInfo_EvmGetCodeReq.Size(m)
+}
+func (m *EvmGetCodeReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvmGetCodeReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvmGetCodeReq proto.InternalMessageInfo
+
+func (m *EvmGetCodeReq) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+type EvmGetCodeResp struct {
+	Addr                 string   `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
+	Code                 []byte   `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EvmGetCodeResp) Reset()         { *m = EvmGetCodeResp{} }
+func (m *EvmGetCodeResp) String() string { return proto.CompactTextString(m) }
+func (*EvmGetCodeResp) ProtoMessage()    {}
+func (*EvmGetCodeResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74353de561acd7c6, []int{29}
+}
+
+func (m *EvmGetCodeResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvmGetCodeResp.Unmarshal(m, b)
+}
+func (m *EvmGetCodeResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvmGetCodeResp.Marshal(b, m, deterministic)
+}
+func (m *EvmGetCodeResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvmGetCodeResp.Merge(m, src)
+}
+func (m *EvmGetCodeResp) XXX_Size() int {
+	return xxx_messageInfo_EvmGetCodeResp.Size(m)
+}
+func (m *EvmGetCodeResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvmGetCodeResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvmGetCodeResp proto.InternalMessageInfo
+
+func (m *EvmGetCodeResp) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *EvmGetCodeResp) GetCode() []byte {
+	if m != nil {
+		return m.Code
+	}
+	return nil
+}
+
+type EvmGetStorageAtReq struct {
+	Addr string `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
+	// 十六进制格式的存储位置
+	Key                  string   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EvmGetStorageAtReq) Reset()         { *m = EvmGetStorageAtReq{} }
+func (m *EvmGetStorageAtReq) String() string { return proto.CompactTextString(m) }
+func (*EvmGetStorageAtReq) ProtoMessage()    {}
+func (*EvmGetStorageAtReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74353de561acd7c6, []int{30}
+}
+
+func (m *EvmGetStorageAtReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvmGetStorageAtReq.Unmarshal(m, b)
+}
+func (m *EvmGetStorageAtReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvmGetStorageAtReq.Marshal(b, m, deterministic)
+}
+func (m *EvmGetStorageAtReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvmGetStorageAtReq.Merge(m, src)
+}
+func (m *EvmGetStorageAtReq) XXX_Size() int {
+	return xxx_messageInfo_EvmGetStorageAtReq.Size(m)
+}
+func (m *EvmGetStorageAtReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvmGetStorageAtReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvmGetStorageAtReq proto.InternalMessageInfo
+
+func (m *EvmGetStorageAtReq) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *EvmGetStorageAtReq) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+type EvmGetStorageAtResp struct {
+	Addr                 string   `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
+	Key                  string   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value                []byte   `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EvmGetStorageAtResp) Reset()         { *m = EvmGetStorageAtResp{} }
+func (m *EvmGetStorageAtResp) String() string { return proto.CompactTextString(m) }
+func (*EvmGetStorageAtResp) ProtoMessage()    {}
+func (*EvmGetStorageAtResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74353de561acd7c6, []int{31}
+}
+
+func (m *EvmGetStorageAtResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvmGetStorageAtResp.Unmarshal(m, b)
+}
+func (m *EvmGetStorageAtResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvmGetStorageAtResp.Marshal(b, m, deterministic)
+}
+func (m *EvmGetStorageAtResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvmGetStorageAtResp.Merge(m, src)
+}
+func (m *EvmGetStorageAtResp) XXX_Size() int {
+	return xxx_messageInfo_EvmGetStorageAtResp.Size(m)
+}
+func (m *EvmGetStorageAtResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvmGetStorageAtResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvmGetStorageAtResp proto.InternalMessageInfo
+
+func (m *EvmGetStorageAtResp) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *EvmGetStorageAtResp) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *EvmGetStorageAtResp) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+// 同一位置上的主题之间为或的关系，为空时匹配任意主题
+type EvmLogTopics struct {
+	Topics               []string `protobuf:"bytes,1,rep,name=topics,proto3" json:"topics,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EvmLogTopics) Reset()         { *m = EvmLogTopics{} }
+func (m *EvmLogTopics) String() string { return proto.CompactTextString(m) }
+func (*EvmLogTopics) ProtoMessage()    {}
+func (*EvmLogTopics) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74353de561acd7c6, []int{32}
+}
+
+func (m *EvmLogTopics) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvmLogTopics.Unmarshal(m, b)
+}
+func (m *EvmLogTopics) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvmLogTopics.Marshal(b, m, deterministic)
+}
+func (m *EvmLogTopics) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvmLogTopics.Merge(m, src)
+}
+func (m *EvmLogTopics) XXX_Size() int {
+	return xxx_messageInfo_EvmLogTopics.Size(m)
+}
+func (m *EvmLogTopics) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvmLogTopics.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvmLogTopics proto.InternalMessageInfo
+
+func (m *EvmLogTopics) GetTopics() []string {
+	if m != nil {
+		return m.Topics
+	}
+	return nil
+}
+
+type EvmGetLogsReq struct {
+	FromBlock int64 `protobuf:"varint,1,opt,name=fromBlock,proto3" json:"fromBlock,omitempty"`
+	// 小于0时不限制结束高度
+	ToBlock              int64           `protobuf:"varint,2,opt,name=toBlock,proto3" json:"toBlock,omitempty"`
+	Addresses            []string        `protobuf:"bytes,3,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	Topics               []*EvmLogTopics `protobuf:"bytes,4,rep,name=topics,proto3" json:"topics,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *EvmGetLogsReq) Reset()         { *m = EvmGetLogsReq{} }
+func (m *EvmGetLogsReq) String() string { return proto.CompactTextString(m) }
+func (*EvmGetLogsReq) ProtoMessage()    {}
+func (*EvmGetLogsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74353de561acd7c6, []int{33}
+}
+
+func (m *EvmGetLogsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvmGetLogsReq.Unmarshal(m, b)
+}
+func (m *EvmGetLogsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvmGetLogsReq.Marshal(b, m, deterministic)
+}
+func (m *EvmGetLogsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvmGetLogsReq.Merge(m, src)
+}
+func (m *EvmGetLogsReq) XXX_Size() int {
+	return xxx_messageInfo_EvmGetLogsReq.Size(m)
+}
+func (m *EvmGetLogsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvmGetLogsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvmGetLogsReq proto.InternalMessageInfo
+
+func (m *EvmGetLogsReq) GetFromBlock() int64 {
+	if m != nil {
+		return m.FromBlock
+	}
+	return 0
+}
+
+func (m *EvmGetLogsReq) GetToBlock() int64 {
+	if m != nil {
+		return m.ToBlock
+	}
+	return 0
+}
+
+func (m *EvmGetLogsReq) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+func (m *EvmGetLogsReq) GetTopics() []*EvmLogTopics {
+	if m != nil {
+		return m.Topics
+	}
+	return nil
+}
+
+type EvmGetLogsResp struct {
+	Logs                 []*EvmLogItem `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *EvmGetLogsResp) Reset()         { *m = EvmGetLogsResp{} }
+func (m *EvmGetLogsResp) String() string { return proto.CompactTextString(m) }
+func (*EvmGetLogsResp) ProtoMessage()    {}
+func (*EvmGetLogsResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74353de561acd7c6, []int{34}
+}
+
+func (m *EvmGetLogsResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvmGetLogsResp.Unmarshal(m, b)
+}
+func (m *EvmGetLogsResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvmGetLogsResp.Marshal(b, m, deterministic)
+}
+func (m *EvmGetLogsResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvmGetLogsResp.Merge(m, src)
+}
+func (m *EvmGetLogsResp) XXX_Size() int {
+	return xxx_messageInfo_EvmGetLogsResp.Size(m)
+}
+func (m *EvmGetLogsResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvmGetLogsResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvmGetLogsResp proto.InternalMessageInfo
+
+func (m *EvmGetLogsResp) GetLogs() []*EvmLogItem {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*EVMContractObject)(nil), "types.EVMContractObject")
 	proto.RegisterType((*EVMContractData)(nil), "types.EVMContractData")
@@ -1469,6 +2266,8 @@ func init() {
 	proto.RegisterType((*EVMContractAction)(nil), "types.EVMContractAction")
 	proto.RegisterType((*ReceiptEVMContract)(nil), "types.ReceiptEVMContract")
 	proto.RegisterType((*EVMStateChangeItem)(nil), "types.EVMStateChangeItem")
+	proto.RegisterType((*EVMLog)(nil), "types.EVMLog")
+	proto.RegisterType((*EvmLogItem)(nil), "types.EvmLogItem")
 	proto.RegisterType((*EVMContractDataCmd)(nil), "types.EVMContractDataCmd")
 	proto.RegisterType((*EVMContractStateCmd)(nil), "types.EVMContractStateCmd")
 	proto.RegisterMapType((map[string]string)(nil), "types.EVMContractStateCmd.StorageEntry")
@@ -1486,6 +2285,18 @@ func init() {
 	proto.RegisterType((*EvmContractCreateReq)(nil), "types.EvmContractCreateReq")
 	proto.RegisterType((*EvmContractCallReq)(nil), "types.EvmContractCallReq")
 	proto.RegisterType((*EvmContractTransferReq)(nil), "types.EvmContractTransferReq")
+	proto.RegisterType((*EvmTraceTxReq)(nil), "types.EvmTraceTxReq")
+	proto.RegisterType((*EvmCallFrame)(nil), "types.EvmCallFrame")
+	proto.RegisterType((*EvmPrestateAccount)(nil), "types.EvmPrestateAccount")
+	proto.RegisterMapType((map[string]string)(nil), "types.EvmPrestateAccount.StorageEntry")
+	proto.RegisterType((*EvmTraceTxResp)(nil), "types.EvmTraceTxResp")
+	proto.RegisterType((*EvmGetCodeReq)(nil), "types.EvmGetCodeReq")
+	proto.RegisterType((*EvmGetCodeResp)(nil), "types.EvmGetCodeResp")
+	proto.RegisterType((*EvmGetStorageAtReq)(nil), "types.EvmGetStorageAtReq")
+	proto.RegisterType((*EvmGetStorageAtResp)(nil), "types.EvmGetStorageAtResp")
+	proto.RegisterType((*EvmLogTopics)(nil), "types.EvmLogTopics")
+	proto.RegisterType((*EvmGetLogsReq)(nil), "types.EvmGetLogsReq")
+	proto.RegisterType((*EvmGetLogsResp)(nil), "types.EvmGetLogsResp")
 }
 
 func init() {
@@ -1493,68 +2304,99 @@ func init() {
 }
 
 var fileDescriptor_74353de561acd7c6 = []byte{
-	// 1004 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x56, 0xcf, 0x8f, 0xdb, 0x44,
-	0x14, 0x96, 0x63, 0x27, 0x1b, 0xbf, 0x0d, 0xed, 0xae, 0x29, 0x4b, 0xb4, 0x42, 0x28, 0xb2, 0x28,
-	0xac, 0x2a, 0xb1, 0x42, 0xe5, 0x82, 0x2a, 0x81, 0xb4, 0x4a, 0xa3, 0x82, 0xc4, 0xf2, 0x63, 0x0a,
-	0xe1, 0x3c, 0xb1, 0x67, 0xb3, 0x6e, 0xe3, 0x1f, 0xcc, 0x4c, 0xd2, 0xe6, 0xca, 0x99, 0x23, 0x47,
-	0x6e, 0x1c, 0xb9, 0x20, 0x71, 0xe3, 0xcc, 0x1f, 0xc1, 0x99, 0x13, 0x7f, 0x06, 0x7a, 0xcf, 0x63,
-	0x7b, 0xec, 0x4d, 0x2a, 0x90, 0x2a, 0xc4, 0x29, 0xf3, 0xcd, 0x3c, 0xcf, 0x7c, 0xdf, 0xbc, 0xef,
-	0xbd, 0x09, 0x1c, 0x8b, 0x4d, 0x1a, 0xe5, 0x99, 0x96, 0x3c, 0xd2, 0xe7, 0x85, 0xcc, 0x75, 0x1e,
-	0xf4, 0xf5, 0xb6, 0x10, 0x2a, 0xfc, 0xce, 0x81, 0xe3, 0xd9, 0xfc, 0x72, 0x6a, 0x16, 0x3f, 0x5f,
-	0x3c, 0x11, 0x91, 0x0e, 0x02, 0xf0, 0x78, 0x1c, 0xcb, 0xb1, 0x33, 0x71, 0xce, 0x7c, 0x46, 0xe3,
-	0xe0, 0x1e, 0x78, 0x31, 0xd7, 0x7c, 0xdc, 0x9b, 0x38, 0x67, 0x87, 0xf7, 0x4f, 0xce, 0xe9, 0xfb,
-	0x73, 0xeb, 0xdb, 0x87, 0x5c, 0x73, 0x46, 0x31, 0xc1, 0xbb, 0xd0, 0x57, 0x9a, 0x6b, 0x31, 0x76,
-	0x29, 0xf8, 0xf5, 0x9b, 0xc1, 0x8f, 0x71, 0x99, 0x95, 0x51, 0xe1, 0xcf, 0x0e, 0xdc, 0xee, 0x6c,
-	0x14, 0x8c, 0xe1, 0x20, 0x92, 0x82, 0xeb, 0xbc, 0x62, 0x51, 0x41, 0x24, 0x97, 0xf1, 0x54, 0x10,
-	0x11, 0x9f, 0xd1, 0x38, 0xb8, 0x03, 0x7d, 0xbe, 0x4a, 0xb8, 0xa2, 0x03, 0x7d, 0x56, 0x82, 0x5a,
-	0x86, 0x67, 0xc9, 0x08, 0xc0, 0x8b, 0xf2, 0x58, 0x8c, 0xfb, 0x13, 0xe7, 0x6c, 0xc4, 0x68, 0x1c,
-	0x9c, 0xc2, 0x10, 0x7f, 0x3f, 0xe6, 0xea, 0x7a, 0x3c, 0xa0, 0xf9, 0x1a, 0x07, 0x47, 0xe0, 0xf2,
-	0x45, 0x32, 0x3e, 0xa0, 0x2d, 0x70, 0x18, 0xfe, 0xe9, 0xc0, 0x51, 0x57, 0x09, 0x12, 0xc8, 0xf2,
-	0x2c, 0x12, 0x44, 0xd6, 0x63, 0x25, 0xc0, 0x8d, 0xd5, 0x3a, 0x89, 0x92, 0x58, 0xc4, 0x44, 0x77,
-	0xc8, 0x6a, 0x1c, 0x4c, 0xe0, 0x50, 0xe9, 0x5c, 0xf2, 0x65, 0x79, 0xae, 0x4b, 0xe7, 0xda, 0x53,
-	0xc1, 0x47, 0x70, 0x60, 0xe0, 0xd8, 0x9b, 0xb8, 0x67, 0x87, 0xf7, 0xdf, 0xda, 0x73, 0x8f, 0xe7,
-	0x8f, 0xcb, 0xb0, 0x59, 0xa6, 0xe5, 0x96, 0x55, 0x1f, 0x9d, 0x3e, 0x80, 0x91, 0xbd, 0x80, 0x52,
-	0x9e, 0x8a, 0xad, 0xb9, 0x4e, 0x1c, 0x22, 0xeb, 0x0d, 0x5f, 0xad, 0xcb, 0xbb, 0x1c, 0xb1, 0x12,
-	0x3c, 0xe8, 0x7d, 0xe0, 0x84, 0xbf, 0xb6, 0x7d, 0x71, 0x11, 0xe9, 0x24, 0xcf, 0x82, 0x13, 0x18,
-	0xf0, 0x34, 0x5f, 0x67, 0xda, 0xc8, 0x34, 0x08, 0x75, 0x2e, 0xb9, 0xfa, 0x34, 0x49, 0x13, 0x4d,
-	0x5b, 0x79, 0xac, 0xc6, 0x66, 0xed, 0x0b, 0x99, 0x44, 0xa5, 0x1d, 0x5e, 0x61, 0x35, 0xae, 0x93,
-	0xe1, 0x59, 0xc9, 0xa8, 0x53, 0xd9, 0xef, 0xa4, 0x32, 0xcb, 0xb5, 0xa0, 0xf4, 0x60, 0xd2, 0x73,
-	0x2d, 0x76, 0xa4, 0xe6, 0x37, 0x07, 0x02, 0x26, 0x22, 0x91, 0x14, 0xda, 0x22, 0x8f, 0xb4, 0x23,
-	0xbe, 0x5a, 0x89, 0xca, 0x4a, 0x06, 0x05, 0x21, 0x8c, 0xaa, 0xaa, 0xf8, 0xac, 0x71, 0x54, 0x6b,
-	0xce, 0x8e, 0xb9, 0x40, 0x2f, 0xb9, 0xed, 0x18, 0x9c, 0x43, 0xaf, 0xae, 0x95, 0x88, 0x1f, 0x71,
-	0x45, 0x4a, 0x3c, 0x56, 0x41, 0xa4, 0x28, 0x85, 0x36, 0x66, 0xc3, 0x21, 0xc6, 0x3e, 0x51, 0x79,
-	0xc6, 0x84, 0x36, 0x5a, 0x2a, 0x18, 0x5e, 0x41, 0x30, 0x9b, 0x5f, 0x52, 0x42, 0xa7, 0xd7, 0x3c,
-	0x5b, 0x8a, 0x4f, 0xb4, 0x48, 0x77, 0x24, 0xed, 0x14, 0x86, 0x85, 0x14, 0x73, 0x2b, 0x6f, 0x35,
-	0x26, 0xb6, 0x6b, 0x29, 0x45, 0xa6, 0xcb, 0xf5, 0xd2, 0x55, 0xad, 0xb9, 0xf0, 0x47, 0x87, 0x0e,
-	0xb2, 0xab, 0x6d, 0x9a, 0xc6, 0xff, 0x49, 0xc1, 0xf9, 0x7b, 0x0a, 0xce, 0x6f, 0x0a, 0x2e, 0xfc,
-	0xcb, 0x81, 0x57, 0xbb, 0x06, 0x47, 0x7e, 0x2f, 0xa5, 0xc2, 0xfc, 0x76, 0x85, 0x5d, 0x74, 0x2b,
-	0xec, 0x9d, 0x3d, 0x15, 0x36, 0x4d, 0xe3, 0x97, 0x53, 0x64, 0xbe, 0x5d, 0x64, 0x3f, 0x39, 0xf0,
-	0xda, 0x4d, 0xbb, 0xa2, 0xd8, 0xff, 0x85, 0x63, 0x7d, 0x72, 0x6c, 0x78, 0x17, 0x6e, 0x4f, 0xaf,
-	0x45, 0xf4, 0x74, 0x36, 0xbf, 0xc4, 0x6f, 0x99, 0xf8, 0x76, 0xd7, 0xfb, 0x10, 0xfe, 0xe0, 0xc0,
-	0x51, 0x3b, 0x4e, 0x15, 0x65, 0xa2, 0xcb, 0x73, 0x29, 0x78, 0xc8, 0x6a, 0x7c, 0x83, 0x67, 0x6f,
-	0x07, 0xcf, 0xae, 0x5e, 0x77, 0x87, 0xde, 0x37, 0xc0, 0x27, 0xf7, 0x51, 0x40, 0xe9, 0xbc, 0x66,
-	0x22, 0xdc, 0xc2, 0xf1, 0x4c, 0xe9, 0x24, 0xe5, 0x5a, 0xcc, 0xe6, 0x97, 0x8f, 0xb8, 0x42, 0xfe,
-	0xb7, 0xa0, 0xa7, 0x73, 0xc3, 0xbe, 0xa7, 0xf3, 0xda, 0xa3, 0x3d, 0xab, 0x0f, 0x35, 0x29, 0x70,
-	0x5b, 0x29, 0x68, 0x7a, 0xa0, 0xd7, 0xea, 0x81, 0xa6, 0x1b, 0xf5, 0x9b, 0x6e, 0xf4, 0x36, 0x04,
-	0xdd, 0xa3, 0x55, 0x81, 0x71, 0x4b, 0xae, 0x8c, 0x8b, 0x71, 0x18, 0xde, 0x85, 0xc3, 0xd9, 0x26,
-	0x7d, 0x28, 0x16, 0xeb, 0x25, 0x92, 0x3b, 0x81, 0x41, 0x5e, 0xa0, 0x0d, 0x29, 0xa6, 0xcf, 0x0c,
-	0x0a, 0xdf, 0x83, 0x51, 0x13, 0xa6, 0x0a, 0xb4, 0x77, 0x8c, 0x00, 0x0d, 0xba, 0x56, 0x46, 0x8d,
-	0x3d, 0x15, 0xde, 0x83, 0x5b, 0xb3, 0x4d, 0xfa, 0xe5, 0x5a, 0xc8, 0xed, 0xc5, 0x22, 0xc1, 0xbd,
-	0xc7, 0x70, 0x80, 0xc9, 0x12, 0xaa, 0x8a, 0xaf, 0x60, 0xf8, 0x21, 0xdc, 0x6e, 0xc5, 0xaa, 0x62,
-	0x7f, 0x70, 0xa5, 0xb5, 0xd7, 0x68, 0xfd, 0x9a, 0x34, 0xd0, 0xe7, 0x2f, 0x3c, 0x07, 0xab, 0x21,
-	0xc9, 0x8a, 0xb5, 0xae, 0xaa, 0x81, 0xc0, 0xbe, 0xcb, 0x0e, 0xbf, 0x77, 0x48, 0xb4, 0xd9, 0xf7,
-	0x85, 0x9c, 0xfe, 0xd5, 0xc6, 0xb8, 0x8f, 0xe4, 0xcf, 0xb0, 0xf7, 0x19, 0xcb, 0x54, 0x10, 0x2d,
-	0x8b, 0x1d, 0x99, 0x96, 0xca, 0x64, 0xd6, 0x38, 0xfc, 0xdd, 0x81, 0x3b, 0xb3, 0x4d, 0x5a, 0x57,
-	0x2a, 0x36, 0x48, 0x61, 0x0a, 0x82, 0x0c, 0xe4, 0x58, 0x4d, 0xee, 0x08, 0xdc, 0x2b, 0x51, 0x7a,
-	0xca, 0x65, 0x38, 0xac, 0x1f, 0x31, 0xd7, 0x7a, 0xc4, 0xea, 0x46, 0xea, 0xd9, 0x8d, 0xb4, 0xa1,
-	0xdd, 0x6f, 0xd1, 0x36, 0x17, 0x3f, 0xa8, 0x2f, 0x1e, 0x23, 0xc5, 0xf3, 0x22, 0x91, 0xc2, 0xbc,
-	0x83, 0x06, 0xd1, 0x2b, 0xc1, 0x25, 0xa7, 0xa2, 0x18, 0x96, 0x32, 0x2a, 0x1c, 0xfe, 0x81, 0x2f,
-	0x80, 0x25, 0x83, 0xaf, 0x56, 0xc6, 0x78, 0x3b, 0x5f, 0x77, 0xbb, 0x3a, 0x3a, 0xe2, 0xdc, 0x9b,
-	0xe2, 0x3c, 0x4b, 0xdc, 0x3f, 0x97, 0x11, 0x80, 0x27, 0x9e, 0x8b, 0xc8, 0x88, 0xa0, 0xb1, 0x25,
-	0x6d, 0xb8, 0x57, 0x9a, 0xdf, 0x91, 0xf6, 0x8b, 0x03, 0x27, 0x96, 0xb4, 0xaf, 0x24, 0xcf, 0xd4,
-	0x95, 0x90, 0x46, 0xde, 0xce, 0x9e, 0xda, 0xc8, 0x46, 0x81, 0x3d, 0x5b, 0x36, 0x51, 0x72, 0x77,
-	0x52, 0xf2, 0x5a, 0x94, 0xde, 0x04, 0x48, 0xd4, 0x37, 0x89, 0xbe, 0x8e, 0x25, 0x7f, 0x46, 0x62,
-	0x87, 0xcc, 0x9a, 0x69, 0x51, 0x1e, 0xb4, 0x29, 0x2f, 0x06, 0xf4, 0x87, 0xfc, 0xfd, 0xbf, 0x03,
-	0x00, 0x00, 0xff, 0xff, 0x77, 0x17, 0xf5, 0x15, 0xa5, 0x0b, 0x00, 0x00,
+	// 1501 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0xcf, 0x6f, 0x1c, 0x35,
+	0x1b, 0xd6, 0xec, 0xcc, 0x6e, 0xb2, 0x6f, 0xd2, 0x34, 0x99, 0xf6, 0xcb, 0xb7, 0x5f, 0x55, 0x7d,
+	0x8a, 0x06, 0x5a, 0x42, 0x11, 0x11, 0x14, 0x21, 0xaa, 0x22, 0x10, 0x21, 0x5d, 0x42, 0xa5, 0xa4,
+	0xb4, 0x6e, 0x08, 0x67, 0x67, 0xd6, 0xd9, 0x4c, 0xbb, 0x3b, 0x1e, 0x6c, 0xef, 0x36, 0xb9, 0x72,
+	0xe6, 0x84, 0xb8, 0xc1, 0x8d, 0x23, 0x17, 0x24, 0x2e, 0x08, 0x71, 0xe4, 0x8f, 0xe0, 0xcc, 0x89,
+	0x23, 0x7f, 0x02, 0x7a, 0x5f, 0x7b, 0x66, 0xbc, 0x9b, 0x5d, 0x54, 0xa4, 0x80, 0x38, 0xad, 0x1f,
+	0xfb, 0xf5, 0xf8, 0x79, 0xfc, 0xfe, 0xb0, 0xbd, 0xb0, 0x26, 0xc6, 0xc3, 0x54, 0xe6, 0x46, 0xf1,
+	0xd4, 0x6c, 0x15, 0x4a, 0x1a, 0x19, 0x37, 0xcd, 0x59, 0x21, 0x74, 0xf2, 0x59, 0x00, 0x6b, 0xdd,
+	0xc3, 0xfd, 0x1d, 0x37, 0xf8, 0xd1, 0xd1, 0x13, 0x91, 0x9a, 0x38, 0x86, 0x88, 0xf7, 0x7a, 0xaa,
+	0x13, 0x6c, 0x04, 0x9b, 0x6d, 0x46, 0xed, 0xf8, 0x16, 0x44, 0x3d, 0x6e, 0x78, 0xa7, 0xb1, 0x11,
+	0x6c, 0x2e, 0xdd, 0x5e, 0xdf, 0xa2, 0xf9, 0x5b, 0xde, 0xdc, 0x7b, 0xdc, 0x70, 0x46, 0x36, 0xf1,
+	0xab, 0xd0, 0xd4, 0x86, 0x1b, 0xd1, 0x09, 0xc9, 0xf8, 0xbf, 0xe7, 0x8d, 0x1f, 0xe3, 0x30, 0xb3,
+	0x56, 0xc9, 0xb7, 0x01, 0x5c, 0x9e, 0xfa, 0x50, 0xdc, 0x81, 0x85, 0x54, 0x09, 0x6e, 0x64, 0xc9,
+	0xa2, 0x84, 0x48, 0x2e, 0xe7, 0x43, 0x41, 0x44, 0xda, 0x8c, 0xda, 0xf1, 0x55, 0x68, 0xf2, 0x41,
+	0xc6, 0x35, 0x2d, 0xd8, 0x66, 0x16, 0x54, 0x32, 0x22, 0x4f, 0x46, 0x0c, 0x51, 0x2a, 0x7b, 0xa2,
+	0xd3, 0xdc, 0x08, 0x36, 0x97, 0x19, 0xb5, 0xe3, 0x6b, 0xb0, 0x88, 0xbf, 0x1f, 0x72, 0x7d, 0xd2,
+	0x69, 0x51, 0x7f, 0x85, 0xe3, 0x55, 0x08, 0xf9, 0x51, 0xd6, 0x59, 0xa0, 0x4f, 0x60, 0x33, 0xf9,
+	0x35, 0x80, 0xd5, 0x69, 0x25, 0x48, 0x20, 0x97, 0x79, 0x2a, 0x88, 0x6c, 0xc4, 0x2c, 0xc0, 0x0f,
+	0xeb, 0x51, 0x96, 0x66, 0x3d, 0xd1, 0x23, 0xba, 0x8b, 0xac, 0xc2, 0xf1, 0x06, 0x2c, 0x69, 0x23,
+	0x15, 0xef, 0xdb, 0x75, 0x43, 0x5a, 0xd7, 0xef, 0x8a, 0xdf, 0x85, 0x05, 0x07, 0x3b, 0xd1, 0x46,
+	0xb8, 0xb9, 0x74, 0xfb, 0xc5, 0x39, 0xfb, 0xb8, 0xf5, 0xd8, 0x9a, 0x75, 0x73, 0xa3, 0xce, 0x58,
+	0x39, 0xe9, 0xda, 0x5d, 0x58, 0xf6, 0x07, 0x50, 0xca, 0x53, 0x71, 0xe6, 0xb6, 0x13, 0x9b, 0xc8,
+	0x7a, 0xcc, 0x07, 0x23, 0xbb, 0x97, 0xcb, 0xcc, 0x82, 0xbb, 0x8d, 0x3b, 0x41, 0xf2, 0xfd, 0x64,
+	0x5c, 0x6c, 0xa7, 0x26, 0x93, 0x79, 0xbc, 0x0e, 0x2d, 0x3e, 0x94, 0xa3, 0xdc, 0x38, 0x99, 0x0e,
+	0xa1, 0xce, 0x3e, 0xd7, 0x7b, 0xd9, 0x30, 0x33, 0xf4, 0xa9, 0x88, 0x55, 0xd8, 0x8d, 0x3d, 0x54,
+	0x59, 0x6a, 0xc3, 0xe1, 0x12, 0xab, 0x70, 0xe5, 0x8c, 0xc8, 0x73, 0x46, 0xe5, 0xca, 0xe6, 0x94,
+	0x2b, 0x73, 0x69, 0x44, 0xa7, 0xe5, 0x9c, 0x2e, 0x8d, 0x98, 0xe1, 0x9a, 0x1f, 0x03, 0x88, 0x99,
+	0x48, 0x45, 0x56, 0x18, 0x8f, 0x3c, 0xd2, 0x4e, 0xf9, 0x60, 0x20, 0xca, 0x50, 0x72, 0x28, 0x4e,
+	0x60, 0xb9, 0xcc, 0x8a, 0x07, 0x75, 0x44, 0x4d, 0xf4, 0xf9, 0x36, 0xdb, 0x18, 0x4b, 0xe1, 0xa4,
+	0x0d, 0xf6, 0x61, 0xac, 0x8e, 0xb4, 0xe8, 0xed, 0x72, 0x4d, 0x4a, 0x22, 0x56, 0x42, 0xa4, 0xa8,
+	0x84, 0x71, 0xc1, 0x86, 0x4d, 0xb4, 0x7d, 0xa2, 0x65, 0xce, 0x84, 0x71, 0x5a, 0x4a, 0x98, 0x1c,
+	0x43, 0xdc, 0x3d, 0xdc, 0x27, 0x87, 0xee, 0x9c, 0xf0, 0xbc, 0x2f, 0xee, 0x1b, 0x31, 0x9c, 0xe1,
+	0xb4, 0x6b, 0xb0, 0x58, 0x28, 0x71, 0xe8, 0xf9, 0xad, 0xc2, 0xc4, 0x76, 0xa4, 0x94, 0xc8, 0x8d,
+	0x1d, 0xb7, 0x51, 0x35, 0xd1, 0x97, 0x3c, 0x80, 0x56, 0xf7, 0x70, 0x7f, 0x4f, 0xf6, 0x91, 0x0b,
+	0xe6, 0x84, 0xd0, 0xba, 0xcc, 0x31, 0x07, 0x71, 0xc7, 0x8c, 0x2c, 0xb2, 0x54, 0x77, 0x1a, 0x1b,
+	0xe1, 0xe6, 0x32, 0x73, 0x08, 0xdd, 0x40, 0x45, 0xc0, 0x7e, 0x97, 0xda, 0xc9, 0x0f, 0x01, 0x40,
+	0x77, 0x3c, 0xdc, 0x93, 0x7d, 0x22, 0x7c, 0x21, 0x1f, 0x45, 0xdb, 0x13, 0x91, 0xf5, 0x4f, 0x0c,
+	0xed, 0x68, 0xc8, 0x1c, 0xc2, 0xaf, 0x9b, 0xd3, 0xfb, 0x79, 0x4f, 0x9c, 0xd2, 0xa6, 0x86, 0xac,
+	0x84, 0xb8, 0x2d, 0x03, 0xd9, 0xb7, 0x43, 0xb8, 0xb3, 0x4d, 0x56, 0x61, 0x5a, 0xf9, 0x94, 0xd2,
+	0x6c, 0x81, 0xd6, 0x70, 0x28, 0xf9, 0x3a, 0x80, 0xd8, 0x0b, 0x14, 0x2c, 0x3c, 0x3b, 0xc3, 0xde,
+	0x3f, 0x52, 0x7b, 0xda, 0x73, 0x6a, 0x4f, 0xbb, 0xae, 0x3d, 0xc9, 0x6f, 0x01, 0x5c, 0x99, 0xce,
+	0x75, 0xe4, 0x77, 0x21, 0xc5, 0xa6, 0x3d, 0x59, 0x6c, 0xb6, 0xa7, 0x8b, 0xcd, 0x4b, 0x73, 0x8a,
+	0xcd, 0xce, 0xb0, 0x77, 0x31, 0xf5, 0xa6, 0xed, 0xd7, 0x9b, 0x6f, 0x02, 0xf8, 0xcf, 0xf9, 0xcc,
+	0x45, 0xb1, 0xff, 0x8a, 0xe4, 0x6d, 0x53, 0xf2, 0x26, 0x37, 0xe0, 0xf2, 0xce, 0x89, 0x48, 0x9f,
+	0x76, 0x0f, 0xf7, 0x71, 0x2e, 0x13, 0x9f, 0xce, 0x3a, 0x2a, 0x93, 0x2f, 0x03, 0x58, 0x9d, 0xb4,
+	0xd3, 0x85, 0x75, 0xb4, 0x5d, 0x97, 0x8c, 0x17, 0x59, 0x85, 0xcf, 0xf1, 0x6c, 0xcc, 0xe0, 0x39,
+	0xad, 0x37, 0x9c, 0xa1, 0xf7, 0x3a, 0xb4, 0x29, 0xfa, 0xc8, 0xc0, 0x46, 0x5e, 0xdd, 0x91, 0x9c,
+	0xc1, 0x5a, 0x57, 0x9b, 0x6c, 0xc8, 0x8d, 0xe8, 0x1e, 0xee, 0xef, 0x72, 0x8d, 0xfc, 0x57, 0xa0,
+	0x61, 0xa4, 0x63, 0xdf, 0x30, 0xb2, 0x8a, 0xd1, 0x86, 0x57, 0x92, 0x6b, 0x17, 0x84, 0x13, 0x2e,
+	0xa8, 0x8f, 0x83, 0x68, 0xe2, 0x38, 0x70, 0x85, 0xb9, 0x59, 0x17, 0xe6, 0x9b, 0x10, 0x4f, 0x2f,
+	0xad, 0x0b, 0xb4, 0xeb, 0x73, 0xed, 0xa2, 0x18, 0x9b, 0xc9, 0x0d, 0x58, 0xea, 0x8e, 0x87, 0xf7,
+	0xc4, 0xd1, 0xa8, 0x8f, 0xe4, 0xd6, 0xa1, 0x25, 0x0b, 0x0c, 0x43, 0xb2, 0x69, 0x32, 0x87, 0x92,
+	0xd7, 0x60, 0xb9, 0x36, 0xd3, 0x05, 0x86, 0x77, 0x0f, 0x01, 0x06, 0xe8, 0xa8, 0xac, 0x3b, 0x7e,
+	0x57, 0x72, 0x0b, 0x56, 0xba, 0xe3, 0xe1, 0xa3, 0x91, 0x50, 0x67, 0xdb, 0x47, 0x19, 0x7e, 0x7b,
+	0x6e, 0x9d, 0x4a, 0xde, 0x81, 0xcb, 0x13, 0xb6, 0xba, 0x98, 0x6f, 0x5c, 0x6a, 0x6d, 0xd4, 0x5a,
+	0x33, 0x58, 0x2a, 0xa7, 0xff, 0xe9, 0x3a, 0x98, 0x0d, 0x59, 0x5e, 0x8c, 0x4c, 0x99, 0x0d, 0x04,
+	0xe6, 0x6e, 0x76, 0x59, 0x25, 0x23, 0xaf, 0xf4, 0x7e, 0x1e, 0xc0, 0x72, 0xbd, 0x96, 0x2e, 0x2e,
+	0x6c, 0xb1, 0x0e, 0x2c, 0x28, 0xfe, 0xec, 0x5e, 0xb9, 0x5e, 0x9b, 0x95, 0x10, 0xc3, 0x18, 0x0f,
+	0x2c, 0x1a, 0xb2, 0x0e, 0xae, 0x70, 0xf2, 0x73, 0x00, 0x57, 0xbb, 0xe3, 0x61, 0x95, 0xbd, 0x4a,
+	0x70, 0x23, 0x5c, 0x92, 0x50, 0x50, 0x05, 0x5e, 0xe1, 0x5b, 0x85, 0xf0, 0x58, 0xd8, 0x38, 0x0b,
+	0x19, 0x36, 0xab, 0x33, 0x3e, 0xf4, 0xce, 0xf8, 0xaa, 0xb8, 0x46, 0x7e, 0x71, 0xad, 0x69, 0x37,
+	0x27, 0x68, 0x3b, 0x67, 0xb4, 0x2a, 0x67, 0xa0, 0xa5, 0x38, 0x2d, 0x32, 0x25, 0xdc, 0x35, 0xc1,
+	0x21, 0x3a, 0x44, 0xb9, 0xe2, 0x94, 0x28, 0x8b, 0x56, 0x46, 0x89, 0x93, 0x5f, 0xf0, 0x54, 0xf0,
+	0x64, 0xf0, 0xc1, 0xc0, 0x05, 0xe3, 0xcc, 0xcb, 0x8f, 0x9f, 0x31, 0x53, 0xe2, 0xc2, 0xf3, 0xe2,
+	0x22, 0x4f, 0xdc, 0xf3, 0xcb, 0x88, 0x21, 0x12, 0xa7, 0x22, 0x75, 0x22, 0xa8, 0xed, 0x49, 0x5b,
+	0x9c, 0x2b, 0xad, 0x3d, 0x25, 0xed, 0xbb, 0x00, 0xd6, 0x3d, 0x69, 0x07, 0x8a, 0xe7, 0xfa, 0x58,
+	0x28, 0x27, 0x6f, 0x66, 0x9d, 0xad, 0x65, 0xa3, 0xc0, 0x86, 0x2f, 0x9b, 0x28, 0x85, 0x33, 0x29,
+	0x45, 0x13, 0x94, 0xfe, 0x0f, 0x90, 0xe9, 0x4f, 0x32, 0x73, 0xd2, 0x53, 0xfc, 0x19, 0x89, 0x5d,
+	0x64, 0x5e, 0xcf, 0x04, 0xe5, 0xd6, 0x14, 0xe5, 0xb7, 0xe1, 0x52, 0x77, 0x3c, 0x3c, 0x50, 0x3c,
+	0x15, 0x07, 0xa7, 0x2e, 0x98, 0x4e, 0xf0, 0x10, 0x73, 0xc1, 0x84, 0x6d, 0x5c, 0x18, 0x05, 0x89,
+	0xb2, 0x74, 0x3a, 0x94, 0x7c, 0xd5, 0xa0, 0x04, 0x41, 0x17, 0x7e, 0xa0, 0xb0, 0x42, 0xc6, 0x10,
+	0x55, 0xf5, 0xa4, 0xcd, 0xa8, 0x8d, 0x7d, 0xc7, 0x4a, 0x0e, 0x4b, 0x07, 0x62, 0xdb, 0x95, 0xc5,
+	0xb0, 0x2a, 0x8b, 0xd5, 0xc9, 0x65, 0x2b, 0x9d, 0x05, 0x65, 0x01, 0x6b, 0x56, 0x05, 0x0c, 0x13,
+	0xa7, 0xcf, 0xf5, 0xc7, 0x5a, 0xf4, 0x48, 0x48, 0xc4, 0x4a, 0x58, 0x27, 0xe0, 0xc2, 0x54, 0x02,
+	0xca, 0x91, 0xc1, 0x6e, 0xe7, 0x44, 0x8b, 0xd0, 0x5a, 0x28, 0x25, 0x95, 0xf3, 0xa0, 0x05, 0x78,
+	0x06, 0x28, 0x31, 0x16, 0xca, 0x30, 0xc1, 0xb5, 0xcc, 0x3b, 0x60, 0xcf, 0x00, 0xbf, 0x2f, 0x7e,
+	0x19, 0x9a, 0xe8, 0x39, 0xdd, 0x59, 0xa2, 0x63, 0xfc, 0x4a, 0x79, 0x8c, 0x7b, 0xbb, 0xc0, 0xac,
+	0x45, 0xf2, 0xbb, 0x0d, 0xf4, 0x87, 0x4a, 0xd0, 0x3b, 0x6c, 0x3b, 0x4d, 0xc9, 0xb3, 0xf3, 0x8b,
+	0x48, 0x07, 0x16, 0x8e, 0xf8, 0x80, 0xe3, 0xd5, 0xc3, 0x5e, 0xf3, 0x4b, 0x58, 0x5f, 0x49, 0x42,
+	0xff, 0x4a, 0xe2, 0xdf, 0xef, 0xcb, 0xd4, 0x78, 0xaf, 0xbe, 0x68, 0x34, 0x89, 0xe1, 0xcd, 0x9a,
+	0xe1, 0x14, 0x93, 0xbf, 0xe1, 0x9e, 0xf1, 0x53, 0x00, 0x2b, 0x7e, 0x38, 0xe9, 0xe2, 0xaf, 0xc4,
+	0x53, 0xfc, 0x3a, 0xb4, 0x71, 0xeb, 0x68, 0xba, 0x7b, 0xdc, 0xce, 0xdc, 0xe0, 0xda, 0x2a, 0x7e,
+	0x93, 0xae, 0xeb, 0xf6, 0x39, 0x6c, 0x6f, 0x56, 0xff, 0x9b, 0x2b, 0x98, 0x55, 0xa6, 0x75, 0x00,
+	0x34, 0xbd, 0x00, 0x48, 0x5e, 0xa0, 0x64, 0xd8, 0x15, 0x66, 0x47, 0xf6, 0xc4, 0xbc, 0xeb, 0xc7,
+	0x1d, 0x58, 0xf1, 0x8d, 0xac, 0xc4, 0x69, 0xab, 0x59, 0x07, 0x7d, 0x72, 0x97, 0xe2, 0x61, 0x57,
+	0x18, 0xb7, 0xbf, 0xdb, 0x66, 0xce, 0x1a, 0xe5, 0x9e, 0x37, 0xaa, 0x3d, 0x4f, 0x1e, 0xc1, 0x95,
+	0x73, 0x73, 0x75, 0xf1, 0x7c, 0x93, 0x6b, 0x87, 0x85, 0xde, 0x43, 0x34, 0xb9, 0x49, 0xc9, 0xbb,
+	0x27, 0xfb, 0x07, 0xf6, 0xa1, 0x50, 0x3f, 0x20, 0x82, 0x8d, 0x90, 0xbc, 0x42, 0x28, 0xf9, 0x22,
+	0x28, 0xb7, 0x65, 0x4f, 0xf6, 0xe9, 0x56, 0x73, 0x1d, 0xda, 0x98, 0xc6, 0xef, 0x0f, 0x64, 0xfa,
+	0x94, 0x96, 0x0e, 0x59, 0xdd, 0x41, 0x8f, 0x08, 0x69, 0xc7, 0x1a, 0xee, 0x11, 0x61, 0x21, 0xce,
+	0x73, 0xb1, 0x2e, 0xf0, 0x3e, 0x1f, 0xd2, 0x05, 0xaa, 0xec, 0x88, 0x5f, 0xa9, 0xd6, 0x8f, 0xa6,
+	0x73, 0xab, 0x22, 0x59, 0x91, 0x7a, 0x0b, 0x56, 0x7c, 0x4e, 0xba, 0x88, 0x6f, 0x40, 0x34, 0x90,
+	0x7d, 0x4b, 0x7e, 0xe9, 0xf6, 0xda, 0xc4, 0x64, 0x7c, 0x3a, 0x31, 0x1a, 0x3e, 0x6a, 0xd1, 0x1f,
+	0x34, 0x6f, 0xfc, 0x31, 0x00, 0x15, 0xb1, 0xfe, 0x5a, 0xb5, 0x11, 0x00, 0x00,
 }
@@ -24,9 +24,21 @@ const (
 	TyLogCallContract = 603
 	// TyLogEVMStateChangeItem  合约状态数据变更项日志
 	TyLogEVMStateChangeItem = 604
+	// TyLogEVMEventData  合约LOG0-4指令生成的事件日志
+	TyLogEVMEventData = 605
 
 	// MaxGasLimit  最大Gas消耗上限
 	MaxGasLimit = 10000000
+
+	// CallTracer 调用树跟踪器
+	CallTracer = "callTracer"
+	// PrestateTracer 交易执行前状态跟踪器
+	PrestateTracer = "prestateTracer"
+
+	// SignNameEthSecp256k1 以太坊格式签名交易的签名名称
+	SignNameEthSecp256k1 = "evm.eth_secp256k1"
+	// EthSecp256k1 以太坊格式签名交易的签名类型，只在evm执行器中有效
+	EthSecp256k1 = 260
 )
 
 const (
@@ -44,6 +56,14 @@ const (
 	ForkEVMYoloV1 = "ForkEVMYoloV1"
 	//ForkEVMTxGroup 交易组中的交易通过GAS检查
 	ForkEVMTxGroup = "ForkEVMTxGroup"
+	// ForkEVMLondon 伦敦版本虚拟机指令分叉，支持CHAINID、BASEFEE指令以及EIP-2929计费规则
+	ForkEVMLondon = "ForkEVMLondon"
+	// ForkEVMShanghai 上海版本虚拟机指令分叉，支持PUSH0指令
+	ForkEVMShanghai = "ForkEVMShanghai"
+	// ForkEVMEventLog EVM合约LOG0-4指令生成的事件日志保存到交易回执中
+	ForkEVMEventLog = "ForkEVMEventLog"
+	// ForkEVMABIError EVM合约ABI支持error类型的定义，用于解析自定义错误
+	ForkEVMABIError = "ForkEVMABIError"
 )
 
 var (
@@ -62,5 +82,6 @@ var (
 		TyLogContractData:       {Ty: reflect.TypeOf(EVMContractData{}), Name: "LogContractData"},
 		TyLogContractState:      {Ty: reflect.TypeOf(EVMContractState{}), Name: "LogContractState"},
 		TyLogEVMStateChangeItem: {Ty: reflect.TypeOf(EVMStateChangeItem{}), Name: "LogEVMStateChangeItem"},
+		TyLogEVMEventData:       {Ty: reflect.TypeOf(EVMLog{}), Name: "LogEVMEventData"},
 	}
 )
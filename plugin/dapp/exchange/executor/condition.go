@@ -0,0 +1,359 @@
+package executor
+
+import (
+	"fmt"
+
+	"github.com/33cn/chain33/account"
+	dbm "github.com/33cn/chain33/common/db"
+	tab "github.com/33cn/chain33/common/db/table"
+	"github.com/33cn/chain33/types"
+	et "github.com/33cn/plugin/plugin/dapp/exchange/types"
+)
+
+/*
+ * 条件单(止损单,止盈单)
+ * 挂单时冻结资金,存放在单独的trigger表中,不参与撮合
+ * 当交易对的成交价格越过触发价时,在同一笔交易中转为限价单继续撮合
+ */
+
+//TriggerDirection 条件单的触发方向
+func TriggerDirection(ty, op int32) int32 {
+	if ty == et.TyStopLimitOrderAction {
+		//止损卖单在价格下跌时触发,止损买单在价格上涨时触发
+		if op == et.OpSell {
+			return et.TriggerDown
+		}
+		return et.TriggerUp
+	}
+	//止盈卖单在价格上涨时触发,止盈买单在价格下跌时触发
+	if op == et.OpSell {
+		return et.TriggerUp
+	}
+	return et.TriggerDown
+}
+
+//StopLimitOrder 止损单
+func (a *Action) StopLimitOrder(payload *et.StopLimitOrder) (*types.Receipt, error) {
+	return a.conditionOrder(et.TyStopLimitOrderAction, payload.GetLimitOrder(), payload.GetTriggerPrice())
+}
+
+//TakeProfitOrder 止盈单
+func (a *Action) TakeProfitOrder(payload *et.TakeProfitOrder) (*types.Receipt, error) {
+	return a.conditionOrder(et.TyTakeProfitOrderAction, payload.GetLimitOrder(), payload.GetTriggerPrice())
+}
+
+//CheckConditionOrder 条件单参数检查
+func CheckConditionOrder(limitOrder *et.LimitOrder, triggerPrice int64) error {
+	if limitOrder == nil {
+		return types.ErrInvalidParam
+	}
+	if !CheckExchangeAsset(limitOrder.GetLeftAsset(), limitOrder.GetRightAsset()) {
+		return et.ErrAsset
+	}
+	if !CheckAmount(limitOrder.GetAmount()) {
+		return et.ErrAssetAmount
+	}
+	if !CheckPrice(limitOrder.GetPrice()) {
+		return et.ErrAssetPrice
+	}
+	if !CheckOp(limitOrder.GetOp()) {
+		return et.ErrAssetOp
+	}
+	//条件单由其他交易触发,不能因为成交方式导致触发交易失败,只支持GTC
+	if limitOrder.GetTimeInForce() != et.TimeInForceGTC {
+		return et.ErrTimeInForce
+	}
+	if !CheckPrice(triggerPrice) {
+		return et.ErrTriggerPrice
+	}
+	return nil
+}
+
+func (a *Action) conditionOrder(ty int32, payload *et.LimitOrder, triggerPrice int64) (*types.Receipt, error) {
+	var logs []*types.ReceiptLog
+	var kvs []*types.KeyValue
+	if err := CheckConditionOrder(payload, triggerPrice); err != nil {
+		return nil, err
+	}
+	assetDB, amount, err := a.conditionFrozenAsset(payload)
+	if err != nil {
+		return nil, err
+	}
+	//挂单时冻结资金,保证触发后能够成交
+	acc := assetDB.LoadExecAccount(a.fromaddr, a.execaddr)
+	if acc.Balance < amount {
+		elog.Error("conditionOrder check balance", "addr", a.fromaddr, "avail", acc.Balance, "need", amount)
+		return nil, et.ErrAssetBalance
+	}
+	receipt, err := assetDB.ExecFrozen(a.fromaddr, a.execaddr, amount)
+	if err != nil {
+		elog.Error("conditionOrder.ExecFrozen", "addr", a.fromaddr, "amount", amount, "err", err.Error())
+		return nil, err
+	}
+	logs = append(logs, receipt.Logs...)
+	kvs = append(kvs, receipt.KV...)
+
+	order := &et.ConditionOrder{
+		OrderID:      a.GetIndex(),
+		Ty:           ty,
+		LimitOrder:   payload,
+		TriggerPrice: triggerPrice,
+		Status:       et.Ordered,
+		Addr:         a.fromaddr,
+		UpdateTime:   a.blocktime,
+		Index:        a.GetIndex(),
+	}
+	kvs = append(kvs, a.GetConditionKVSet(order)...)
+	re := &et.ReceiptConditionOrder{Order: order, Index: a.GetIndex()}
+	logs = append(logs, &types.ReceiptLog{Ty: et.TyConditionOrderLog, Log: types.Encode(re)})
+	return &types.Receipt{Ty: types.ExecOk, KV: kvs, Logs: logs}, nil
+}
+
+//RevokeConditionOrder 撤回未触发的条件单,解冻资金
+func (a *Action) RevokeConditionOrder(payload *et.RevokeConditionOrder) (*types.Receipt, error) {
+	var logs []*types.ReceiptLog
+	var kvs []*types.KeyValue
+	order, err := findConditionOrderByOrderID(a.statedb, payload.GetOrderID())
+	if err != nil {
+		return nil, err
+	}
+	if order.Addr != a.fromaddr {
+		elog.Error("RevokeConditionOrder.OrderCheck", "addr", a.fromaddr, "order.addr", order.Addr, "order.status", order.Status)
+		return nil, et.ErrAddr
+	}
+	if order.Status != et.Ordered {
+		elog.Error("RevokeConditionOrder.OrderCheck", "addr", a.fromaddr, "order.addr", order.Addr, "order.status", order.Status)
+		return nil, et.ErrOrderSatus
+	}
+	assetDB, amount, err := a.conditionFrozenAsset(order.GetLimitOrder())
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := assetDB.ExecActive(a.fromaddr, a.execaddr, amount)
+	if err != nil {
+		elog.Error("RevokeConditionOrder.ExecActive", "addr", a.fromaddr, "amount", amount, "err", err.Error())
+		return nil, err
+	}
+	logs = append(logs, receipt.Logs...)
+	kvs = append(kvs, receipt.KV...)
+
+	order.Status = et.Revoked
+	order.UpdateTime = a.blocktime
+	order.Index = a.GetIndex()
+	kvs = append(kvs, a.GetConditionKVSet(order)...)
+	re := &et.ReceiptConditionOrder{Order: order, Index: a.GetIndex()}
+	logs = append(logs, &types.ReceiptLog{Ty: et.TyConditionOrderLog, Log: types.Encode(re)})
+	return &types.Receipt{Ty: types.ExecOk, KV: kvs, Logs: logs}, nil
+}
+
+//条件单冻结的资产及数量,与限价单挂单时冻结的资金一致
+func (a *Action) conditionFrozenAsset(payload *et.LimitOrder) (*account.DB, int64, error) {
+	asset := payload.GetLeftAsset()
+	if payload.GetOp() == et.OpBuy {
+		asset = payload.GetRightAsset()
+	}
+	assetDB, err := account.NewAccountDB(a.api.GetConfig(), asset.GetExecer(), asset.GetSymbol(), a.statedb)
+	if err != nil {
+		return nil, 0, err
+	}
+	return assetDB, CalcActualCost(payload.GetOp(), payload.GetAmount(), payload.GetPrice()), nil
+}
+
+//GetConditionKVSet get kv set
+func (a *Action) GetConditionKVSet(order *et.ConditionOrder) (kvset []*types.KeyValue) {
+	kvset = append(kvset, &types.KeyValue{Key: calcConditionOrderKey(order.OrderID), Value: types.Encode(order)})
+	return kvset
+}
+
+//TriggerConditionOrders 本交易撮合后成交价格发生变化时,依次触发满足条件的条件单
+//触发的条件单以原订单号转为限价单,作为吃单继续撮合,可能再次推动价格触发其他条件单
+func (a *Action) TriggerConditionOrders(left, right *et.Asset, receipt *types.Receipt) (*types.Receipt, error) {
+	cfg := a.api.GetConfig()
+	leftAssetDB, err := account.NewAccountDB(cfg, left.GetExecer(), left.GetSymbol(), a.statedb)
+	if err != nil {
+		return nil, err
+	}
+	rightAssetDB, err := account.NewAccountDB(cfg, right.GetExecer(), right.GetSymbol(), a.statedb)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < et.MaxTriggerCount; i++ {
+		if a.cache.lastPrice == 0 {
+			break
+		}
+		order, err := a.findTriggeredOrder(left, right, a.cache.lastPrice)
+		if err != nil {
+			return nil, err
+		}
+		if order == nil {
+			break
+		}
+		a.cache.triggered[order.OrderID] = true
+		//触发后先解冻资金,撮合结束后未成交的部分重新冻结
+		assetDB, amount, err := a.conditionFrozenAsset(order.GetLimitOrder())
+		if err != nil {
+			return nil, err
+		}
+		active, err := assetDB.ExecActive(order.Addr, a.execaddr, amount)
+		if err != nil {
+			elog.Error("TriggerConditionOrders.ExecActive", "addr", order.Addr, "amount", amount, "err", err.Error())
+			return nil, err
+		}
+		receipt.Logs = append(receipt.Logs, active.Logs...)
+		receipt.KV = append(receipt.KV, active.KV...)
+
+		order.Status = et.Completed
+		order.UpdateTime = a.blocktime
+		order.Index = a.triggerIndex(i)
+		receipt.KV = append(receipt.KV, a.GetConditionKVSet(order)...)
+		re := &et.ReceiptConditionOrder{Order: order, Index: order.Index}
+		receipt.Logs = append(receipt.Logs, &types.ReceiptLog{Ty: et.TyConditionOrderLog, Log: types.Encode(re)})
+
+		limitOrder, err := a.triggerLimitOrder(order, leftAssetDB, rightAssetDB)
+		if err != nil {
+			return nil, err
+		}
+		receipt.Logs = append(receipt.Logs, limitOrder.Logs...)
+		receipt.KV = append(receipt.KV, limitOrder.KV...)
+	}
+	return receipt, nil
+}
+
+//第i个触发的条件单使用的索引,每个限价单撮合时最多占用MaxMatchCount个索引
+func (a *Action) triggerIndex(i int) int64 {
+	return a.GetIndex() + int64(i+1)*(et.MaxMatchCount+1)
+}
+
+//以条件单地址作为吃单方撮合触发后的限价单
+func (a *Action) triggerLimitOrder(order *et.ConditionOrder, leftAssetDB, rightAssetDB *account.DB) (*types.Receipt, error) {
+	payload := order.GetLimitOrder()
+	taker := *a
+	taker.fromaddr = order.Addr
+	or := &et.Order{
+		OrderID:    order.OrderID,
+		Value:      &et.Order_LimitOrder{LimitOrder: payload},
+		Ty:         et.TyLimitOrderAction,
+		Executed:   0,
+		AVGPrice:   0,
+		Balance:    payload.GetAmount(),
+		Status:     et.Ordered,
+		Addr:       order.Addr,
+		UpdateTime: a.blocktime,
+		Index:      order.Index,
+	}
+	re := &et.ReceiptExchange{
+		Order: or,
+		Index: order.Index,
+	}
+	return taker.matchOrder(payload, or, re, leftAssetDB, rightAssetDB)
+}
+
+//查找满足触发条件的条件单,价格下跌方向按触发价由高往低,价格上涨方向按触发价由低往高
+func (a *Action) findTriggeredOrder(left, right *et.Asset, price int64) (*et.ConditionOrder, error) {
+	for _, direction := range []int32{et.TriggerDown, et.TriggerUp} {
+		primaryKey := ""
+		for {
+			list, err := QueryTriggerList(a.localDB, left, right, direction, primaryKey)
+			if err == types.ErrNotFound {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			for _, order := range list.List {
+				if direction == et.TriggerDown && order.TriggerPrice < price {
+					break
+				}
+				if direction == et.TriggerUp && order.TriggerPrice > price {
+					break
+				}
+				if a.cache.triggered[order.OrderID] {
+					continue
+				}
+				return order, nil
+			}
+			if list.PrimaryKey == "" {
+				break
+			}
+			primaryKey = list.PrimaryKey
+		}
+	}
+	return nil, nil
+}
+
+//触发方向上按触发价排序,下跌方向降序,上涨方向升序
+func triggerListDirection(direction int32) int32 {
+	if direction == et.TriggerDown {
+		return et.ListDESC
+	}
+	return et.ListASC
+}
+
+//QueryTriggerList 按触发方向查询等待触发的条件单
+func QueryTriggerList(localdb dbm.KV, left, right *et.Asset, direction int32, primaryKey string) (*et.ConditionOrderList, error) {
+	table := NewTriggerTable(localdb)
+	prefix := []byte(fmt.Sprintf("%s:%s:%d:%d", left.GetSymbol(), right.GetSymbol(), et.Ordered, direction))
+	var rows []*tab.Row
+	var err error
+	if primaryKey == "" {
+		rows, err = table.ListIndex("trigger", prefix, nil, et.Count, triggerListDirection(direction))
+	} else {
+		rows, err = table.ListIndex("trigger", prefix, []byte(primaryKey), et.Count, triggerListDirection(direction))
+	}
+	if err != nil {
+		return nil, err
+	}
+	var list et.ConditionOrderList
+	for _, row := range rows {
+		list.List = append(list.List, row.Data.(*et.ConditionOrder))
+	}
+	if len(rows) == int(et.Count) {
+		list.PrimaryKey = string(rows[len(rows)-1].Primary)
+	}
+	return &list, nil
+}
+
+//QueryConditionOrderList 根据地址和状态查询条件单,默认展示最新的
+func QueryConditionOrderList(localdb dbm.KV, addr string, status, count, direction int32, primaryKey string) (types.Message, error) {
+	table := NewTriggerTable(localdb)
+	prefix := []byte(fmt.Sprintf("%s:%d", addr, status))
+	indexName := "addr_status"
+	if count == 0 {
+		count = et.Count
+	}
+	var rows []*tab.Row
+	var err error
+	if primaryKey == "" {
+		rows, err = table.ListIndex(indexName, prefix, nil, count, direction)
+	} else {
+		rows, err = table.ListIndex(indexName, prefix, []byte(primaryKey), count, direction)
+	}
+	if err != nil {
+		elog.Error("QueryConditionOrderList.", "addr", addr, "err", err.Error())
+		return nil, err
+	}
+	var list et.ConditionOrderList
+	for _, row := range rows {
+		list.List = append(list.List, row.Data.(*et.ConditionOrder))
+	}
+	if len(rows) == int(count) {
+		list.PrimaryKey = string(rows[len(rows)-1].Primary)
+	}
+	return &list, nil
+}
+
+//条件单的状态只保存在状态数据库中
+func findConditionOrderByOrderID(statedb dbm.KV, orderID int64) (*et.ConditionOrder, error) {
+	data, err := statedb.Get(calcConditionOrderKey(orderID))
+	if err != nil {
+		elog.Error("findConditionOrderByOrderID.Get", "orderID", orderID, "err", err.Error())
+		return nil, err
+	}
+	var order et.ConditionOrder
+	err = types.Decode(data, &order)
+	if err != nil {
+		elog.Error("findConditionOrderByOrderID.Decode", "orderID", orderID, "err", err.Error())
+		return nil, err
+	}
+	return &order, nil
+}
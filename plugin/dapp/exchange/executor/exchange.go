@@ -77,9 +77,34 @@ func (e *exchange) CheckTx(tx *types.Transaction, index int) error {
 		if !CheckOp(op) {
 			return exchangetypes.ErrAssetOp
 		}
+		if !CheckTimeInForce(limitOrder.GetTimeInForce()) {
+			return exchangetypes.ErrTimeInForce
+		}
 	}
 	if exchange.Ty == exchangetypes.TyMarketOrderAction {
-		return types.ErrActionNotSupport
+		marketOrder := exchange.GetMarketOrder()
+		left := marketOrder.GetLeftAsset()
+		right := marketOrder.GetRightAsset()
+		if !CheckExchangeAsset(left, right) {
+			return exchangetypes.ErrAsset
+		}
+		if !CheckAmount(marketOrder.GetAmount()) {
+			return exchangetypes.ErrAssetAmount
+		}
+		if !CheckOp(marketOrder.GetOp()) {
+			return exchangetypes.ErrAssetOp
+		}
+		if !CheckSlippage(marketOrder.GetSlippage()) {
+			return exchangetypes.ErrSlippage
+		}
+	}
+	if exchange.Ty == exchangetypes.TyStopLimitOrderAction {
+		stopLimitOrder := exchange.GetStopLimitOrder()
+		return CheckConditionOrder(stopLimitOrder.GetLimitOrder(), stopLimitOrder.GetTriggerPrice())
+	}
+	if exchange.Ty == exchangetypes.TyTakeProfitOrderAction {
+		takeProfitOrder := exchange.GetTakeProfitOrder()
+		return CheckConditionOrder(takeProfitOrder.GetLimitOrder(), takeProfitOrder.GetTriggerPrice())
 	}
 	return nil
 }
@@ -1,6 +1,7 @@
 package executor
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/33cn/chain33/common/db"
@@ -11,6 +12,7 @@ import (
 	"github.com/33cn/chain33/util"
 
 	"github.com/33cn/chain33/client"
+	"github.com/33cn/chain33/client/mocks"
 	"github.com/33cn/chain33/common"
 	"github.com/33cn/chain33/common/crypto"
 	"github.com/33cn/chain33/queue"
@@ -37,11 +39,20 @@ var (
 	}
 )
 
+var initOnce sync.Once
+
+//执行器只能注册一次
+func initExchange(cfg *types.Chain33Config) {
+	initOnce.Do(func() {
+		Init(et.ExchangeX, cfg, nil)
+	})
+}
+
 func TestExchange(t *testing.T) {
 	//环境准备
 	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
 	cfg.SetTitleOnlyForTest("chain33")
-	Init(et.ExchangeX, cfg, nil)
+	initExchange(cfg)
 	total := 100 * types.Coin
 	accountA := types.Account{
 		Balance: total,
@@ -682,6 +693,503 @@ func TestExchange(t *testing.T) {
 
 }
 
+func TestMarketOrder(t *testing.T) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	initExchange(cfg)
+	total := 100 * types.Coin
+	dir, stateDB, kvdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, stateDB)
+	execAddr := address.ExecAddress(et.ExchangeX)
+
+	accA, _ := account.NewAccountDB(cfg, "coins", "bty", stateDB)
+	accA.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: Nodes[0]})
+	accD, _ := account.NewAccountDB(cfg, "coins", "bty", stateDB)
+	accD.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: Nodes[3]})
+	accD1, _ := account.NewAccountDB(cfg, "token", "CCNY", stateDB)
+	accD1.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: Nodes[3]})
+
+	env := &execEnv{
+		10,
+		1,
+		1539918074,
+	}
+	left := &et.Asset{Symbol: "bty", Execer: "coins"}
+	right := &et.Asset{Execer: "token", Symbol: "CCNY"}
+
+	/*
+	  市价买单按卖单价格由低往高成交,超出滑点的部分退回
+	  用例说明：
+	   1.A 依次挂 2@1, 3@1.1, 5@2 的卖单
+	   2.D 以10%的滑点市价买入10
+	   3.只有前两档成交,剩余5退回
+	*/
+	err := Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: 2 * types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env)
+	assert.Nil(t, err)
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 110000000, Amount: 3 * types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env)
+	assert.Nil(t, err)
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 2 * types.Coin, Amount: 5 * types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env)
+	assert.Nil(t, err)
+
+	err = Exec_MarketOrder(t, &et.MarketOrder{LeftAsset: left, RightAsset: right, Amount: 10 * types.Coin, Op: et.OpBuy, Slippage: 1000}, PrivKeyD, stateDB, kvdb, env)
+	assert.Nil(t, err)
+	orderList, err := Exec_QueryOrderList(et.Revoked, Nodes[3], "", stateDB, kvdb)
+	assert.Nil(t, err)
+	order := orderList.List[0]
+	assert.Equal(t, int32(et.TyMarketOrderAction), order.Ty)
+	assert.Equal(t, 5*types.Coin, order.Executed)
+	assert.Equal(t, 5*types.Coin, order.Balance)
+	assert.Equal(t, int64(106000000), order.AVGPrice)
+
+	acc := accD1.LoadExecAccount(Nodes[3], execAddr)
+	assert.Equal(t, total-2*types.Coin-330000000, acc.Balance)
+	assert.Equal(t, int64(0), acc.Frozen)
+	acc = accD.LoadExecAccount(Nodes[3], execAddr)
+	assert.Equal(t, total+5*types.Coin, acc.Balance)
+
+	marketDepthList, err := Exec_QueryMarketDepth(&et.QueryMarketDepth{LeftAsset: left, RightAsset: right, Op: et.OpSell}, stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(marketDepthList.List))
+	assert.Equal(t, 2*types.Coin, marketDepthList.List[0].Price)
+	assert.Equal(t, 5*types.Coin, marketDepthList.List[0].Amount)
+
+	/*
+	  余额不足时只按可支付的数量成交
+	  用例说明：
+	   1.A 再挂 100@2 的卖单
+	   2.D 以最大滑点市价买入60,余额只够买入47.35
+	*/
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 2 * types.Coin, Amount: 90 * types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env)
+	assert.Nil(t, err)
+	err = Exec_MarketOrder(t, &et.MarketOrder{LeftAsset: left, RightAsset: right, Amount: 60 * types.Coin, Op: et.OpBuy, Slippage: et.MaxSlippage}, PrivKeyD, stateDB, kvdb, env)
+	assert.Nil(t, err)
+	orderList, err = Exec_QueryOrderList(et.Revoked, Nodes[3], "", stateDB, kvdb)
+	assert.Nil(t, err)
+	order = orderList.List[0]
+	assert.Equal(t, int64(4735000000), order.Executed)
+	assert.Equal(t, int64(1265000000), order.Balance)
+	acc = accD1.LoadExecAccount(Nodes[3], execAddr)
+	assert.Equal(t, int64(0), acc.Balance)
+
+	marketDepthList, err = Exec_QueryMarketDepth(&et.QueryMarketDepth{LeftAsset: left, RightAsset: right, Op: et.OpSell}, stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, 95*types.Coin-4735000000, marketDepthList.List[0].Amount)
+
+	/*
+	  D 市价卖出全部成交
+	  用例说明：
+	   1.D 以最大滑点市价卖出5,没有买单,全部退回
+	*/
+	err = Exec_MarketOrder(t, &et.MarketOrder{LeftAsset: left, RightAsset: right, Amount: 5 * types.Coin, Op: et.OpSell, Slippage: et.MaxSlippage}, PrivKeyD, stateDB, kvdb, env)
+	assert.Nil(t, err)
+	orderList, err = Exec_QueryOrderList(et.Revoked, Nodes[3], "", stateDB, kvdb)
+	assert.Nil(t, err)
+	order, err = Exec_QueryOrder(orderList.List[0].OrderID, stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(et.Revoked), order.Status)
+	assert.Equal(t, 5*types.Coin, order.Balance)
+	acc = accD.LoadExecAccount(Nodes[3], execAddr)
+	assert.Equal(t, total+5*types.Coin+4735000000, acc.Balance)
+	assert.Equal(t, int64(0), acc.Frozen)
+
+	//滑点超出范围
+	err = Exec_MarketOrder(t, &et.MarketOrder{LeftAsset: left, RightAsset: right, Amount: 5 * types.Coin, Op: et.OpSell, Slippage: et.MaxSlippage + 1}, PrivKeyD, stateDB, kvdb, env)
+	assert.Equal(t, et.ErrSlippage, err)
+
+	/*
+	  余额不足时跨多个价格成交,均价只按实际成交的数量计算
+	  用例说明：
+	   1.A 依次挂 2@1, 10@2 的卖单
+	   2.D 只有5个CCNY, 以最大滑点市价买入10
+	   3.2@1 全部成交, 剩余3个CCNY只够买入1.5@2, 均价为 (2*1+1.5*2)/3.5
+	*/
+	dir2, stateDB2, kvdb2 := util.CreateTestDB()
+	defer util.CloseTestDB(dir2, stateDB2)
+	accA, _ = account.NewAccountDB(cfg, "coins", "bty", stateDB2)
+	accA.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: Nodes[0]})
+	accD1, _ = account.NewAccountDB(cfg, "token", "CCNY", stateDB2)
+	accD1.SaveExecAccount(execAddr, &types.Account{Balance: 5 * types.Coin, Addr: Nodes[3]})
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: 2 * types.Coin, Op: et.OpSell}, PrivKeyA, stateDB2, kvdb2, env)
+	assert.Nil(t, err)
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 2 * types.Coin, Amount: 10 * types.Coin, Op: et.OpSell}, PrivKeyA, stateDB2, kvdb2, env)
+	assert.Nil(t, err)
+	err = Exec_MarketOrder(t, &et.MarketOrder{LeftAsset: left, RightAsset: right, Amount: 10 * types.Coin, Op: et.OpBuy, Slippage: et.MaxSlippage}, PrivKeyD, stateDB2, kvdb2, env)
+	assert.Nil(t, err)
+	orderList, err = Exec_QueryOrderList(et.Revoked, Nodes[3], "", stateDB2, kvdb2)
+	assert.Nil(t, err)
+	order = orderList.List[0]
+	assert.Equal(t, int64(350000000), order.Executed)
+	assert.Equal(t, int64(650000000), order.Balance)
+	assert.Equal(t, int64(142857142), order.AVGPrice)
+	assert.Equal(t, int64(0), accD1.LoadExecAccount(Nodes[3], execAddr).Balance)
+}
+
+func TestMatchFee(t *testing.T) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	initExchange(cfg)
+	total := 100 * types.Coin
+	dir, stateDB, kvdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, stateDB)
+	execAddr := address.ExecAddress(et.ExchangeX)
+
+	acc, _ := account.NewAccountDB(cfg, "coins", "bty", stateDB)
+	acc.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: Nodes[0]})
+	acc1, _ := account.NewAccountDB(cfg, "token", "CCNY", stateDB)
+	acc1.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: Nodes[0]})
+	acc1.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: Nodes[3]})
+
+	//手续费收取地址为C, 挂单方费率0.1%, 吃单方费率0.2%
+	setManageConfig(stateDB, et.ConfigKeyFeeAddr, Nodes[2])
+	setManageConfig(stateDB, et.ConfigKeyMakerFeePrefix+"coins.bty:token.CCNY", "100000")
+	setManageConfig(stateDB, et.ConfigKeyTakerFeePrefix+"coins.bty:token.CCNY", "200000")
+
+	env := &execEnv{
+		10,
+		1,
+		1539918074,
+	}
+	left := &et.Asset{Symbol: "bty", Execer: "coins"}
+	right := &et.Asset{Execer: "token", Symbol: "CCNY"}
+
+	//A 挂卖 10@1, D 吃单买入 10@1
+	err := Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: 10 * types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env)
+	assert.Nil(t, err)
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: 10 * types.Coin, Op: et.OpBuy}, PrivKeyD, stateDB, kvdb, env)
+	assert.Nil(t, err)
+
+	//吃单方D以bty支付手续费
+	assert.Equal(t, 10*types.Coin-2000000, acc.LoadExecAccount(Nodes[3], execAddr).Balance)
+	assert.Equal(t, total-10*types.Coin, acc1.LoadExecAccount(Nodes[3], execAddr).Balance)
+	//挂单方A以CCNY支付手续费
+	assert.Equal(t, total-10*types.Coin, acc.LoadExecAccount(Nodes[0], execAddr).Balance)
+	assert.Equal(t, total+10*types.Coin-1000000, acc1.LoadExecAccount(Nodes[0], execAddr).Balance)
+	//手续费地址
+	assert.Equal(t, int64(2000000), acc.LoadExecAccount(Nodes[2], execAddr).Balance)
+	assert.Equal(t, int64(1000000), acc1.LoadExecAccount(Nodes[2], execAddr).Balance)
+
+	fee, err := Exec_QueryFeeCollected(&et.QueryFeeCollected{LeftAsset: left, RightAsset: right}, stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2000000), fee.LeftAmount)
+	assert.Equal(t, int64(1000000), fee.RightAmount)
+
+	//D 挂卖 5@1, A 市价买入,同样收取手续费
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: 5 * types.Coin, Op: et.OpSell}, PrivKeyD, stateDB, kvdb, env)
+	assert.Nil(t, err)
+	err = Exec_MarketOrder(t, &et.MarketOrder{LeftAsset: left, RightAsset: right, Amount: 5 * types.Coin, Op: et.OpBuy}, PrivKeyA, stateDB, kvdb, env)
+	assert.Nil(t, err)
+	fee, err = Exec_QueryFeeCollected(&et.QueryFeeCollected{LeftAsset: left, RightAsset: right}, stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3000000), fee.LeftAmount)
+	assert.Equal(t, int64(1500000), fee.RightAmount)
+
+	//其他执行器下相同symbol的资产是不同的交易对,不使用token.CCNY的费率
+	paraRight := &et.Asset{Execer: "paracross", Symbol: "CCNY"}
+	acc2, _ := account.NewAccountDB(cfg, "paracross", "CCNY", stateDB)
+	acc2.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: Nodes[0]})
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: paraRight, Price: types.Coin, Amount: types.Coin, Op: et.OpBuy}, PrivKeyA, stateDB, kvdb, env)
+	assert.Nil(t, err)
+	before := acc.LoadExecAccount(Nodes[3], execAddr).Balance
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: paraRight, Price: types.Coin, Amount: types.Coin, Op: et.OpSell}, PrivKeyD, stateDB, kvdb, env)
+	assert.Nil(t, err)
+	assert.Equal(t, types.Coin, acc2.LoadExecAccount(Nodes[3], execAddr).Balance)
+	assert.Equal(t, before-types.Coin, acc.LoadExecAccount(Nodes[3], execAddr).Balance)
+	fee, err = Exec_QueryFeeCollected(&et.QueryFeeCollected{LeftAsset: left, RightAsset: paraRight}, stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), fee.LeftAmount)
+	assert.Equal(t, int64(0), fee.RightAmount)
+}
+
+func TestKline(t *testing.T) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	initExchange(cfg)
+	total := 100 * types.Coin
+	dir, stateDB, kvdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, stateDB)
+	execAddr := address.ExecAddress(et.ExchangeX)
+
+	acc, _ := account.NewAccountDB(cfg, "coins", "bty", stateDB)
+	acc.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: Nodes[0]})
+	acc.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: Nodes[3]})
+	acc1, _ := account.NewAccountDB(cfg, "token", "CCNY", stateDB)
+	acc1.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: Nodes[1]})
+	acc1.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: Nodes[3]})
+
+	//每个区块间隔20秒,前两笔成交落在同一根1分钟K线内
+	env := &execEnv{
+		1539918074,
+		1,
+		1,
+	}
+	left := &et.Asset{Symbol: "bty", Execer: "coins"}
+	right := &et.Asset{Execer: "token", Symbol: "CCNY"}
+
+	_, err := Exec_QueryKline(&et.QueryKline{LeftAsset: left, RightAsset: right, Period: "2m"}, stateDB, kvdb)
+	assert.Equal(t, et.ErrKlinePeriod, err)
+	ticker, err := Exec_QueryTicker(&et.QueryTicker{LeftAsset: left, RightAsset: right}, stateDB, kvdb, env.blockTime)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), ticker.Count)
+
+	//A 挂卖 10@1, D 买入 4@1
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: 10 * types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env)
+	assert.Nil(t, err)
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: 4 * types.Coin, Op: et.OpBuy}, PrivKeyD, stateDB, kvdb, env)
+	assert.Nil(t, err)
+	//B 挂买 5@0.8, D 市价卖出3, 按挂单价0.8成交
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 80000000, Amount: 5 * types.Coin, Op: et.OpBuy}, PrivKeyB, stateDB, kvdb, env)
+	assert.Nil(t, err)
+	tx, err := CreateMarketOrder(&et.MarketOrder{LeftAsset: left, RightAsset: right, Amount: 3 * types.Coin, Op: et.OpSell}, PrivKeyD)
+	assert.Nil(t, err)
+	err = Exec_Block(t, stateDB, kvdb, env, tx)
+	assert.Nil(t, err)
+
+	klines, err := Exec_QueryKline(&et.QueryKline{LeftAsset: left, RightAsset: right, Period: "1m"}, stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(klines.List))
+	//默认按时间降序
+	assert.Equal(t, int64(1539918120), klines.List[0].Time)
+	assert.Equal(t, int64(80000000), klines.List[0].Close)
+	assert.Equal(t, 3*types.Coin, klines.List[0].Volume)
+	assert.Equal(t, int64(1539918060), klines.List[1].Time)
+	assert.Equal(t, types.Coin, klines.List[1].Open)
+	assert.Equal(t, 4*types.Coin, klines.List[1].Volume)
+
+	klines, err = Exec_QueryKline(&et.QueryKline{LeftAsset: left, RightAsset: right, Period: "5m"}, stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(klines.List))
+	kline := klines.List[0]
+	assert.Equal(t, int64(1539918000), kline.Time)
+	assert.Equal(t, types.Coin, kline.Open)
+	assert.Equal(t, types.Coin, kline.High)
+	assert.Equal(t, int64(80000000), kline.Low)
+	assert.Equal(t, int64(80000000), kline.Close)
+	assert.Equal(t, 7*types.Coin, kline.Volume)
+	assert.Equal(t, int64(2), kline.Count)
+
+	ticker, err = Exec_QueryTicker(&et.QueryTicker{LeftAsset: left, RightAsset: right}, stateDB, kvdb, env.blockTime)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1539918120), ticker.Time)
+	assert.Equal(t, int64(1539918120+60-86400), ticker.StartTime)
+	assert.Equal(t, types.Coin, ticker.Open)
+	assert.Equal(t, int64(80000000), ticker.Low)
+	assert.Equal(t, int64(80000000), ticker.Close)
+	assert.Equal(t, 7*types.Coin, ticker.Volume)
+
+	//统计区间以最新区块为结束,不是最后一笔成交,超过24小时的成交不再统计
+	ticker, err = Exec_QueryTicker(&et.QueryTicker{LeftAsset: left, RightAsset: right}, stateDB, kvdb, 1539918060+86400)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1539918120), ticker.StartTime)
+	assert.Equal(t, int64(80000000), ticker.Open)
+	assert.Equal(t, int64(80000000), ticker.High)
+	assert.Equal(t, 3*types.Coin, ticker.Volume)
+	assert.Equal(t, int64(1), ticker.Count)
+	ticker, err = Exec_QueryTicker(&et.QueryTicker{LeftAsset: left, RightAsset: right}, stateDB, kvdb, env.blockTime+2*86400)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), ticker.Count)
+	assert.Equal(t, int64(0), ticker.Close)
+	assert.Equal(t, int64(0), ticker.Volume)
+
+	//回滚市价单,K线恢复到之前的状态
+	err = Exec_DelLocal(t, tx, kvdb)
+	assert.Nil(t, err)
+	klines, err = Exec_QueryKline(&et.QueryKline{LeftAsset: left, RightAsset: right, Period: "1m"}, stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(klines.List))
+	klines, err = Exec_QueryKline(&et.QueryKline{LeftAsset: left, RightAsset: right, Period: "5m"}, stateDB, kvdb)
+	assert.Nil(t, err)
+	kline = klines.List[0]
+	assert.Equal(t, types.Coin, kline.Low)
+	assert.Equal(t, types.Coin, kline.Close)
+	assert.Equal(t, 4*types.Coin, kline.Volume)
+	assert.Equal(t, int64(1), kline.Count)
+	ticker, err = Exec_QueryTicker(&et.QueryTicker{LeftAsset: left, RightAsset: right}, stateDB, kvdb, env.blockTime-20)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1539918120), ticker.Time)
+	assert.Equal(t, types.Coin, ticker.Close)
+	assert.Equal(t, 4*types.Coin, ticker.Volume)
+}
+
+func TestTimeInForce(t *testing.T) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	initExchange(cfg)
+	total := 100 * types.Coin
+	dir, stateDB, kvdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, stateDB)
+	execAddr := address.ExecAddress(et.ExchangeX)
+
+	acc, _ := account.NewAccountDB(cfg, "coins", "bty", stateDB)
+	acc.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: Nodes[0]})
+	acc1, _ := account.NewAccountDB(cfg, "token", "CCNY", stateDB)
+	acc1.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: Nodes[3]})
+
+	env := &execEnv{
+		10,
+		1,
+		1539918074,
+	}
+	left := &et.Asset{Symbol: "bty", Execer: "coins"}
+	right := &et.Asset{Execer: "token", Symbol: "CCNY"}
+
+	err := Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: 5 * types.Coin, Op: et.OpBuy, TimeInForce: et.TimeInForcePostOnly + 1}, PrivKeyD, stateDB, kvdb, env)
+	assert.Equal(t, et.ErrTimeInForce, err)
+
+	//A 挂卖 5@1
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: 5 * types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env)
+	assert.Nil(t, err)
+
+	//postOnly买单会立即成交,交易失败;不会成交时正常挂单
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: 5 * types.Coin, Op: et.OpBuy, TimeInForce: et.TimeInForcePostOnly}, PrivKeyD, stateDB, kvdb, env)
+	assert.Equal(t, et.ErrPostOnly, err)
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 90000000, Amount: 2 * types.Coin, Op: et.OpBuy, TimeInForce: et.TimeInForcePostOnly}, PrivKeyD, stateDB, kvdb, env)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(180000000), acc1.LoadExecAccount(Nodes[3], execAddr).Frozen)
+
+	//FOK买单无法全部成交,交易失败;可以全部成交时正常成交
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 50000000, Amount: 5 * types.Coin, Op: et.OpBuy, TimeInForce: et.TimeInForceFOK}, PrivKeyD, stateDB, kvdb, env)
+	assert.Equal(t, et.ErrFOKNotFilled, err)
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: 3 * types.Coin, Op: et.OpBuy, TimeInForce: et.TimeInForceFOK}, PrivKeyD, stateDB, kvdb, env)
+	assert.Nil(t, err)
+	assert.Equal(t, 3*types.Coin, acc.LoadExecAccount(Nodes[3], execAddr).Balance)
+
+	//IOC买单成交2,剩余部分撤回且不冻结
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: 5 * types.Coin, Op: et.OpBuy, TimeInForce: et.TimeInForceIOC}, PrivKeyD, stateDB, kvdb, env)
+	assert.Nil(t, err)
+	assert.Equal(t, 5*types.Coin, acc.LoadExecAccount(Nodes[3], execAddr).Balance)
+	assert.Equal(t, int64(180000000), acc1.LoadExecAccount(Nodes[3], execAddr).Frozen)
+	assert.Equal(t, total-5*types.Coin-180000000, acc1.LoadExecAccount(Nodes[3], execAddr).Balance)
+	orderList, err := Exec_QueryOrderList(et.Revoked, Nodes[3], "", stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(orderList.List))
+	assert.Equal(t, 2*types.Coin, orderList.List[0].Executed)
+	order, err := Exec_QueryOrder(orderList.List[0].OrderID, stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(et.Revoked), order.Status)
+	//挂单列表中只剩下postOnly买单
+	orderList, err = Exec_QueryOrderList(et.Ordered, Nodes[3], "", stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(orderList.List))
+	assert.Equal(t, int64(90000000), orderList.List[0].GetLimitOrder().Price)
+	_, err = Exec_QueryMarketDepth(&et.QueryMarketDepth{LeftAsset: left, RightAsset: right, Op: et.OpSell}, stateDB, kvdb)
+	assert.Equal(t, types.ErrNotFound, err)
+}
+
+func TestConditionOrder(t *testing.T) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	initExchange(cfg)
+	total := 100 * types.Coin
+	dir, stateDB, kvdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, stateDB)
+	execAddr := address.ExecAddress(et.ExchangeX)
+
+	acc, _ := account.NewAccountDB(cfg, "coins", "bty", stateDB)
+	acc1, _ := account.NewAccountDB(cfg, "token", "CCNY", stateDB)
+	for _, addr := range []string{Nodes[0], Nodes[1], Nodes[3]} {
+		acc.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: addr})
+		acc1.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: addr})
+	}
+
+	env := &execEnv{
+		10,
+		1,
+		1539918074,
+	}
+	left := &et.Asset{Symbol: "bty", Execer: "coins"}
+	right := &et.Asset{Execer: "token", Symbol: "CCNY"}
+
+	/*
+	  止损卖单在成交价跌至触发价时转为限价单撮合
+	  用例说明：
+	   1.A 挂止损卖单 5@0.9, 触发价0.95
+	   2.D 挂买单 5@0.9
+	   3.B 卖出1@0.9, 成交价0.9触发A的止损单, A成交4, 剩余1挂单
+	*/
+	//条件单只支持GTC成交方式
+	stop := &et.StopLimitOrder{LimitOrder: &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 90000000, Amount: 5 * types.Coin, Op: et.OpSell, TimeInForce: et.TimeInForceIOC}, TriggerPrice: 95000000}
+	err := Exec_Action(t, et.NameStopLimitOrderAction, stop, PrivKeyA, stateDB, kvdb, env)
+	assert.Equal(t, et.ErrTimeInForce, err)
+	stop = &et.StopLimitOrder{LimitOrder: &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 90000000, Amount: 5 * types.Coin, Op: et.OpSell}, TriggerPrice: 95000000}
+	err = Exec_Action(t, et.NameStopLimitOrderAction, stop, PrivKeyA, stateDB, kvdb, env)
+	assert.Nil(t, err)
+	accA := acc.LoadExecAccount(Nodes[0], execAddr)
+	assert.Equal(t, total-5*types.Coin, accA.Balance)
+	assert.Equal(t, 5*types.Coin, accA.Frozen)
+
+	list, err := Exec_QueryConditionOrderList(et.Ordered, Nodes[0], stateDB, kvdb)
+	assert.Nil(t, err)
+	stopID := list.List[0].OrderID
+	assert.Equal(t, int32(et.TyStopLimitOrderAction), list.List[0].Ty)
+
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 90000000, Amount: 5 * types.Coin, Op: et.OpBuy}, PrivKeyD, stateDB, kvdb, env)
+	assert.Nil(t, err)
+	//止损单不参与撮合
+	_, err = Exec_QueryMarketDepth(&et.QueryMarketDepth{LeftAsset: left, RightAsset: right, Op: et.OpSell}, stateDB, kvdb)
+	assert.NotNil(t, err)
+
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 90000000, Amount: types.Coin, Op: et.OpSell}, PrivKeyB, stateDB, kvdb, env)
+	assert.Nil(t, err)
+
+	list, err = Exec_QueryConditionOrderList(et.Completed, Nodes[0], stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, stopID, list.List[0].OrderID)
+	//触发后以相同的订单号挂出限价单
+	order, err := Exec_QueryOrder(stopID, stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(et.Ordered), order.Status)
+	assert.Equal(t, types.Coin, order.Balance)
+	assert.Equal(t, 4*types.Coin, order.Executed)
+
+	accA = acc.LoadExecAccount(Nodes[0], execAddr)
+	assert.Equal(t, total-5*types.Coin, accA.Balance)
+	assert.Equal(t, types.Coin, accA.Frozen)
+	assert.Equal(t, total+360000000, acc1.LoadExecAccount(Nodes[0], execAddr).Balance)
+
+	marketDepthList, err := Exec_QueryMarketDepth(&et.QueryMarketDepth{LeftAsset: left, RightAsset: right, Op: et.OpSell}, stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, types.Coin, marketDepthList.List[0].Amount)
+	_, err = Exec_QueryMarketDepth(&et.QueryMarketDepth{LeftAsset: left, RightAsset: right, Op: et.OpBuy}, stateDB, kvdb)
+	assert.NotNil(t, err)
+
+	/*
+	  止盈单撤回
+	  用例说明：
+	   1.A 挂止盈买单 2@0.5, 触发价0.5
+	   2.A 撤回止盈单, 解冻资金
+	*/
+	profit := &et.TakeProfitOrder{LimitOrder: &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 50000000, Amount: 2 * types.Coin, Op: et.OpBuy}, TriggerPrice: 50000000}
+	err = Exec_Action(t, et.NameTakeProfitOrderAction, profit, PrivKeyA, stateDB, kvdb, env)
+	assert.Nil(t, err)
+	assert.Equal(t, types.Coin, acc1.LoadExecAccount(Nodes[0], execAddr).Frozen)
+	list, err = Exec_QueryConditionOrderList(et.Ordered, Nodes[0], stateDB, kvdb)
+	assert.Nil(t, err)
+	profitID := list.List[0].OrderID
+
+	//只有本人才能撤回
+	err = Exec_Action(t, et.NameRevokeConditionOrderAction, &et.RevokeConditionOrder{OrderID: profitID}, PrivKeyB, stateDB, kvdb, env)
+	assert.Equal(t, et.ErrAddr, err)
+	err = Exec_Action(t, et.NameRevokeConditionOrderAction, &et.RevokeConditionOrder{OrderID: profitID}, PrivKeyA, stateDB, kvdb, env)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), acc1.LoadExecAccount(Nodes[0], execAddr).Frozen)
+	_, err = Exec_QueryConditionOrderList(et.Ordered, Nodes[0], stateDB, kvdb)
+	assert.Equal(t, types.ErrNotFound, err)
+	list, err = Exec_QueryConditionOrderList(et.Revoked, Nodes[0], stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, profitID, list.List[0].OrderID)
+	//已撤回的条件单不能再次撤回
+	err = Exec_Action(t, et.NameRevokeConditionOrderAction, &et.RevokeConditionOrder{OrderID: profitID}, PrivKeyA, stateDB, kvdb, env)
+	assert.Equal(t, et.ErrOrderSatus, err)
+}
+
+func setManageConfig(stateDB db.DB, key, value string) {
+	item := &types.ConfigItem{
+		Key:   key,
+		Value: &types.ConfigItem_Arr{Arr: &types.ArrayConfig{Value: []string{value}}},
+	}
+	stateDB.Set([]byte(types.ManageKey(key)), types.Encode(item))
+}
+
 func CreateLimitOrder(limitOrder *et.LimitOrder, privKey string) (tx *types.Transaction, err error) {
 	ety := types.LoadExecutorType(et.ExchangeX)
 	tx, err = ety.Create("LimitOrder", limitOrder)
@@ -700,6 +1208,40 @@ func CreateLimitOrder(limitOrder *et.LimitOrder, privKey string) (tx *types.Tran
 	}
 	return tx, nil
 }
+func CreateMarketOrder(marketOrder *et.MarketOrder, privKey string) (tx *types.Transaction, err error) {
+	ety := types.LoadExecutorType(et.ExchangeX)
+	tx, err = ety.Create("MarketOrder", marketOrder)
+	if err != nil {
+		return nil, err
+	}
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	tx, err = types.FormatTx(cfg, et.ExchangeX, tx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err = signTx(tx, privKey)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func CreateExchangeTx(action string, payload types.Message, privKey string) (tx *types.Transaction, err error) {
+	ety := types.LoadExecutorType(et.ExchangeX)
+	tx, err = ety.Create(action, payload)
+	if err != nil {
+		return nil, err
+	}
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	tx, err = types.FormatTx(cfg, et.ExchangeX, tx)
+	if err != nil {
+		return nil, err
+	}
+	return signTx(tx, privKey)
+}
+
 func CreateRevokeOrder(orderID int64, privKey string) (tx *types.Transaction, err error) {
 	ety := types.LoadExecutorType(et.ExchangeX)
 	tx, err = ety.Create("RevokeOrder", &et.RevokeOrder{OrderID: orderID})
@@ -775,6 +1317,22 @@ func Exec_LimitOrder(t *testing.T, limitOrder *et.LimitOrder, privKey string, st
 	return Exec_Block(t, stateDB, kvdb, env, tx)
 }
 
+func Exec_MarketOrder(t *testing.T, marketOrder *et.MarketOrder, privKey string, stateDB db.DB, kvdb db.KVDB, env *execEnv) error {
+	tx, err := CreateMarketOrder(marketOrder, privKey)
+	if err != nil {
+		return err
+	}
+	return Exec_Block(t, stateDB, kvdb, env, tx)
+}
+
+func Exec_Action(t *testing.T, action string, payload types.Message, privKey string, stateDB db.DB, kvdb db.KVDB, env *execEnv) error {
+	tx, err := CreateExchangeTx(action, payload, privKey)
+	if err != nil {
+		return err
+	}
+	return Exec_Block(t, stateDB, kvdb, env, tx)
+}
+
 func Exec_RevokeOrder(t *testing.T, orderID int64, privKey string, stateDB db.DB, kvdb db.KVDB, env *execEnv) error {
 	tx, err := CreateRevokeOrder(orderID, privKey)
 	if err != nil {
@@ -800,6 +1358,23 @@ func Exec_QueryOrderList(status int32, addr string, primaryKey string, stateDB d
 	}
 	return msg.(*et.OrderList), nil
 }
+func Exec_QueryConditionOrderList(status int32, addr string, stateDB db.KV, kvdb db.KVDB) (*et.ConditionOrderList, error) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	exec := NewExchange()
+	q := queue.New("channel")
+	q.SetConfig(cfg)
+	api, _ := client.New(q.Client(), nil)
+	exec.SetAPI(api)
+	exec.SetStateDB(stateDB)
+	exec.SetLocalDB(kvdb)
+	msg, err := exec.Query(et.FuncNameQueryConditionOrderList, types.Encode(&et.QueryOrderList{Status: status, Address: addr}))
+	if err != nil {
+		return nil, err
+	}
+	return msg.(*et.ConditionOrderList), nil
+}
+
 func Exec_QueryOrder(orderID int64, stateDB db.KV, kvdb db.KVDB) (*et.Order, error) {
 	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
 	cfg.SetTitleOnlyForTest("chain33")
@@ -850,6 +1425,72 @@ func Exec_QueryHistoryOrder(query *et.QueryHistoryOrderList, stateDB db.KV, kvdb
 	msg, err := exec.Query(et.FuncNameQueryHistoryOrderList, types.Encode(query))
 	return msg.(*et.OrderList), err
 }
+func Exec_QueryFeeCollected(query *et.QueryFeeCollected, stateDB db.KV, kvdb db.KVDB) (*et.FeeCollected, error) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	exec := NewExchange()
+	q := queue.New("channel")
+	q.SetConfig(cfg)
+	api, _ := client.New(q.Client(), nil)
+	exec.SetAPI(api)
+	exec.SetStateDB(stateDB)
+	exec.SetLocalDB(kvdb)
+	msg, err := exec.Query(et.FuncNameQueryFeeCollected, types.Encode(query))
+	if err != nil {
+		return nil, err
+	}
+	return msg.(*et.FeeCollected), err
+}
+
+//模拟区块回退时交易的本地数据清除
+func Exec_DelLocal(t *testing.T, tx *types.Transaction, kvdb db.KVDB) error {
+	exec := NewExchange()
+	exec.SetLocalDB(kvdb)
+	set, err := exec.ExecDelLocal(tx, nil, 0)
+	if err != nil {
+		t.Log(err.Error())
+		return err
+	}
+	for _, kv := range set.KV {
+		kvdb.Set(kv.Key, kv.Value)
+	}
+	return nil
+}
+
+func Exec_QueryKline(query *et.QueryKline, stateDB db.KV, kvdb db.KVDB) (*et.KlineList, error) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	exec := NewExchange()
+	q := queue.New("channel")
+	q.SetConfig(cfg)
+	api, _ := client.New(q.Client(), nil)
+	exec.SetAPI(api)
+	exec.SetStateDB(stateDB)
+	exec.SetLocalDB(kvdb)
+	msg, err := exec.Query(et.FuncNameQueryKline, types.Encode(query))
+	if err != nil {
+		return nil, err
+	}
+	return msg.(*et.KlineList), err
+}
+
+func Exec_QueryTicker(query *et.QueryTicker, stateDB db.KV, kvdb db.KVDB, blockTime int64) (*et.Ticker, error) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	exec := NewExchange()
+	api := &mocks.QueueProtocolAPI{}
+	api.On("GetConfig").Return(cfg)
+	api.On("GetLastHeader").Return(&types.Header{BlockTime: blockTime}, nil)
+	exec.SetAPI(api)
+	exec.SetStateDB(stateDB)
+	exec.SetLocalDB(kvdb)
+	msg, err := exec.Query(et.FuncNameQueryTicker, types.Encode(query))
+	if err != nil {
+		return nil, err
+	}
+	return msg.(*et.Ticker), err
+}
+
 func signTx(tx *types.Transaction, hexPrivKey string) (*types.Transaction, error) {
 	signType := types.SECP256K1
 	c, err := crypto.New(types.GetSignName("", signType))
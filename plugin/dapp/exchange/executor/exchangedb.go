@@ -3,6 +3,7 @@ package executor
 import (
 	"fmt"
 	"math/big"
+	"strconv"
 
 	"github.com/33cn/chain33/account"
 	"github.com/33cn/chain33/client"
@@ -24,14 +25,26 @@ type Action struct {
 	localDB   dbm.KVDB
 	index     int
 	api       client.QueueProtocolAPI
+	cache     *matchCache
+}
+
+//单笔交易内撮合产生的中间状态,localdb要到ExecLocal时才会更新,触发条件单继续撮合时需要用到
+type matchCache struct {
+	//本交易内已经撮合过的挂单
+	orders map[int64]*et.Order
+	//本交易内最新的成交价格
+	lastPrice int64
+	//本交易内已经触发的条件单
+	triggered map[int64]bool
 }
 
 //NewAction ...
 func NewAction(e *exchange, tx *types.Transaction, index int) *Action {
 	hash := tx.Hash()
 	fromaddr := tx.From()
+	cache := &matchCache{orders: make(map[int64]*et.Order), triggered: make(map[int64]bool)}
 	return &Action{e.GetStateDB(), hash, fromaddr,
-		e.GetBlockTime(), e.GetHeight(), dapp.ExecAddress(string(tx.Execer)), e.GetLocalDB(), index, e.GetAPI()}
+		e.GetBlockTime(), e.GetHeight(), dapp.ExecAddress(string(tx.Execer)), e.GetLocalDB(), index, e.GetAPI(), cache}
 }
 
 //GetIndex get index
@@ -107,6 +120,16 @@ func CheckStatus(status int32) bool {
 	return false
 }
 
+//CheckSlippage 滑点范围 0<=slippage<=10000
+func CheckSlippage(slippage int32) bool {
+	return slippage >= 0 && slippage <= et.MaxSlippage
+}
+
+//CheckTimeInForce 限价单成交方式
+func CheckTimeInForce(timeInForce int32) bool {
+	return timeInForce >= et.TimeInForceGTC && timeInForce <= et.TimeInForcePostOnly
+}
+
 //CheckExchangeAsset 检查交易得资产是否合法
 func CheckExchangeAsset(left, right *et.Asset) bool {
 	if left.Execer == "" || left.Symbol == "" || right.Execer == "" || right.Symbol == "" {
@@ -136,6 +159,9 @@ func (a *Action) LimitOrder(payload *et.LimitOrder) (*types.Receipt, error) {
 	if !CheckOp(payload.GetOp()) {
 		return nil, et.ErrAssetOp
 	}
+	if !CheckTimeInForce(payload.GetTimeInForce()) {
+		return nil, et.ErrTimeInForce
+	}
 	//TODO 这里symbol
 	cfg := a.api.GetConfig()
 	leftAssetDB, err := account.NewAccountDB(cfg, leftAsset.GetExecer(), leftAsset.GetSymbol(), a.statedb)
@@ -169,6 +195,43 @@ func (a *Action) LimitOrder(payload *et.LimitOrder) (*types.Receipt, error) {
 	return nil, fmt.Errorf("unknow op")
 }
 
+//MarketOrder 市价单,按对手方市场深度依次成交,超出滑点上限或撮合深度后,未成交的部分直接退回
+func (a *Action) MarketOrder(payload *et.MarketOrder) (*types.Receipt, error) {
+	leftAsset := payload.GetLeftAsset()
+	rightAsset := payload.GetRightAsset()
+	if !CheckExchangeAsset(leftAsset, rightAsset) {
+		return nil, et.ErrAsset
+	}
+	if !CheckAmount(payload.GetAmount()) {
+		return nil, et.ErrAssetAmount
+	}
+	if !CheckOp(payload.GetOp()) {
+		return nil, et.ErrAssetOp
+	}
+	if !CheckSlippage(payload.GetSlippage()) {
+		return nil, et.ErrSlippage
+	}
+	cfg := a.api.GetConfig()
+	leftAssetDB, err := account.NewAccountDB(cfg, leftAsset.GetExecer(), leftAsset.GetSymbol(), a.statedb)
+	if err != nil {
+		return nil, err
+	}
+	rightAssetDB, err := account.NewAccountDB(cfg, rightAsset.GetExecer(), rightAsset.GetSymbol(), a.statedb)
+	if err != nil {
+		return nil, err
+	}
+	//买单成交价格未知,撮合时再按可用余额限制成交量
+	if payload.GetOp() == et.OpSell {
+		amount := payload.GetAmount()
+		leftAccount := leftAssetDB.LoadExecAccount(a.fromaddr, a.execaddr)
+		if leftAccount.Balance < amount {
+			elog.Error("market check left balance", "addr", a.fromaddr, "avail", leftAccount.Balance, "need", amount)
+			return nil, et.ErrAssetBalance
+		}
+	}
+	return a.matchMarketOrder(payload, leftAssetDB, rightAssetDB)
+}
+
 //RevokeOrder ...
 func (a *Action) RevokeOrder(payload *et.RevokeOrder) (*types.Receipt, error) {
 	var logs []*types.ReceiptLog
@@ -253,12 +316,6 @@ func (a *Action) RevokeOrder(payload *et.RevokeOrder) (*types.Receipt, error) {
 //3.价格相同按先进先出的原则进行撮合
 //4.买家获利得原则
 func (a *Action) matchLimitOrder(payload *et.LimitOrder, leftAccountDB, rightAccountDB *account.DB) (*types.Receipt, error) {
-	var logs []*types.ReceiptLog
-	var kvs []*types.KeyValue
-	var orderKey string
-	var priceKey string
-	var count int
-
 	or := &et.Order{
 		OrderID:    a.GetIndex(),
 		Value:      &et.Order_LimitOrder{LimitOrder: payload},
@@ -275,6 +332,17 @@ func (a *Action) matchLimitOrder(payload *et.LimitOrder, leftAccountDB, rightAcc
 		Order: or,
 		Index: a.GetIndex(),
 	}
+	return a.matchOrder(payload, or, re, leftAccountDB, rightAccountDB)
+}
+
+//以限价单or作为吃单进行撮合,未成交的部分冻结后挂单
+func (a *Action) matchOrder(payload *et.LimitOrder, or *et.Order, re *et.ReceiptExchange, leftAccountDB, rightAccountDB *account.DB) (*types.Receipt, error) {
+	var logs []*types.ReceiptLog
+	var kvs []*types.KeyValue
+	var orderKey string
+	var priceKey string
+	var count int
+	fee := a.getFeeConfig(payload.GetLeftAsset(), payload.GetRightAsset())
 
 	//单笔交易最多撮合100笔历史订单,最大可撮合得深度，系统得自我防护
 	//迭代已有挂单价格
@@ -320,8 +388,16 @@ func (a *Action) matchLimitOrder(payload *et.LimitOrder, leftAccountDB, rightAcc
 					if matchorder.Addr == a.fromaddr {
 						continue
 					}
+					matchorder, ok := a.cachedOrder(matchorder)
+					if !ok {
+						continue
+					}
+					//postOnly订单只做挂单方,存在可撮合的对手单时直接失败
+					if payload.GetTimeInForce() == et.TimeInForcePostOnly {
+						return nil, et.ErrPostOnly
+					}
 					//撮合,指针传递
-					log, kv, err := a.matchModel(leftAccountDB, rightAccountDB, payload, matchorder, or, re) // payload, or redundant
+					log, kv, err := a.matchModel(leftAccountDB, rightAccountDB, payload, matchorder, or, re, fee) // payload, or redundant
 					if err != nil {
 						return nil, err
 					}
@@ -353,6 +429,20 @@ func (a *Action) matchLimitOrder(payload *et.LimitOrder, leftAccountDB, rightAcc
 		priceKey = marketDepthList.PrimaryKey
 	}
 
+	//FOK订单未能全部成交,整笔交易失败
+	if payload.GetTimeInForce() == et.TimeInForceFOK {
+		return nil, et.ErrFOKNotFilled
+	}
+	//IOC订单不挂单,未成交的部分不冻结,直接撤回
+	if payload.GetTimeInForce() == et.TimeInForceIOC {
+		or.Status = et.Revoked
+		kvs = append(kvs, a.GetKVSet(or)...)
+		re.Order = or
+		receiptlog := &types.ReceiptLog{Ty: et.TyLimitOrderLog, Log: types.Encode(re)}
+		logs = append(logs, receiptlog)
+		receipts := &types.Receipt{Ty: types.ExecOk, KV: kvs, Logs: logs}
+		return receipts, nil
+	}
 	//未完成的订单需要冻结剩余未成交的资金
 	if payload.Op == et.OpBuy {
 		amount := CalcActualCost(et.OpBuy, or.Balance, payload.Price)
@@ -383,11 +473,180 @@ func (a *Action) matchLimitOrder(payload *et.LimitOrder, leftAccountDB, rightAcc
 	return receipts, nil
 }
 
+//市价单撮合逻辑
+// 规则：
+//1.买单按卖单价格由低往高撮合,卖单按买单价格由高往低撮合,成交价格取对手方挂单价格。
+//2.以首个可撮合的对手方价格为基准,超出滑点上限的价格不再撮合。
+//3.买单可用余额不足以支付时,按可支付的数量成交后停止撮合。
+//4.市价单不挂单,未成交的部分不冻结,订单状态置为revoked。
+func (a *Action) matchMarketOrder(payload *et.MarketOrder, leftAccountDB, rightAccountDB *account.DB) (*types.Receipt, error) {
+	var logs []*types.ReceiptLog
+	var kvs []*types.KeyValue
+	var orderKey string
+	var priceKey string
+	var count int
+	var bound int64
+	//余额不足时暂时扣除的未成交量
+	var reserved int64
+
+	or := &et.Order{
+		OrderID:    a.GetIndex(),
+		Value:      &et.Order_MarketOrder{MarketOrder: payload},
+		Ty:         et.TyMarketOrderAction,
+		Executed:   0,
+		AVGPrice:   0,
+		Balance:    payload.GetAmount(),
+		Status:     et.Ordered,
+		Addr:       a.fromaddr,
+		UpdateTime: a.blocktime,
+		Index:      a.GetIndex(),
+	}
+	re := &et.ReceiptExchange{
+		Order: or,
+		Index: a.GetIndex(),
+	}
+	fee := a.getFeeConfig(payload.GetLeftAsset(), payload.GetRightAsset())
+
+	done := false
+	for !done {
+		if count >= et.MaxMatchCount {
+			break
+		}
+		marketDepthList, err := QueryMarketDepth(a.localDB, payload.GetLeftAsset(), payload.GetRightAsset(), a.OpSwap(payload.Op), priceKey, et.Count)
+		if err == types.ErrNotFound {
+			break
+		}
+		for _, marketDepth := range marketDepthList.List {
+			if done || count >= et.MaxMatchCount {
+				break
+			}
+			//对手方最优价作为滑点基准
+			if bound == 0 {
+				bound = calcSlippageBound(payload.Op, marketDepth.Price, payload.GetSlippage())
+			}
+			if payload.Op == et.OpBuy && marketDepth.Price > bound {
+				done = true
+				break
+			}
+			if payload.Op == et.OpSell && marketDepth.Price < bound {
+				done = true
+				break
+			}
+			orderKey = ""
+			for !done {
+				if count >= et.MaxMatchCount {
+					break
+				}
+				orderList, err := findOrderIDListByPrice(a.localDB, payload.GetLeftAsset(), payload.GetRightAsset(), marketDepth.Price, a.OpSwap(payload.Op), et.ListASC, orderKey)
+				if err == types.ErrNotFound {
+					break
+				}
+				for _, matchorder := range orderList.List {
+					if count >= et.MaxMatchCount {
+						break
+					}
+					//同地址不能交易
+					if matchorder.Addr == a.fromaddr {
+						continue
+					}
+					matchorder, ok := a.cachedOrder(matchorder)
+					if !ok {
+						continue
+					}
+					price := matchorder.GetLimitOrder().Price
+					matched := or.Balance
+					if matchorder.Balance < matched {
+						matched = matchorder.Balance
+					}
+					//均价按调整余额之前的已成交数量计算,退回的部分不算成交
+					filled := orderAmount(or) - or.Balance
+					if payload.Op == et.OpBuy {
+						rightAccount := rightAccountDB.LoadExecAccount(a.fromaddr, a.execaddr)
+						affordable := calcAffordableAmount(rightAccount.Balance, price)
+						if affordable < matched {
+							if affordable <= 0 {
+								done = true
+								break
+							}
+							//只按可支付的数量成交,剩余部分在撮合结束后退回
+							reserved = or.Balance - affordable
+							or.Balance = affordable
+							matched = affordable
+							done = true
+						}
+					}
+					avgPrice := calcAVGPriceByFilled(or.AVGPrice, filled, price, matched)
+					//成交价格取对手方挂单价格
+					limitOrder := &et.LimitOrder{
+						LeftAsset:  payload.GetLeftAsset(),
+						RightAsset: payload.GetRightAsset(),
+						Price:      price,
+						Amount:     payload.GetAmount(),
+						Op:         payload.GetOp(),
+					}
+					log, kv, err := a.matchModel(leftAccountDB, rightAccountDB, limitOrder, matchorder, or, re, fee)
+					if err != nil {
+						return nil, err
+					}
+					or.AVGPrice = avgPrice
+					logs = append(logs, log...)
+					kvs = append(kvs, kv...)
+					count = count + 1
+					if done || or.Status == et.Completed {
+						done = true
+						break
+					}
+				}
+				if done || orderList.PrimaryKey == "" {
+					break
+				}
+				orderKey = orderList.PrimaryKey
+			}
+		}
+		if marketDepthList.PrimaryKey == "" {
+			break
+		}
+		priceKey = marketDepthList.PrimaryKey
+	}
+
+	//未成交的部分直接退回,市价单不挂单
+	or.Balance += reserved
+	if or.Balance > 0 {
+		or.Status = et.Revoked
+	} else {
+		or.Status = et.Completed
+	}
+	kvs = append(kvs, a.GetKVSet(or)...)
+	re.Order = or
+	receiptlog := &types.ReceiptLog{Ty: et.TyMarketOrderLog, Log: types.Encode(re)}
+	logs = append(logs, receiptlog)
+	receipts := &types.Receipt{Ty: types.ExecOk, KV: kvs, Logs: logs}
+	return receipts, nil
+}
+
+//计算滑点边界价格,买单向上浮动,卖单向下浮动
+func calcSlippageBound(op int32, price int64, slippage int32) int64 {
+	delta := big.NewInt(0).Mul(big.NewInt(price), big.NewInt(int64(slippage)))
+	delta = big.NewInt(0).Div(delta, big.NewInt(int64(et.MaxSlippage)))
+	if op == et.OpBuy {
+		return price + delta.Int64()
+	}
+	return price - delta.Int64()
+}
+
+//计算可用余额按指定价格最多能买入的数量,与SafeMul互逆
+func calcAffordableAmount(balance int64, price int64) int64 {
+	res := big.NewInt(0).Mul(big.NewInt(balance), big.NewInt(types.Coin))
+	res = big.NewInt(0).Div(res, big.NewInt(price))
+	return res.Int64()
+}
+
 //交易撮合模型
-func (a *Action) matchModel(leftAccountDB, rightAccountDB *account.DB, payload *et.LimitOrder, matchorder *et.Order, or *et.Order, re *et.ReceiptExchange) ([]*types.ReceiptLog, []*types.KeyValue, error) {
+func (a *Action) matchModel(leftAccountDB, rightAccountDB *account.DB, payload *et.LimitOrder, matchorder *et.Order, or *et.Order, re *et.ReceiptExchange, fee *feeConfig) ([]*types.ReceiptLog, []*types.KeyValue, error) {
 	var logs []*types.ReceiptLog
 	var kvs []*types.KeyValue
 	var matched int64
+	matchFee := &et.MatchFee{OrderID: matchorder.OrderID}
 
 	if matchorder.GetBalance() >= or.GetBalance() {
 		matched = or.GetBalance()
@@ -429,6 +688,22 @@ func (a *Action) matchModel(leftAccountDB, rightAccountDB *account.DB, payload *
 		logs = append(logs, receipt.Logs...)
 		kvs = append(kvs, receipt.KV...)
 
+		//双方从各自收到的资产中支付手续费
+		takerFee, receipt, err := a.chargeFee(rightAccountDB, a.fromaddr, CalcActualCost(et.OpBuy, matched, payload.Price), fee.taker, fee.addr)
+		if err != nil {
+			return nil, nil, err
+		}
+		logs = append(logs, receipt.Logs...)
+		kvs = append(kvs, receipt.KV...)
+		makerFee, receipt, err := a.chargeFee(leftAccountDB, matchorder.Addr, matched, fee.maker, fee.addr)
+		if err != nil {
+			return nil, nil, err
+		}
+		logs = append(logs, receipt.Logs...)
+		kvs = append(kvs, receipt.KV...)
+		matchFee.TakerAsset, matchFee.TakerFee = payload.GetRightAsset(), takerFee
+		matchFee.MakerAsset, matchFee.MakerFee = payload.GetLeftAsset(), makerFee
+
 		//卖单成交得平均价格始终与自身挂单价格相同
 		or.AVGPrice = payload.Price
 		//计算matchOrder平均成交价格
@@ -454,6 +729,22 @@ func (a *Action) matchModel(leftAccountDB, rightAccountDB *account.DB, payload *
 		logs = append(logs, receipt.Logs...)
 		kvs = append(kvs, receipt.KV...)
 
+		//双方从各自收到的资产中支付手续费
+		takerFee, receipt, err := a.chargeFee(leftAccountDB, a.fromaddr, matched, fee.taker, fee.addr)
+		if err != nil {
+			return nil, nil, err
+		}
+		logs = append(logs, receipt.Logs...)
+		kvs = append(kvs, receipt.KV...)
+		makerFee, receipt, err := a.chargeFee(rightAccountDB, matchorder.Addr, amount, fee.maker, fee.addr)
+		if err != nil {
+			return nil, nil, err
+		}
+		logs = append(logs, receipt.Logs...)
+		kvs = append(kvs, receipt.KV...)
+		matchFee.TakerAsset, matchFee.TakerFee = payload.GetLeftAsset(), takerFee
+		matchFee.MakerAsset, matchFee.MakerFee = payload.GetRightAsset(), makerFee
+
 		//买单得话，价格选取卖单的价格
 		or.AVGPrice = matchorder.GetLimitOrder().Price
 		//计算matchOrder平均成交价格
@@ -491,9 +782,154 @@ func (a *Action) matchModel(leftAccountDB, rightAccountDB *account.DB, payload *
 
 	re.Order = or
 	re.MatchOrders = append(re.MatchOrders, matchorder)
+	re.Fees = append(re.Fees, matchFee)
+	//记录本交易内的撮合结果
+	a.cache.orders[matchorder.OrderID] = types.Clone(matchorder).(*et.Order)
+	if payload.Op == et.OpSell {
+		a.cache.lastPrice = payload.Price
+	} else {
+		a.cache.lastPrice = matchorder.GetLimitOrder().Price
+	}
 	return logs, kvs, nil
 }
 
+//本交易内已经撮合过的挂单以缓存中的状态为准,已经完成的挂单不再参与撮合
+func (a *Action) cachedOrder(order *et.Order) (*et.Order, bool) {
+	cached, ok := a.cache.orders[order.OrderID]
+	if !ok {
+		return order, true
+	}
+	if cached.Status != et.Ordered {
+		return nil, false
+	}
+	order = types.Clone(cached).(*et.Order)
+	order.Executed = orderAmount(order) - order.Balance
+	return order, true
+}
+
+//交易对手续费配置
+type feeConfig struct {
+	addr  string
+	maker int64
+	taker int64
+}
+
+//获取交易对的手续费配置,未配置收取地址时不收取手续费
+func (a *Action) getFeeConfig(left, right *et.Asset) *feeConfig {
+	cfg := a.api.GetConfig()
+	fee := &feeConfig{addr: getConfString(cfg, a.statedb, et.ConfigKeyFeeAddr, "")}
+	if fee.addr == "" {
+		return fee
+	}
+	pair := calcFeePair(left, right)
+	fee.maker = getConfValue(cfg, a.statedb, et.ConfigKeyMakerFeePrefix+pair, 0)
+	fee.taker = getConfValue(cfg, a.statedb, et.ConfigKeyTakerFeePrefix+pair, 0)
+	if !CheckFeeRate(fee.maker) {
+		elog.Error("getFeeConfig", "pair", pair, "maker", fee.maker)
+		fee.maker = 0
+	}
+	if !CheckFeeRate(fee.taker) {
+		elog.Error("getFeeConfig", "pair", pair, "taker", fee.taker)
+		fee.taker = 0
+	}
+	return fee
+}
+
+//CheckFeeRate 费率精度1e8, 0<=rate<=1e7
+func CheckFeeRate(rate int64) bool {
+	return rate >= 0 && rate <= et.MaxFeeRate
+}
+
+//从addr收到的资产中按费率收取手续费,转入手续费地址
+func (a *Action) chargeFee(accountDB *account.DB, addr string, amount, rate int64, feeAddr string) (int64, *types.Receipt, error) {
+	fee := SafeMul(amount, rate)
+	if fee <= 0 || feeAddr == "" {
+		return 0, &types.Receipt{}, nil
+	}
+	receipt, err := accountDB.ExecTransfer(addr, feeAddr, a.execaddr, fee)
+	if err != nil {
+		elog.Error("chargeFee.ExecTransfer", "from", addr, "to", feeAddr, "amount", fee, "err", err.Error())
+		return 0, nil, err
+	}
+	return fee, receipt, nil
+}
+
+func getConfValue(cfg *types.Chain33Config, db dbm.KV, key string, defaultValue int64) int64 {
+	value := getConfString(cfg, db, key, "")
+	if value == "" {
+		return defaultValue
+	}
+	v, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		elog.Debug("exchange getConfValue", "Type conversion error:", err.Error())
+		return defaultValue
+	}
+	return v
+}
+
+//取配置数组最后一位，作为最新配置项的值
+func getConfString(cfg *types.Chain33Config, db dbm.KV, key, defaultValue string) string {
+	var item types.ConfigItem
+	value, err := getManageKey(cfg, key, db)
+	if err != nil {
+		return defaultValue
+	}
+	if value != nil {
+		err = types.Decode(value, &item)
+		if err != nil {
+			elog.Debug("exchange getConfString", "decode db key:", key, "err", err.Error())
+			return defaultValue
+		}
+	}
+	values := item.GetArr().GetValue()
+	if len(values) == 0 {
+		elog.Debug("exchange getConfString", "can't get value from values arr. key:", key)
+		return defaultValue
+	}
+	return values[len(values)-1]
+}
+
+func getManageKey(cfg *types.Chain33Config, key string, db dbm.KV) ([]byte, error) {
+	manageKey := types.ManageKey(key)
+	value, err := db.Get([]byte(manageKey))
+	if err != nil {
+		if cfg.IsPara() { //平行链只有一种存储方式
+			elog.Debug("exchange getManage", "can't get value from db,key:", key, "err", err.Error())
+			return nil, err
+		}
+		return getConfigKey(key, db)
+	}
+	return value, nil
+}
+
+func getConfigKey(key string, db dbm.KV) ([]byte, error) {
+	configKey := types.ConfigKey(key)
+	value, err := db.Get([]byte(configKey))
+	if err != nil {
+		elog.Debug("exchange getConfigKey", "can't get value from db,key:", key, "err", err.Error())
+		return nil, err
+	}
+	return value, nil
+}
+
+//QueryFeeCollected 查询交易对累计收取的手续费
+func QueryFeeCollected(localdb dbm.KV, left, right *et.Asset) (*et.FeeCollected, error) {
+	fee := &et.FeeCollected{LeftAsset: left, RightAsset: right}
+	data, err := localdb.Get(calcFeeCollectedKey(left, right))
+	if err == types.ErrNotFound {
+		return fee, nil
+	}
+	if err != nil {
+		elog.Error("QueryFeeCollected.", "left", left, "right", right, "err", err.Error())
+		return nil, err
+	}
+	err = types.Decode(data, fee)
+	if err != nil {
+		return nil, err
+	}
+	return fee, nil
+}
+
 //根据订单号查询，分为两步，优先去localdb中查询，如没有则再去状态数据库中查询
 // 1.挂单中得订单信会根据orderID在localdb中存储
 // 2.订单撤销，或者成交后，根据orderID在localdb中存储得数据会被删除，这时只能到状态数据库中查询
@@ -538,7 +974,7 @@ func findOrderIDListByPrice(localdb dbm.KV, left, right *et.Asset, price int64,
 	for _, row := range rows {
 		order := row.Data.(*et.Order)
 		//替换已经成交得量
-		order.Executed = order.GetLimitOrder().Amount - order.Balance
+		order.Executed = orderAmount(order) - order.Balance
 		orderList.List = append(orderList.List, order)
 	}
 	//设置主键索引
@@ -617,7 +1053,7 @@ HERE:
 			continue
 		}
 		//替换已经成交得量
-		order.Executed = order.GetLimitOrder().Amount - order.Balance
+		order.Executed = orderAmount(order) - order.Balance
 		orderList.List = append(orderList.List, order)
 		if len(orderList.List) == int(count) {
 			//设置主键索引
@@ -660,7 +1096,7 @@ func QueryOrderList(localdb dbm.KV, addr string, status, count, direction int32,
 	for _, row := range rows {
 		order := row.Data.(*et.Order)
 		//替换已经成交得量
-		order.Executed = order.GetLimitOrder().Amount - order.Balance
+		order.Executed = orderAmount(order) - order.Balance
 		orderList.List = append(orderList.List, order)
 	}
 	//设置主键索引
@@ -689,10 +1125,39 @@ func SafeMul(x, y int64) int64 {
 
 //计算平均成交价格
 func caclAVGPrice(order *et.Order, price int64, amount int64) int64 {
-	x := big.NewInt(0).Mul(big.NewInt(order.AVGPrice), big.NewInt(order.GetLimitOrder().Amount-order.GetBalance()))
+	return calcAVGPriceByFilled(order.AVGPrice, orderAmount(order)-order.GetBalance(), price, amount)
+}
+
+//按已成交数量和均价计算再成交amount之后的均价
+func calcAVGPriceByFilled(avgPrice int64, filled int64, price int64, amount int64) int64 {
+	x := big.NewInt(0).Mul(big.NewInt(avgPrice), big.NewInt(filled))
 	y := big.NewInt(0).Mul(big.NewInt(price), big.NewInt(amount))
 	total := big.NewInt(0).Add(x, y)
-	div := big.NewInt(0).Add(big.NewInt(order.GetLimitOrder().Amount-order.GetBalance()), big.NewInt(amount))
+	div := big.NewInt(0).Add(big.NewInt(filled), big.NewInt(amount))
 	avg := big.NewInt(0).Div(total, div)
 	return avg.Int64()
 }
+
+//订单交易对,兼容限价单和市价单
+func orderAssets(order *et.Order) (left, right *et.Asset) {
+	if marketOrder := order.GetMarketOrder(); marketOrder != nil {
+		return marketOrder.GetLeftAsset(), marketOrder.GetRightAsset()
+	}
+	return order.GetLimitOrder().GetLeftAsset(), order.GetLimitOrder().GetRightAsset()
+}
+
+//订单操作方向,兼容限价单和市价单
+func orderOp(order *et.Order) int32 {
+	if marketOrder := order.GetMarketOrder(); marketOrder != nil {
+		return marketOrder.GetOp()
+	}
+	return order.GetLimitOrder().GetOp()
+}
+
+//订单总量,兼容限价单和市价单
+func orderAmount(order *et.Order) int64 {
+	if marketOrder := order.GetMarketOrder(); marketOrder != nil {
+		return marketOrder.GetAmount()
+	}
+	return order.GetLimitOrder().GetAmount()
+}
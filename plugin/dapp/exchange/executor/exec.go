@@ -12,15 +12,38 @@ import (
 
 func (e *exchange) Exec_LimitOrder(payload *exchangetypes.LimitOrder, tx *types.Transaction, index int) (*types.Receipt, error) {
 	action := NewAction(e, tx, index)
-	return action.LimitOrder(payload)
+	receipt, err := action.LimitOrder(payload)
+	if err != nil {
+		return nil, err
+	}
+	return action.TriggerConditionOrders(payload.GetLeftAsset(), payload.GetRightAsset(), receipt)
 }
 
 func (e *exchange) Exec_MarketOrder(payload *exchangetypes.MarketOrder, tx *types.Transaction, index int) (*types.Receipt, error) {
-	//TODO marketOrder
-	return nil, types.ErrActionNotSupport
+	action := NewAction(e, tx, index)
+	receipt, err := action.MarketOrder(payload)
+	if err != nil {
+		return nil, err
+	}
+	return action.TriggerConditionOrders(payload.GetLeftAsset(), payload.GetRightAsset(), receipt)
 }
 
 func (e *exchange) Exec_RevokeOrder(payload *exchangetypes.RevokeOrder, tx *types.Transaction, index int) (*types.Receipt, error) {
 	action := NewAction(e, tx, index)
 	return action.RevokeOrder(payload)
 }
+
+func (e *exchange) Exec_StopLimitOrder(payload *exchangetypes.StopLimitOrder, tx *types.Transaction, index int) (*types.Receipt, error) {
+	action := NewAction(e, tx, index)
+	return action.StopLimitOrder(payload)
+}
+
+func (e *exchange) Exec_TakeProfitOrder(payload *exchangetypes.TakeProfitOrder, tx *types.Transaction, index int) (*types.Receipt, error) {
+	action := NewAction(e, tx, index)
+	return action.TakeProfitOrder(payload)
+}
+
+func (e *exchange) Exec_RevokeConditionOrder(payload *exchangetypes.RevokeConditionOrder, tx *types.Transaction, index int) (*types.Receipt, error) {
+	action := NewAction(e, tx, index)
+	return action.RevokeConditionOrder(payload)
+}
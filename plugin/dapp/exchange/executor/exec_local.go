@@ -2,6 +2,7 @@ package executor
 
 import (
 	"github.com/33cn/chain33/common/db/table"
+	"github.com/33cn/chain33/system/dapp"
 	"github.com/33cn/chain33/types"
 	ety "github.com/33cn/plugin/plugin/dapp/exchange/types"
 )
@@ -12,39 +13,49 @@ import (
  */
 
 func (e *exchange) ExecLocal_LimitOrder(payload *ety.LimitOrder, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
-	dbSet := &types.LocalDBSet{}
-	if receiptData.Ty == types.ExecOk {
-		for _, log := range receiptData.Logs {
-			switch log.Ty {
-			case ety.TyLimitOrderLog:
-				receipt := &ety.ReceiptExchange{}
-				if err := types.Decode(log.Log, receipt); err != nil {
-					return nil, err
-				}
-				kv := e.updateIndex(receipt)
-				dbSet.KV = append(dbSet.KV, kv...)
-			}
-		}
-	}
-	return e.addAutoRollBack(tx, dbSet.KV), nil
+	return e.execLocalOrderLogs(tx, receiptData)
 }
 
 func (e *exchange) ExecLocal_MarketOrder(payload *ety.MarketOrder, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
-	dbSet := &types.LocalDBSet{}
+	return e.execLocalOrderLogs(tx, receiptData)
+}
+
+func (e *exchange) ExecLocal_StopLimitOrder(payload *ety.StopLimitOrder, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return e.execLocalOrderLogs(tx, receiptData)
+}
+
+func (e *exchange) ExecLocal_TakeProfitOrder(payload *ety.TakeProfitOrder, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return e.execLocalOrderLogs(tx, receiptData)
+}
+
+func (e *exchange) ExecLocal_RevokeConditionOrder(payload *ety.RevokeConditionOrder, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return e.execLocalOrderLogs(tx, receiptData)
+}
+
+//一笔交易可能因为触发条件单而包含多个订单日志,按顺序逐条更新索引,
+//每条日志更新后立即写入localdb,后面的日志才能读到最新的市场深度和挂单
+func (e *exchange) execLocalOrderLogs(tx *types.Transaction, receiptData *types.ReceiptData) (*types.LocalDBSet, error) {
+	kvc := dapp.NewKVCreator(e.GetLocalDB(), types.CalcLocalPrefix(tx.Execer), types.CalcRollbackKey(types.GetRealExecName(tx.Execer), tx.Hash()))
 	if receiptData.Ty == types.ExecOk {
 		for _, log := range receiptData.Logs {
 			switch log.Ty {
-			case ety.TyMarketOrderLog:
+			case ety.TyLimitOrderLog, ety.TyMarketOrderLog:
 				receipt := &ety.ReceiptExchange{}
 				if err := types.Decode(log.Log, receipt); err != nil {
 					return nil, err
 				}
-				kv := e.updateIndex(receipt)
-				dbSet.KV = append(dbSet.KV, kv...)
+				kvc.AddListNoPrefix(e.updateIndex(receipt))
+			case ety.TyConditionOrderLog:
+				receipt := &ety.ReceiptConditionOrder{}
+				if err := types.Decode(log.Log, receipt); err != nil {
+					return nil, err
+				}
+				kvc.AddListNoPrefix(e.updateConditionIndex(receipt))
 			}
 		}
 	}
-	return e.addAutoRollBack(tx, dbSet.KV), nil
+	kvc.AddRollbackKV()
+	return &types.LocalDBSet{KV: kvc.KVList()}, nil
 }
 
 func (e *exchange) ExecLocal_RevokeOrder(payload *ety.RevokeOrder, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
@@ -100,6 +111,11 @@ func (e *exchange) updateIndex(receipt *ety.ReceiptExchange) (kvs []*types.KeyVa
 		if err != nil {
 			return nil
 		}
+		//市价单部分成交后撤回,同样需要更新被撮合的订单
+		err = e.updateMatchOrders(marketTable, orderTable, historyTable, receipt.GetOrder(), receipt.GetMatchOrders(), receipt.GetIndex())
+		if err != nil {
+			return nil
+		}
 	}
 
 	//刷新KV
@@ -121,10 +137,36 @@ func (e *exchange) updateIndex(receipt *ety.ReceiptExchange) (kvs []*types.KeyVa
 		return nil
 	}
 	kvs = append(kvs, kv...)
+	kvs = append(kvs, e.updateFeeCollected(receipt)...)
+	kvs = append(kvs, e.updateKline(receipt)...)
 
 	return
 }
 
+//按交易对累计收取的手续费
+func (e *exchange) updateFeeCollected(receipt *ety.ReceiptExchange) []*types.KeyValue {
+	if len(receipt.GetFees()) == 0 {
+		return nil
+	}
+	left, right := orderAssets(receipt.GetOrder())
+	collected, err := QueryFeeCollected(e.GetLocalDB(), left, right)
+	if err != nil {
+		return nil
+	}
+	add := func(asset *ety.Asset, amount int64) {
+		if asset.GetExecer() == left.GetExecer() && asset.GetSymbol() == left.GetSymbol() {
+			collected.LeftAmount += amount
+		} else {
+			collected.RightAmount += amount
+		}
+	}
+	for _, fee := range receipt.GetFees() {
+		add(fee.GetTakerAsset(), fee.GetTakerFee())
+		add(fee.GetMakerAsset(), fee.GetMakerFee())
+	}
+	return []*types.KeyValue{{Key: calcFeeCollectedKey(left, right), Value: types.Encode(collected)}}
+}
+
 func (e *exchange) updateOrder(marketTable, orderTable, historyTable *table.Table, order *ety.Order, index int64) error {
 	left := order.GetLimitOrder().GetLeftAsset()
 	right := order.GetLimitOrder().GetRightAsset()
@@ -166,6 +208,15 @@ func (e *exchange) updateOrder(marketTable, orderTable, historyTable *table.Tabl
 			return err
 		}
 	case ety.Revoked:
+		//市价单和IOC限价单不挂单,直接记录到历史订单中
+		if order.Ty == ety.TyMarketOrderAction || order.GetLimitOrder().GetTimeInForce() == ety.TimeInForceIOC {
+			err := historyTable.Replace(order)
+			if err != nil {
+				elog.Error("updateIndex", "historyTable.Replace", err.Error())
+				return err
+			}
+			return nil
+		}
 		//只有状态时ordered状态的订单才能被撤回
 		var marketDepth ety.MarketDepth
 		depth, err := queryMarketDepth(e.GetLocalDB(), left, right, op, price)
@@ -209,9 +260,8 @@ func (e *exchange) updateOrder(marketTable, orderTable, historyTable *table.Tabl
 	return nil
 }
 func (e *exchange) updateMatchOrders(marketTable, orderTable, historyTable *table.Table, order *ety.Order, matchOrders []*ety.Order, index int64) error {
-	left := order.GetLimitOrder().GetLeftAsset()
-	right := order.GetLimitOrder().GetRightAsset()
-	op := order.GetLimitOrder().GetOp()
+	left, right := orderAssets(order)
+	op := orderOp(order)
 	if len(matchOrders) > 0 {
 		//撮合交易更新
 		cache := make(map[int64]int64)
@@ -278,6 +328,22 @@ func (e *exchange) updateMatchOrders(marketTable, orderTable, historyTable *tabl
 	return nil
 }
 
+//更新条件单表
+func (e *exchange) updateConditionIndex(receipt *ety.ReceiptConditionOrder) []*types.KeyValue {
+	triggerTable := NewTriggerTable(e.GetLocalDB())
+	err := triggerTable.Replace(receipt.GetOrder())
+	if err != nil {
+		elog.Error("updateConditionIndex", "triggerTable.Replace", err.Error())
+		return nil
+	}
+	kvs, err := triggerTable.Save()
+	if err != nil {
+		elog.Error("updateConditionIndex", "triggerTable.Save", err.Error())
+		return nil
+	}
+	return kvs
+}
+
 //OpSwap ...
 func OpSwap(op int32) int32 {
 	if op == ety.OpBuy {
@@ -0,0 +1,158 @@
+package executor
+
+import (
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/common/db/table"
+	"github.com/33cn/chain33/types"
+	et "github.com/33cn/plugin/plugin/dapp/exchange/types"
+)
+
+/*
+ * K线和24小时行情统计
+ * 每笔撮合按成交价和成交量更新交易对各周期的K线,数据只存放在localdb中,
+ * 通过KVCreator记录回滚数据,区块回退时由ExecDelLocal自动恢复
+ */
+
+//CheckKlinePeriod 检查K线周期
+func CheckKlinePeriod(period string) bool {
+	_, ok := et.KlinePeriodSeconds[period]
+	return ok
+}
+
+//成交价格,卖单主动成交时按卖单价格成交,其余情况按挂单方价格成交,与matchModel保持一致
+func tradePrice(order, matchorder *et.Order) int64 {
+	if order.GetLimitOrder() != nil && order.GetLimitOrder().GetOp() == et.OpSell {
+		return order.GetLimitOrder().GetPrice()
+	}
+	return matchorder.GetLimitOrder().GetPrice()
+}
+
+//按本次撮合结果更新各周期的K线
+func (e *exchange) updateKline(receipt *et.ReceiptExchange) []*types.KeyValue {
+	if len(receipt.GetMatchOrders()) == 0 {
+		return nil
+	}
+	left, right := orderAssets(receipt.GetOrder())
+	klineTable := NewKlineTable(e.GetLocalDB())
+	for _, period := range et.KlinePeriods {
+		start := e.GetBlockTime() - e.GetBlockTime()%et.KlinePeriodSeconds[period]
+		kline, err := queryKline(klineTable, left, right, period, start)
+		if err != nil {
+			elog.Error("updateKline", "period", period, "time", start, "err", err.Error())
+			return nil
+		}
+		for _, matchorder := range receipt.GetMatchOrders() {
+			//matchModel中matchorder.Executed记录的是本次撮合的成交量
+			price, volume := tradePrice(receipt.GetOrder(), matchorder), matchorder.GetExecuted()
+			if kline.Count == 0 {
+				kline.Open, kline.High, kline.Low = price, price, price
+			}
+			if price > kline.High {
+				kline.High = price
+			}
+			if price < kline.Low {
+				kline.Low = price
+			}
+			kline.Close = price
+			kline.Volume += volume
+			kline.Count++
+		}
+		err = klineTable.Replace(kline)
+		if err != nil {
+			elog.Error("updateKline", "klineTable.Replace", err.Error())
+			return nil
+		}
+	}
+	kvs, err := klineTable.Save()
+	if err != nil {
+		elog.Error("updateKline", "klineTable.Save", err.Error())
+		return nil
+	}
+	return kvs
+}
+
+//查询某一周期的K线,不存在时返回一根空的K线
+func queryKline(klineTable *table.Table, left, right *et.Asset, period string, time int64) (*et.Kline, error) {
+	row, err := klineTable.GetData([]byte(calcKlinePrimary(left, right, period, time)))
+	if err == types.ErrNotFound {
+		return &et.Kline{LeftAsset: left, RightAsset: right, Period: period, Time: time}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return row.Data.(*et.Kline), nil
+}
+
+//QueryKline 分页查询交易对的K线
+func QueryKline(localdb dbm.KV, left, right *et.Asset, period string, primaryKey string, count, direction int32) (types.Message, error) {
+	klineTable := NewKlineTable(localdb)
+	prefix := []byte(calcKlinePrefix(left, right, period))
+	if count == 0 {
+		count = et.Count
+	}
+	var rows []*table.Row
+	var err error
+	var klineList et.KlineList
+	if primaryKey == "" { //第一次查询,默认展示最新的K线
+		rows, err = klineTable.ListIndex("time", prefix, nil, count, direction)
+	} else {
+		rows, err = klineTable.ListIndex("time", prefix, []byte(primaryKey), count, direction)
+	}
+	if err != nil && err != types.ErrNotFound {
+		elog.Error("QueryKline.", "left", left, "right", right, "period", period, "err", err.Error())
+		return nil, err
+	}
+	if err == types.ErrNotFound {
+		return &klineList, nil
+	}
+	for _, row := range rows {
+		klineList.List = append(klineList.List, row.Data.(*et.Kline))
+	}
+	if len(rows) == int(count) {
+		//设置主键索引
+		klineList.PrimaryKey = string(rows[len(rows)-1].Primary)
+	}
+	return &klineList, nil
+}
+
+//QueryTicker 以最新区块所在分钟为结束,汇总最近24小时的1分钟K线,区间内没有成交时只返回统计区间
+func QueryTicker(localdb dbm.KV, left, right *et.Asset, blockTime int64) (types.Message, error) {
+	klineTable := NewKlineTable(localdb)
+	prefix := []byte(calcKlinePrefix(left, right, et.KlineBasePeriod))
+	seconds := et.KlinePeriodSeconds[et.KlineBasePeriod]
+	ticker := &et.Ticker{LeftAsset: left, RightAsset: right}
+	ticker.Time = blockTime - blockTime%seconds
+	ticker.StartTime = ticker.Time + seconds - et.TickerDuration
+	rows, err := klineTable.ListIndex("time", prefix, nil, int32(et.TickerDuration/seconds), et.ListDESC)
+	if err == types.ErrNotFound {
+		return ticker, nil
+	}
+	if err != nil {
+		elog.Error("QueryTicker.", "left", left, "right", right, "err", err.Error())
+		return nil, err
+	}
+	for _, row := range rows {
+		kline := row.Data.(*et.Kline)
+		//查询的时候可能有新的区块已经执行,只统计最新区块之前的K线
+		if kline.Time > ticker.Time {
+			continue
+		}
+		if kline.Time < ticker.StartTime {
+			break
+		}
+		if ticker.Count == 0 {
+			ticker.Close, ticker.High, ticker.Low = kline.Close, kline.High, kline.Low
+		}
+		//按时间降序遍历,最后一根K线的开盘价即为区间开盘价
+		ticker.Open = kline.Open
+		if kline.High > ticker.High {
+			ticker.High = kline.High
+		}
+		if kline.Low < ticker.Low {
+			ticker.Low = kline.Low
+		}
+		ticker.Volume += kline.Volume
+		ticker.Count += kline.Count
+	}
+	return ticker, nil
+}
@@ -61,3 +61,68 @@ func (s *exchange) Query_QueryOrderList(in *et.QueryOrderList) (types.Message, e
 	}
 	return QueryOrderList(s.GetLocalDB(), in.Address, in.Status, in.Count, in.Direction, in.PrimaryKey)
 }
+
+//查询交易对累计收取的手续费
+func (s *exchange) Query_QueryFeeCollected(in *et.QueryFeeCollected) (types.Message, error) {
+	if !CheckExchangeAsset(in.LeftAsset, in.RightAsset) {
+		return nil, et.ErrAsset
+	}
+	return QueryFeeCollected(s.GetLocalDB(), in.LeftAsset, in.RightAsset)
+}
+
+//根据orderID查询条件单信息
+func (s *exchange) Query_QueryConditionOrder(in *et.QueryOrder) (types.Message, error) {
+	if in.OrderID == 0 {
+		return nil, et.ErrOrderID
+	}
+	return findConditionOrderByOrderID(s.GetStateDB(), in.OrderID)
+}
+
+//根据条件单状态，查询用户的条件单信息（这里面包含所有交易对）
+func (s *exchange) Query_QueryConditionOrderList(in *et.QueryOrderList) (types.Message, error) {
+	if !CheckStatus(in.Status) {
+		return nil, et.ErrStatus
+	}
+	if !CheckCount(in.Count) {
+		return nil, et.ErrCount
+	}
+
+	if !CheckDirection(in.Direction) {
+		return nil, et.ErrDirection
+	}
+
+	if in.Address == "" {
+		return nil, et.ErrAddr
+	}
+	return QueryConditionOrderList(s.GetLocalDB(), in.Address, in.Status, in.Count, in.Direction, in.PrimaryKey)
+}
+
+//查询交易对的K线
+func (s *exchange) Query_QueryKline(in *et.QueryKline) (types.Message, error) {
+	if !CheckExchangeAsset(in.LeftAsset, in.RightAsset) {
+		return nil, et.ErrAsset
+	}
+	if !CheckKlinePeriod(in.Period) {
+		return nil, et.ErrKlinePeriod
+	}
+	if !CheckCount(in.Count) {
+		return nil, et.ErrCount
+	}
+
+	if !CheckDirection(in.Direction) {
+		return nil, et.ErrDirection
+	}
+	return QueryKline(s.GetLocalDB(), in.LeftAsset, in.RightAsset, in.Period, in.PrimaryKey, in.Count, in.Direction)
+}
+
+//查询交易对最近24小时行情
+func (s *exchange) Query_QueryTicker(in *et.QueryTicker) (types.Message, error) {
+	if !CheckExchangeAsset(in.LeftAsset, in.RightAsset) {
+		return nil, et.ErrAsset
+	}
+	header, err := s.GetAPI().GetLastHeader()
+	if err != nil {
+		return nil, err
+	}
+	return QueryTicker(s.GetLocalDB(), in.LeftAsset, in.RightAsset, header.GetBlockTime())
+}
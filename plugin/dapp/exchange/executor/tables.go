@@ -28,6 +28,23 @@ func calcOrderKey(orderID int64) []byte {
 	return []byte(key)
 }
 
+//状态数据库中存储条件单信息
+func calcConditionOrderKey(orderID int64) []byte {
+	key := fmt.Sprintf("%s"+"conditionOrderID:%022d", KeyPrefixStateDB, orderID)
+	return []byte(key)
+}
+
+//本地数据库中按交易对累计的手续费
+func calcFeeCollectedKey(left, right *ety.Asset) []byte {
+	key := fmt.Sprintf("%s-fee:%s", KeyPrefixLocalDB, calcFeePair(left, right))
+	return []byte(key)
+}
+
+//手续费按交易对区分,不同执行器下相同symbol的资产是不同的资产,交易对由{execer}.{symbol}构成
+func calcFeePair(left, right *ety.Asset) string {
+	return fmt.Sprintf("%s.%s:%s.%s", left.GetExecer(), left.GetSymbol(), right.GetExecer(), right.GetSymbol())
+}
+
 var opt_exchange_depth = &table.Option{
 	Prefix:  KeyPrefixLocalDB,
 	Name:    "depth",
@@ -50,6 +67,22 @@ var opt_exchange_history = &table.Option{
 	Index:   []string{"name", "addr_status"},
 }
 
+//条件单表,trigger索引用于撮合时查找满足触发条件的条件单
+var opt_exchange_trigger = &table.Option{
+	Prefix:  KeyPrefixLocalDB,
+	Name:    "trigger",
+	Primary: "orderID",
+	Index:   []string{"trigger", "addr_status"},
+}
+
+//K线表,主键按交易对、周期和起始时间排列,便于按时间顺序分页查询
+var opt_exchange_kline = &table.Option{
+	Prefix:  KeyPrefixLocalDB,
+	Name:    "kline",
+	Primary: "time",
+	Index:   nil,
+}
+
 //NewMarketDepthTable 新建表
 func NewMarketDepthTable(kvdb db.KV) *table.Table {
 	rowmeta := NewMarketDepthRow()
@@ -80,6 +113,26 @@ func NewHistoryOrderTable(kvdb db.KV) *table.Table {
 	return table
 }
 
+//NewTriggerTable ...
+func NewTriggerTable(kvdb db.KV) *table.Table {
+	rowmeta := NewConditionOrderRow()
+	table, err := table.NewTable(rowmeta, kvdb, opt_exchange_trigger)
+	if err != nil {
+		panic(err)
+	}
+	return table
+}
+
+//NewKlineTable ...
+func NewKlineTable(kvdb db.KV) *table.Table {
+	rowmeta := NewKlineRow()
+	table, err := table.NewTable(rowmeta, kvdb, opt_exchange_kline)
+	if err != nil {
+		panic(err)
+	}
+	return table
+}
+
 //OrderRow table meta 结构
 type OrderRow struct {
 	*ety.Order
@@ -145,7 +198,8 @@ func (m *HistoryOrderRow) Get(key string) ([]byte, error) {
 	if key == "index" {
 		return []byte(fmt.Sprintf("%022d", m.Index)), nil
 	} else if key == "name" {
-		return []byte(fmt.Sprintf("%s:%s", m.GetLimitOrder().LeftAsset.GetSymbol(), m.GetLimitOrder().RightAsset.GetSymbol())), nil
+		left, right := orderAssets(m.Order)
+		return []byte(fmt.Sprintf("%s:%s", left.GetSymbol(), right.GetSymbol())), nil
 	} else if key == "addr_status" {
 		return []byte(fmt.Sprintf("%s:%d", m.Addr, m.Status)), nil
 	}
@@ -183,3 +237,82 @@ func (m *MarketDepthRow) Get(key string) ([]byte, error) {
 	}
 	return nil, types.ErrNotFound
 }
+
+//ConditionOrderRow table meta 结构
+type ConditionOrderRow struct {
+	*ety.ConditionOrder
+}
+
+//NewConditionOrderRow 新建一个meta 结构
+func NewConditionOrderRow() *ConditionOrderRow {
+	return &ConditionOrderRow{ConditionOrder: &ety.ConditionOrder{LimitOrder: &ety.LimitOrder{}}}
+}
+
+//CreateRow ...
+func (m *ConditionOrderRow) CreateRow() *table.Row {
+	return &table.Row{Data: &ety.ConditionOrder{}}
+}
+
+//SetPayload 设置数据
+func (m *ConditionOrderRow) SetPayload(data types.Message) error {
+	if txdata, ok := data.(*ety.ConditionOrder); ok {
+		m.ConditionOrder = txdata
+		return nil
+	}
+	return types.ErrTypeAsset
+}
+
+//Get 按照indexName 查询 indexValue
+func (m *ConditionOrderRow) Get(key string) ([]byte, error) {
+	if key == "orderID" {
+		return []byte(fmt.Sprintf("%022d", m.OrderID)), nil
+	} else if key == "trigger" {
+		limitOrder := m.GetLimitOrder()
+		return []byte(fmt.Sprintf("%s:%s:%d:%d:%016d", limitOrder.GetLeftAsset().GetSymbol(), limitOrder.GetRightAsset().GetSymbol(),
+			m.Status, TriggerDirection(m.Ty, limitOrder.GetOp()), m.TriggerPrice)), nil
+	} else if key == "addr_status" {
+		return []byte(fmt.Sprintf("%s:%d", m.Addr, m.Status)), nil
+	}
+	return nil, types.ErrNotFound
+}
+
+//KlineRow table meta 结构
+type KlineRow struct {
+	*ety.Kline
+}
+
+//NewKlineRow 新建一个meta 结构
+func NewKlineRow() *KlineRow {
+	return &KlineRow{Kline: &ety.Kline{}}
+}
+
+//CreateRow ...
+func (m *KlineRow) CreateRow() *table.Row {
+	return &table.Row{Data: &ety.Kline{}}
+}
+
+//SetPayload 设置数据
+func (m *KlineRow) SetPayload(data types.Message) error {
+	if txdata, ok := data.(*ety.Kline); ok {
+		m.Kline = txdata
+		return nil
+	}
+	return types.ErrTypeAsset
+}
+
+//Get 按照indexName 查询 indexValue
+func (m *KlineRow) Get(key string) ([]byte, error) {
+	if key == "time" {
+		return []byte(calcKlinePrimary(m.LeftAsset, m.RightAsset, m.Period, m.Time)), nil
+	}
+	return nil, types.ErrNotFound
+}
+
+//K线主键前缀 left:right:period:
+func calcKlinePrefix(left, right *ety.Asset, period string) string {
+	return fmt.Sprintf("%s:%s:%s:", left.GetSymbol(), right.GetSymbol(), period)
+}
+
+func calcKlinePrimary(left, right *ety.Asset, period string, time int64) string {
+	return fmt.Sprintf("%s%016d", calcKlinePrefix(left, right, period), time)
+}
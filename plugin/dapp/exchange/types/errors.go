@@ -15,4 +15,10 @@ var (
 	ErrDirection    = fmt.Errorf("%s", "The direction only 0 or 1!")
 	ErrStatus       = fmt.Errorf("%s", "The status only in  0 , 1, 2!")
 	ErrOrderID      = fmt.Errorf("%s", "Wrong OrderID!")
+	ErrSlippage     = fmt.Errorf("%s", "The slippage only in 0 ~ 10000!")
+	ErrTriggerPrice = fmt.Errorf("%s", "The trigger price is not valid!")
+	ErrKlinePeriod  = fmt.Errorf("%s", "The kline period only in 1m, 5m, 1h, 1d!")
+	ErrTimeInForce  = fmt.Errorf("%s", "The timeInForce only in 0, 1, 2, 3!")
+	ErrFOKNotFilled = fmt.Errorf("%s", "The FOK order can't be filled completely!")
+	ErrPostOnly     = fmt.Errorf("%s", "The post-only order would match immediately!")
 )
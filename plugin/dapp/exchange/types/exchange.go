@@ -18,15 +18,27 @@ const (
 	TyLimitOrderAction
 	TyMarketOrderAction
 	TyRevokeOrderAction
+	TyStopLimitOrderAction
+	TyTakeProfitOrderAction
+	TyRevokeConditionOrderAction
 
-	NameLimitOrderAction  = "LimitOrder"
-	NameMarketOrderAction = "MarketOrder"
-	NameRevokeOrderAction = "RevokeOrder"
+	NameLimitOrderAction           = "LimitOrder"
+	NameMarketOrderAction          = "MarketOrder"
+	NameRevokeOrderAction          = "RevokeOrder"
+	NameStopLimitOrderAction       = "StopLimitOrder"
+	NameTakeProfitOrderAction      = "TakeProfitOrder"
+	NameRevokeConditionOrderAction = "RevokeConditionOrder"
 
 	FuncNameQueryMarketDepth      = "QueryMarketDepth"
 	FuncNameQueryHistoryOrderList = "QueryHistoryOrderList"
 	FuncNameQueryOrder            = "QueryOrder"
 	FuncNameQueryOrderList        = "QueryOrderList"
+	FuncNameQueryFeeCollected     = "QueryFeeCollected"
+	FuncNameQueryConditionOrder   = "QueryConditionOrder"
+	//FuncNameQueryConditionOrderList 复用QueryOrderList作为查询参数
+	FuncNameQueryConditionOrderList = "QueryConditionOrderList"
+	FuncNameQueryKline              = "QueryKline"
+	FuncNameQueryTicker             = "QueryTicker"
 )
 
 // log类型id值
@@ -35,6 +47,7 @@ const (
 	TyLimitOrderLog
 	TyMarketOrderLog
 	TyRevokeOrderLog
+	TyConditionOrderLog
 )
 
 // OP
@@ -43,6 +56,26 @@ const (
 	OpSell
 )
 
+//限价单成交方式
+const (
+	//TimeInForceGTC 未成交的部分挂单,直到成交或撤回
+	TimeInForceGTC = iota
+	//TimeInForceIOC 立即撮合,未成交的部分直接撤回
+	TimeInForceIOC
+	//TimeInForceFOK 必须全部立即成交,否则交易失败
+	TimeInForceFOK
+	//TimeInForcePostOnly 只做挂单方,会立即撮合时交易失败
+	TimeInForcePostOnly
+)
+
+//条件单触发方向
+const (
+	//TriggerDown 成交价跌至触发价及以下时触发
+	TriggerDown = iota + 1
+	//TriggerUp 成交价涨至触发价及以上时触发
+	TriggerUp
+)
+
 //order status
 const (
 	Ordered = iota
@@ -62,6 +95,40 @@ const (
 	Count = int32(10)
 	//MaxMatchCount 系统最大撮合深度
 	MaxMatchCount = 100
+	//MaxSlippage 市价单最大滑点,单位万分之一
+	MaxSlippage = int32(10000)
+	//MaxFeeRate 手续费率上限,精度1e8,即10%
+	MaxFeeRate = int64(1e7)
+	//MaxTriggerCount 单笔交易最多触发的条件单数量
+	MaxTriggerCount = 20
+)
+
+//KlinePeriods 支持的K线周期,按周期由短到长排列
+var KlinePeriods = []string{"1m", "5m", "1h", "1d"}
+
+//KlinePeriodSeconds K线周期对应的秒数
+var KlinePeriodSeconds = map[string]int64{
+	"1m": 60,
+	"5m": 300,
+	"1h": 3600,
+	"1d": 86400,
+}
+
+const (
+	//KlineBasePeriod 24小时行情统计使用的K线周期
+	KlineBasePeriod = "1m"
+	//TickerDuration 行情统计时长(秒)
+	TickerDuration = int64(86400)
+)
+
+//手续费配置项,由管理员通过manage合约配置,费率以交易对区分,精度1e8
+const (
+	//ConfigKeyFeeAddr 手续费收取地址,未配置时不收取手续费
+	ConfigKeyFeeAddr = "exchange-fee-addr"
+	//ConfigKeyMakerFeePrefix 挂单方费率,完整key为{prefix}{leftExecer}.{leftSymbol}:{rightExecer}.{rightSymbol}
+	ConfigKeyMakerFeePrefix = "exchange-maker-fee-"
+	//ConfigKeyTakerFeePrefix 吃单方费率,完整key为{prefix}{leftExecer}.{leftSymbol}:{rightExecer}.{rightSymbol}
+	ConfigKeyTakerFeePrefix = "exchange-taker-fee-"
 )
 
 var (
@@ -72,12 +139,18 @@ var (
 		NameLimitOrderAction:  TyLimitOrderAction,
 		NameMarketOrderAction: TyMarketOrderAction,
 		NameRevokeOrderAction: TyRevokeOrderAction,
+
+		NameStopLimitOrderAction:       TyStopLimitOrderAction,
+		NameTakeProfitOrderAction:      TyTakeProfitOrderAction,
+		NameRevokeConditionOrderAction: TyRevokeConditionOrderAction,
 	}
 	//定义log的id和具体log类型及名称，填入具体自定义log类型
 	logMap = map[int64]*types.LogInfo{
 		TyLimitOrderLog:  {Ty: reflect.TypeOf(ReceiptExchange{}), Name: "TyLimitOrderLog"},
 		TyMarketOrderLog: {Ty: reflect.TypeOf(ReceiptExchange{}), Name: "TyMarketOrderLog"},
 		TyRevokeOrderLog: {Ty: reflect.TypeOf(ReceiptExchange{}), Name: "TyRevokeOrderLog"},
+
+		TyConditionOrderLog: {Ty: reflect.TypeOf(ReceiptConditionOrder{}), Name: "TyConditionOrderLog"},
 	}
 	//tlog = log.New("module", "exchange.types")
 )
@@ -59,6 +59,9 @@ type ExchangeAction struct {
 	//	*ExchangeAction_LimitOrder
 	//	*ExchangeAction_MarketOrder
 	//	*ExchangeAction_RevokeOrder
+	//	*ExchangeAction_StopLimitOrder
+	//	*ExchangeAction_TakeProfitOrder
+	//	*ExchangeAction_RevokeConditionOrder
 	Value                isExchangeAction_Value `protobuf_oneof:"value"`
 	Ty                   int32                  `protobuf:"varint,6,opt,name=ty,proto3" json:"ty,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
@@ -107,12 +110,30 @@ type ExchangeAction_RevokeOrder struct {
 	RevokeOrder *RevokeOrder `protobuf:"bytes,3,opt,name=revokeOrder,proto3,oneof"`
 }
 
+type ExchangeAction_StopLimitOrder struct {
+	StopLimitOrder *StopLimitOrder `protobuf:"bytes,7,opt,name=stopLimitOrder,proto3,oneof"`
+}
+
+type ExchangeAction_TakeProfitOrder struct {
+	TakeProfitOrder *TakeProfitOrder `protobuf:"bytes,8,opt,name=takeProfitOrder,proto3,oneof"`
+}
+
+type ExchangeAction_RevokeConditionOrder struct {
+	RevokeConditionOrder *RevokeConditionOrder `protobuf:"bytes,9,opt,name=revokeConditionOrder,proto3,oneof"`
+}
+
 func (*ExchangeAction_LimitOrder) isExchangeAction_Value() {}
 
 func (*ExchangeAction_MarketOrder) isExchangeAction_Value() {}
 
 func (*ExchangeAction_RevokeOrder) isExchangeAction_Value() {}
 
+func (*ExchangeAction_StopLimitOrder) isExchangeAction_Value() {}
+
+func (*ExchangeAction_TakeProfitOrder) isExchangeAction_Value() {}
+
+func (*ExchangeAction_RevokeConditionOrder) isExchangeAction_Value() {}
+
 func (m *ExchangeAction) GetValue() isExchangeAction_Value {
 	if m != nil {
 		return m.Value
@@ -141,6 +162,27 @@ func (m *ExchangeAction) GetRevokeOrder() *RevokeOrder {
 	return nil
 }
 
+func (m *ExchangeAction) GetStopLimitOrder() *StopLimitOrder {
+	if x, ok := m.GetValue().(*ExchangeAction_StopLimitOrder); ok {
+		return x.StopLimitOrder
+	}
+	return nil
+}
+
+func (m *ExchangeAction) GetTakeProfitOrder() *TakeProfitOrder {
+	if x, ok := m.GetValue().(*ExchangeAction_TakeProfitOrder); ok {
+		return x.TakeProfitOrder
+	}
+	return nil
+}
+
+func (m *ExchangeAction) GetRevokeConditionOrder() *RevokeConditionOrder {
+	if x, ok := m.GetValue().(*ExchangeAction_RevokeConditionOrder); ok {
+		return x.RevokeConditionOrder
+	}
+	return nil
+}
+
 func (m *ExchangeAction) GetTy() int32 {
 	if m != nil {
 		return m.Ty
@@ -154,6 +196,9 @@ func (*ExchangeAction) XXX_OneofWrappers() []interface{} {
 		(*ExchangeAction_LimitOrder)(nil),
 		(*ExchangeAction_MarketOrder)(nil),
 		(*ExchangeAction_RevokeOrder)(nil),
+		(*ExchangeAction_StopLimitOrder)(nil),
+		(*ExchangeAction_TakeProfitOrder)(nil),
+		(*ExchangeAction_RevokeConditionOrder)(nil),
 	}
 }
 
@@ -168,7 +213,9 @@ type LimitOrder struct {
 	//总量
 	Amount int64 `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
 	//操作， 1为买，2为卖
-	Op                   int32    `protobuf:"varint,5,opt,name=op,proto3" json:"op,omitempty"`
+	Op int32 `protobuf:"varint,5,opt,name=op,proto3" json:"op,omitempty"`
+	//成交方式, 0 GTC挂单直到成交或撤回, 1 IOC立即成交剩余撤回, 2 FOK全部成交否则失败, 3 postOnly只挂单
+	TimeInForce          int32    `protobuf:"varint,6,opt,name=timeInForce,proto3" json:"timeInForce,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -234,6 +281,13 @@ func (m *LimitOrder) GetOp() int32 {
 	return 0
 }
 
+func (m *LimitOrder) GetTimeInForce() int32 {
+	if m != nil {
+		return m.TimeInForce
+	}
+	return 0
+}
+
 //市价委托
 type MarketOrder struct {
 	//资产1
@@ -243,7 +297,9 @@ type MarketOrder struct {
 	//总量
 	Amount int64 `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
 	//操作， 1为买，2为卖
-	Op                   int32    `protobuf:"varint,4,opt,name=op,proto3" json:"op,omitempty"`
+	Op int32 `protobuf:"varint,4,opt,name=op,proto3" json:"op,omitempty"`
+	//滑点上限,单位万分之一,以撮合时对手方最优价为基准,取值范围0~10000
+	Slippage             int32    `protobuf:"varint,5,opt,name=slippage,proto3" json:"slippage,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -302,6 +358,13 @@ func (m *MarketOrder) GetOp() int32 {
 	return 0
 }
 
+func (m *MarketOrder) GetSlippage() int32 {
+	if m != nil {
+		return m.Slippage
+	}
+	return 0
+}
+
 //撤回订单
 type RevokeOrder struct {
 	//订单号
@@ -343,6 +406,347 @@ func (m *RevokeOrder) GetOrderID() int64 {
 	return 0
 }
 
+//止损单,卖单在成交价跌至触发价时挂出,买单在成交价涨至触发价时挂出
+type StopLimitOrder struct {
+	//触发后挂出的限价单
+	LimitOrder *LimitOrder `protobuf:"bytes,1,opt,name=limitOrder,proto3" json:"limitOrder,omitempty"`
+	//触发价格
+	TriggerPrice         int64    `protobuf:"varint,2,opt,name=triggerPrice,proto3" json:"triggerPrice,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StopLimitOrder) Reset()         { *m = StopLimitOrder{} }
+func (m *StopLimitOrder) String() string { return proto.CompactTextString(m) }
+func (*StopLimitOrder) ProtoMessage()    {}
+func (*StopLimitOrder) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0328a4f16f87ea1, []int{5}
+}
+
+func (m *StopLimitOrder) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StopLimitOrder.Unmarshal(m, b)
+}
+func (m *StopLimitOrder) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StopLimitOrder.Marshal(b, m, deterministic)
+}
+func (m *StopLimitOrder) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopLimitOrder.Merge(m, src)
+}
+func (m *StopLimitOrder) XXX_Size() int {
+	return xxx_messageInfo_StopLimitOrder.Size(m)
+}
+func (m *StopLimitOrder) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopLimitOrder.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StopLimitOrder proto.InternalMessageInfo
+
+func (m *StopLimitOrder) GetLimitOrder() *LimitOrder {
+	if m != nil {
+		return m.LimitOrder
+	}
+	return nil
+}
+
+func (m *StopLimitOrder) GetTriggerPrice() int64 {
+	if m != nil {
+		return m.TriggerPrice
+	}
+	return 0
+}
+
+//止盈单,卖单在成交价涨至触发价时挂出,买单在成交价跌至触发价时挂出
+type TakeProfitOrder struct {
+	//触发后挂出的限价单
+	LimitOrder *LimitOrder `protobuf:"bytes,1,opt,name=limitOrder,proto3" json:"limitOrder,omitempty"`
+	//触发价格
+	TriggerPrice         int64    `protobuf:"varint,2,opt,name=triggerPrice,proto3" json:"triggerPrice,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TakeProfitOrder) Reset()         { *m = TakeProfitOrder{} }
+func (m *TakeProfitOrder) String() string { return proto.CompactTextString(m) }
+func (*TakeProfitOrder) ProtoMessage()    {}
+func (*TakeProfitOrder) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0328a4f16f87ea1, []int{6}
+}
+
+func (m *TakeProfitOrder) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TakeProfitOrder.Unmarshal(m, b)
+}
+func (m *TakeProfitOrder) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TakeProfitOrder.Marshal(b, m, deterministic)
+}
+func (m *TakeProfitOrder) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TakeProfitOrder.Merge(m, src)
+}
+func (m *TakeProfitOrder) XXX_Size() int {
+	return xxx_messageInfo_TakeProfitOrder.Size(m)
+}
+func (m *TakeProfitOrder) XXX_DiscardUnknown() {
+	xxx_messageInfo_TakeProfitOrder.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TakeProfitOrder proto.InternalMessageInfo
+
+func (m *TakeProfitOrder) GetLimitOrder() *LimitOrder {
+	if m != nil {
+		return m.LimitOrder
+	}
+	return nil
+}
+
+func (m *TakeProfitOrder) GetTriggerPrice() int64 {
+	if m != nil {
+		return m.TriggerPrice
+	}
+	return 0
+}
+
+//撤回条件单
+type RevokeConditionOrder struct {
+	//条件单号
+	OrderID              int64    `protobuf:"varint,1,opt,name=orderID,proto3" json:"orderID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokeConditionOrder) Reset()         { *m = RevokeConditionOrder{} }
+func (m *RevokeConditionOrder) String() string { return proto.CompactTextString(m) }
+func (*RevokeConditionOrder) ProtoMessage()    {}
+func (*RevokeConditionOrder) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0328a4f16f87ea1, []int{7}
+}
+
+func (m *RevokeConditionOrder) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeConditionOrder.Unmarshal(m, b)
+}
+func (m *RevokeConditionOrder) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeConditionOrder.Marshal(b, m, deterministic)
+}
+func (m *RevokeConditionOrder) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeConditionOrder.Merge(m, src)
+}
+func (m *RevokeConditionOrder) XXX_Size() int {
+	return xxx_messageInfo_RevokeConditionOrder.Size(m)
+}
+func (m *RevokeConditionOrder) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeConditionOrder.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeConditionOrder proto.InternalMessageInfo
+
+func (m *RevokeConditionOrder) GetOrderID() int64 {
+	if m != nil {
+		return m.OrderID
+	}
+	return 0
+}
+
+//条件单信息
+type ConditionOrder struct {
+	//条件单号,触发后挂出的限价单使用相同的订单号
+	OrderID int64 `protobuf:"varint,1,opt,name=orderID,proto3" json:"orderID,omitempty"`
+	//条件单类型
+	Ty int32 `protobuf:"varint,2,opt,name=ty,proto3" json:"ty,omitempty"`
+	//触发后挂出的限价单
+	LimitOrder *LimitOrder `protobuf:"bytes,3,opt,name=limitOrder,proto3" json:"limitOrder,omitempty"`
+	//触发价格
+	TriggerPrice int64 `protobuf:"varint,4,opt,name=triggerPrice,proto3" json:"triggerPrice,omitempty"`
+	//状态,0 等待触发ordered， 1 已触发completed， 2撤回 revoked
+	Status int32 `protobuf:"varint,5,opt,name=status,proto3" json:"status,omitempty"`
+	//用户地址
+	Addr string `protobuf:"bytes,6,opt,name=addr,proto3" json:"addr,omitempty"`
+	//更新时间
+	UpdateTime int64 `protobuf:"varint,7,opt,name=updateTime,proto3" json:"updateTime,omitempty"`
+	//索引
+	Index                int64    `protobuf:"varint,8,opt,name=index,proto3" json:"index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConditionOrder) Reset()         { *m = ConditionOrder{} }
+func (m *ConditionOrder) String() string { return proto.CompactTextString(m) }
+func (*ConditionOrder) ProtoMessage()    {}
+func (*ConditionOrder) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0328a4f16f87ea1, []int{8}
+}
+
+func (m *ConditionOrder) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConditionOrder.Unmarshal(m, b)
+}
+func (m *ConditionOrder) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConditionOrder.Marshal(b, m, deterministic)
+}
+func (m *ConditionOrder) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConditionOrder.Merge(m, src)
+}
+func (m *ConditionOrder) XXX_Size() int {
+	return xxx_messageInfo_ConditionOrder.Size(m)
+}
+func (m *ConditionOrder) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConditionOrder.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConditionOrder proto.InternalMessageInfo
+
+func (m *ConditionOrder) GetOrderID() int64 {
+	if m != nil {
+		return m.OrderID
+	}
+	return 0
+}
+
+func (m *ConditionOrder) GetTy() int32 {
+	if m != nil {
+		return m.Ty
+	}
+	return 0
+}
+
+func (m *ConditionOrder) GetLimitOrder() *LimitOrder {
+	if m != nil {
+		return m.LimitOrder
+	}
+	return nil
+}
+
+func (m *ConditionOrder) GetTriggerPrice() int64 {
+	if m != nil {
+		return m.TriggerPrice
+	}
+	return 0
+}
+
+func (m *ConditionOrder) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *ConditionOrder) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *ConditionOrder) GetUpdateTime() int64 {
+	if m != nil {
+		return m.UpdateTime
+	}
+	return 0
+}
+
+func (m *ConditionOrder) GetIndex() int64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+//条件单列表
+type ConditionOrderList struct {
+	List                 []*ConditionOrder `protobuf:"bytes,1,rep,name=list,proto3" json:"list,omitempty"`
+	PrimaryKey           string            `protobuf:"bytes,2,opt,name=primaryKey,proto3" json:"primaryKey,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ConditionOrderList) Reset()         { *m = ConditionOrderList{} }
+func (m *ConditionOrderList) String() string { return proto.CompactTextString(m) }
+func (*ConditionOrderList) ProtoMessage()    {}
+func (*ConditionOrderList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0328a4f16f87ea1, []int{9}
+}
+
+func (m *ConditionOrderList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConditionOrderList.Unmarshal(m, b)
+}
+func (m *ConditionOrderList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConditionOrderList.Marshal(b, m, deterministic)
+}
+func (m *ConditionOrderList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConditionOrderList.Merge(m, src)
+}
+func (m *ConditionOrderList) XXX_Size() int {
+	return xxx_messageInfo_ConditionOrderList.Size(m)
+}
+func (m *ConditionOrderList) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConditionOrderList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConditionOrderList proto.InternalMessageInfo
+
+func (m *ConditionOrderList) GetList() []*ConditionOrder {
+	if m != nil {
+		return m.List
+	}
+	return nil
+}
+
+func (m *ConditionOrderList) GetPrimaryKey() string {
+	if m != nil {
+		return m.PrimaryKey
+	}
+	return ""
+}
+
+// 条件单执行票据日志
+type ReceiptConditionOrder struct {
+	Order                *ConditionOrder `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Index                int64           `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ReceiptConditionOrder) Reset()         { *m = ReceiptConditionOrder{} }
+func (m *ReceiptConditionOrder) String() string { return proto.CompactTextString(m) }
+func (*ReceiptConditionOrder) ProtoMessage()    {}
+func (*ReceiptConditionOrder) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0328a4f16f87ea1, []int{10}
+}
+
+func (m *ReceiptConditionOrder) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceiptConditionOrder.Unmarshal(m, b)
+}
+func (m *ReceiptConditionOrder) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceiptConditionOrder.Marshal(b, m, deterministic)
+}
+func (m *ReceiptConditionOrder) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiptConditionOrder.Merge(m, src)
+}
+func (m *ReceiptConditionOrder) XXX_Size() int {
+	return xxx_messageInfo_ReceiptConditionOrder.Size(m)
+}
+func (m *ReceiptConditionOrder) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiptConditionOrder.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiptConditionOrder proto.InternalMessageInfo
+
+func (m *ReceiptConditionOrder) GetOrder() *ConditionOrder {
+	if m != nil {
+		return m.Order
+	}
+	return nil
+}
+
+func (m *ReceiptConditionOrder) GetIndex() int64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
 //资产类型
 type Asset struct {
 	Execer               string   `protobuf:"bytes,1,opt,name=execer,proto3" json:"execer,omitempty"`
@@ -356,7 +760,7 @@ func (m *Asset) Reset()         { *m = Asset{} }
 func (m *Asset) String() string { return proto.CompactTextString(m) }
 func (*Asset) ProtoMessage()    {}
 func (*Asset) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e0328a4f16f87ea1, []int{5}
+	return fileDescriptor_e0328a4f16f87ea1, []int{11}
 }
 
 func (m *Asset) XXX_Unmarshal(b []byte) error {
@@ -423,7 +827,7 @@ func (m *Order) Reset()         { *m = Order{} }
 func (m *Order) String() string { return proto.CompactTextString(m) }
 func (*Order) ProtoMessage()    {}
 func (*Order) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e0328a4f16f87ea1, []int{6}
+	return fileDescriptor_e0328a4f16f87ea1, []int{12}
 }
 
 func (m *Order) XXX_Unmarshal(b []byte) error {
@@ -573,7 +977,7 @@ func (m *QueryMarketDepth) Reset()         { *m = QueryMarketDepth{} }
 func (m *QueryMarketDepth) String() string { return proto.CompactTextString(m) }
 func (*QueryMarketDepth) ProtoMessage()    {}
 func (*QueryMarketDepth) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e0328a4f16f87ea1, []int{7}
+	return fileDescriptor_e0328a4f16f87ea1, []int{13}
 }
 
 func (m *QueryMarketDepth) XXX_Unmarshal(b []byte) error {
@@ -650,7 +1054,7 @@ func (m *MarketDepth) Reset()         { *m = MarketDepth{} }
 func (m *MarketDepth) String() string { return proto.CompactTextString(m) }
 func (*MarketDepth) ProtoMessage()    {}
 func (*MarketDepth) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e0328a4f16f87ea1, []int{8}
+	return fileDescriptor_e0328a4f16f87ea1, []int{14}
 }
 
 func (m *MarketDepth) XXX_Unmarshal(b []byte) error {
@@ -719,7 +1123,7 @@ func (m *MarketDepthList) Reset()         { *m = MarketDepthList{} }
 func (m *MarketDepthList) String() string { return proto.CompactTextString(m) }
 func (*MarketDepthList) ProtoMessage()    {}
 func (*MarketDepthList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e0328a4f16f87ea1, []int{9}
+	return fileDescriptor_e0328a4f16f87ea1, []int{15}
 }
 
 func (m *MarketDepthList) XXX_Unmarshal(b []byte) error {
@@ -775,7 +1179,7 @@ func (m *QueryHistoryOrderList) Reset()         { *m = QueryHistoryOrderList{} }
 func (m *QueryHistoryOrderList) String() string { return proto.CompactTextString(m) }
 func (*QueryHistoryOrderList) ProtoMessage()    {}
 func (*QueryHistoryOrderList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e0328a4f16f87ea1, []int{10}
+	return fileDescriptor_e0328a4f16f87ea1, []int{16}
 }
 
 func (m *QueryHistoryOrderList) XXX_Unmarshal(b []byte) error {
@@ -843,7 +1247,7 @@ func (m *QueryOrder) Reset()         { *m = QueryOrder{} }
 func (m *QueryOrder) String() string { return proto.CompactTextString(m) }
 func (*QueryOrder) ProtoMessage()    {}
 func (*QueryOrder) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e0328a4f16f87ea1, []int{11}
+	return fileDescriptor_e0328a4f16f87ea1, []int{17}
 }
 
 func (m *QueryOrder) XXX_Unmarshal(b []byte) error {
@@ -877,7 +1281,7 @@ type QueryOrderList struct {
 	Status int32 `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
 	//用户地址信息，必填
 	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
-	// 主键索引
+	//主键索引
 	PrimaryKey string `protobuf:"bytes,3,opt,name=primaryKey,proto3" json:"primaryKey,omitempty"`
 	//单页返回多少条记录，默认返回10条,为了系统安全最多单次只能返回20条
 	Count int32 `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
@@ -892,7 +1296,7 @@ func (m *QueryOrderList) Reset()         { *m = QueryOrderList{} }
 func (m *QueryOrderList) String() string { return proto.CompactTextString(m) }
 func (*QueryOrderList) ProtoMessage()    {}
 func (*QueryOrderList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e0328a4f16f87ea1, []int{12}
+	return fileDescriptor_e0328a4f16f87ea1, []int{18}
 }
 
 func (m *QueryOrderList) XXX_Unmarshal(b []byte) error {
@@ -961,7 +1365,7 @@ func (m *OrderList) Reset()         { *m = OrderList{} }
 func (m *OrderList) String() string { return proto.CompactTextString(m) }
 func (*OrderList) ProtoMessage()    {}
 func (*OrderList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e0328a4f16f87ea1, []int{13}
+	return fileDescriptor_e0328a4f16f87ea1, []int{19}
 }
 
 func (m *OrderList) XXX_Unmarshal(b []byte) error {
@@ -998,19 +1402,21 @@ func (m *OrderList) GetPrimaryKey() string {
 
 // exchange执行票据日志
 type ReceiptExchange struct {
-	Order                *Order   `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
-	MatchOrders          []*Order `protobuf:"bytes,2,rep,name=matchOrders,proto3" json:"matchOrders,omitempty"`
-	Index                int64    `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Order       *Order   `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	MatchOrders []*Order `protobuf:"bytes,2,rep,name=matchOrders,proto3" json:"matchOrders,omitempty"`
+	Index       int64    `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	//每笔撮合收取的手续费,与matchOrders一一对应
+	Fees                 []*MatchFee `protobuf:"bytes,4,rep,name=fees,proto3" json:"fees,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *ReceiptExchange) Reset()         { *m = ReceiptExchange{} }
 func (m *ReceiptExchange) String() string { return proto.CompactTextString(m) }
 func (*ReceiptExchange) ProtoMessage()    {}
 func (*ReceiptExchange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e0328a4f16f87ea1, []int{14}
+	return fileDescriptor_e0328a4f16f87ea1, []int{20}
 }
 
 func (m *ReceiptExchange) XXX_Unmarshal(b []byte) error {
@@ -1052,12 +1458,647 @@ func (m *ReceiptExchange) GetIndex() int64 {
 	return 0
 }
 
+func (m *ReceiptExchange) GetFees() []*MatchFee {
+	if m != nil {
+		return m.Fees
+	}
+	return nil
+}
+
+//撮合手续费,从双方各自收到的资产中扣除
+type MatchFee struct {
+	//被撮合的挂单号
+	OrderID int64 `protobuf:"varint,1,opt,name=orderID,proto3" json:"orderID,omitempty"`
+	//吃单方(taker)支付的手续费资产
+	TakerAsset *Asset `protobuf:"bytes,2,opt,name=takerAsset,proto3" json:"takerAsset,omitempty"`
+	//吃单方(taker)支付的手续费
+	TakerFee int64 `protobuf:"varint,3,opt,name=takerFee,proto3" json:"takerFee,omitempty"`
+	//挂单方(maker)支付的手续费资产
+	MakerAsset *Asset `protobuf:"bytes,4,opt,name=makerAsset,proto3" json:"makerAsset,omitempty"`
+	//挂单方(maker)支付的手续费
+	MakerFee             int64    `protobuf:"varint,5,opt,name=makerFee,proto3" json:"makerFee,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MatchFee) Reset()         { *m = MatchFee{} }
+func (m *MatchFee) String() string { return proto.CompactTextString(m) }
+func (*MatchFee) ProtoMessage()    {}
+func (*MatchFee) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0328a4f16f87ea1, []int{21}
+}
+
+func (m *MatchFee) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MatchFee.Unmarshal(m, b)
+}
+func (m *MatchFee) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MatchFee.Marshal(b, m, deterministic)
+}
+func (m *MatchFee) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MatchFee.Merge(m, src)
+}
+func (m *MatchFee) XXX_Size() int {
+	return xxx_messageInfo_MatchFee.Size(m)
+}
+func (m *MatchFee) XXX_DiscardUnknown() {
+	xxx_messageInfo_MatchFee.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MatchFee proto.InternalMessageInfo
+
+func (m *MatchFee) GetOrderID() int64 {
+	if m != nil {
+		return m.OrderID
+	}
+	return 0
+}
+
+func (m *MatchFee) GetTakerAsset() *Asset {
+	if m != nil {
+		return m.TakerAsset
+	}
+	return nil
+}
+
+func (m *MatchFee) GetTakerFee() int64 {
+	if m != nil {
+		return m.TakerFee
+	}
+	return 0
+}
+
+func (m *MatchFee) GetMakerAsset() *Asset {
+	if m != nil {
+		return m.MakerAsset
+	}
+	return nil
+}
+
+func (m *MatchFee) GetMakerFee() int64 {
+	if m != nil {
+		return m.MakerFee
+	}
+	return 0
+}
+
+//查询交易对累计收取的手续费
+type QueryFeeCollected struct {
+	//资产1
+	LeftAsset *Asset `protobuf:"bytes,1,opt,name=leftAsset,proto3" json:"leftAsset,omitempty"`
+	//资产2
+	RightAsset           *Asset   `protobuf:"bytes,2,opt,name=rightAsset,proto3" json:"rightAsset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryFeeCollected) Reset()         { *m = QueryFeeCollected{} }
+func (m *QueryFeeCollected) String() string { return proto.CompactTextString(m) }
+func (*QueryFeeCollected) ProtoMessage()    {}
+func (*QueryFeeCollected) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0328a4f16f87ea1, []int{22}
+}
+
+func (m *QueryFeeCollected) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryFeeCollected.Unmarshal(m, b)
+}
+func (m *QueryFeeCollected) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryFeeCollected.Marshal(b, m, deterministic)
+}
+func (m *QueryFeeCollected) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryFeeCollected.Merge(m, src)
+}
+func (m *QueryFeeCollected) XXX_Size() int {
+	return xxx_messageInfo_QueryFeeCollected.Size(m)
+}
+func (m *QueryFeeCollected) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryFeeCollected.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryFeeCollected proto.InternalMessageInfo
+
+func (m *QueryFeeCollected) GetLeftAsset() *Asset {
+	if m != nil {
+		return m.LeftAsset
+	}
+	return nil
+}
+
+func (m *QueryFeeCollected) GetRightAsset() *Asset {
+	if m != nil {
+		return m.RightAsset
+	}
+	return nil
+}
+
+//交易对累计收取的手续费
+type FeeCollected struct {
+	//资产1
+	LeftAsset *Asset `protobuf:"bytes,1,opt,name=leftAsset,proto3" json:"leftAsset,omitempty"`
+	//资产2
+	RightAsset *Asset `protobuf:"bytes,2,opt,name=rightAsset,proto3" json:"rightAsset,omitempty"`
+	//以资产1收取的手续费总量
+	LeftAmount int64 `protobuf:"varint,3,opt,name=leftAmount,proto3" json:"leftAmount,omitempty"`
+	//以资产2收取的手续费总量
+	RightAmount          int64    `protobuf:"varint,4,opt,name=rightAmount,proto3" json:"rightAmount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FeeCollected) Reset()         { *m = FeeCollected{} }
+func (m *FeeCollected) String() string { return proto.CompactTextString(m) }
+func (*FeeCollected) ProtoMessage()    {}
+func (*FeeCollected) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0328a4f16f87ea1, []int{23}
+}
+
+func (m *FeeCollected) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FeeCollected.Unmarshal(m, b)
+}
+func (m *FeeCollected) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FeeCollected.Marshal(b, m, deterministic)
+}
+func (m *FeeCollected) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FeeCollected.Merge(m, src)
+}
+func (m *FeeCollected) XXX_Size() int {
+	return xxx_messageInfo_FeeCollected.Size(m)
+}
+func (m *FeeCollected) XXX_DiscardUnknown() {
+	xxx_messageInfo_FeeCollected.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FeeCollected proto.InternalMessageInfo
+
+func (m *FeeCollected) GetLeftAsset() *Asset {
+	if m != nil {
+		return m.LeftAsset
+	}
+	return nil
+}
+
+func (m *FeeCollected) GetRightAsset() *Asset {
+	if m != nil {
+		return m.RightAsset
+	}
+	return nil
+}
+
+func (m *FeeCollected) GetLeftAmount() int64 {
+	if m != nil {
+		return m.LeftAmount
+	}
+	return 0
+}
+
+func (m *FeeCollected) GetRightAmount() int64 {
+	if m != nil {
+		return m.RightAmount
+	}
+	return 0
+}
+
+//K线,按交易对和周期统计的成交价格与成交量
+type Kline struct {
+	//资产1
+	LeftAsset *Asset `protobuf:"bytes,1,opt,name=leftAsset,proto3" json:"leftAsset,omitempty"`
+	//资产2
+	RightAsset *Asset `protobuf:"bytes,2,opt,name=rightAsset,proto3" json:"rightAsset,omitempty"`
+	//周期 1m,5m,1h,1d
+	Period string `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`
+	//周期起始时间(秒)
+	Time int64 `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+	//开盘价
+	Open int64 `protobuf:"varint,5,opt,name=open,proto3" json:"open,omitempty"`
+	//最高价
+	High int64 `protobuf:"varint,6,opt,name=high,proto3" json:"high,omitempty"`
+	//最低价
+	Low int64 `protobuf:"varint,7,opt,name=low,proto3" json:"low,omitempty"`
+	//收盘价
+	Close int64 `protobuf:"varint,8,opt,name=close,proto3" json:"close,omitempty"`
+	//成交量(资产1)
+	Volume int64 `protobuf:"varint,9,opt,name=volume,proto3" json:"volume,omitempty"`
+	//成交笔数
+	Count                int64    `protobuf:"varint,10,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Kline) Reset()         { *m = Kline{} }
+func (m *Kline) String() string { return proto.CompactTextString(m) }
+func (*Kline) ProtoMessage()    {}
+func (*Kline) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0328a4f16f87ea1, []int{24}
+}
+
+func (m *Kline) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Kline.Unmarshal(m, b)
+}
+func (m *Kline) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Kline.Marshal(b, m, deterministic)
+}
+func (m *Kline) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Kline.Merge(m, src)
+}
+func (m *Kline) XXX_Size() int {
+	return xxx_messageInfo_Kline.Size(m)
+}
+func (m *Kline) XXX_DiscardUnknown() {
+	xxx_messageInfo_Kline.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Kline proto.InternalMessageInfo
+
+func (m *Kline) GetLeftAsset() *Asset {
+	if m != nil {
+		return m.LeftAsset
+	}
+	return nil
+}
+
+func (m *Kline) GetRightAsset() *Asset {
+	if m != nil {
+		return m.RightAsset
+	}
+	return nil
+}
+
+func (m *Kline) GetPeriod() string {
+	if m != nil {
+		return m.Period
+	}
+	return ""
+}
+
+func (m *Kline) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *Kline) GetOpen() int64 {
+	if m != nil {
+		return m.Open
+	}
+	return 0
+}
+
+func (m *Kline) GetHigh() int64 {
+	if m != nil {
+		return m.High
+	}
+	return 0
+}
+
+func (m *Kline) GetLow() int64 {
+	if m != nil {
+		return m.Low
+	}
+	return 0
+}
+
+func (m *Kline) GetClose() int64 {
+	if m != nil {
+		return m.Close
+	}
+	return 0
+}
+
+func (m *Kline) GetVolume() int64 {
+	if m != nil {
+		return m.Volume
+	}
+	return 0
+}
+
+func (m *Kline) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+//查询K线
+type QueryKline struct {
+	//资产1
+	LeftAsset *Asset `protobuf:"bytes,1,opt,name=leftAsset,proto3" json:"leftAsset,omitempty"`
+	//资产2
+	RightAsset *Asset `protobuf:"bytes,2,opt,name=rightAsset,proto3" json:"rightAsset,omitempty"`
+	//周期 1m,5m,1h,1d
+	Period string `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`
+	//主键索引
+	PrimaryKey string `protobuf:"bytes,4,opt,name=primaryKey,proto3" json:"primaryKey,omitempty"`
+	//单页返回多少条记录，默认返回10条,为了系统安全最多单次只能返回20条
+	Count int32 `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
+	// 0降序，1升序，默认降序
+	Direction            int32    `protobuf:"varint,6,opt,name=direction,proto3" json:"direction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryKline) Reset()         { *m = QueryKline{} }
+func (m *QueryKline) String() string { return proto.CompactTextString(m) }
+func (*QueryKline) ProtoMessage()    {}
+func (*QueryKline) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0328a4f16f87ea1, []int{25}
+}
+
+func (m *QueryKline) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryKline.Unmarshal(m, b)
+}
+func (m *QueryKline) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryKline.Marshal(b, m, deterministic)
+}
+func (m *QueryKline) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryKline.Merge(m, src)
+}
+func (m *QueryKline) XXX_Size() int {
+	return xxx_messageInfo_QueryKline.Size(m)
+}
+func (m *QueryKline) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryKline.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryKline proto.InternalMessageInfo
+
+func (m *QueryKline) GetLeftAsset() *Asset {
+	if m != nil {
+		return m.LeftAsset
+	}
+	return nil
+}
+
+func (m *QueryKline) GetRightAsset() *Asset {
+	if m != nil {
+		return m.RightAsset
+	}
+	return nil
+}
+
+func (m *QueryKline) GetPeriod() string {
+	if m != nil {
+		return m.Period
+	}
+	return ""
+}
+
+func (m *QueryKline) GetPrimaryKey() string {
+	if m != nil {
+		return m.PrimaryKey
+	}
+	return ""
+}
+
+func (m *QueryKline) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *QueryKline) GetDirection() int32 {
+	if m != nil {
+		return m.Direction
+	}
+	return 0
+}
+
+type KlineList struct {
+	List                 []*Kline `protobuf:"bytes,1,rep,name=list,proto3" json:"list,omitempty"`
+	PrimaryKey           string   `protobuf:"bytes,2,opt,name=primaryKey,proto3" json:"primaryKey,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KlineList) Reset()         { *m = KlineList{} }
+func (m *KlineList) String() string { return proto.CompactTextString(m) }
+func (*KlineList) ProtoMessage()    {}
+func (*KlineList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0328a4f16f87ea1, []int{26}
+}
+
+func (m *KlineList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KlineList.Unmarshal(m, b)
+}
+func (m *KlineList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KlineList.Marshal(b, m, deterministic)
+}
+func (m *KlineList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KlineList.Merge(m, src)
+}
+func (m *KlineList) XXX_Size() int {
+	return xxx_messageInfo_KlineList.Size(m)
+}
+func (m *KlineList) XXX_DiscardUnknown() {
+	xxx_messageInfo_KlineList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KlineList proto.InternalMessageInfo
+
+func (m *KlineList) GetList() []*Kline {
+	if m != nil {
+		return m.List
+	}
+	return nil
+}
+
+func (m *KlineList) GetPrimaryKey() string {
+	if m != nil {
+		return m.PrimaryKey
+	}
+	return ""
+}
+
+//查询最近24小时行情
+type QueryTicker struct {
+	//资产1
+	LeftAsset *Asset `protobuf:"bytes,1,opt,name=leftAsset,proto3" json:"leftAsset,omitempty"`
+	//资产2
+	RightAsset           *Asset   `protobuf:"bytes,2,opt,name=rightAsset,proto3" json:"rightAsset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryTicker) Reset()         { *m = QueryTicker{} }
+func (m *QueryTicker) String() string { return proto.CompactTextString(m) }
+func (*QueryTicker) ProtoMessage()    {}
+func (*QueryTicker) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0328a4f16f87ea1, []int{27}
+}
+
+func (m *QueryTicker) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryTicker.Unmarshal(m, b)
+}
+func (m *QueryTicker) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryTicker.Marshal(b, m, deterministic)
+}
+func (m *QueryTicker) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryTicker.Merge(m, src)
+}
+func (m *QueryTicker) XXX_Size() int {
+	return xxx_messageInfo_QueryTicker.Size(m)
+}
+func (m *QueryTicker) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryTicker.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryTicker proto.InternalMessageInfo
+
+func (m *QueryTicker) GetLeftAsset() *Asset {
+	if m != nil {
+		return m.LeftAsset
+	}
+	return nil
+}
+
+func (m *QueryTicker) GetRightAsset() *Asset {
+	if m != nil {
+		return m.RightAsset
+	}
+	return nil
+}
+
+//最近24小时行情,以最新区块所在分钟为结束
+type Ticker struct {
+	//资产1
+	LeftAsset *Asset `protobuf:"bytes,1,opt,name=leftAsset,proto3" json:"leftAsset,omitempty"`
+	//资产2
+	RightAsset *Asset `protobuf:"bytes,2,opt,name=rightAsset,proto3" json:"rightAsset,omitempty"`
+	//统计区间起始时间(秒)
+	StartTime int64 `protobuf:"varint,3,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	//最新区块所在分钟的起始时间(秒)
+	Time int64 `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+	//开盘价
+	Open int64 `protobuf:"varint,5,opt,name=open,proto3" json:"open,omitempty"`
+	//最高价
+	High int64 `protobuf:"varint,6,opt,name=high,proto3" json:"high,omitempty"`
+	//最低价
+	Low int64 `protobuf:"varint,7,opt,name=low,proto3" json:"low,omitempty"`
+	//区间内最后一笔成交价,区间内没有成交时为0
+	Close int64 `protobuf:"varint,8,opt,name=close,proto3" json:"close,omitempty"`
+	//成交量(资产1)
+	Volume int64 `protobuf:"varint,9,opt,name=volume,proto3" json:"volume,omitempty"`
+	//成交笔数
+	Count                int64    `protobuf:"varint,10,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Ticker) Reset()         { *m = Ticker{} }
+func (m *Ticker) String() string { return proto.CompactTextString(m) }
+func (*Ticker) ProtoMessage()    {}
+func (*Ticker) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0328a4f16f87ea1, []int{28}
+}
+
+func (m *Ticker) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Ticker.Unmarshal(m, b)
+}
+func (m *Ticker) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Ticker.Marshal(b, m, deterministic)
+}
+func (m *Ticker) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Ticker.Merge(m, src)
+}
+func (m *Ticker) XXX_Size() int {
+	return xxx_messageInfo_Ticker.Size(m)
+}
+func (m *Ticker) XXX_DiscardUnknown() {
+	xxx_messageInfo_Ticker.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Ticker proto.InternalMessageInfo
+
+func (m *Ticker) GetLeftAsset() *Asset {
+	if m != nil {
+		return m.LeftAsset
+	}
+	return nil
+}
+
+func (m *Ticker) GetRightAsset() *Asset {
+	if m != nil {
+		return m.RightAsset
+	}
+	return nil
+}
+
+func (m *Ticker) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *Ticker) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *Ticker) GetOpen() int64 {
+	if m != nil {
+		return m.Open
+	}
+	return 0
+}
+
+func (m *Ticker) GetHigh() int64 {
+	if m != nil {
+		return m.High
+	}
+	return 0
+}
+
+func (m *Ticker) GetLow() int64 {
+	if m != nil {
+		return m.Low
+	}
+	return 0
+}
+
+func (m *Ticker) GetClose() int64 {
+	if m != nil {
+		return m.Close
+	}
+	return 0
+}
+
+func (m *Ticker) GetVolume() int64 {
+	if m != nil {
+		return m.Volume
+	}
+	return 0
+}
+
+func (m *Ticker) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Exchange)(nil), "types.Exchange")
 	proto.RegisterType((*ExchangeAction)(nil), "types.ExchangeAction")
 	proto.RegisterType((*LimitOrder)(nil), "types.LimitOrder")
 	proto.RegisterType((*MarketOrder)(nil), "types.MarketOrder")
 	proto.RegisterType((*RevokeOrder)(nil), "types.RevokeOrder")
+	proto.RegisterType((*StopLimitOrder)(nil), "types.StopLimitOrder")
+	proto.RegisterType((*TakeProfitOrder)(nil), "types.TakeProfitOrder")
+	proto.RegisterType((*RevokeConditionOrder)(nil), "types.RevokeConditionOrder")
+	proto.RegisterType((*ConditionOrder)(nil), "types.ConditionOrder")
+	proto.RegisterType((*ConditionOrderList)(nil), "types.ConditionOrderList")
+	proto.RegisterType((*ReceiptConditionOrder)(nil), "types.ReceiptConditionOrder")
 	proto.RegisterType((*Asset)(nil), "types.asset")
 	proto.RegisterType((*Order)(nil), "types.Order")
 	proto.RegisterType((*QueryMarketDepth)(nil), "types.QueryMarketDepth")
@@ -1068,6 +2109,14 @@ func init() {
 	proto.RegisterType((*QueryOrderList)(nil), "types.QueryOrderList")
 	proto.RegisterType((*OrderList)(nil), "types.OrderList")
 	proto.RegisterType((*ReceiptExchange)(nil), "types.ReceiptExchange")
+	proto.RegisterType((*MatchFee)(nil), "types.MatchFee")
+	proto.RegisterType((*QueryFeeCollected)(nil), "types.QueryFeeCollected")
+	proto.RegisterType((*FeeCollected)(nil), "types.FeeCollected")
+	proto.RegisterType((*Kline)(nil), "types.Kline")
+	proto.RegisterType((*QueryKline)(nil), "types.QueryKline")
+	proto.RegisterType((*KlineList)(nil), "types.KlineList")
+	proto.RegisterType((*QueryTicker)(nil), "types.QueryTicker")
+	proto.RegisterType((*Ticker)(nil), "types.Ticker")
 }
 
 func init() {
@@ -1075,49 +2124,78 @@ func init() {
 }
 
 var fileDescriptor_e0328a4f16f87ea1 = []byte{
-	// 667 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0xdd, 0x6e, 0xd3, 0x4a,
-	0x10, 0xae, 0xed, 0x38, 0xa9, 0x27, 0x47, 0xe9, 0x39, 0xab, 0x73, 0x8e, 0x2c, 0x40, 0xa8, 0xf2,
-	0x45, 0xa9, 0x10, 0xca, 0x45, 0x2b, 0xc1, 0x75, 0x50, 0x51, 0x8b, 0x68, 0x05, 0xac, 0x50, 0x25,
-	0xae, 0x90, 0x6b, 0x0f, 0xcd, 0xaa, 0x49, 0x6c, 0xad, 0x37, 0x55, 0x2d, 0x1e, 0x82, 0x3b, 0x9e,
-	0x00, 0x5e, 0x80, 0x77, 0xe0, 0x96, 0x27, 0xe0, 0x61, 0xd0, 0xce, 0xae, 0xe3, 0x4d, 0x5b, 0xd4,
-	0x0a, 0x94, 0x3b, 0x7f, 0xf3, 0xb3, 0xfe, 0x66, 0xe6, 0x9b, 0x5d, 0x18, 0xe0, 0x45, 0x36, 0x4e,
-	0x67, 0xa7, 0x38, 0x2c, 0x65, 0xa1, 0x0a, 0x16, 0xaa, 0xba, 0xc4, 0x2a, 0x01, 0x58, 0x7f, 0x66,
-	0x1d, 0xc9, 0x77, 0x0f, 0x06, 0x0d, 0x18, 0x65, 0x4a, 0x14, 0x33, 0xb6, 0x0b, 0x30, 0x11, 0x53,
-	0xa1, 0x5e, 0xca, 0x1c, 0x65, 0xec, 0x6d, 0x7a, 0xdb, 0xfd, 0x9d, 0x7f, 0x86, 0x94, 0x3a, 0x3c,
-	0x5c, 0x38, 0x0e, 0xd6, 0xb8, 0x13, 0xc6, 0x1e, 0x43, 0x7f, 0x9a, 0xca, 0x33, 0xb4, 0x59, 0x3e,
-	0x65, 0x31, 0x9b, 0x75, 0xd4, 0x7a, 0x0e, 0xd6, 0xb8, 0x1b, 0xa8, 0xf3, 0x24, 0x9e, 0x17, 0x67,
-	0x68, 0xf2, 0x82, 0xa5, 0x3c, 0xde, 0x7a, 0x74, 0x9e, 0x13, 0xc8, 0x06, 0xe0, 0xab, 0x3a, 0xee,
-	0x6e, 0x7a, 0xdb, 0x21, 0xf7, 0x55, 0xfd, 0xb4, 0x07, 0xe1, 0x79, 0x3a, 0x99, 0x63, 0xf2, 0xd9,
-	0x03, 0x68, 0x59, 0xb2, 0x87, 0x10, 0x4d, 0xf0, 0xbd, 0x1a, 0x55, 0x15, 0x2a, 0x5b, 0xcb, 0x5f,
-	0xf6, 0xf4, 0x54, 0xdb, 0x78, 0xeb, 0x66, 0x8f, 0x00, 0xa4, 0x38, 0x1d, 0xdb, 0x60, 0xff, 0x9a,
-	0x60, 0xc7, 0xcf, 0xfe, 0x85, 0xb0, 0x94, 0x22, 0x43, 0xe2, 0x1c, 0x70, 0x03, 0xd8, 0xff, 0xd0,
-	0x4d, 0xa7, 0xc5, 0x7c, 0xa6, 0xe2, 0x0e, 0x99, 0x2d, 0xd2, 0x7c, 0x8b, 0x32, 0x0e, 0x0d, 0xdf,
-	0xa2, 0x4c, 0x3e, 0x7a, 0xd0, 0x77, 0xda, 0xb2, 0x42, 0x9e, 0x2d, 0xa3, 0xe0, 0x1a, 0x46, 0x9d,
-	0x05, 0xa3, 0x07, 0xd0, 0x77, 0xfa, 0xcd, 0x62, 0xe8, 0x15, 0xfa, 0xe3, 0xf9, 0x1e, 0xd1, 0x09,
-	0x78, 0x03, 0x93, 0x27, 0x10, 0xa6, 0xcd, 0xc9, 0x78, 0x81, 0x99, 0x15, 0x49, 0xc4, 0x2d, 0xd2,
-	0xf6, 0xaa, 0x9e, 0x9e, 0x14, 0x13, 0xe2, 0x16, 0x71, 0x8b, 0x92, 0x1f, 0x3e, 0x84, 0x37, 0x1c,
-	0x7e, 0x49, 0x7c, 0xfe, 0x6f, 0x89, 0x2f, 0xb8, 0xad, 0xf8, 0x8c, 0x88, 0x3a, 0x8d, 0x88, 0xd8,
-	0x1d, 0x58, 0xd7, 0x25, 0xcc, 0x15, 0xe6, 0x34, 0xaa, 0x80, 0x2f, 0x30, 0xbb, 0x0b, 0xd1, 0xe8,
-	0x78, 0xff, 0x9d, 0x19, 0x79, 0xd7, 0x38, 0x47, 0xc7, 0xfb, 0xaf, 0x68, 0xea, 0x31, 0xf4, 0x4e,
-	0xd2, 0x49, 0x3a, 0xcb, 0x30, 0xee, 0x99, 0x7a, 0x2c, 0xa4, 0x5e, 0xa8, 0x54, 0xcd, 0xab, 0x78,
-	0x9d, 0x7e, 0x63, 0x11, 0x63, 0xd0, 0x49, 0xf3, 0x5c, 0xc6, 0x11, 0x75, 0x88, 0xbe, 0xd9, 0x7d,
-	0x80, 0x79, 0x99, 0xa7, 0x0a, 0xdf, 0x88, 0x29, 0xc6, 0x40, 0x07, 0x39, 0x16, 0xad, 0x38, 0x31,
-	0xcb, 0xf1, 0x22, 0xee, 0x1b, 0xc5, 0x11, 0x68, 0x95, 0xff, 0xd5, 0x83, 0xbf, 0x5f, 0xcf, 0x51,
-	0xd6, 0xa6, 0xe2, 0x3d, 0x2c, 0xd5, 0x78, 0x85, 0xba, 0x32, 0xfa, 0x09, 0x1a, 0xfd, 0x68, 0xf6,
-	0xa5, 0x14, 0xd3, 0x54, 0xd6, 0x2f, 0xd0, 0x34, 0x35, 0xe2, 0x8e, 0x45, 0xb3, 0xcf, 0x48, 0x86,
-	0x66, 0x09, 0x0c, 0x48, 0xbe, 0x2c, 0xf6, 0x60, 0xd5, 0x7c, 0xff, 0x6c, 0x5f, 0xdf, 0xc2, 0x86,
-	0x43, 0xf3, 0x50, 0x54, 0x8a, 0x6d, 0x41, 0x67, 0x22, 0x2a, 0xcd, 0x32, 0xb8, 0x22, 0x37, 0x8a,
-	0xe2, 0xe4, 0xbf, 0xd4, 0x18, 0xff, 0x72, 0x63, 0x92, 0x6f, 0x1e, 0xfc, 0x47, 0x73, 0x3b, 0x10,
-	0x95, 0x2a, 0x64, 0x4d, 0xda, 0xa4, 0x3f, 0xac, 0xae, 0x19, 0xcb, 0x9c, 0x82, 0x5f, 0x0f, 0xab,
-	0xe3, 0x0c, 0x8b, 0xdd, 0x83, 0x28, 0x17, 0x12, 0xe9, 0x99, 0xb0, 0xbd, 0x69, 0x0d, 0xc9, 0x16,
-	0x00, 0x95, 0x71, 0xd3, 0xfd, 0xf1, 0xc9, 0x83, 0x41, 0x1b, 0x48, 0x85, 0xb6, 0x5b, 0xe2, 0x2d,
-	0x6d, 0x49, 0x0c, 0x3d, 0xbd, 0x19, 0x58, 0x55, 0xb6, 0x6f, 0x0d, 0x5c, 0x49, 0x01, 0x47, 0x10,
-	0xb5, 0x94, 0x36, 0x97, 0xa6, 0xdb, 0x74, 0x92, 0xfc, 0xb7, 0x9c, 0xeb, 0x07, 0xd8, 0xe0, 0x98,
-	0xa1, 0x28, 0x55, 0xf3, 0xc0, 0xb2, 0x04, 0xc2, 0xc2, 0x79, 0x55, 0x97, 0x4f, 0x35, 0x2e, 0x36,
-	0xd4, 0x97, 0x99, 0xca, 0xc6, 0x64, 0xd4, 0x75, 0x5f, 0xfd, 0xbf, 0x1b, 0xd0, 0xde, 0x0a, 0x81,
-	0x73, 0x2b, 0xec, 0x80, 0xbe, 0xca, 0xcc, 0x5f, 0x4f, 0xba, 0xf4, 0xfa, 0xef, 0xfe, 0x0c, 0x00,
-	0x00, 0xff, 0xff, 0xf2, 0x3c, 0xac, 0xed, 0x0f, 0x08, 0x00, 0x00,
+	// 1133 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0x4f, 0x6f, 0x1b, 0x45,
+	0x14, 0xcf, 0xfe, 0xb1, 0x63, 0x3f, 0x47, 0x4e, 0x3b, 0x4a, 0x2a, 0xab, 0x45, 0x28, 0x5a, 0xa4,
+	0x52, 0xa0, 0x8a, 0xa0, 0x95, 0xe0, 0x88, 0xd2, 0x96, 0xd4, 0x55, 0x5b, 0xd1, 0x2e, 0x51, 0x25,
+	0xb8, 0x54, 0x9b, 0xf5, 0x8b, 0x3d, 0xca, 0xae, 0x67, 0xb5, 0x3b, 0x0e, 0xf1, 0x67, 0xe0, 0xc6,
+	0x81, 0x33, 0x17, 0x38, 0xc0, 0x8d, 0x13, 0x5f, 0x00, 0x3e, 0x00, 0x67, 0xbe, 0x05, 0x5f, 0x00,
+	0xcd, 0xdb, 0x59, 0xef, 0x8c, 0xeb, 0xa4, 0x21, 0x68, 0xa1, 0xb7, 0x7d, 0xff, 0x66, 0xde, 0x9b,
+	0xf7, 0x7b, 0xbf, 0x19, 0x1b, 0xfa, 0x78, 0x1a, 0x4f, 0xa2, 0xe9, 0x18, 0x77, 0xb3, 0x5c, 0x48,
+	0xc1, 0x5a, 0x72, 0x9e, 0x61, 0x11, 0x00, 0x74, 0x3e, 0xd3, 0x86, 0xe0, 0x47, 0x0f, 0xfa, 0x95,
+	0xb0, 0x17, 0x4b, 0x2e, 0xa6, 0xec, 0x2e, 0x40, 0xc2, 0x53, 0x2e, 0x3f, 0xcf, 0x47, 0x98, 0x0f,
+	0x9c, 0x1d, 0xe7, 0x56, 0xef, 0xce, 0xd5, 0x5d, 0x0a, 0xdd, 0x7d, 0xb2, 0x30, 0x0c, 0xd7, 0x42,
+	0xc3, 0x8d, 0x7d, 0x0c, 0xbd, 0x34, 0xca, 0x8f, 0x51, 0x47, 0xb9, 0x14, 0xc5, 0x74, 0xd4, 0xd3,
+	0xda, 0x32, 0x5c, 0x0b, 0x4d, 0x47, 0x15, 0x97, 0xe3, 0x89, 0x38, 0xc6, 0x32, 0xce, 0xb3, 0xe2,
+	0xc2, 0xda, 0xa2, 0xe2, 0x0c, 0x47, 0xf6, 0x29, 0xf4, 0x0b, 0x29, 0xb2, 0x3a, 0x9f, 0xc1, 0x3a,
+	0x85, 0x6e, 0xeb, 0xd0, 0x2f, 0x2c, 0xe3, 0x70, 0x2d, 0x5c, 0x72, 0x67, 0xf7, 0x60, 0x53, 0x46,
+	0xc7, 0xf8, 0x2c, 0x17, 0x47, 0xd5, 0x0a, 0x1d, 0x5a, 0xe1, 0x9a, 0x5e, 0xe1, 0xc0, 0xb6, 0x0e,
+	0xd7, 0xc2, 0xe5, 0x00, 0xf6, 0x1c, 0xb6, 0xca, 0x9c, 0xee, 0x8b, 0xe9, 0x88, 0xab, 0xc3, 0x2b,
+	0x17, 0xea, 0xd2, 0x42, 0x37, 0xac, 0x2a, 0x6c, 0x97, 0xe1, 0x5a, 0xb8, 0x32, 0x94, 0xf5, 0xc1,
+	0x95, 0xf3, 0x41, 0x7b, 0xc7, 0xb9, 0xd5, 0x0a, 0x5d, 0x39, 0xbf, 0xb7, 0x0e, 0xad, 0x93, 0x28,
+	0x99, 0x61, 0xf0, 0xbb, 0x03, 0x60, 0xa4, 0xff, 0x3e, 0x74, 0x13, 0x3c, 0x92, 0x7b, 0x45, 0x81,
+	0x52, 0xf7, 0x68, 0x43, 0xef, 0x17, 0x29, 0x5d, 0x58, 0x9b, 0xd9, 0x6d, 0x80, 0x9c, 0x8f, 0x27,
+	0xda, 0xd9, 0x5d, 0xe1, 0x6c, 0xd8, 0xd9, 0x16, 0xb4, 0xb2, 0x9c, 0xc7, 0x48, 0xbd, 0xf0, 0xc2,
+	0x52, 0x60, 0xd7, 0xa0, 0x1d, 0xa5, 0x62, 0x36, 0x95, 0x03, 0x9f, 0xd4, 0x5a, 0x52, 0xf9, 0x8a,
+	0x6c, 0xd0, 0x2a, 0xf3, 0x15, 0x19, 0xdb, 0x81, 0x9e, 0xe4, 0x29, 0x3e, 0x9a, 0xee, 0x8b, 0x3c,
+	0x46, 0x5d, 0x88, 0xa9, 0x0a, 0x7e, 0x76, 0xa0, 0x67, 0x00, 0xa2, 0xc1, 0x4a, 0xea, 0x9c, 0xbd,
+	0x15, 0x39, 0xfb, 0x8b, 0x9c, 0xaf, 0x43, 0xa7, 0x48, 0x78, 0x96, 0x45, 0x63, 0xd4, 0x95, 0x2c,
+	0xe4, 0xe0, 0x5d, 0xe8, 0x19, 0x28, 0x64, 0x03, 0x58, 0x17, 0xea, 0xe3, 0xd1, 0x03, 0x4a, 0xd5,
+	0x0b, 0x2b, 0x31, 0x18, 0x43, 0xdf, 0xc6, 0x1c, 0xfb, 0xe8, 0x42, 0x73, 0x64, 0x4d, 0x51, 0x00,
+	0x1b, 0x32, 0xe7, 0xe3, 0x31, 0xe6, 0xcf, 0xa8, 0x05, 0x2e, 0xed, 0x61, 0xe9, 0x82, 0x09, 0x6c,
+	0x2e, 0x41, 0xb3, 0xa9, 0x9d, 0x3e, 0x84, 0xad, 0x55, 0xd8, 0x3d, 0xe7, 0x10, 0xfe, 0x72, 0xa0,
+	0x7f, 0x51, 0x67, 0x0d, 0x75, 0xb7, 0x82, 0xfa, 0x52, 0x15, 0xde, 0x65, 0xaa, 0xf0, 0x5f, 0xad,
+	0x42, 0xa1, 0xa0, 0x90, 0x91, 0x9c, 0x15, 0xba, 0xb7, 0x5a, 0x62, 0x0c, 0xfc, 0x68, 0x34, 0xca,
+	0x09, 0xa2, 0xdd, 0x90, 0xbe, 0xd9, 0xdb, 0x00, 0xb3, 0x6c, 0x14, 0x49, 0x3c, 0xe0, 0x29, 0x12,
+	0xa3, 0x78, 0xa1, 0xa1, 0x51, 0xb3, 0xc1, 0xa7, 0x23, 0x3c, 0x25, 0xaa, 0xf0, 0xc2, 0x52, 0x08,
+	0x5e, 0x02, 0xb3, 0x8b, 0x7e, 0xc2, 0x0b, 0xc9, 0xde, 0x03, 0x3f, 0xe1, 0x85, 0x82, 0xb4, 0x67,
+	0xf0, 0x92, 0xed, 0x18, 0x92, 0x8b, 0xda, 0x36, 0xcb, 0x79, 0x1a, 0xe5, 0xf3, 0xc7, 0x58, 0x9e,
+	0x48, 0x37, 0x34, 0x34, 0xc1, 0x57, 0xb0, 0x1d, 0x62, 0x8c, 0x3c, 0x93, 0x4b, 0x87, 0xfb, 0x01,
+	0xb4, 0x84, 0xd1, 0xf3, 0x33, 0x36, 0x29, 0x7d, 0xea, 0xe4, 0x5d, 0x33, 0xf9, 0x4f, 0xa0, 0x15,
+	0x55, 0xd3, 0x82, 0xa7, 0x18, 0xeb, 0xc5, 0xba, 0xa1, 0x96, 0x94, 0xbe, 0x98, 0xa7, 0x87, 0x22,
+	0xd1, 0x89, 0x69, 0x29, 0xf8, 0xd3, 0x85, 0xd6, 0xeb, 0x5a, 0x6c, 0x5f, 0x25, 0xee, 0xa5, 0xae,
+	0x12, 0xef, 0xa2, 0x57, 0x49, 0x89, 0x27, 0x7f, 0x81, 0xa7, 0xeb, 0xd0, 0x51, 0x25, 0xcc, 0x24,
+	0x8e, 0xa8, 0xf5, 0x5e, 0xb8, 0x90, 0xd9, 0x0d, 0xe8, 0xee, 0xbd, 0x78, 0xf8, 0xb2, 0x24, 0xba,
+	0x76, 0x69, 0xdc, 0x7b, 0xf1, 0xb0, 0x44, 0xcc, 0x00, 0xd6, 0x0f, 0xa3, 0x24, 0x9a, 0xc6, 0x15,
+	0x04, 0x2a, 0xd1, 0xc0, 0x52, 0x67, 0x25, 0x96, 0xba, 0x67, 0x62, 0x09, 0xce, 0xc6, 0x52, 0xcf,
+	0x68, 0x47, 0xcd, 0xf7, 0xbf, 0x38, 0x70, 0xe5, 0xf9, 0x0c, 0xf3, 0x79, 0x59, 0xf1, 0x03, 0xcc,
+	0xe4, 0xa4, 0x41, 0xae, 0x2c, 0x39, 0xd1, 0x5b, 0x70, 0xa2, 0x0d, 0x49, 0x7f, 0x19, 0x92, 0x2a,
+	0xfb, 0x98, 0xa8, 0xb5, 0x1c, 0xaa, 0x52, 0x08, 0x7e, 0x58, 0x70, 0x7b, 0xd3, 0xf9, 0xfe, 0xab,
+	0x5b, 0x2a, 0xf8, 0x12, 0x36, 0x8d, 0x34, 0x69, 0x5c, 0x6f, 0x5a, 0xe3, 0x6a, 0xc3, 0x8d, 0xbc,
+	0x2e, 0x38, 0xab, 0xbf, 0x39, 0xb0, 0x4d, 0x7d, 0x1b, 0xf2, 0x42, 0x8a, 0x7c, 0x5e, 0x13, 0x42,
+	0x73, 0x87, 0x61, 0xe7, 0xe4, 0x9d, 0xdd, 0x2c, 0xdf, 0x68, 0x16, 0x7b, 0x0b, 0xba, 0x23, 0x9e,
+	0x23, 0x3d, 0xfa, 0xf4, 0xd9, 0xd4, 0x8a, 0xe0, 0x26, 0x00, 0x95, 0xf1, 0x3a, 0xca, 0xff, 0xce,
+	0x81, 0x7e, 0xed, 0x48, 0x85, 0xd6, 0x53, 0xe2, 0x58, 0x53, 0x32, 0x80, 0x75, 0x35, 0x19, 0x58,
+	0x14, 0xfa, 0xdc, 0x2a, 0xb1, 0x91, 0x02, 0x9e, 0x42, 0xb7, 0x4e, 0x69, 0xc7, 0xea, 0x6e, 0x75,
+	0x92, 0xff, 0x84, 0x83, 0xbf, 0x77, 0x60, 0x53, 0x93, 0x70, 0xf5, 0x5e, 0x66, 0x81, 0x4d, 0xbf,
+	0xf6, 0xb2, 0xa5, 0x89, 0xed, 0x2a, 0x36, 0x93, 0xf1, 0x84, 0x94, 0xaa, 0xf0, 0x57, 0x13, 0x30,
+	0x1d, 0x6a, 0x5a, 0xf0, 0x0c, 0x5a, 0x60, 0xef, 0x80, 0x7f, 0x84, 0x58, 0x0c, 0x7c, 0x0a, 0xdf,
+	0x5c, 0xa0, 0x53, 0xc6, 0x93, 0x7d, 0xc4, 0x90, 0x8c, 0xc1, 0xaf, 0x0e, 0x74, 0x2a, 0xd5, 0x39,
+	0xa4, 0x7c, 0x1b, 0x40, 0x3d, 0x64, 0xf3, 0x73, 0xb0, 0x55, 0xdb, 0x15, 0x8b, 0x92, 0xb4, 0x8f,
+	0xd5, 0xac, 0x2d, 0x64, 0xb5, 0x52, 0x5a, 0xaf, 0xe4, 0xaf, 0x5a, 0x29, 0xb5, 0x56, 0x4a, 0xab,
+	0x95, 0x34, 0x1f, 0x57, 0x72, 0x90, 0xc2, 0x55, 0x02, 0xd1, 0x3e, 0xe2, 0x7d, 0x91, 0x24, 0x18,
+	0x2b, 0x92, 0x6e, 0x6c, 0x60, 0x82, 0x9f, 0x1c, 0xd8, 0xf8, 0x6f, 0xb6, 0x52, 0xb8, 0xa2, 0x50,
+	0xf3, 0x21, 0x6a, 0x68, 0xd4, 0x83, 0xb9, 0xf4, 0x36, 0x79, 0xcb, 0x54, 0x05, 0xdf, 0xb8, 0xd0,
+	0x7a, 0x9c, 0xf0, 0x29, 0x36, 0xfb, 0x54, 0xce, 0x30, 0xe7, 0x62, 0xa4, 0x87, 0x4f, 0x4b, 0xea,
+	0x62, 0x53, 0x6f, 0x77, 0x9d, 0x16, 0x7d, 0x2b, 0x9d, 0xc8, 0x70, 0xaa, 0x7b, 0x48, 0xdf, 0x4a,
+	0x37, 0xe1, 0xe3, 0x89, 0xbe, 0x4a, 0xe9, 0x9b, 0x5d, 0x01, 0x2f, 0x11, 0x5f, 0xeb, 0x2b, 0x54,
+	0x7d, 0xd2, 0x18, 0x27, 0xa2, 0xc0, 0xea, 0xf9, 0x44, 0x82, 0xda, 0xfb, 0x44, 0x24, 0xb3, 0x14,
+	0xe9, 0xfa, 0xf4, 0x42, 0x2d, 0xd5, 0x43, 0x0f, 0xda, 0x9b, 0x4e, 0xe3, 0x0f, 0x47, 0x13, 0xd3,
+	0xff, 0x75, 0x24, 0x97, 0xba, 0x19, 0x6d, 0xae, 0x6a, 0xaf, 0xe0, 0x2a, 0x2a, 0xe7, 0x1c, 0xae,
+	0x22, 0xfb, 0x05, 0xb9, 0x6a, 0x0c, 0x3d, 0x3a, 0xa2, 0x03, 0x1e, 0x1f, 0x37, 0xf9, 0x0b, 0x2b,
+	0xf8, 0xd6, 0x85, 0x76, 0xd3, 0x9b, 0xa8, 0xa3, 0x2b, 0x64, 0x94, 0x4b, 0x7a, 0x47, 0x95, 0x03,
+	0x54, 0x2b, 0xde, 0x3c, 0x84, 0xde, 0x01, 0xf5, 0xee, 0x2c, 0x6f, 0x88, 0xc3, 0x36, 0xfd, 0xf1,
+	0x72, 0xf7, 0xef, 0x01, 0x00, 0x9c, 0x42, 0x1b, 0x01, 0x8a, 0x11, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
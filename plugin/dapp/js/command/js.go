@@ -30,6 +30,7 @@ func JavaScriptCmd() *cobra.Command {
 		JavaScriptCreateCmd(),
 		JavaScriptCallCmd(),
 		JavaScriptQueryCmd(),
+		JavaScriptLogsCmd(),
 	)
 	return cmd
 }
@@ -159,3 +160,50 @@ func queryJavaScript(cmd *cobra.Command, args []string) {
 	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.Query", params, rep)
 	ctx.Run()
 }
+
+//JavaScriptLogsCmd :
+func JavaScriptLogsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: "list java script contract logs by block range",
+		Run:   listJavaScriptLogs,
+	}
+	listJavaScriptLogsFlags(cmd)
+	return cmd
+}
+
+func listJavaScriptLogsFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("name", "n", "", "java script contract name")
+	cmd.MarkFlagRequired("name")
+	cmd.Flags().Int32P("type", "t", 0, "log type, 0 for all types")
+	cmd.Flags().Int64P("start", "s", 0, "start height")
+	cmd.Flags().Int64P("end", "e", 0, "end height, 0 for no limit")
+	cmd.Flags().Int32P("count", "c", 20, "max count of logs")
+	cmd.Flags().StringP("primary", "p", "", "primary key returned by last page")
+}
+
+func listJavaScriptLogs(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	name, _ := cmd.Flags().GetString("name")
+	ty, _ := cmd.Flags().GetInt32("type")
+	start, _ := cmd.Flags().GetInt64("start")
+	end, _ := cmd.Flags().GetInt64("end")
+	count, _ := cmd.Flags().GetInt32("count")
+	primary, _ := cmd.Flags().GetString("primary")
+	var params rpctypes.Query4Jrpc
+	req := &jsproto.ReqJsLogs{
+		Name:        name,
+		Ty:          ty,
+		StartHeight: start,
+		EndHeight:   end,
+		Count:       count,
+		PrimaryKey:  primary,
+	}
+
+	params.Execer = jsty.JsX
+	params.FuncName = "ListLogs"
+	params.Payload = types.MustPBToJSON(req)
+	rep := &jsproto.JsLogRecords{}
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.Query", params, rep)
+	ctx.Run()
+}
@@ -6,7 +6,14 @@ import (
 )
 
 func (c *js) ExecLocal_Create(payload *jsproto.Create, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
-	return &types.LocalDBSet{}, nil
+	kvs := c.logKV(payload.Name, tx, receiptData, index)
+	if len(kvs) == 0 {
+		return &types.LocalDBSet{}, nil
+	}
+	execer := c.userExecName(payload.Name, true)
+	r := &types.LocalDBSet{}
+	r.KV = c.AddRollbackKV(tx, []byte(execer), kvs)
+	return r, nil
 }
 
 func (c *js) ExecLocal_Call(payload *jsproto.Call, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
@@ -20,6 +27,8 @@ func (c *js) ExecLocal_Call(payload *jsproto.Call, tx *types.Transaction, receip
 	if err != nil {
 		return nil, err
 	}
+	//合约日志的索引
+	kvs = append(kvs, c.logKV(payload.Name, tx, receiptData, index)...)
 	r := &types.LocalDBSet{}
 	r.KV = c.AddRollbackKV(tx, []byte(execer), kvs)
 	return r, nil
@@ -0,0 +1,223 @@
+package executor
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/robertkrimen/otto/ast"
+	"github.com/robertkrimen/otto/file"
+	"github.com/robertkrimen/otto/parser"
+	"github.com/robertkrimen/otto/token"
+)
+
+//meterFuncName 插桩代码中计量内存的函数名
+const meterFuncName = "__jsvm_meter__"
+
+var errUnbalanced = errors.New("unbalanced parentheses")
+
+//instrument 把合约代码中的字符串拼接(+ 和 +=)以及函数调用的结果包在计量函数里面，
+//虚拟机内部字符串和数组的增长也要计入内存，比如 s += s 这样每一步都翻倍的代码。
+//只插入计量函数的调用，代码的执行结果不变。
+func instrument(code string) (string, error) {
+	program, err := parser.ParseFile(nil, "", code, 0)
+	if err != nil {
+		return "", err
+	}
+	v := &instrumenter{}
+	ast.Walk(v, program)
+	parens, err := matchParens(code, v.regexps)
+	if err != nil {
+		return "", err
+	}
+	positions := make([]int, 0, len(parens))
+	for pos := range parens {
+		positions = append(positions, pos)
+	}
+	sort.Ints(positions)
+	type insertion struct {
+		pos  int
+		text string
+		//同一个位置，先关闭里面的表达式，再打开外面的表达式
+		order int
+	}
+	var inserts []insertion
+	for _, n := range v.nodes {
+		start, end := balance(exprStart(n), exprEnd(n), parens, positions)
+		width := end - start
+		inserts = append(inserts, insertion{pos: start, text: meterFuncName + "(", order: -width})
+		inserts = append(inserts, insertion{pos: end, text: ")", order: width - 2*len(code) - 2})
+	}
+	sort.SliceStable(inserts, func(i, j int) bool {
+		if inserts[i].pos != inserts[j].pos {
+			return inserts[i].pos < inserts[j].pos
+		}
+		return inserts[i].order < inserts[j].order
+	})
+	var b strings.Builder
+	last := 0
+	for _, ins := range inserts {
+		b.WriteString(code[last:ins.pos])
+		b.WriteString(ins.text)
+		last = ins.pos
+	}
+	b.WriteString(code[last:])
+	return b.String(), nil
+}
+
+//instrumenter 收集需要计量的表达式和正则表达式的位置
+type instrumenter struct {
+	nodes   []ast.Expression
+	regexps [][2]int
+}
+
+func (v *instrumenter) Enter(n ast.Node) ast.Visitor {
+	switch n := n.(type) {
+	case *ast.BinaryExpression:
+		if n.Operator == token.PLUS {
+			v.nodes = append(v.nodes, n)
+		}
+	case *ast.AssignExpression:
+		if n.Operator == token.PLUS {
+			v.nodes = append(v.nodes, n)
+		}
+	case *ast.CallExpression:
+		v.nodes = append(v.nodes, n)
+	case *ast.RegExpLiteral:
+		v.regexps = append(v.regexps, [2]int{offset(n.Idx), offset(n.Idx) + len(n.Literal)})
+	}
+	return v
+}
+
+func (v *instrumenter) Exit(n ast.Node) {}
+
+//offset 没有 FileSet 的时候, 位置从 1 开始
+func offset(idx file.Idx) int {
+	return int(idx) - 1
+}
+
+//exprStart 表达式在代码中开始的位置，不包括外面的括号
+func exprStart(n ast.Expression) int {
+	switch n := n.(type) {
+	case *ast.AssignExpression:
+		return exprStart(n.Left)
+	case *ast.BinaryExpression:
+		return exprStart(n.Left)
+	case *ast.CallExpression:
+		return exprStart(n.Callee)
+	case *ast.BracketExpression:
+		return exprStart(n.Left)
+	case *ast.DotExpression:
+		return exprStart(n.Left)
+	case *ast.ConditionalExpression:
+		return exprStart(n.Test)
+	case *ast.SequenceExpression:
+		return exprStart(n.Sequence[0])
+	case *ast.UnaryExpression:
+		//后缀运算的 Idx 是运算符的位置
+		if n.Postfix {
+			return exprStart(n.Operand)
+		}
+	}
+	return offset(n.Idx0())
+}
+
+//exprEnd 表达式在代码中结束的位置，不包括外面的括号。
+//otto 的 Idx1 对数组，对象，条件表达式，逗号表达式和没有参数的 new 不准确
+func exprEnd(n ast.Expression) int {
+	switch n := n.(type) {
+	case *ast.AssignExpression:
+		return exprEnd(n.Right)
+	case *ast.BinaryExpression:
+		return exprEnd(n.Right)
+	case *ast.ConditionalExpression:
+		return exprEnd(n.Alternate)
+	case *ast.SequenceExpression:
+		return exprEnd(n.Sequence[len(n.Sequence)-1])
+	case *ast.ArrayLiteral:
+		return offset(n.RightBracket) + 1
+	case *ast.ObjectLiteral:
+		return offset(n.RightBrace) + 1
+	case *ast.UnaryExpression:
+		if n.Postfix {
+			return offset(n.Idx) + 2
+		}
+		return exprEnd(n.Operand)
+	case *ast.NewExpression:
+		if n.RightParenthesis == 0 {
+			return exprEnd(n.Callee)
+		}
+	}
+	return offset(n.Idx1())
+}
+
+//balance 把表达式的范围扩大到包括被截断的括号，保证插入的计量函数和原来的括号正确嵌套。
+//positions 是所有括号按照位置排序的列表
+func balance(start, end int, parens map[int]int, positions []int) (int, int) {
+	for changed := true; changed; {
+		changed = false
+		for i := sort.SearchInts(positions, start); i < len(positions) && positions[i] < end; i++ {
+			pos := positions[i]
+			match := parens[pos]
+			if match >= end {
+				end = match + 1
+				changed = true
+			} else if match < start {
+				start = match
+				changed = true
+			}
+		}
+	}
+	return start, end
+}
+
+//matchParens 找到代码中所有相互匹配的小括号，跳过字符串，正则表达式和注释。
+//返回的 map 中，左括号映射到右括号，右括号也映射到左括号
+func matchParens(code string, regexps [][2]int) (map[int]int, error) {
+	regexpEnd := make(map[int]int, len(regexps))
+	for _, r := range regexps {
+		regexpEnd[r[0]] = r[1]
+	}
+	parens := make(map[int]int)
+	var stack []int
+	for i := 0; i < len(code); i++ {
+		if end, ok := regexpEnd[i]; ok {
+			i = end - 1
+			continue
+		}
+		switch c := code[i]; c {
+		case '\'', '"':
+			for i++; i < len(code) && code[i] != c; i++ {
+				if code[i] == '\\' {
+					i++
+				}
+			}
+		case '/':
+			if i+1 < len(code) && code[i+1] == '/' {
+				for i < len(code) && code[i] != '\n' {
+					i++
+				}
+			} else if i+1 < len(code) && code[i+1] == '*' {
+				end := strings.Index(code[i+2:], "*/")
+				if end < 0 {
+					return nil, errUnbalanced
+				}
+				i += end + 3
+			}
+		case '(':
+			stack = append(stack, i)
+		case ')':
+			if len(stack) == 0 {
+				return nil, errUnbalanced
+			}
+			open := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			parens[open] = i
+			parens[i] = open
+		}
+	}
+	if len(stack) != 0 {
+		return nil, errUnbalanced
+	}
+	return parens, nil
+}
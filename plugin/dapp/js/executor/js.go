@@ -55,6 +55,7 @@ type js struct {
 	prefix            []byte
 	globalTableHandle sync.Map
 	globalHanldeID    int64
+	meter             *meter
 }
 
 func newjs() drivers.Driver {
@@ -110,6 +111,13 @@ func (u *js) callVM(prefix string, payload *jsproto.Call, tx *types.Transaction,
 	if err != nil {
 		return nil, err
 	}
+	u.meter = nil
+	if u.isMetering(tx) {
+		u.meter = newMeter(txSteps(tx))
+		if err := u.meter.alloc(len(payload.Args)); err != nil {
+			return nil, err
+		}
+	}
 	vm, err := u.createVM(payload.Name, tx, index)
 	if err != nil {
 		return nil, err
@@ -122,7 +130,13 @@ func (u *js) callVM(prefix string, payload *jsproto.Call, tx *types.Transaction,
 	}
 	vm.Set("args", payload.Args)
 	callfunc := "callcode(context, f, args, loglist)"
-	jsvalue, err := vm.Run(callfunc)
+	var jsvalue otto.Value
+	if u.meter != nil {
+		u.meter.attach(vm)
+		jsvalue, err = u.meter.run(vm, callfunc)
+	} else {
+		jsvalue, err = vm.Run(callfunc)
+	}
 	//除非你知道怎么做，不要返回这样的操作，这会引起整个区块执行失败，从而引起严重的安全问题。
 	//要保证不能人工的创造这样的条件，也就是调用接口的输入，不能用户可以任意修改的。
 	if u.GetExecutorAPI().IsErr() {
@@ -136,6 +150,9 @@ func (u *js) callVM(prefix string, payload *jsproto.Call, tx *types.Transaction,
 		if err != nil {
 			return nil, err
 		}
+		if err := u.meter.alloc(len(s)); err != nil {
+			return nil, err
+		}
 		return newObject(vm).setValue("result", s).object(), nil
 	}
 	if !jsvalue.IsObject() {
@@ -144,6 +161,15 @@ func (u *js) callVM(prefix string, payload *jsproto.Call, tx *types.Transaction,
 	return jsvalue.Object(), nil
 }
 
+//isMetering 分叉之后执行交易需要计量，查询总是需要计量
+func (u *js) isMetering(tx *types.Transaction) bool {
+	if tx == nil {
+		return true
+	}
+	cfg := u.GetAPI().GetConfig()
+	return cfg.IsDappFork(u.GetHeight(), ptypes.JsX, ptypes.ForkJsMetering)
+}
+
 type jslogInfo struct {
 	Log    string `json:"log"`
 	Ty     int32  `json:"ty"`
@@ -297,7 +323,12 @@ func (u *js) createVM(name string, tx *types.Transaction, index int) (*otto.Otto
 		return nil, err
 	}
 	var vm *otto.Otto
-	if vmitem, ok := codecache.Get(name); ok {
+	//计量的时候执行插桩以后的代码，和不计量的代码分开 cache
+	cachekey := name
+	if u.meter != nil {
+		cachekey = meterFuncName + name
+	}
+	if vmitem, ok := codecache.Get(cachekey); ok {
 		vm = vmitem.(*otto.Otto).Copy()
 	} else {
 		code, err := u.GetStateDB().Get(calcCodeKey(name))
@@ -306,10 +337,29 @@ func (u *js) createVM(name string, tx *types.Transaction, index int) (*otto.Otto
 		}
 		//cache 合约代码部分，不会cache 具体执行
 		cachevm := basevm.Copy()
-		cachevm.Run(code)
-		codecache.Add(name, cachevm)
+		if u.meter != nil {
+			//代码有语法错误的时候执行原来的代码，和不计量的时候一样报错
+			if src, err := instrument(string(code)); err == nil {
+				code = []byte(src)
+			}
+			//加载代码的步数和具体的交易无关，保证 cache 是否命中不影响执行的结果
+			loader := newMeter(ptypes.JsMaxSteps)
+			loader.attach(cachevm)
+			loader.register(cachevm)
+			_, err = loader.run(cachevm, code)
+			loader.detach(cachevm)
+			if err == ptypes.ErrJsOutOfGas || err == ptypes.ErrJsOutOfMemory {
+				return nil, err
+			}
+		} else {
+			cachevm.Run(code)
+		}
+		codecache.Add(cachekey, cachevm)
 		vm = cachevm.Copy()
 	}
+	if u.meter != nil {
+		u.meter.register(vm)
+	}
 	vm.Set("context", string(data))
 	u.statedbFunc(vm, name)
 	u.localdbFunc(vm, name)
@@ -432,12 +482,14 @@ func accountReturn(vm *otto.Otto, acc *types.Account) otto.Value {
 
 func (u *js) getstatedb(key string) (value string, err error) {
 	s, err := u.GetStateDB().Get([]byte(key))
+	u.meter.useMemory(len(key) + len(s))
 	value = string(s)
 	return value, err
 }
 
 func (u *js) getlocaldb(key string) (value string, err error) {
 	s, err := u.GetLocalDB().Get([]byte(key))
+	u.meter.useMemory(len(key) + len(s))
 	value = string(s)
 	return value, err
 }
@@ -445,6 +497,7 @@ func (u *js) getlocaldb(key string) (value string, err error) {
 func (u *js) listdb(prefix, key string, count, direction int32) (value []string, err error) {
 	values, err := u.GetLocalDB().List([]byte(prefix), []byte(key), count, direction)
 	for _, v := range values {
+		u.meter.useMemory(len(v))
 		value = append(value, string(v))
 	}
 	return value, err
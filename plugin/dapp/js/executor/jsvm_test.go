@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/33cn/chain33/client/mocks"
+	"github.com/33cn/chain33/common"
 	"github.com/33cn/chain33/common/db"
 	"github.com/33cn/chain33/rpc/grpcclient"
 	"github.com/33cn/chain33/types"
@@ -227,3 +228,223 @@ func printMemUsage() {
 func bToMb(b uint64) uint64 {
 	return b / 1024 / 1024
 }
+
+var metercode = `
+function Init(context) {
+    this.kvc = new kvcreator("init")
+    this.context = context
+    return this.kvc.receipt()
+}
+
+Exec.prototype.loop = function(args) {
+    while (true) {}
+}
+
+Exec.prototype.count = function(args) {
+    var n = 0
+    for (var i = 0; i < args.n; i++) {
+        n += i
+    }
+    this.kvc.add("n", n)
+    return this.kvc.receipt()
+}
+
+Exec.prototype.grow = function(args) {
+    var s = "x"
+    for (var i = 0; i < 40; i++) {
+        s += s
+    }
+    this.kvc.add("n", s.length)
+    return this.kvc.receipt()
+}
+
+Exec.prototype.growarray = function(args) {
+    var a = [0]
+    for (var i = 0; i < 40; i++) {
+        a = a.concat(a)
+    }
+    this.kvc.add("n", a.length)
+    return this.kvc.receipt()
+}
+
+Exec.prototype.recurse = function(args) {
+    function f(n) {
+        return f(n + 1)
+    }
+    return f(0)
+}
+
+Query.prototype.big = function(args) {
+    var s = "0123456789abcdef"
+    for (var i = 0; i < args.n; i++) {
+        s = s + s
+    }
+    return s
+}
+`
+
+func TestMetering(t *testing.T) {
+	dir, ldb, kvdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, ldb)
+	e := initExec(ldb, kvdb, jscode, t)
+	c, tx := createCodeTx("meter", metercode)
+	_, err := e.Exec_Create(c, tx, 0)
+	assert.Nil(t, err)
+
+	call, tx := callCodeTx("meter", "loop", "{}")
+	_, err = e.callVM("exec", call, tx, 0, nil)
+	assert.Equal(t, ptypes.ErrJsOutOfGas, err)
+
+	//交易费越高，可以执行的步数越多
+	call, tx = callCodeTx("meter", "count", `{"n":500000}`)
+	_, err = e.callVM("exec", call, tx, 0, nil)
+	assert.Equal(t, ptypes.ErrJsOutOfGas, err)
+	steps := e.meter.steps
+	call, tx = callCodeTx("meter", "count", `{"n":500000}`)
+	tx.Fee = 1000000
+	_, err = e.callVM("exec", call, tx, 0, nil)
+	assert.Nil(t, err)
+	assert.True(t, e.meter.steps > steps)
+	assert.True(t, e.meter.steps <= txSteps(tx))
+	//同样的输入执行的步数相同
+	used := e.meter.steps
+	_, err = e.callVM("exec", call, tx, 0, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, used, e.meter.steps)
+
+	call, tx = callCodeTx("meter", "recurse", "{}")
+	_, err = e.callVM("exec", call, tx, 0, nil)
+	assert.NotNil(t, err)
+	assert.True(t, strings.Contains(err.Error(), "Maximum call stack size exceeded"))
+
+	//虚拟机内部字符串和数组的增长计入内存
+	call, tx = callCodeTx("meter", "grow", "{}")
+	_, err = e.callVM("exec", call, tx, 0, nil)
+	assert.Equal(t, ptypes.ErrJsOutOfMemory, err)
+	call, tx = callCodeTx("meter", "growarray", "{}")
+	_, err = e.callVM("exec", call, tx, 0, nil)
+	assert.Equal(t, ptypes.ErrJsOutOfMemory, err)
+
+	call, _ = callCodeTx("meter", "big", `{"n":22}`)
+	_, err = e.callVM("query", call, nil, 0, nil)
+	assert.Equal(t, ptypes.ErrJsOutOfMemory, err)
+	call, _ = callCodeTx("meter", "big", `{"n":4}`)
+	_, err = e.callVM("query", call, nil, 0, nil)
+	assert.Nil(t, err)
+}
+
+func TestInstrument(t *testing.T) {
+	codes := []string{
+		`var a = 1, b = "x"; (a) + b`,
+		`var a = 1, b = "x"; a + (b)`,
+		`var a = 1, b = "x"; ((a + b) + ((b) + a))`,
+		`[1, 2].concat([3]).length + "" + [1, 2].join("-")`,
+		`var o = {f: function(x) { return x + 1 }}; o.f(1) + o["f"](2)`,
+		`var c = true ? "a" : "b" + "c"; c + (false ? 1 : 2)`,
+		`var i = 0; i++ + ++i + (i)++ + (i ++)`,
+		`"(" + ')' + /\(/.source + "/*" // (`,
+		`/* ( */ String(1 + 2) + "中文" // 注释 (`,
+		`var s = "a"; for (var k = 0; k < 3; k++) { s += s }; s`,
+		`new Date(0).getTime() + new Object + 1`,
+		`(function(x) { return x + x })("y")`,
+		`var t = (1, 2) + 3; t + [(4), 5] + {a: 1}.a`,
+	}
+	vm := otto.New()
+	vm.Set(meterFuncName, func(call otto.FunctionCall) otto.Value {
+		return call.Argument(0)
+	})
+	for _, code := range codes {
+		src, err := instrument(code)
+		assert.Nil(t, err, code)
+		assert.True(t, strings.Contains(src, meterFuncName), src)
+		expect, err := vm.Run(code)
+		assert.Nil(t, err, code)
+		value, err := vm.Run(src)
+		assert.Nil(t, err, src)
+		assert.Equal(t, expect.String(), value.String(), src)
+	}
+	_, err := instrument("function (")
+	assert.NotNil(t, err)
+}
+
+func TestTxSteps(t *testing.T) {
+	assert.Equal(t, int64(ptypes.JsQuerySteps), txSteps(nil))
+	assert.Equal(t, int64(ptypes.JsBaseSteps), txSteps(&types.Transaction{}))
+	assert.Equal(t, int64(ptypes.JsBaseSteps+100*ptypes.JsStepsPerFee), txSteps(&types.Transaction{Fee: 100}))
+	assert.Equal(t, int64(ptypes.JsMaxSteps), txSteps(&types.Transaction{Fee: math.MaxInt64}))
+}
+
+func TestListLogs(t *testing.T) {
+	dir, ldb, kvdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, ldb)
+	e := initExec(ldb, kvdb, jscode, t)
+	var txs []*types.Transaction
+	for height := int64(1); height <= 5; height++ {
+		if height == 3 {
+			continue
+		}
+		e.SetEnv(height, time.Now().Unix(), 1)
+		call, tx := callCodeTx("test", "hello", `{"hello":"world"}`)
+		tx.Nonce = height
+		receipt, err := e.Exec_Call(call, tx, 0)
+		assert.Nil(t, err)
+		util.SaveKVList(ldb, receipt.KV)
+		receipt.Logs = append(receipt.Logs, &types.ReceiptLog{Ty: 100, Log: []byte("proto")})
+		kvset, err := e.ExecLocal_Call(call, tx, &types.ReceiptData{Ty: types.ExecOk, Logs: receipt.Logs}, 0)
+		assert.Nil(t, err)
+		util.SaveKVList(ldb, kvset.KV)
+		txs = append(txs, tx)
+	}
+	//每个高度 3 条日志, 高度 3 没有日志
+	reply, err := e.Query_ListLogs(&jsproto.ReqJsLogs{Name: "test", StartHeight: 2, EndHeight: 4})
+	assert.Nil(t, err)
+	logs := reply.(*jsproto.JsLogRecords)
+	assert.Equal(t, 6, len(logs.Logs))
+	assert.Equal(t, "", logs.PrimaryKey)
+	assert.Equal(t, int64(2), logs.Logs[0].Height)
+	assert.Equal(t, "json", logs.Logs[0].Format)
+	assert.Equal(t, `{"key1":"value1"}`, logs.Logs[0].Data)
+	assert.Equal(t, common.ToHex(txs[1].Hash()), logs.Logs[0].TxHash)
+	assert.Equal(t, "proto", logs.Logs[2].Format)
+	assert.Equal(t, common.ToHex([]byte("proto")), logs.Logs[2].Data)
+	assert.Equal(t, int64(4), logs.Logs[5].Height)
+
+	//起始高度没有日志
+	reply, err = e.Query_ListLogs(&jsproto.ReqJsLogs{Name: "test", StartHeight: 3})
+	assert.Nil(t, err)
+	logs = reply.(*jsproto.JsLogRecords)
+	assert.Equal(t, 6, len(logs.Logs))
+	assert.Equal(t, int64(4), logs.Logs[0].Height)
+
+	//分页
+	var all []*jsproto.JsLogRecord
+	req := &jsproto.ReqJsLogs{Name: "test", Count: 4}
+	for {
+		reply, err = e.Query_ListLogs(req)
+		assert.Nil(t, err)
+		logs = reply.(*jsproto.JsLogRecords)
+		all = append(all, logs.Logs...)
+		if logs.PrimaryKey == "" {
+			break
+		}
+		req.PrimaryKey = logs.PrimaryKey
+	}
+	assert.Equal(t, 12, len(all))
+	assert.Equal(t, int64(1), all[0].Height)
+	assert.Equal(t, int32(2), all[11].LogIndex)
+
+	//按类型查询
+	reply, err = e.Query_ListLogs(&jsproto.ReqJsLogs{Name: "test", Ty: 100, StartHeight: 2})
+	assert.Nil(t, err)
+	logs = reply.(*jsproto.JsLogRecords)
+	assert.Equal(t, 3, len(logs.Logs))
+	for _, log := range logs.Logs {
+		assert.Equal(t, int32(100), log.Ty)
+	}
+	reply, err = e.Query_ListLogs(&jsproto.ReqJsLogs{Name: "test", Ty: ptypes.TyLogJs})
+	assert.Nil(t, err)
+	assert.Equal(t, 8, len(reply.(*jsproto.JsLogRecords).Logs))
+
+	_, err = e.Query_ListLogs(&jsproto.ReqJsLogs{Name: "test", StartHeight: 4, EndHeight: 2})
+	assert.Equal(t, types.ErrInvalidParam, err)
+}
@@ -0,0 +1,152 @@
+package executor
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+	ptypes "github.com/33cn/plugin/plugin/dapp/js/types"
+	"github.com/33cn/plugin/plugin/dapp/js/types/jsproto"
+)
+
+//一次查询最多返回的日志数量
+const maxLogCount = 100
+
+//日志索引的 key:
+//所有类型: LODB-jsvm-log-{name}-{height}-{index}-{logindex}
+//指定类型: LODB-jsvm-logty-{name}-{ty}-{height}-{index}-{logindex}
+//每个有日志的高度还有一个不带序号的标记 key, 标记排在这个高度所有日志的前面,
+//ListHelper 从 seek 到的位置开始会跳过第一个 key, 按高度查询的时候跳过的总是标记而不是日志
+func calcLogPrefix(name string, ty int32) string {
+	if ty == 0 {
+		return "LODB-" + ptypes.JsX + "-log-" + name + "-"
+	}
+	return fmt.Sprintf("LODB-%s-logty-%s-%d-", ptypes.JsX, name, ty)
+}
+
+func calcLogHeightKey(name string, ty int32, height int64) []byte {
+	return []byte(fmt.Sprintf("%s%018d", calcLogPrefix(name, ty), height))
+}
+
+func calcLogKey(name string, ty int32, height, index int64, logIndex int32) []byte {
+	return []byte(fmt.Sprintf("%s%018d-%010d-%05d", calcLogPrefix(name, ty), height, index, logIndex))
+}
+
+//logKV 为交易的日志生成索引
+func (u *js) logKV(name string, tx *types.Transaction, receiptData *types.ReceiptData, index int) []*types.KeyValue {
+	if receiptData == nil || receiptData.Ty != types.ExecOk || len(receiptData.Logs) == 0 {
+		return nil
+	}
+	height := u.GetHeight()
+	txhash := common.ToHex(tx.Hash())
+	var kvs []*types.KeyValue
+	tys := make(map[int32]bool)
+	for i, item := range receiptData.Logs {
+		record := decodeLog(item)
+		record.Name = name
+		record.Height = height
+		record.Index = int64(index)
+		record.LogIndex = int32(i)
+		record.TxHash = txhash
+		value := types.Encode(record)
+		if i == 0 {
+			marker := &jsproto.JsLogRecord{Name: name, Height: height}
+			kvs = append(kvs, &types.KeyValue{Key: calcLogHeightKey(name, 0, height), Value: types.Encode(marker)})
+		}
+		kvs = append(kvs, &types.KeyValue{Key: calcLogKey(name, 0, height, record.Index, record.LogIndex), Value: value})
+		if item.Ty == 0 {
+			continue
+		}
+		if !tys[item.Ty] {
+			tys[item.Ty] = true
+			marker := &jsproto.JsLogRecord{Name: name, Ty: item.Ty, Height: height}
+			kvs = append(kvs, &types.KeyValue{Key: calcLogHeightKey(name, item.Ty, height), Value: types.Encode(marker)})
+		}
+		kvs = append(kvs, &types.KeyValue{Key: calcLogKey(name, item.Ty, height, record.Index, record.LogIndex), Value: value})
+	}
+	return kvs
+}
+
+//decodeLog json 格式的日志解码成 json, 其他的日志用 hex 表示
+func decodeLog(item *types.ReceiptLog) *jsproto.JsLogRecord {
+	record := &jsproto.JsLogRecord{Ty: item.Ty}
+	if item.Ty != ptypes.TyLogJs {
+		record.Format = "proto"
+		record.Data = common.ToHex(item.Log)
+		return record
+	}
+	var jslog jsproto.JsLog
+	err := types.Decode(item.Log, &jslog)
+	if err != nil {
+		record.Format = "proto"
+		record.Data = common.ToHex(item.Log)
+		return record
+	}
+	record.Format = "json"
+	record.Data = jslog.Data
+	var event struct {
+		Type string `json:"__type__"`
+	}
+	if json.Unmarshal([]byte(jslog.Data), &event) == nil {
+		record.Event = event.Type
+	}
+	return record
+}
+
+func (u *js) listLogs(req *jsproto.ReqJsLogs) (*jsproto.JsLogRecords, error) {
+	if req.Name == "" || req.StartHeight < 0 || (req.EndHeight > 0 && req.EndHeight < req.StartHeight) {
+		return nil, types.ErrInvalidParam
+	}
+	count := req.Count
+	if count <= 0 || count > maxLogCount {
+		count = maxLogCount
+	}
+	prefix := []byte(calcLogPrefix(req.Name, req.Ty))
+	key := []byte(req.PrimaryKey)
+	if len(key) == 0 {
+		key = calcLogHeightKey(req.Name, req.Ty, req.StartHeight)
+	}
+	reply := &jsproto.JsLogRecords{}
+	for {
+		values, err := u.GetLocalDB().List(prefix, key, count, db.ListASC)
+		if err == types.ErrNotFound || len(values) == 0 {
+			return reply, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, value := range values {
+			var record jsproto.JsLogRecord
+			err := types.Decode(value, &record)
+			if err != nil {
+				return nil, err
+			}
+			//标记和其他合约的记录也要更新下一次查询的位置
+			ty := int32(0)
+			if req.Ty != 0 {
+				ty = record.Ty
+			}
+			if record.Format == "" {
+				key = calcLogHeightKey(record.Name, ty, record.Height)
+			} else {
+				key = calcLogKey(record.Name, ty, record.Height, record.Index, record.LogIndex)
+			}
+			if record.Name != req.Name || record.Format == "" {
+				continue
+			}
+			if req.EndHeight > 0 && record.Height > req.EndHeight {
+				return reply, nil
+			}
+			reply.Logs = append(reply.Logs, &record)
+			if int32(len(reply.Logs)) == count {
+				reply.PrimaryKey = string(key)
+				return reply, nil
+			}
+		}
+		if int32(len(values)) < count {
+			return reply, nil
+		}
+	}
+}
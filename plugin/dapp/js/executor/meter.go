@@ -0,0 +1,135 @@
+package executor
+
+import (
+	"math"
+
+	"github.com/33cn/chain33/types"
+	ptypes "github.com/33cn/plugin/plugin/dapp/js/types"
+	"github.com/robertkrimen/otto"
+)
+
+//meter 合约执行的计量
+//otto 在执行每一条语句和表达式之前都会检查一次 Interrupt 通道，
+//同样的代码和输入执行的次数是固定的，所以计量的结果在所有节点上都相同。
+type meter struct {
+	steps  int64
+	limit  int64
+	memory int64
+}
+
+//jsArrayElemSize 估算内存的时候数组每个元素占用的字节数, 包括 otto 的 Value 和其中的数据
+const jsArrayElemSize = 64
+
+//meterError 计量超限时中断虚拟机的 panic 类型
+type meterError struct {
+	err error
+}
+
+func newMeter(limit int64) *meter {
+	return &meter{limit: limit}
+}
+
+//txSteps 根据交易费计算交易可以执行的步数
+func txSteps(tx *types.Transaction) int64 {
+	if tx == nil {
+		return ptypes.JsQuerySteps
+	}
+	fee := tx.Fee
+	if fee <= 0 {
+		return ptypes.JsBaseSteps
+	}
+	if fee > (math.MaxInt64-ptypes.JsBaseSteps)/ptypes.JsStepsPerFee {
+		return ptypes.JsMaxSteps
+	}
+	steps := ptypes.JsBaseSteps + fee*ptypes.JsStepsPerFee
+	if steps > ptypes.JsMaxSteps {
+		return ptypes.JsMaxSteps
+	}
+	return steps
+}
+
+//attach 把计量挂到虚拟机上，同时限制调用栈的深度
+func (m *meter) attach(vm *otto.Otto) {
+	vm.Interrupt = make(chan func(), 1)
+	var tick func()
+	tick = func() {
+		m.steps++
+		if m.steps > m.limit {
+			panic(&meterError{err: ptypes.ErrJsOutOfGas})
+		}
+		vm.Interrupt <- tick
+	}
+	vm.Interrupt <- tick
+	vm.SetStackDepthLimit(ptypes.JsMaxStackDepth)
+}
+
+//detach 去掉虚拟机上的计量，cache 中的虚拟机不能带计量
+func (m *meter) detach(vm *otto.Otto) {
+	vm.Interrupt = nil
+	vm.SetStackDepthLimit(0)
+}
+
+//alloc 记录占用的内存
+func (m *meter) alloc(size int) error {
+	if m == nil {
+		return nil
+	}
+	m.memory += int64(size)
+	if m.memory > ptypes.JsMaxMemory {
+		return ptypes.ErrJsOutOfMemory
+	}
+	return nil
+}
+
+//useMemory 在虚拟机执行过程中记录占用的内存，超过限制的时候中断执行
+func (m *meter) useMemory(size int) {
+	if err := m.alloc(size); err != nil {
+		panic(&meterError{err: err})
+	}
+}
+
+//register 注册插桩代码调用的计量函数，字符串按照长度，数组按照元素个数计入内存。
+//计入的是累计分配的内存，不会因为变量不再使用而减少
+func (m *meter) register(vm *otto.Otto) {
+	vm.Set(meterFuncName, func(call otto.FunctionCall) otto.Value {
+		v := call.Argument(0)
+		m.useMemory(valueSize(v))
+		return v
+	})
+}
+
+//valueSize 估算值占用的内存
+func valueSize(v otto.Value) int {
+	if v.IsString() {
+		return len(v.String())
+	}
+	if v.IsObject() && v.Class() == "Array" {
+		length, err := v.Object().Get("length")
+		if err != nil {
+			return 0
+		}
+		n, err := length.ToInteger()
+		if err != nil || n < 0 {
+			return 0
+		}
+		if n > ptypes.JsMaxMemory/jsArrayElemSize {
+			return ptypes.JsMaxMemory + 1
+		}
+		return int(n) * jsArrayElemSize
+	}
+	return 0
+}
+
+//run 执行代码，把计量超限的中断转换成错误返回
+func (m *meter) run(vm *otto.Otto, src interface{}) (value otto.Value, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(*meterError)
+			if !ok {
+				panic(r)
+			}
+			err = e.err
+		}
+	}()
+	return vm.Run(src)
+}
@@ -22,3 +22,8 @@ func (c *js) Query_Query(payload *jsproto.Call) (types.Message, error) {
 	}
 	return &jsproto.QueryResult{Data: str}, nil
 }
+
+//Query_ListLogs 按区块范围分页查询合约的日志
+func (c *js) Query_ListLogs(payload *jsproto.ReqJsLogs) (types.Message, error) {
+	return c.listLogs(payload)
+}
@@ -22,6 +22,25 @@ const (
 // JsCreator 配置项 创建js合约的管理员
 const JsCreator = "js-creator"
 
+// ForkJsMetering 合约执行计量的分叉, 分叉之后限制合约执行的步数, 调用栈深度和内存
+const ForkJsMetering = "ForkJsMetering"
+
+//合约执行计量的参数, 步数指 js 虚拟机执行的语句和表达式的数量
+const (
+	//JsBaseSteps 每笔交易默认可以执行的步数
+	JsBaseSteps = 1000000
+	//JsStepsPerFee 每单位交易费可以增加的步数
+	JsStepsPerFee = 10
+	//JsMaxSteps 单笔交易最多可以执行的步数, 也是加载合约代码可以执行的步数
+	JsMaxSteps = 50000000
+	//JsQuerySteps 查询可以执行的步数
+	JsQuerySteps = 10000000
+	//JsMaxStackDepth 最大的调用栈深度
+	JsMaxStackDepth = 256
+	//JsMaxMemory 参数, 数据库读取, 返回值以及合约中字符串拼接和函数调用结果累计占用的最大字节数
+	JsMaxMemory = 32 * 1024 * 1024
+)
+
 var (
 	typeMap = map[string]int32{
 		"Create": jsActionCreate,
@@ -52,6 +71,10 @@ var (
 	ErrDBType       = errors.New("chain33.js: ErrDBType")
 	// ErrJsCreator
 	ErrJsCreator = errors.New("ErrJsCreator")
+	//ErrJsOutOfGas 执行步数超过限制
+	ErrJsOutOfGas = errors.New("chain33.js: out of gas, execution steps exceeded")
+	//ErrJsOutOfMemory 占用内存超过限制
+	ErrJsOutOfMemory = errors.New("chain33.js: out of memory")
 )
 
 func init() {
@@ -63,6 +86,7 @@ func init() {
 //InitFork ...
 func InitFork(cfg *types.Chain33Config) {
 	cfg.RegisterDappFork(JsX, "Enable", 0)
+	cfg.RegisterDappFork(JsX, ForkJsMetering, types.MaxHeight)
 }
 
 //InitExecutor ...
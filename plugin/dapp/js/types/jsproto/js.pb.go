@@ -291,12 +291,246 @@ func (m *QueryResult) GetData() string {
 	return ""
 }
 
+// 合约日志的索引记录
+type JsLogRecord struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Ty                   int32    `protobuf:"varint,2,opt,name=ty,proto3" json:"ty,omitempty"`
+	Format               string   `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"`
+	Event                string   `protobuf:"bytes,4,opt,name=event,proto3" json:"event,omitempty"`
+	Data                 string   `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+	Height               int64    `protobuf:"varint,6,opt,name=height,proto3" json:"height,omitempty"`
+	Index                int64    `protobuf:"varint,7,opt,name=index,proto3" json:"index,omitempty"`
+	LogIndex             int32    `protobuf:"varint,8,opt,name=logIndex,proto3" json:"logIndex,omitempty"`
+	TxHash               string   `protobuf:"bytes,9,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JsLogRecord) Reset()         { *m = JsLogRecord{} }
+func (m *JsLogRecord) String() string { return proto.CompactTextString(m) }
+func (*JsLogRecord) ProtoMessage()    {}
+func (*JsLogRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d11539bc790542aa, []int{5}
+}
+
+func (m *JsLogRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JsLogRecord.Unmarshal(m, b)
+}
+func (m *JsLogRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JsLogRecord.Marshal(b, m, deterministic)
+}
+func (m *JsLogRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JsLogRecord.Merge(m, src)
+}
+func (m *JsLogRecord) XXX_Size() int {
+	return xxx_messageInfo_JsLogRecord.Size(m)
+}
+func (m *JsLogRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_JsLogRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JsLogRecord proto.InternalMessageInfo
+
+func (m *JsLogRecord) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *JsLogRecord) GetTy() int32 {
+	if m != nil {
+		return m.Ty
+	}
+	return 0
+}
+
+func (m *JsLogRecord) GetFormat() string {
+	if m != nil {
+		return m.Format
+	}
+	return ""
+}
+
+func (m *JsLogRecord) GetEvent() string {
+	if m != nil {
+		return m.Event
+	}
+	return ""
+}
+
+func (m *JsLogRecord) GetData() string {
+	if m != nil {
+		return m.Data
+	}
+	return ""
+}
+
+func (m *JsLogRecord) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *JsLogRecord) GetIndex() int64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *JsLogRecord) GetLogIndex() int32 {
+	if m != nil {
+		return m.LogIndex
+	}
+	return 0
+}
+
+func (m *JsLogRecord) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+// 按区块范围分页查询合约日志
+type ReqJsLogs struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Ty                   int32    `protobuf:"varint,2,opt,name=ty,proto3" json:"ty,omitempty"`
+	StartHeight          int64    `protobuf:"varint,3,opt,name=startHeight,proto3" json:"startHeight,omitempty"`
+	EndHeight            int64    `protobuf:"varint,4,opt,name=endHeight,proto3" json:"endHeight,omitempty"`
+	Count                int32    `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
+	PrimaryKey           string   `protobuf:"bytes,6,opt,name=primaryKey,proto3" json:"primaryKey,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqJsLogs) Reset()         { *m = ReqJsLogs{} }
+func (m *ReqJsLogs) String() string { return proto.CompactTextString(m) }
+func (*ReqJsLogs) ProtoMessage()    {}
+func (*ReqJsLogs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d11539bc790542aa, []int{6}
+}
+
+func (m *ReqJsLogs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqJsLogs.Unmarshal(m, b)
+}
+func (m *ReqJsLogs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqJsLogs.Marshal(b, m, deterministic)
+}
+func (m *ReqJsLogs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqJsLogs.Merge(m, src)
+}
+func (m *ReqJsLogs) XXX_Size() int {
+	return xxx_messageInfo_ReqJsLogs.Size(m)
+}
+func (m *ReqJsLogs) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqJsLogs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqJsLogs proto.InternalMessageInfo
+
+func (m *ReqJsLogs) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ReqJsLogs) GetTy() int32 {
+	if m != nil {
+		return m.Ty
+	}
+	return 0
+}
+
+func (m *ReqJsLogs) GetStartHeight() int64 {
+	if m != nil {
+		return m.StartHeight
+	}
+	return 0
+}
+
+func (m *ReqJsLogs) GetEndHeight() int64 {
+	if m != nil {
+		return m.EndHeight
+	}
+	return 0
+}
+
+func (m *ReqJsLogs) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *ReqJsLogs) GetPrimaryKey() string {
+	if m != nil {
+		return m.PrimaryKey
+	}
+	return ""
+}
+
+type JsLogRecords struct {
+	Logs                 []*JsLogRecord `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	PrimaryKey           string         `protobuf:"bytes,2,opt,name=primaryKey,proto3" json:"primaryKey,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *JsLogRecords) Reset()         { *m = JsLogRecords{} }
+func (m *JsLogRecords) String() string { return proto.CompactTextString(m) }
+func (*JsLogRecords) ProtoMessage()    {}
+func (*JsLogRecords) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d11539bc790542aa, []int{7}
+}
+
+func (m *JsLogRecords) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JsLogRecords.Unmarshal(m, b)
+}
+func (m *JsLogRecords) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JsLogRecords.Marshal(b, m, deterministic)
+}
+func (m *JsLogRecords) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JsLogRecords.Merge(m, src)
+}
+func (m *JsLogRecords) XXX_Size() int {
+	return xxx_messageInfo_JsLogRecords.Size(m)
+}
+func (m *JsLogRecords) XXX_DiscardUnknown() {
+	xxx_messageInfo_JsLogRecords.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JsLogRecords proto.InternalMessageInfo
+
+func (m *JsLogRecords) GetLogs() []*JsLogRecord {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
+func (m *JsLogRecords) GetPrimaryKey() string {
+	if m != nil {
+		return m.PrimaryKey
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Create)(nil), "jsproto.Create")
 	proto.RegisterType((*Call)(nil), "jsproto.Call")
 	proto.RegisterType((*JsAction)(nil), "jsproto.JsAction")
 	proto.RegisterType((*JsLog)(nil), "jsproto.JsLog")
 	proto.RegisterType((*QueryResult)(nil), "jsproto.QueryResult")
+	proto.RegisterType((*JsLogRecord)(nil), "jsproto.JsLogRecord")
+	proto.RegisterType((*ReqJsLogs)(nil), "jsproto.ReqJsLogs")
+	proto.RegisterType((*JsLogRecords)(nil), "jsproto.JsLogRecords")
 }
 
 func init() {
@@ -304,20 +538,32 @@ func init() {
 }
 
 var fileDescriptor_d11539bc790542aa = []byte{
-	// 231 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x8f, 0xbf, 0x4e, 0xc3, 0x30,
-	0x10, 0xc6, 0x9b, 0x90, 0xa4, 0xe1, 0x22, 0x40, 0xf2, 0x14, 0xc1, 0x02, 0x66, 0x81, 0x25, 0x42,
-	0xe5, 0x09, 0xa0, 0x4b, 0x15, 0xb1, 0xe0, 0x37, 0x38, 0x5c, 0x53, 0x51, 0x1d, 0x31, 0xf2, 0x1f,
-	0xa4, 0xbc, 0x3d, 0xf2, 0xa5, 0x34, 0x0c, 0x6c, 0x3f, 0xdf, 0xfd, 0xec, 0xef, 0x33, 0xd4, 0x7b,
-	0xdf, 0x7d, 0x39, 0x1b, 0xac, 0x58, 0xee, 0x3d, 0x83, 0x7c, 0x80, 0x6a, 0xed, 0x0c, 0x06, 0x23,
-	0x04, 0x14, 0xda, 0x6e, 0x4d, 0x9b, 0x5d, 0x67, 0x77, 0xa7, 0x8a, 0x39, 0xcd, 0x06, 0xfc, 0x34,
-	0x6d, 0x3e, 0xcd, 0x12, 0xcb, 0x1e, 0x8a, 0x35, 0x12, 0x1d, 0x77, 0xd9, 0xbc, 0x13, 0x97, 0x50,
-	0xbf, 0xc7, 0x41, 0xff, 0xb9, 0x73, 0x3c, 0x27, 0x1f, 0xdd, 0xce, 0xb7, 0x27, 0x93, 0x9f, 0x58,
-	0x7a, 0xa8, 0x7b, 0xff, 0xa4, 0xc3, 0x87, 0x1d, 0xc4, 0x3d, 0x54, 0x9a, 0x9b, 0xf0, 0x8b, 0xcd,
-	0xea, 0xa2, 0x3b, 0x74, 0xec, 0xa6, 0x82, 0x9b, 0x85, 0x3a, 0x08, 0xe2, 0x16, 0x0a, 0x8d, 0x44,
-	0x1c, 0xd1, 0xac, 0xce, 0x66, 0x11, 0x89, 0x36, 0x0b, 0xc5, 0x4b, 0x71, 0x0e, 0x79, 0x18, 0x39,
-	0xad, 0x54, 0x79, 0x18, 0x9f, 0x97, 0x50, 0x7e, 0x23, 0x45, 0x23, 0xaf, 0xa0, 0xec, 0xfd, 0x8b,
-	0xdd, 0xa5, 0x46, 0x5b, 0x0c, 0xf8, 0xfb, 0x83, 0xc4, 0xf2, 0x06, 0x9a, 0xd7, 0x68, 0xdc, 0xa8,
-	0x8c, 0x8f, 0x14, 0xfe, 0x53, 0xde, 0x2a, 0x0e, 0x7b, 0xfc, 0x09, 0x00, 0x00, 0xff, 0xff, 0xdf,
-	0xa4, 0x90, 0x55, 0x4e, 0x01, 0x00, 0x00,
+	// 417 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x52, 0x4d, 0x8f, 0xd3, 0x30,
+	0x14, 0xdc, 0xa4, 0x49, 0xda, 0xbc, 0xf0, 0x21, 0x59, 0x2b, 0x64, 0x01, 0x42, 0x25, 0x5c, 0xc2,
+	0xa5, 0x42, 0xe5, 0x17, 0xc0, 0x5e, 0x42, 0xe1, 0x82, 0x4f, 0x5c, 0x4d, 0xe2, 0x4d, 0xb3, 0x72,
+	0xe3, 0xc5, 0x76, 0x56, 0x9b, 0x1f, 0xc4, 0x4f, 0xe2, 0xff, 0x20, 0x3f, 0x67, 0x93, 0x68, 0xd5,
+	0xc3, 0xde, 0xde, 0xcc, 0x9b, 0xcc, 0x9b, 0x71, 0x0b, 0x9b, 0x1b, 0xb3, 0xbb, 0xd5, 0xca, 0x2a,
+	0xb2, 0xbe, 0x31, 0x38, 0xe4, 0x9f, 0x20, 0xb9, 0xd2, 0x82, 0x5b, 0x41, 0x08, 0x44, 0x95, 0xaa,
+	0x05, 0x0d, 0xb6, 0x41, 0x91, 0x32, 0x9c, 0x1d, 0xd7, 0xf1, 0x93, 0xa0, 0xa1, 0xe7, 0xdc, 0x9c,
+	0x1f, 0x20, 0xba, 0xe2, 0x52, 0x4e, 0xbb, 0x60, 0xde, 0x91, 0xd7, 0xb0, 0xb9, 0xee, 0xbb, 0x6a,
+	0xf1, 0xcd, 0x84, 0x9d, 0x9e, 0xeb, 0xc6, 0xd0, 0x95, 0xd7, 0xbb, 0x39, 0x37, 0xb0, 0x39, 0x98,
+	0x2f, 0x95, 0x6d, 0x55, 0x47, 0x3e, 0x42, 0x52, 0x61, 0x12, 0x74, 0xcc, 0xf6, 0x2f, 0x77, 0x63,
+	0xc6, 0x9d, 0x0f, 0x58, 0x5e, 0xb0, 0x51, 0x40, 0x3e, 0x40, 0x54, 0x71, 0x29, 0xf1, 0x44, 0xb6,
+	0x7f, 0x3e, 0x0b, 0xb9, 0x94, 0xe5, 0x05, 0xc3, 0x25, 0x79, 0x01, 0xa1, 0x1d, 0xf0, 0x5a, 0xcc,
+	0x42, 0x3b, 0x7c, 0x5d, 0x43, 0x7c, 0xc7, 0x65, 0x2f, 0xf2, 0x37, 0x10, 0x1f, 0xcc, 0x0f, 0xd5,
+	0xb8, 0x44, 0x35, 0xb7, 0xfc, 0xa1, 0x81, 0x9b, 0xf3, 0xf7, 0x90, 0xfd, 0xec, 0x85, 0x1e, 0x98,
+	0x30, 0xbd, 0xb4, 0x67, 0x25, 0xff, 0x02, 0xc8, 0xd0, 0x80, 0x89, 0x4a, 0xe9, 0xfa, 0xec, 0x43,
+	0xf8, 0xe3, 0xe1, 0xc3, 0x71, 0xf2, 0x0a, 0x92, 0x6b, 0xa5, 0x4f, 0xdc, 0x8e, 0xf5, 0x47, 0x44,
+	0x2e, 0x21, 0x16, 0x77, 0xa2, 0xb3, 0x34, 0x42, 0xda, 0x83, 0xe9, 0x6a, 0x3c, 0x5f, 0x75, 0x0e,
+	0x47, 0xd1, 0x36, 0x47, 0x4b, 0x93, 0x6d, 0x50, 0xac, 0xd8, 0x88, 0x9c, 0x43, 0xdb, 0xd5, 0xe2,
+	0x9e, 0xae, 0x91, 0xf6, 0xc0, 0xfd, 0x10, 0x52, 0x35, 0xdf, 0x70, 0xb1, 0xc1, 0x14, 0x13, 0x76,
+	0x4e, 0xf6, 0xbe, 0xe4, 0xe6, 0x48, 0x53, 0x9f, 0xc5, 0xa3, 0xfc, 0x6f, 0x00, 0x29, 0x13, 0x7f,
+	0xb0, 0x9a, 0x79, 0x52, 0xab, 0x2d, 0x64, 0xc6, 0x72, 0x6d, 0x4b, 0x1f, 0x6c, 0x85, 0x09, 0x96,
+	0x14, 0x79, 0x0b, 0xa9, 0xe8, 0xea, 0x71, 0x1f, 0xe1, 0x7e, 0x26, 0x5c, 0xf6, 0x4a, 0xf5, 0x9d,
+	0xc5, 0xa2, 0x31, 0xf3, 0x80, 0xbc, 0x03, 0xb8, 0xd5, 0xed, 0x89, 0xeb, 0xe1, 0xbb, 0x18, 0xb0,
+	0x6d, 0xca, 0x16, 0x4c, 0xfe, 0x0b, 0x9e, 0x2d, 0x9e, 0xdf, 0x90, 0x02, 0x22, 0xa9, 0x1a, 0x43,
+	0x83, 0xed, 0xaa, 0xc8, 0xf6, 0x97, 0xd3, 0xbf, 0x61, 0x21, 0x62, 0xa8, 0x78, 0xe4, 0x1c, 0x3e,
+	0x76, 0xfe, 0x9d, 0xe0, 0x87, 0x9f, 0xff, 0x0f, 0x00, 0x8a, 0x10, 0xb9, 0xa0, 0x28, 0x03, 0x00,
+	0x00,
 }
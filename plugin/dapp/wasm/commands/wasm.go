@@ -24,6 +24,10 @@ func Cmd() *cobra.Command {
 		cmdCheckContract(),
 		cmdCreateContract(),
 		cmdCallContract(),
+		cmdUpdateContract(),
+		cmdGetABI(),
+		cmdQueryContract(),
+		cmdListVersions(),
 	)
 
 	return cmd
@@ -48,6 +52,22 @@ func cmdCreateContract() *cobra.Command {
 	}
 	cmd.Flags().StringP("name", "n", "", "contract name")
 	cmd.Flags().StringP("path", "p", "", "path of the wasm file, such as ./test.wasm")
+	cmd.Flags().StringP("abi", "a", "", "path of the abi file in json format, optional")
+	cmd.Flags().StringP("admin", "d", "", "admin address who can update the contract besides the creator, optional")
+	_ = cmd.MarkFlagRequired("name")
+	_ = cmd.MarkFlagRequired("path")
+	return cmd
+}
+
+func cmdUpdateContract() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "replace the code of the contract, only the creator or admin is allowed",
+		Run:   updateContract,
+	}
+	cmd.Flags().StringP("name", "n", "", "contract name")
+	cmd.Flags().StringP("path", "p", "", "path of the new wasm file, such as ./test.wasm")
+	cmd.Flags().StringP("abi", "a", "", "path of the new abi file in json format, the old abi is removed if not set")
 	_ = cmd.MarkFlagRequired("name")
 	_ = cmd.MarkFlagRequired("path")
 	return cmd
@@ -62,11 +82,50 @@ func cmdCallContract() *cobra.Command {
 	cmd.Flags().StringP("name", "n", "", "contract name")
 	cmd.Flags().StringP("method", "m", "", "method name")
 	cmd.Flags().IntSliceP("parameters", "p", nil, "parameters of the method which should be num")
+	cmd.Flags().StringP("args", "a", "", "parameters of the method in json array format, such as '[1,\"hello\"]', the contract must have abi")
+	_ = cmd.MarkFlagRequired("name")
+	_ = cmd.MarkFlagRequired("method")
+	return cmd
+}
+
+func cmdGetABI() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "abi",
+		Short: "get the abi of the contract",
+		Run:   getABI,
+	}
+	cmd.Flags().StringP("name", "n", "", "contract name")
+	_ = cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func cmdQueryContract() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query",
+		Short: "call the view method of the contract without sending transaction",
+		Run:   queryContract,
+	}
+	cmd.Flags().StringP("name", "n", "", "contract name")
+	cmd.Flags().StringP("method", "m", "", "method name")
+	cmd.Flags().IntSliceP("parameters", "p", nil, "parameters of the method which should be num")
+	cmd.Flags().StringP("args", "a", "", "parameters of the method in json array format, the contract must have abi")
+	cmd.Flags().StringP("caller", "c", "", "caller address, optional")
 	_ = cmd.MarkFlagRequired("name")
 	_ = cmd.MarkFlagRequired("method")
 	return cmd
 }
 
+func cmdListVersions() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "versions",
+		Short: "list the code versions of the contract",
+		Run:   listVersions,
+	}
+	cmd.Flags().StringP("name", "n", "", "contract name")
+	_ = cmd.MarkFlagRequired("name")
+	return cmd
+}
+
 func checkContract(cmd *cobra.Command, args []string) {
 	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
 	name, _ := cmd.Flags().GetString("name")
@@ -87,33 +146,80 @@ func checkContract(cmd *cobra.Command, args []string) {
 func createContract(cmd *cobra.Command, args []string) {
 	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
 	name, _ := cmd.Flags().GetString("name")
-	path, _ := cmd.Flags().GetString("path")
-
-	// Read WebAssembly *.wasm file.
-	code, err := ioutil.ReadFile(path)
+	admin, _ := cmd.Flags().GetString("admin")
+	code, abi, err := readCode(cmd)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		return
 	}
 
 	payload := wasmtypes.WasmCreate{
+		Name:  name,
+		Code:  code,
+		Abi:   abi,
+		Admin: admin,
+	}
+	params := rpctypes.CreateTxIn{
+		Execer:     wasmtypes.WasmX,
+		ActionName: "Create",
+		Payload:    types.MustPBToJSON(&payload),
+	}
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.CreateTransaction", params, nil)
+	ctx.RunWithoutMarshal()
+}
+
+func updateContract(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	name, _ := cmd.Flags().GetString("name")
+	code, abi, err := readCode(cmd)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+
+	payload := wasmtypes.WasmUpdate{
 		Name: name,
 		Code: code,
+		Abi:  abi,
 	}
 	params := rpctypes.CreateTxIn{
 		Execer:     wasmtypes.WasmX,
-		ActionName: "Create",
+		ActionName: "Update",
 		Payload:    types.MustPBToJSON(&payload),
 	}
 	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.CreateTransaction", params, nil)
 	ctx.RunWithoutMarshal()
 }
 
+// 读取path指定的wasm文件以及abi指定的ABI文件
+func readCode(cmd *cobra.Command) ([]byte, string, error) {
+	path, _ := cmd.Flags().GetString("path")
+	abiPath, _ := cmd.Flags().GetString("abi")
+
+	// Read WebAssembly *.wasm file.
+	code, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+	if abiPath == "" {
+		return code, "", nil
+	}
+	abi, err := ioutil.ReadFile(abiPath)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err = wasmtypes.ParseABI(string(abi)); err != nil {
+		return nil, "", err
+	}
+	return code, string(abi), nil
+}
+
 func callContract(cmd *cobra.Command, args []string) {
 	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
 	name, _ := cmd.Flags().GetString("name")
 	method, _ := cmd.Flags().GetString("method")
 	parameters, _ := cmd.Flags().GetIntSlice("parameters")
+	jsonArgs, _ := cmd.Flags().GetString("args")
 	var parameters2 []int64
 	for _, param := range parameters {
 		parameters2 = append(parameters2, int64(param))
@@ -123,6 +229,7 @@ func callContract(cmd *cobra.Command, args []string) {
 		Contract:   name,
 		Method:     method,
 		Parameters: parameters2,
+		JsonArgs:   jsonArgs,
 	}
 	params := rpctypes.CreateTxIn{
 		Execer:     wasmtypes.WasmX,
@@ -132,3 +239,66 @@ func callContract(cmd *cobra.Command, args []string) {
 	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.CreateTransaction", params, nil)
 	ctx.RunWithoutMarshal()
 }
+
+func getABI(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	name, _ := cmd.Flags().GetString("name")
+
+	params := rpctypes.Query4Jrpc{
+		Execer:   wasmtypes.WasmX,
+		FuncName: "GetABI",
+		Payload: types.MustPBToJSON(&wasmtypes.QueryCheckContract{
+			Name: name,
+		}),
+	}
+
+	var resp types.ReplyString
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.Query", params, &resp)
+	ctx.Run()
+}
+
+func queryContract(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	name, _ := cmd.Flags().GetString("name")
+	method, _ := cmd.Flags().GetString("method")
+	parameters, _ := cmd.Flags().GetIntSlice("parameters")
+	jsonArgs, _ := cmd.Flags().GetString("args")
+	caller, _ := cmd.Flags().GetString("caller")
+	var parameters2 []int64
+	for _, param := range parameters {
+		parameters2 = append(parameters2, int64(param))
+	}
+
+	params := rpctypes.Query4Jrpc{
+		Execer:   wasmtypes.WasmX,
+		FuncName: "Call",
+		Payload: types.MustPBToJSON(&wasmtypes.QueryCallContract{
+			Contract:   name,
+			Method:     method,
+			Parameters: parameters2,
+			JsonArgs:   jsonArgs,
+			Caller:     caller,
+		}),
+	}
+
+	var resp wasmtypes.QueryCallResult
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.Query", params, &resp)
+	ctx.Run()
+}
+
+func listVersions(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	name, _ := cmd.Flags().GetString("name")
+
+	params := rpctypes.Query4Jrpc{
+		Execer:   wasmtypes.WasmX,
+		FuncName: "ListVersions",
+		Payload: types.MustPBToJSON(&wasmtypes.QueryCheckContract{
+			Name: name,
+		}),
+	}
+
+	var resp wasmtypes.WasmContractVersions
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.Query", params, &resp)
+	ctx.Run()
+}
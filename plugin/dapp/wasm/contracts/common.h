@@ -33,6 +33,11 @@ int64_t getRandom();
 void sha256(const char* data, size_t data_len, char* sum, size_t sum_len);
 void printlog(const char* log, size_t len);
 void printint(int64_t n);
+void setReturnData(const char* data, size_t len);
+
+int64_t callContract(const char* name, size_t name_len, const char* method, size_t method_len, const char* args, size_t args_len);
+size_t getReturnDataSize();
+size_t getReturnData(char* data, size_t len);
 
 #ifdef __cplusplus
 }
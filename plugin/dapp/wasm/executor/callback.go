@@ -5,55 +5,65 @@ import (
 	"github.com/33cn/chain33/common/address"
 	"github.com/33cn/chain33/types"
 	types2 "github.com/33cn/plugin/plugin/dapp/wasm/types"
+	"github.com/perlin-network/life/exec"
 )
 
+// 只读调用中不允许修改状态，直接终止合约执行
+func (w *Wasm) checkWritable() {
+	if w.readOnly {
+		panic(types2.ErrReadOnly)
+	}
+}
+
 //stateDB wrapper
-func setStateDB(key, value []byte) {
-	wasmCB.stateKVC.Add(key, value)
+func (w *Wasm) setStateDB(key, value []byte) {
+	w.checkWritable()
+	w.stateKVC.Add(key, value)
 }
 
-func getStateDBSize(key []byte) int {
-	value, err := getStateDB(key)
+func (w *Wasm) getStateDBSize(key []byte) int {
+	value, err := w.getStateDB(key)
 	if err != nil {
 		return 0
 	}
 	return len(value)
 }
 
-func getStateDB(key []byte) ([]byte, error) {
-	return wasmCB.stateKVC.Get(key)
+func (w *Wasm) getStateDB(key []byte) ([]byte, error) {
+	return w.stateKVC.Get(key)
 }
 
 //localDB wrapper
-func setLocalDB(key, value []byte) {
-	wasmCB.localCache = append(wasmCB.localCache, &types2.LocalDataLog{
-		Key:   append(calcLocalPrefix(wasmCB.contractName), key...),
+func (w *Wasm) setLocalDB(key, value []byte) {
+	w.checkWritable()
+	w.localCache = append(w.localCache, &types2.LocalDataLog{
+		Key:   append(calcLocalPrefix(w.contractName), key...),
 		Value: value,
 	})
 }
 
-func getLocalDBSize(key []byte) int {
-	value, err := getLocalDB(key)
+func (w *Wasm) getLocalDBSize(key []byte) int {
+	value, err := w.getLocalDB(key)
 	if err != nil {
 		return 0
 	}
 	return len(value)
 }
 
-func getLocalDB(key []byte) ([]byte, error) {
-	newKey := append(calcLocalPrefix(wasmCB.contractName), key...)
+func (w *Wasm) getLocalDB(key []byte) ([]byte, error) {
+	newKey := append(calcLocalPrefix(w.contractName), key...)
 	// 先查缓存，再查数据库
-	for _, kv := range wasmCB.localCache {
+	for _, kv := range w.localCache {
 		if string(newKey) == string(kv.Key) {
 			return kv.Value, nil
 		}
 	}
-	return wasmCB.GetLocalDB().Get(newKey)
+	return w.GetLocalDB().Get(newKey)
 }
 
 //account wrapper
-func getBalance(addr, execer string) (balance, frozen int64, err error) {
-	accounts, err := wasmCB.GetCoinsAccount().GetBalance(wasmCB.GetAPI(), &types.ReqBalance{
+func (w *Wasm) getBalance(addr, execer string) (balance, frozen int64, err error) {
+	accounts, err := w.GetCoinsAccount().GetBalance(w.GetAPI(), &types.ReqBalance{
 		Addresses: []string{addr},
 		Execer:    execer,
 	})
@@ -63,71 +73,78 @@ func getBalance(addr, execer string) (balance, frozen int64, err error) {
 	return accounts[0].Balance, accounts[0].Frozen, nil
 }
 
-func transfer(from, to string, amount int64) error {
-	receipt, err := wasmCB.GetCoinsAccount().Transfer(from, to, amount)
+func (w *Wasm) transfer(from, to string, amount int64) error {
+	w.checkWritable()
+	receipt, err := w.GetCoinsAccount().Transfer(from, to, amount)
 	if err != nil {
 		return err
 	}
-	wasmCB.receiptLogs = append(wasmCB.receiptLogs, receipt.Logs...)
+	w.receiptLogs = append(w.receiptLogs, receipt.Logs...)
 	return nil
 }
 
-func transferToExec(addr, execaddr string, amount int64) error {
-	receipt, err := wasmCB.GetCoinsAccount().TransferToExec(addr, execaddr, amount)
+func (w *Wasm) transferToExec(addr, execaddr string, amount int64) error {
+	w.checkWritable()
+	receipt, err := w.GetCoinsAccount().TransferToExec(addr, execaddr, amount)
 	if err != nil {
 		return err
 	}
-	wasmCB.receiptLogs = append(wasmCB.receiptLogs, receipt.Logs...)
+	w.receiptLogs = append(w.receiptLogs, receipt.Logs...)
 	return nil
 }
 
-func transferWithdraw(addr, execaddr string, amount int64) error {
-	receipt, err := wasmCB.GetCoinsAccount().TransferWithdraw(addr, execaddr, amount)
+func (w *Wasm) transferWithdraw(addr, execaddr string, amount int64) error {
+	w.checkWritable()
+	receipt, err := w.GetCoinsAccount().TransferWithdraw(addr, execaddr, amount)
 	if err != nil {
 		return err
 	}
-	wasmCB.receiptLogs = append(wasmCB.receiptLogs, receipt.Logs...)
+	w.receiptLogs = append(w.receiptLogs, receipt.Logs...)
 	return nil
 }
 
-func execFrozen(addr string, amount int64) error {
-	receipt, err := wasmCB.GetCoinsAccount().ExecFrozen(addr, wasmCB.execAddr, amount)
+func (w *Wasm) execFrozen(addr string, amount int64) error {
+	w.checkWritable()
+	receipt, err := w.GetCoinsAccount().ExecFrozen(addr, w.execAddr, amount)
 	if err != nil {
 		log.Error("execFrozen", "error", err)
 		return err
 	}
-	wasmCB.kvs = append(wasmCB.kvs, receipt.KV...)
-	wasmCB.receiptLogs = append(wasmCB.receiptLogs, receipt.Logs...)
+	w.kvs = append(w.kvs, receipt.KV...)
+	w.receiptLogs = append(w.receiptLogs, receipt.Logs...)
 	return nil
 }
 
-func execActive(addr string, amount int64) error {
-	receipt, err := wasmCB.GetCoinsAccount().ExecActive(addr, wasmCB.execAddr, amount)
+func (w *Wasm) execActive(addr string, amount int64) error {
+	w.checkWritable()
+	receipt, err := w.GetCoinsAccount().ExecActive(addr, w.execAddr, amount)
 	if err != nil {
 		return err
 	}
-	wasmCB.kvs = append(wasmCB.kvs, receipt.KV...)
-	wasmCB.receiptLogs = append(wasmCB.receiptLogs, receipt.Logs...)
+	w.kvs = append(w.kvs, receipt.KV...)
+	w.receiptLogs = append(w.receiptLogs, receipt.Logs...)
 	return nil
 }
 
-func execTransfer(from, to string, amount int64) error {
-	receipt, err := wasmCB.GetCoinsAccount().ExecTransfer(from, to, wasmCB.execAddr, amount)
+func (w *Wasm) execTransfer(from, to string, amount int64) error {
+	w.checkWritable()
+	receipt, err := w.GetCoinsAccount().ExecTransfer(from, to, w.execAddr, amount)
 	if err != nil {
 		return err
 	}
-	wasmCB.kvs = append(wasmCB.kvs, receipt.KV...)
-	wasmCB.receiptLogs = append(wasmCB.receiptLogs, receipt.Logs...)
+	w.kvs = append(w.kvs, receipt.KV...)
+	w.receiptLogs = append(w.receiptLogs, receipt.Logs...)
 	return nil
 }
 
-func execTransferFrozen(from, to string, amount int64) error {
-	receipt, err := wasmCB.GetCoinsAccount().ExecTransferFrozen(from, to, wasmCB.execAddr, amount)
+func (w *Wasm) execTransferFrozen(from, to string, amount int64) error {
+	w.checkWritable()
+	receipt, err := w.GetCoinsAccount().ExecTransferFrozen(from, to, w.execAddr, amount)
 	if err != nil {
 		return err
 	}
-	wasmCB.kvs = append(wasmCB.kvs, receipt.KV...)
-	wasmCB.receiptLogs = append(wasmCB.receiptLogs, receipt.Logs...)
+	w.kvs = append(w.kvs, receipt.KV...)
+	w.receiptLogs = append(w.receiptLogs, receipt.Logs...)
 	return nil
 }
 
@@ -135,21 +152,21 @@ func execAddress(name string) string {
 	return address.ExecAddress(name)
 }
 
-func getFrom() string {
-	return wasmCB.tx.From()
+func (w *Wasm) getFrom() string {
+	return w.from
 }
 
-func getHeight() int64 {
-	return wasmCB.GetHeight()
+func (w *Wasm) getHeight() int64 {
+	return w.GetHeight()
 }
 
-func getRandom() int64 {
+func (w *Wasm) getRandom() int64 {
 	req := &types.ReqRandHash{
 		ExecName: "ticket",
 		BlockNum: 5,
-		Hash:     wasmCB.GetLastHash(),
+		Hash:     w.GetLastHash(),
 	}
-	hash, err := wasmCB.GetExecutorAPI().GetRandNum(req)
+	hash, err := w.GetExecutorAPI().GetRandNum(req)
 	if err != nil {
 		return -1
 	}
@@ -163,8 +180,26 @@ func getRandom() int64 {
 	return rand
 }
 
-func printlog(s string) {
-	wasmCB.customLogs = append(wasmCB.customLogs, s)
+func (w *Wasm) setReturnData(data []byte) {
+	w.returnData = data
+}
+
+//跨合约调用，被调用合约执行失败时返回-1
+func (w *Wasm) crossCall(vm *exec.VirtualMachine, contract, method string, args []byte) int64 {
+	ret, err := w.nestedCall(vm, contract, method, args)
+	if err != nil {
+		log.Error("callContract", "contract", contract, "method", method, "error", err)
+		return -1
+	}
+	return ret
+}
+
+func (w *Wasm) getCallReturnData() []byte {
+	return w.lastReturn
+}
+
+func (w *Wasm) printlog(s string) {
+	w.customLogs = append(w.customLogs, s)
 }
 
 func sha256(data []byte) []byte {
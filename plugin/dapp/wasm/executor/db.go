@@ -1,6 +1,9 @@
 package executor
 
 import (
+	"fmt"
+
+	"github.com/33cn/chain33/common/db"
 	"github.com/33cn/chain33/types"
 	types2 "github.com/33cn/plugin/plugin/dapp/wasm/types"
 )
@@ -10,6 +13,21 @@ func contractKey(name string) []byte {
 	return append([]byte("mavl-"+types2.WasmX+"-code-"), []byte(name)...)
 }
 
+// "mavl-wasm-abi-{name}"
+func abiKey(name string) []byte {
+	return append([]byte("mavl-"+types2.WasmX+"-abi-"), []byte(name)...)
+}
+
+// "mavl-wasm-info-{name}"
+func infoKey(name string) []byte {
+	return append([]byte("mavl-"+types2.WasmX+"-info-"), []byte(name)...)
+}
+
+// "mavl-wasm-version-{name}-{version}"
+func versionKey(name string, version int32) []byte {
+	return []byte(fmt.Sprintf("mavl-%s-version-%s-%d", types2.WasmX, name, version))
+}
+
 // "mavl-wasm-{contract}-"
 func calcStatePrefix(contract string) []byte {
 	var prefix []byte
@@ -35,3 +53,66 @@ func (w *Wasm) contractExist(name string) bool {
 	}
 	return err == nil
 }
+
+func (w *Wasm) contractABI(name string) (types2.ABI, error) {
+	data, err := w.GetStateDB().Get(abiKey(name))
+	// 升级合约时删除的ABI为空值
+	if err == types.ErrNotFound || (err == nil && len(data) == 0) {
+		return nil, types2.ErrNoABI
+	}
+	if err != nil {
+		return nil, err
+	}
+	return types2.ParseABI(string(data))
+}
+
+func (w *Wasm) contractInfo(name string) (*types2.WasmContractInfo, error) {
+	data, err := w.GetStateDB().Get(infoKey(name))
+	if err != nil {
+		return nil, err
+	}
+	var info types2.WasmContractInfo
+	if err := types.Decode(data, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+func (w *Wasm) contractVersions(info *types2.WasmContractInfo) ([]*types2.WasmCodeVersion, error) {
+	var versions []*types2.WasmCodeVersion
+	for v := int32(1); v <= info.Version; v++ {
+		data, err := w.GetStateDB().Get(versionKey(info.Name, v))
+		if err != nil {
+			return nil, err
+		}
+		var version types2.WasmCodeVersion
+		if err := types.Decode(data, &version); err != nil {
+			return nil, err
+		}
+		versions = append(versions, &version)
+	}
+	return versions, nil
+}
+
+// journalDB 记录跨合约调用中修改的状态的原始值，被调用合约执行失败时按相反的顺序恢复
+type journalDB struct {
+	db.KV
+	entries []*types.KeyValue
+}
+
+func (j *journalDB) Set(key, value []byte) error {
+	prev, err := j.KV.Get(key)
+	if err != nil && err != types.ErrNotFound {
+		return err
+	}
+	j.entries = append(j.entries, &types.KeyValue{Key: key, Value: prev})
+	return j.KV.Set(key, value)
+}
+
+// 原始值不存在时写入空值，即删除该状态
+func (j *journalDB) revert() {
+	for i := len(j.entries) - 1; i >= 0; i-- {
+		_ = j.KV.Set(j.entries[i].Key, j.entries[i].Value)
+	}
+	j.entries = nil
+}
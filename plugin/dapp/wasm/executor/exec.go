@@ -1,8 +1,10 @@
 package executor
 
 import (
+	"encoding/binary"
 	"encoding/hex"
 
+	"github.com/33cn/chain33/common"
 	"github.com/33cn/chain33/common/address"
 	"github.com/33cn/chain33/system/dapp"
 	"github.com/33cn/chain33/types"
@@ -12,8 +14,6 @@ import (
 	validation "github.com/perlin-network/life/wasm-validation"
 )
 
-var wasmCB *Wasm
-
 func (w *Wasm) userExecName(name string, local bool) string {
 	execer := "user." + types2.WasmX + "." + name
 	if local {
@@ -41,11 +41,19 @@ func (w *Wasm) Exec_Create(payload *types2.WasmCreate, tx *types.Transaction, in
 		return nil, types2.ErrInvalidContractName
 	}
 	code := payload.Code
-	if len(code) > types2.MaxCodeSize {
-		return nil, types2.ErrCodeOversize
+	// 分叉之前不支持ABI，也不记录合约信息和版本
+	v2 := w.isV2()
+	abi := payload.Abi
+	if !v2 {
+		abi = ""
 	}
-	if err := validation.ValidateWasm(code); err != nil {
-		return nil, types2.ErrInvalidWasm
+	if err := checkCode(code, abi); err != nil {
+		return nil, err
+	}
+	if v2 && payload.Admin != "" {
+		if err := address.CheckAddress(payload.Admin); err != nil {
+			return nil, err
+		}
 	}
 
 	kvc := dapp.NewKVCreator(w.GetStateDB(), nil, nil)
@@ -57,6 +65,19 @@ func (w *Wasm) Exec_Create(payload *types2.WasmCreate, tx *types.Transaction, in
 		return nil, err
 	}
 	kvc.AddNoPrefix(contractKey(name), code)
+	if abi != "" {
+		kvc.AddNoPrefix(abiKey(name), []byte(abi))
+	}
+	if v2 {
+		info := &types2.WasmContractInfo{
+			Name:    name,
+			Creator: tx.From(),
+			Admin:   payload.Admin,
+			Version: 1,
+		}
+		kvc.AddNoPrefix(infoKey(name), types.Encode(info))
+		kvc.AddNoPrefix(versionKey(name, info.Version), types.Encode(w.codeVersion(info, code, tx)))
+	}
 
 	receiptLog := &types.ReceiptLog{
 		Ty: types2.TyLogWasmCreate,
@@ -73,65 +94,101 @@ func (w *Wasm) Exec_Create(payload *types2.WasmCreate, tx *types.Transaction, in
 	}, nil
 }
 
-func (w *Wasm) Exec_Call(payload *types2.WasmCall, tx *types.Transaction, index int) (*types.Receipt, error) {
+// Exec_Update 由合约创建者或管理员替换合约代码，合约的状态数据保持不变
+func (w *Wasm) Exec_Update(payload *types2.WasmUpdate, tx *types.Transaction, index int) (*types.Receipt, error) {
 	if payload == nil {
 		return nil, types.ErrInvalidParam
 	}
 	if !w.checkTxExec(string(tx.Execer), types2.WasmX) {
 		return nil, types.ErrExecNameNotMatch
 	}
+	if !w.isV2() {
+		return nil, types.ErrActionNotSupport
+	}
 
-	w.stateKVC = dapp.NewKVCreator(w.GetStateDB(), calcStatePrefix(payload.Contract), nil)
-	var vm *exec.VirtualMachine
-	var ok bool
-	if vm, ok = w.VMCache[payload.Contract]; !ok {
-		code, err := w.stateKVC.GetNoPrefix(contractKey(payload.Contract))
-		if err != nil {
-			return nil, err
-		}
-		vm, err = exec.NewVirtualMachine(code, exec.VMConfig{
-			DefaultMemoryPages:   128,
-			DefaultTableSize:     128,
-			DisableFloatingPoint: true,
-			GasLimit:             uint64(tx.Fee),
-		}, new(Resolver), &compiler.SimpleGasPolicy{GasPerInstruction: 1})
-		if err != nil {
-			return nil, err
-		}
-		w.VMCache[payload.Contract] = vm
-	} else {
-		vm.Config.GasLimit = uint64(tx.Fee)
-		vm.Gas = 0
+	name := payload.Name
+	code := payload.Code
+	if err := checkCode(code, payload.Abi); err != nil {
+		return nil, err
+	}
+	info, err := w.contractInfo(name)
+	// 升级功能之前创建的合约没有记录创建者，不能升级
+	if err == types.ErrNotFound && w.contractExist(name) {
+		return nil, types2.ErrPermissionDenied
+	}
+	if err != nil {
+		return nil, err
+	}
+	from := tx.From()
+	if from != info.Creator && (info.Admin == "" || from != info.Admin) {
+		return nil, types2.ErrPermissionDenied
 	}
 
-	// Get the function ID of the entry function to be executed.
-	entryID, ok := vm.GetFunctionExport(payload.Method)
-	if !ok {
-		return nil, types2.ErrInvalidMethod
+	kvc := dapp.NewKVCreator(w.GetStateDB(), nil, nil)
+	info.Version++
+	kvc.AddNoPrefix(contractKey(name), code)
+	if payload.Abi != "" {
+		kvc.AddNoPrefix(abiKey(name), []byte(payload.Abi))
+	} else if _, err := w.GetStateDB().Get(abiKey(name)); err == nil {
+		kvc.AddNoPrefix(abiKey(name), nil)
 	}
+	kvc.AddNoPrefix(infoKey(name), types.Encode(info))
+	kvc.AddNoPrefix(versionKey(name, info.Version), types.Encode(w.codeVersion(info, code, tx)))
+	delete(w.VMCache, name)
 
-	w.contractName = payload.Contract
-	w.tx = tx
+	receiptLog := &types.ReceiptLog{
+		Ty: types2.TyLogWasmUpdate,
+		Log: types.Encode(&types2.UpdateContractLog{
+			Name:    name,
+			Code:    hex.EncodeToString(code),
+			Version: info.Version,
+		}),
+	}
+
+	return &types.Receipt{
+		Ty:   types.ExecOk,
+		KV:   kvc.KVList(),
+		Logs: []*types.ReceiptLog{receiptLog},
+	}, nil
+}
+
+func (w *Wasm) codeVersion(info *types2.WasmContractInfo, code []byte, tx *types.Transaction) *types2.WasmCodeVersion {
+	return &types2.WasmCodeVersion{
+		Version:  info.Version,
+		CodeHash: hex.EncodeToString(common.Sha256(code)),
+		TxHash:   hex.EncodeToString(tx.Hash()),
+		Height:   w.GetHeight(),
+		Operator: tx.From(),
+	}
+}
+
+func (w *Wasm) Exec_Call(payload *types2.WasmCall, tx *types.Transaction, index int) (*types.Receipt, error) {
+	if payload == nil {
+		return nil, types.ErrInvalidParam
+	}
+	if !w.checkTxExec(string(tx.Execer), types2.WasmX) {
+		return nil, types.ErrExecNameNotMatch
+	}
+
+	w.from = tx.From()
 	w.execAddr = address.ExecAddress(string(types.GetRealExecName(tx.Execer)))
-	wasmCB = w
-	defer func() {
-		wasmCB = nil
-	}()
-	// Run the WebAssembly module's entry function.
-	ret, err := vm.RunWithGasLimit(entryID, int(tx.Fee), payload.Parameters...)
+	ret, jsonResult, err := w.callContract(payload, tx.Fee)
 	if err != nil {
 		return nil, err
 	}
+	callLog := &types2.CallContractLog{
+		Contract:   payload.Contract,
+		Method:     payload.Method,
+		Result:     int32(ret),
+		ReturnData: w.returnData,
+		JsonResult: jsonResult,
+	}
 	var kvs []*types.KeyValue
 	kvs = append(kvs, w.kvs...)
 	kvs = append(kvs, w.stateKVC.KVList()...)
 
 	var logs []*types.ReceiptLog
-	logs = append(logs, &types.ReceiptLog{Ty: types2.TyLogWasmCall, Log: types.Encode(&types2.CallContractLog{
-		Contract: payload.Contract,
-		Method:   payload.Method,
-		Result:   int32(ret),
-	})})
+	logs = append(logs, &types.ReceiptLog{Ty: types2.TyLogWasmCall, Log: types.Encode(callLog)})
 	logs = append(logs, w.receiptLogs...)
 	logs = append(logs, &types.ReceiptLog{Ty: types2.TyLogCustom, Log: types.Encode(&types2.CustomLog{
 		Info: w.customLogs,
@@ -155,6 +212,210 @@ func (w *Wasm) Exec_Call(payload *types2.WasmCall, tx *types.Transaction, index
 	return receipt, nil
 }
 
+// 加载合约并执行导出方法，返回方法的返回值以及按照ABI解析后的返回数据
+func (w *Wasm) callContract(payload *types2.WasmCall, gasLimit int64) (int64, string, error) {
+	abi, err := w.contractABI(payload.Contract)
+	if err != nil && err != types2.ErrNoABI {
+		return 0, "", err
+	}
+	var args []*types2.WasmArg
+	if payload.JsonArgs != "" {
+		if abi == nil {
+			return 0, "", types2.ErrNoABI
+		}
+		if len(payload.Parameters) > 0 {
+			return 0, "", types2.ErrInvalidParam
+		}
+		method, ok := abi.Method(payload.Method)
+		if !ok {
+			return 0, "", types2.ErrInvalidMethod
+		}
+		args, err = method.PackArgs(payload.JsonArgs)
+		if err != nil {
+			return 0, "", err
+		}
+	} else {
+		for _, param := range payload.Parameters {
+			args = append(args, &types2.WasmArg{Value: param})
+		}
+	}
+	ret, jsonResult, _, err := w.execContract(payload.Contract, payload.Method, abi, args, gasLimit)
+	return ret, jsonResult, err
+}
+
+// 执行合约的导出方法，同时返回虚拟机消耗的gas
+func (w *Wasm) execContract(contract, method string, abi types2.ABI, args []*types2.WasmArg, gasLimit int64) (int64, string, uint64, error) {
+	w.stateKVC = dapp.NewKVCreator(w.GetStateDB(), calcStatePrefix(contract), nil)
+	cached, ok := w.VMCache[contract]
+	vm := cached
+	// 合约被重入调用时缓存的虚拟机正在执行，上次执行出错的虚拟机也不能继续使用，需要创建新的虚拟机
+	// 分叉之前一直使用缓存的虚拟机
+	if !ok || (w.isV2() && (cached.CurrentFrame != -1 || cached.ExitError != nil)) {
+		code, err := w.stateKVC.GetNoPrefix(contractKey(contract))
+		if err != nil {
+			return 0, "", 0, err
+		}
+		vm, err = exec.NewVirtualMachine(code, exec.VMConfig{
+			DefaultMemoryPages:   128,
+			DefaultTableSize:     128,
+			DisableFloatingPoint: true,
+			GasLimit:             uint64(gasLimit),
+		}, &Resolver{w: w}, &compiler.SimpleGasPolicy{GasPerInstruction: 1})
+		if err != nil {
+			return 0, "", 0, err
+		}
+		if !ok || cached.ExitError != nil {
+			w.VMCache[contract] = vm
+		}
+	} else {
+		vm.Config.GasLimit = uint64(gasLimit)
+		vm.Gas = 0
+	}
+
+	// Get the function ID of the entry function to be executed.
+	entryID, ok := vm.GetFunctionExport(method)
+	if !ok {
+		return 0, "", 0, types2.ErrInvalidMethod
+	}
+
+	w.contractName = contract
+	w.returnData = nil
+
+	parameters, err := w.packParameters(vm, args, int(gasLimit))
+	if err != nil {
+		return 0, "", vm.Gas, err
+	}
+	// Run the WebAssembly module's entry function.
+	ret, err := vm.RunWithGasLimit(entryID, int(gasLimit), parameters...)
+	if err != nil {
+		return 0, "", vm.Gas, err
+	}
+	var jsonResult string
+	if m, ok := abi.Method(method); ok && len(w.returnData) > 0 {
+		jsonResult, err = m.UnpackOutputs(w.returnData)
+		if err != nil {
+			// 返回数据格式错误不影响合约执行结果
+			log.Error("unpack wasm return data", "contract", contract, "method", method, "error", err)
+		}
+	}
+	return ret, jsonResult, vm.Gas, nil
+}
+
+// 跨合约调用，被调用合约与调用者共享gas，getFrom返回调用者合约的地址
+// 被调用合约执行失败或者返回负值时，回滚其修改的状态以及产生的回执
+func (w *Wasm) nestedCall(vm *exec.VirtualMachine, contract, method string, data []byte) (int64, error) {
+	if w.callDepth >= types2.MaxCallDepth {
+		return -1, types2.ErrCallDepthExceeded
+	}
+	// gas上限为0时不限制gas
+	if vm.Config.GasLimit != 0 && vm.Gas >= vm.Config.GasLimit {
+		return -1, types2.ErrOutOfGas
+	}
+	abi, err := w.contractABI(contract)
+	if err != nil && err != types2.ErrNoABI {
+		return -1, err
+	}
+	var args []*types2.WasmArg
+	if m, ok := abi.Method(method); ok {
+		args, err = m.UnpackArgs(data)
+		if err != nil {
+			return -1, err
+		}
+	} else {
+		// 没有ABI描述的方法，参数为小端编码的int64数组
+		if len(data)%8 != 0 {
+			return -1, types2.ErrInvalidParam
+		}
+		for i := 0; i < len(data); i += 8 {
+			args = append(args, &types2.WasmArg{Value: int64(binary.LittleEndian.Uint64(data[i:]))})
+		}
+	}
+
+	caller := w.contractName
+	// 调用者已经修改的状态先放入回执，调用者之后修改的状态使用新的KVCreator记录，
+	// 这样被调用合约(包括重入的调用者)修改的状态在回执中排在它们之间，和执行的顺序一致
+	w.kvs = append(w.kvs, w.stateKVC.KVList()...)
+	w.stateKVC = dapp.NewKVCreator(w.GetStateDB(), calcStatePrefix(caller), nil)
+	from, stateKVC, returnData, stateDB := w.from, w.stateKVC, w.returnData, w.GetStateDB()
+	nkvs, nlogs, nlocal, ncustom := len(w.kvs), len(w.receiptLogs), len(w.localCache), len(w.customLogs)
+	journal := &journalDB{KV: stateDB}
+	w.SetStateDB(journal)
+	w.from = address.ExecAddress(w.userExecName(caller, false))
+	w.callDepth++
+	var gasLimit uint64
+	if vm.Config.GasLimit != 0 {
+		gasLimit = vm.Config.GasLimit - vm.Gas
+	}
+	ret, jsonResult, gas, err := w.execContract(contract, method, abi, args, int64(gasLimit))
+	w.callDepth--
+	w.SetStateDB(stateDB)
+	w.lastReturn = w.returnData
+	calleeKVs := w.stateKVC.KVList()
+	w.contractName, w.from, w.stateKVC, w.returnData = caller, from, stateKVC, returnData
+	vm.AddAndCheckGas(gas)
+
+	if err == nil && int32(ret) >= 0 && int16(ret) >= 0 {
+		// 被调用合约的kv已经带有被调用合约的状态前缀，不能再加上调用者的前缀
+		w.kvs = append(w.kvs, calleeKVs...)
+		w.receiptLogs = append(w.receiptLogs, &types.ReceiptLog{Ty: types2.TyLogWasmCall, Log: types.Encode(&types2.CallContractLog{
+			Contract:   contract,
+			Method:     method,
+			Result:     int32(ret),
+			ReturnData: w.lastReturn,
+			JsonResult: jsonResult,
+		})})
+		return ret, nil
+	}
+	journal.revert()
+	w.kvs, w.receiptLogs, w.localCache, w.customLogs = w.kvs[:nkvs], w.receiptLogs[:nlogs], w.localCache[:nlocal], w.customLogs[:ncustom]
+	if err != nil {
+		w.lastReturn = nil
+		return -1, err
+	}
+	return ret, nil
+}
+
+// 非数值类型的参数通过合约导出的allocate方法分配内存后写入，以(ptr,len)的形式传入
+func (w *Wasm) packParameters(vm *exec.VirtualMachine, args []*types2.WasmArg, gasLimit int) ([]int64, error) {
+	var parameters []int64
+	for _, arg := range args {
+		if !arg.IsBuffer {
+			parameters = append(parameters, arg.Value)
+			continue
+		}
+		allocID, ok := vm.GetFunctionExport(types2.AllocateFunc)
+		if !ok {
+			return nil, types2.ErrInvalidMethod
+		}
+		ptr, err := vm.RunWithGasLimit(allocID, gasLimit, int64(len(arg.Buffer)))
+		if err != nil {
+			return nil, err
+		}
+		ptr = int64(uint32(ptr))
+		if ptr+int64(len(arg.Buffer)) > int64(len(vm.Memory)) {
+			return nil, types2.ErrOutOfMemory
+		}
+		copy(vm.Memory[ptr:], arg.Buffer)
+		parameters = append(parameters, ptr, int64(len(arg.Buffer)))
+	}
+	return parameters, nil
+}
+
+func checkCode(code []byte, abi string) error {
+	if len(code) > types2.MaxCodeSize {
+		return types2.ErrCodeOversize
+	}
+	if err := validation.ValidateWasm(code); err != nil {
+		return types2.ErrInvalidWasm
+	}
+	if abi != "" {
+		if _, err := types2.ParseABI(abi); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func validateName(name string) bool {
 	if !types2.NameReg.MatchString(name) || len(name) < 4 || len(name) > 20 {
 		return false
@@ -1,6 +1,7 @@
 package executor
 
 import (
+	"github.com/33cn/chain33/common/address"
 	"github.com/33cn/chain33/types"
 	types2 "github.com/33cn/plugin/plugin/dapp/wasm/types"
 )
@@ -11,3 +12,66 @@ func (w *Wasm) Query_Check(query *types2.QueryCheckContract) (types.Message, err
 	}
 	return &types.Reply{IsOk: w.contractExist(query.Name)}, nil
 }
+
+func (w *Wasm) Query_GetABI(query *types2.QueryCheckContract) (types.Message, error) {
+	if query == nil {
+		return nil, types.ErrInvalidParam
+	}
+	data, err := w.GetStateDB().Get(abiKey(query.Name))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, types.ErrNotFound
+	}
+	return &types.ReplyString{Data: string(data)}, nil
+}
+
+// Query_ListVersions 查询合约的信息以及所有的代码版本
+func (w *Wasm) Query_ListVersions(query *types2.QueryCheckContract) (types.Message, error) {
+	if query == nil {
+		return nil, types.ErrInvalidParam
+	}
+	info, err := w.contractInfo(query.Name)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := w.contractVersions(info)
+	if err != nil {
+		return nil, err
+	}
+	return &types2.WasmContractVersions{Info: info, Versions: versions}, nil
+}
+
+func (w *Wasm) Query_Call(query *types2.QueryCallContract) (types.Message, error) {
+	if query == nil {
+		return nil, types.ErrInvalidParam
+	}
+	if query.Caller != "" {
+		if err := address.CheckAddress(query.Caller); err != nil {
+			return nil, err
+		}
+	}
+	w.from = query.Caller
+	w.execAddr = address.ExecAddress(types2.WasmX)
+	w.customLogs = nil
+	w.readOnly = true
+	defer func() {
+		w.readOnly = false
+	}()
+	ret, jsonResult, err := w.callContract(&types2.WasmCall{
+		Contract:   query.Contract,
+		Method:     query.Method,
+		Parameters: query.Parameters,
+		JsonArgs:   query.JsonArgs,
+	}, types2.QueryGasLimit)
+	if err != nil {
+		return nil, err
+	}
+	return &types2.QueryCallResult{
+		Result:     ret,
+		ReturnData: w.returnData,
+		JsonResult: jsonResult,
+		Logs:       w.customLogs,
+	}, nil
+}
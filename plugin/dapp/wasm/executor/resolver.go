@@ -8,12 +8,28 @@ import (
 )
 
 // Resolver defines imports for WebAssembly modules ran in Life.
-type Resolver struct{}
+// 每个虚拟机绑定创建它的执行器，导入函数通过它访问当前执行的上下文，并发的查询之间互不影响
+type Resolver struct {
+	w *Wasm
+}
+
+// v2Imports ForkWasmV2之后增加的导入函数
+var v2Imports = map[string]bool{
+	"setReturnData":     true,
+	"callContract":      true,
+	"getReturnDataSize": true,
+	"getReturnData":     true,
+}
 
 // ResolveFunc defines a set of import functions that may be called within a WebAssembly module.
 func (r *Resolver) ResolveFunc(module, field string) exec.FunctionImport {
 	switch module {
 	case "env":
+		// ForkWasmV2之前没有返回数据和跨合约调用的导入函数
+		if v2Imports[field] && !r.w.isV2() {
+			log.Error("ResolveFunc", "unknown field", field)
+			return nil
+		}
 		switch field {
 		case "setStateDB":
 			return func(vm *exec.VirtualMachine) int64 {
@@ -24,7 +40,7 @@ func (r *Resolver) ResolveFunc(module, field string) exec.FunctionImport {
 				valueLen := int(uint32(vm.GetCurrentFrame().Locals[3]))
 				value := make([]byte, valueLen)
 				copy(value, vm.Memory[valuePtr:valuePtr+valueLen])
-				setStateDB(key, value)
+				r.w.setStateDB(key, value)
 				return 0
 			}
 
@@ -33,7 +49,7 @@ func (r *Resolver) ResolveFunc(module, field string) exec.FunctionImport {
 				keyPtr := int(uint32(vm.GetCurrentFrame().Locals[0]))
 				keyLen := int(uint32(vm.GetCurrentFrame().Locals[1]))
 				key := vm.Memory[keyPtr : keyPtr+keyLen]
-				return int64(getStateDBSize(key))
+				return int64(r.w.getStateDBSize(key))
 			}
 
 		case "getStateDB":
@@ -43,7 +59,7 @@ func (r *Resolver) ResolveFunc(module, field string) exec.FunctionImport {
 				key := vm.Memory[keyPtr : keyPtr+keyLen]
 				valuePtr := int(uint32(vm.GetCurrentFrame().Locals[2]))
 				valueLen := int(uint32(vm.GetCurrentFrame().Locals[3]))
-				value, err := getStateDB(key)
+				value, err := r.w.getStateDB(key)
 				if err != nil {
 					for i := 0; i < valueLen; i++ {
 						vm.Memory[valuePtr+i] = 0
@@ -66,7 +82,7 @@ func (r *Resolver) ResolveFunc(module, field string) exec.FunctionImport {
 				valueLen := int(uint32(vm.GetCurrentFrame().Locals[3]))
 				value := make([]byte, valueLen)
 				copy(value, vm.Memory[valuePtr:valuePtr+valueLen])
-				setLocalDB(key, value)
+				r.w.setLocalDB(key, value)
 				return 0
 			}
 
@@ -75,7 +91,7 @@ func (r *Resolver) ResolveFunc(module, field string) exec.FunctionImport {
 				keyPtr := int(uint32(vm.GetCurrentFrame().Locals[0]))
 				keyLen := int(uint32(vm.GetCurrentFrame().Locals[1]))
 				key := vm.Memory[keyPtr : keyPtr+keyLen]
-				return int64(getLocalDBSize(key))
+				return int64(r.w.getLocalDBSize(key))
 			}
 
 		case "getLocalDB":
@@ -85,7 +101,7 @@ func (r *Resolver) ResolveFunc(module, field string) exec.FunctionImport {
 				key := vm.Memory[keyPtr : keyPtr+keyLen]
 				valuePtr := int(uint32(vm.GetCurrentFrame().Locals[2]))
 				valueLen := int(uint32(vm.GetCurrentFrame().Locals[3]))
-				value, err := getLocalDB(key)
+				value, err := r.w.getLocalDB(key)
 				if err != nil {
 					copy(vm.Memory[valuePtr:valuePtr+valueLen], make([]byte, valueLen))
 				}
@@ -106,7 +122,7 @@ func (r *Resolver) ResolveFunc(module, field string) exec.FunctionImport {
 				execPtr := int(uint32(vm.GetCurrentFrame().Locals[2]))
 				execLen := int(uint32(vm.GetCurrentFrame().Locals[3]))
 				exec := string(vm.Memory[execPtr : execPtr+execLen])
-				balance, _, err := getBalance(addr, exec)
+				balance, _, err := r.w.getBalance(addr, exec)
 				if err != nil {
 					return -1
 				}
@@ -121,7 +137,7 @@ func (r *Resolver) ResolveFunc(module, field string) exec.FunctionImport {
 				execPtr := int(uint32(vm.GetCurrentFrame().Locals[2]))
 				execLen := int(uint32(vm.GetCurrentFrame().Locals[3]))
 				exec := string(vm.Memory[execPtr : execPtr+execLen])
-				_, frozen, err := getBalance(addr, exec)
+				_, frozen, err := r.w.getBalance(addr, exec)
 				if err != nil {
 					return -1
 				}
@@ -137,7 +153,7 @@ func (r *Resolver) ResolveFunc(module, field string) exec.FunctionImport {
 				toLen := int(uint32(vm.GetCurrentFrame().Locals[3]))
 				toAddr := string(vm.Memory[toPtr : toPtr+toLen])
 				amount := vm.GetCurrentFrame().Locals[4]
-				err := transfer(fromAddr, toAddr, amount)
+				err := r.w.transfer(fromAddr, toAddr, amount)
 				if err != nil {
 					return -1
 				}
@@ -153,7 +169,7 @@ func (r *Resolver) ResolveFunc(module, field string) exec.FunctionImport {
 				toLen := int(uint32(vm.GetCurrentFrame().Locals[3]))
 				toAddr := string(vm.Memory[toPtr : toPtr+toLen])
 				amount := vm.GetCurrentFrame().Locals[4]
-				err := transferToExec(fromAddr, toAddr, amount)
+				err := r.w.transferToExec(fromAddr, toAddr, amount)
 				if err != nil {
 					return -1
 				}
@@ -169,7 +185,7 @@ func (r *Resolver) ResolveFunc(module, field string) exec.FunctionImport {
 				toLen := int(uint32(vm.GetCurrentFrame().Locals[3]))
 				toAddr := string(vm.Memory[toPtr : toPtr+toLen])
 				amount := vm.GetCurrentFrame().Locals[4]
-				err := transferWithdraw(fromAddr, toAddr, amount)
+				err := r.w.transferWithdraw(fromAddr, toAddr, amount)
 				if err != nil {
 					return -1
 				}
@@ -194,7 +210,7 @@ func (r *Resolver) ResolveFunc(module, field string) exec.FunctionImport {
 				addrLen := int(uint32(vm.GetCurrentFrame().Locals[1]))
 				addr := string(vm.Memory[addrPtr : addrPtr+addrLen])
 				amount := vm.GetCurrentFrame().Locals[2]
-				err := execFrozen(addr, amount)
+				err := r.w.execFrozen(addr, amount)
 				if err != nil {
 					return -1
 				}
@@ -207,7 +223,7 @@ func (r *Resolver) ResolveFunc(module, field string) exec.FunctionImport {
 				addrLen := int(uint32(vm.GetCurrentFrame().Locals[1]))
 				addr := string(vm.Memory[addrPtr : addrPtr+addrLen])
 				amount := vm.GetCurrentFrame().Locals[2]
-				err := execActive(addr, amount)
+				err := r.w.execActive(addr, amount)
 				if err != nil {
 					return -1
 				}
@@ -223,7 +239,7 @@ func (r *Resolver) ResolveFunc(module, field string) exec.FunctionImport {
 				toLen := int(uint32(vm.GetCurrentFrame().Locals[3]))
 				toAddr := string(vm.Memory[toPtr : toPtr+toLen])
 				amount := vm.GetCurrentFrame().Locals[4]
-				err := execTransfer(fromAddr, toAddr, amount)
+				err := r.w.execTransfer(fromAddr, toAddr, amount)
 				if err != nil {
 					return -1
 				}
@@ -239,7 +255,7 @@ func (r *Resolver) ResolveFunc(module, field string) exec.FunctionImport {
 				toLen := int(uint32(vm.GetCurrentFrame().Locals[3]))
 				toAddr := string(vm.Memory[toPtr : toPtr+toLen])
 				amount := vm.GetCurrentFrame().Locals[4]
-				err := execTransferFrozen(fromAddr, toAddr, amount)
+				err := r.w.execTransferFrozen(fromAddr, toAddr, amount)
 				if err != nil {
 					return -1
 				}
@@ -248,7 +264,7 @@ func (r *Resolver) ResolveFunc(module, field string) exec.FunctionImport {
 
 		case "getFrom":
 			return func(vm *exec.VirtualMachine) int64 {
-				fromAddr := []byte(getFrom())
+				fromAddr := []byte(r.w.getFrom())
 				fromPtr := int(uint32(vm.GetCurrentFrame().Locals[0]))
 				fromLen := int(uint32(vm.GetCurrentFrame().Locals[1]))
 				copy(vm.Memory[fromPtr:fromPtr+fromLen], fromAddr)
@@ -256,24 +272,66 @@ func (r *Resolver) ResolveFunc(module, field string) exec.FunctionImport {
 			}
 
 		case "getHeight":
-			return func(vm *exec.VirtualMachine) int64 { return getHeight() }
+			return func(vm *exec.VirtualMachine) int64 { return r.w.getHeight() }
 
 		case "getRandom":
-			return func(vm *exec.VirtualMachine) int64 { return getRandom() }
+			return func(vm *exec.VirtualMachine) int64 { return r.w.getRandom() }
 
 		case "printlog":
 			return func(vm *exec.VirtualMachine) int64 {
 				logPtr := int(uint32(vm.GetCurrentFrame().Locals[0]))
 				logLen := int(uint32(vm.GetCurrentFrame().Locals[1]))
 				logInfo := string(vm.Memory[logPtr : logPtr+logLen])
-				printlog(logInfo)
+				r.w.printlog(logInfo)
+				return 0
+			}
+
+		case "setReturnData":
+			return func(vm *exec.VirtualMachine) int64 {
+				dataPtr := int(uint32(vm.GetCurrentFrame().Locals[0]))
+				dataLen := int(uint32(vm.GetCurrentFrame().Locals[1]))
+				data := make([]byte, dataLen)
+				copy(data, vm.Memory[dataPtr:dataPtr+dataLen])
+				r.w.setReturnData(data)
 				return 0
 			}
 
+		case "callContract":
+			return func(vm *exec.VirtualMachine) int64 {
+				namePtr := int(uint32(vm.GetCurrentFrame().Locals[0]))
+				nameLen := int(uint32(vm.GetCurrentFrame().Locals[1]))
+				name := string(vm.Memory[namePtr : namePtr+nameLen])
+				methodPtr := int(uint32(vm.GetCurrentFrame().Locals[2]))
+				methodLen := int(uint32(vm.GetCurrentFrame().Locals[3]))
+				method := string(vm.Memory[methodPtr : methodPtr+methodLen])
+				argsPtr := int(uint32(vm.GetCurrentFrame().Locals[4]))
+				argsLen := int(uint32(vm.GetCurrentFrame().Locals[5]))
+				args := make([]byte, argsLen)
+				copy(args, vm.Memory[argsPtr:argsPtr+argsLen])
+				return r.w.crossCall(vm, name, method, args)
+			}
+
+		case "getReturnDataSize":
+			return func(vm *exec.VirtualMachine) int64 {
+				return int64(len(r.w.getCallReturnData()))
+			}
+
+		case "getReturnData":
+			return func(vm *exec.VirtualMachine) int64 {
+				dataPtr := int(uint32(vm.GetCurrentFrame().Locals[0]))
+				dataLen := int(uint32(vm.GetCurrentFrame().Locals[1]))
+				data := r.w.getCallReturnData()
+				if dataLen != len(data) {
+					return 0
+				}
+				copy(vm.Memory[dataPtr:dataPtr+dataLen], data)
+				return int64(dataLen)
+			}
+
 		case "printint":
 			return func(vm *exec.VirtualMachine) int64 {
 				n := vm.GetCurrentFrame().Locals[0]
-				printlog(strconv.FormatInt(n, 10))
+				r.w.printlog(strconv.FormatInt(n, 10))
 				return 0
 			}
 
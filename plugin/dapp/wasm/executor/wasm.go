@@ -29,12 +29,16 @@ func initExecType() {
 type Wasm struct {
 	drivers.DriverBase
 
-	tx           *types.Transaction
+	from         string
+	readOnly     bool
 	stateKVC     *dapp.KVCreator
 	localCache   []*types2.LocalDataLog
 	kvs          []*types.KeyValue
 	receiptLogs  []*types.ReceiptLog
 	customLogs   []string
+	returnData   []byte
+	lastReturn   []byte // 最近一次跨合约调用的返回数据
+	callDepth    int
 	execAddr     string
 	contractName string
 	VMCache      map[string]*exec.VirtualMachine
@@ -54,6 +58,12 @@ func GetName() string {
 	return newWasm().GetName()
 }
 
+// isV2 ForkWasmV2之后支持合约ABI，合约升级和跨合约调用
+func (w *Wasm) isV2() bool {
+	cfg := w.GetAPI().GetConfig()
+	return cfg.IsDappFork(w.GetHeight(), types2.WasmX, types2.ForkWasmV2)
+}
+
 func (w *Wasm) GetDriverName() string {
 	return driverName
 }
@@ -1,9 +1,12 @@
 package executor
 
 import (
+	"bytes"
 	"encoding/hex"
 	"io/ioutil"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -18,6 +21,8 @@ import (
 	"github.com/33cn/chain33/types"
 	"github.com/33cn/chain33/util"
 	types2 "github.com/33cn/plugin/plugin/dapp/wasm/types"
+	"github.com/perlin-network/life/exec"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -39,6 +44,7 @@ var (
 
 func init() {
 	cfg = types.NewChain33Config(strings.Replace(types.GetDefaultCfgstring(), "Title=\"local\"", "Title=\"chain33\"", 1))
+	cfg.SetDappFork(types2.WasmX, types2.ForkWasmV2, 0)
 	Init(types2.WasmX, cfg, nil)
 }
 
@@ -73,10 +79,8 @@ func BenchmarkWasm_Exec_Call(b *testing.B) {
 	api.On("GetConfig").Return(cfg)
 	api.On("GetRandNum", mock.Anything).Return(hex.DecodeString("0x0b1f047927e1c42327bdd3222558eaf7b10b998e7a9bb8144e4b2a27ffa53df3"))
 	wasm.SetAPI(&api)
-	wasmCB = wasm.(*Wasm)
-	err = transferToExec(Addrs[1], wasmAddr, 1e9)
+	err = wasm.(*Wasm).transferToExec(Addrs[1], wasmAddr, 1e9)
 	require.Nil(b, err)
-	wasmCB = nil
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -98,42 +102,42 @@ func TestWasm_Exec(t *testing.T) {
 func TestWasm_Callback(t *testing.T) {
 	dir, ldb, kvdb := util.CreateTestDB()
 	defer util.CloseTestDB(dir, ldb)
-	wasmCB = newWasm().(*Wasm)
+	w := newWasm().(*Wasm)
 	acc := initAccount(ldb)
-	wasmCB.SetCoinsAccount(acc)
-	wasmCB.SetStateDB(kvdb)
-	wasmCB.SetLocalDB(kvdb)
-	wasmCB.execAddr = wasmAddr
-	wasmCB.contractName = "dice"
-	wasmCB.stateKVC = dapp.NewKVCreator(wasmCB.GetStateDB(), calcStatePrefix("dice"), nil)
+	w.SetCoinsAccount(acc)
+	w.SetStateDB(kvdb)
+	w.SetLocalDB(kvdb)
+	w.execAddr = wasmAddr
+	w.contractName = "dice"
+	w.stateKVC = dapp.NewKVCreator(w.GetStateDB(), calcStatePrefix("dice"), nil)
 	api := mocks.QueueProtocolAPI{}
 	api.On("GetConfig").Return(cfg)
-	wasmCB.SetAPI(&api)
+	w.SetAPI(&api)
 
 	var err error
 	testKey, testValue := []byte("test"), []byte("test")
 
 	//test stateDB
-	setStateDB(testKey, testValue)
-	stateValue, _ := getStateDB(testKey)
+	w.setStateDB(testKey, testValue)
+	stateValue, _ := w.getStateDB(testKey)
 	require.Equal(t, testValue, stateValue)
 
 	//test localDB
-	setLocalDB(testKey, testValue)
+	w.setLocalDB(testKey, testValue)
 	var localLogs []*types.ReceiptLog
-	for _, log := range wasmCB.localCache {
+	for _, log := range w.localCache {
 		localLogs = append(localLogs, &types.ReceiptLog{
 			Ty:  types2.TyLogLocalData,
 			Log: types.Encode(log),
 		})
 	}
-	set, err := wasmCB.ExecLocal_Call(&types2.WasmCall{Contract: "dice"}, &types.Transaction{Execer: []byte("wasm")}, &types.ReceiptData{
+	set, err := w.ExecLocal_Call(&types2.WasmCall{Contract: "dice"}, &types.Transaction{Execer: []byte("wasm")}, &types.ReceiptData{
 		Ty:   types.ExecOk,
-		Logs: append(wasmCB.receiptLogs, localLogs...),
+		Logs: append(w.receiptLogs, localLogs...),
 	}, 0)
 	require.Nil(t, err)
 	require.Equal(t, 2, len(set.KV))
-	localValue, _ := getLocalDB(testKey)
+	localValue, _ := w.getLocalDB(testKey)
 	require.Equal(t, testValue, localValue)
 
 	//test getBalance
@@ -145,75 +149,75 @@ func TestWasm_Callback(t *testing.T) {
 			Frozen:  1e10,
 		})},
 	}, nil)
-	balance, frozen, err := getBalance(Addrs[0], types2.WasmX)
+	balance, frozen, err := w.getBalance(Addrs[0], types2.WasmX)
 	require.Nil(t, err)
 	require.Equal(t, int64(1e8), balance)
 	require.Equal(t, int64(1e10), frozen)
 
 	//test account operations
 	//test transfer
-	wasmCB.receiptLogs = nil
-	err = transfer(Addrs[0], Addrs[1], 1e8)
+	w.receiptLogs = nil
+	err = w.transfer(Addrs[0], Addrs[1], 1e8)
 	require.Nil(t, err)
 	accountTransfer := types.ReceiptAccountTransfer{}
-	err = types.Decode(wasmCB.receiptLogs[0].Log, &accountTransfer)
+	err = types.Decode(w.receiptLogs[0].Log, &accountTransfer)
 	require.Nil(t, err)
 	require.Equal(t, int64(1e10), accountTransfer.Prev.Balance)
 	require.Equal(t, int64(99e8), accountTransfer.Current.Balance)
-	err = types.Decode(wasmCB.receiptLogs[1].Log, &accountTransfer)
+	err = types.Decode(w.receiptLogs[1].Log, &accountTransfer)
 	require.Nil(t, err)
 	require.Equal(t, int64(1e10), accountTransfer.Prev.Balance)
 	require.Equal(t, int64(101e8), accountTransfer.Current.Balance)
 
 	//test transfer to exec
-	wasmCB.receiptLogs = nil
-	err = transferToExec(Addrs[0], wasmAddr, 1e9)
+	w.receiptLogs = nil
+	err = w.transferToExec(Addrs[0], wasmAddr, 1e9)
 	require.Nil(t, err)
-	err = types.Decode(wasmCB.receiptLogs[0].Log, &accountTransfer)
+	err = types.Decode(w.receiptLogs[0].Log, &accountTransfer)
 	require.Nil(t, err)
 	require.Equal(t, int64(99e8), accountTransfer.Prev.Balance)
 	require.Equal(t, int64(89e8), accountTransfer.Current.Balance)
-	err = types.Decode(wasmCB.receiptLogs[1].Log, &accountTransfer)
+	err = types.Decode(w.receiptLogs[1].Log, &accountTransfer)
 	require.Nil(t, err)
 	require.Equal(t, int64(0), accountTransfer.Prev.Balance)
 	require.Equal(t, int64(1e9), accountTransfer.Current.Balance)
 
 	//test transfer withdraw
-	wasmCB.receiptLogs = nil
-	err = transferWithdraw(Addrs[0], wasmAddr, 1e8)
+	w.receiptLogs = nil
+	err = w.transferWithdraw(Addrs[0], wasmAddr, 1e8)
 	require.Nil(t, err)
 	execAccountTransfer := types.ReceiptExecAccountTransfer{}
-	err = types.Decode(wasmCB.receiptLogs[0].Log, &execAccountTransfer)
+	err = types.Decode(w.receiptLogs[0].Log, &execAccountTransfer)
 	require.Nil(t, err)
 	require.Equal(t, int64(1e9), execAccountTransfer.Prev.Balance)
 	require.Equal(t, int64(9e8), execAccountTransfer.Current.Balance)
-	err = types.Decode(wasmCB.receiptLogs[1].Log, &accountTransfer)
+	err = types.Decode(w.receiptLogs[1].Log, &accountTransfer)
 	require.Nil(t, err)
 	require.Equal(t, int64(1e9), accountTransfer.Prev.Balance)
 	require.Equal(t, int64(9e8), accountTransfer.Current.Balance)
-	err = types.Decode(wasmCB.receiptLogs[2].Log, &accountTransfer)
+	err = types.Decode(w.receiptLogs[2].Log, &accountTransfer)
 	require.Nil(t, err)
 	require.Equal(t, int64(89e8), accountTransfer.Prev.Balance)
 	require.Equal(t, int64(9e9), accountTransfer.Current.Balance)
 
 	//test exec transfer
-	wasmCB.receiptLogs = nil
-	err = execTransfer(Addrs[0], Addrs[1], 1e8)
+	w.receiptLogs = nil
+	err = w.execTransfer(Addrs[0], Addrs[1], 1e8)
 	require.Nil(t, err)
-	err = types.Decode(wasmCB.receiptLogs[0].Log, &execAccountTransfer)
+	err = types.Decode(w.receiptLogs[0].Log, &execAccountTransfer)
 	require.Nil(t, err)
 	require.Equal(t, int64(9e8), execAccountTransfer.Prev.Balance)
 	require.Equal(t, int64(8e8), execAccountTransfer.Current.Balance)
-	err = types.Decode(wasmCB.receiptLogs[1].Log, &execAccountTransfer)
+	err = types.Decode(w.receiptLogs[1].Log, &execAccountTransfer)
 	require.Nil(t, err)
 	require.Equal(t, int64(0), execAccountTransfer.Prev.Balance)
 	require.Equal(t, int64(1e8), execAccountTransfer.Current.Balance)
 
 	//test exec frozen
-	wasmCB.receiptLogs = nil
-	err = execFrozen(Addrs[0], 2e8)
+	w.receiptLogs = nil
+	err = w.execFrozen(Addrs[0], 2e8)
 	require.Nil(t, err)
-	err = types.Decode(wasmCB.receiptLogs[0].Log, &execAccountTransfer)
+	err = types.Decode(w.receiptLogs[0].Log, &execAccountTransfer)
 	require.Nil(t, err)
 	require.Equal(t, int64(8e8), execAccountTransfer.Prev.Balance)
 	require.Equal(t, int64(0), execAccountTransfer.Prev.Frozen)
@@ -221,16 +225,16 @@ func TestWasm_Callback(t *testing.T) {
 	require.Equal(t, int64(2e8), execAccountTransfer.Current.Frozen)
 
 	//test exec transfer frozen
-	wasmCB.receiptLogs = nil
-	err = execTransferFrozen(Addrs[0], Addrs[1], 1e8)
+	w.receiptLogs = nil
+	err = w.execTransferFrozen(Addrs[0], Addrs[1], 1e8)
 	require.Nil(t, err)
-	err = types.Decode(wasmCB.receiptLogs[0].Log, &execAccountTransfer)
+	err = types.Decode(w.receiptLogs[0].Log, &execAccountTransfer)
 	require.Nil(t, err)
 	require.Equal(t, int64(6e8), execAccountTransfer.Prev.Balance)
 	require.Equal(t, int64(2e8), execAccountTransfer.Prev.Frozen)
 	require.Equal(t, int64(6e8), execAccountTransfer.Current.Balance)
 	require.Equal(t, int64(1e8), execAccountTransfer.Current.Frozen)
-	err = types.Decode(wasmCB.receiptLogs[1].Log, &execAccountTransfer)
+	err = types.Decode(w.receiptLogs[1].Log, &execAccountTransfer)
 	require.Nil(t, err)
 	require.Equal(t, int64(1e8), execAccountTransfer.Prev.Balance)
 	require.Equal(t, int64(0), execAccountTransfer.Prev.Frozen)
@@ -238,10 +242,10 @@ func TestWasm_Callback(t *testing.T) {
 	require.Equal(t, int64(0), execAccountTransfer.Current.Frozen)
 
 	//test exec active
-	wasmCB.receiptLogs = nil
-	err = execActive(Addrs[0], 1e8)
+	w.receiptLogs = nil
+	err = w.execActive(Addrs[0], 1e8)
 	require.Nil(t, err)
-	err = types.Decode(wasmCB.receiptLogs[0].Log, &execAccountTransfer)
+	err = types.Decode(w.receiptLogs[0].Log, &execAccountTransfer)
 	require.Nil(t, err)
 	require.Equal(t, int64(6e8), execAccountTransfer.Prev.Balance)
 	require.Equal(t, int64(1e8), execAccountTransfer.Prev.Frozen)
@@ -258,8 +262,8 @@ func TestWasm_Callback(t *testing.T) {
 	api.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(&types.ReplyHash{
 		Hash: common.Sha256(seedGen()),
 	}, nil)
-	wasmCB.SetExecutorAPI(&api, gclient)
-	random := getRandom()
+	w.SetExecutorAPI(&api, gclient)
+	random := w.getRandom()
 	t.Log(random)
 }
 
@@ -323,8 +327,7 @@ func testCall(t testing.TB, acc *account.DB, stateDB db.KV) {
 	api := mocks.QueueProtocolAPI{}
 	api.On("GetConfig").Return(cfg)
 	wasm.SetAPI(&api)
-	wasmCB = wasm.(*Wasm)
-	err = transferToExec(Addrs[0], wasmAddr, 1e9)
+	err = wasm.(*Wasm).transferToExec(Addrs[0], wasmAddr, 1e9)
 	require.Nil(t, err)
 	receipt, err := wasm.Exec(tx, 0)
 	require.Nil(t, err, "tx exec error")
@@ -369,3 +372,623 @@ func signTx(tx *types.Transaction, hexPrivKey string) error {
 	tx.Sign(int32(signType), privKey)
 	return nil
 }
+
+// 手工编码的测试合约，导入setReturnData和setStateDB，导出：
+// allocate(size) 从1024开始顺序分配内存，每次分配前预留4字节
+// echo(ptr,len) 在数据前写入4字节长度后设置为返回数据，即string类型的紧凑编码
+// raw(ptr,len) 直接将数据设置为返回数据
+// sum(a,b) 返回a+b
+// store(ptr,len) 将数据同时作为key和value写入statedb
+var typedWasm = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	// type
+	0x01, 0x1e, 0x05,
+	0x60, 0x02, 0x7f, 0x7f, 0x00,
+	0x60, 0x01, 0x7f, 0x01, 0x7f,
+	0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7f,
+	0x60, 0x02, 0x7e, 0x7e, 0x01, 0x7e,
+	0x60, 0x04, 0x7f, 0x7f, 0x7f, 0x7f, 0x00,
+	// import env.setReturnData, env.setStateDB
+	0x02, 0x26, 0x02,
+	0x03, 'e', 'n', 'v', 0x0d, 's', 'e', 't', 'R', 'e', 't', 'u', 'r', 'n', 'D', 'a', 't', 'a', 0x00, 0x00,
+	0x03, 'e', 'n', 'v', 0x0a, 's', 'e', 't', 'S', 't', 'a', 't', 'e', 'D', 'B', 0x00, 0x04,
+	// function
+	0x03, 0x06, 0x05, 0x01, 0x02, 0x02, 0x03, 0x02,
+	// memory
+	0x05, 0x03, 0x01, 0x00, 0x01,
+	// global: mut i32 = 1024
+	0x06, 0x07, 0x01, 0x7f, 0x01, 0x41, 0x80, 0x08, 0x0b,
+	// export
+	0x07, 0x30, 0x06,
+	0x06, 'm', 'e', 'm', 'o', 'r', 'y', 0x02, 0x00,
+	0x08, 'a', 'l', 'l', 'o', 'c', 'a', 't', 'e', 0x00, 0x02,
+	0x04, 'e', 'c', 'h', 'o', 0x00, 0x03,
+	0x03, 'r', 'a', 'w', 0x00, 0x04,
+	0x03, 's', 'u', 'm', 0x00, 0x05,
+	0x05, 's', 't', 'o', 'r', 'e', 0x00, 0x06,
+	// code
+	0x0a, 0x50, 0x05,
+	0x11, 0x00, 0x23, 0x00, 0x41, 0x04, 0x6a, 0x23, 0x00, 0x20, 0x00, 0x6a, 0x41, 0x04, 0x6a, 0x24, 0x00, 0x0b,
+	0x1a, 0x00, 0x20, 0x00, 0x41, 0x04, 0x6b, 0x20, 0x01, 0x36, 0x02, 0x00, 0x20, 0x00, 0x41, 0x04, 0x6b, 0x20, 0x01, 0x41, 0x04, 0x6a, 0x10, 0x00, 0x41, 0x00, 0x0b,
+	0x0a, 0x00, 0x20, 0x00, 0x20, 0x01, 0x10, 0x00, 0x41, 0x00, 0x0b,
+	0x07, 0x00, 0x20, 0x00, 0x20, 0x01, 0x7c, 0x0b,
+	0x0e, 0x00, 0x20, 0x00, 0x20, 0x01, 0x20, 0x00, 0x20, 0x01, 0x10, 0x01, 0x41, 0x00, 0x0b,
+}
+
+var typedABI = `[
+	{"name":"echo","inputs":[{"name":"msg","type":"string"}],"outputs":[{"name":"","type":"string"}]},
+	{"name":"raw","inputs":[{"name":"order","type":"tuple","components":[{"name":"id","type":"int64"},{"name":"owner","type":"address"},{"name":"data","type":"bytes"},{"name":"ok","type":"bool"}]}],
+	 "outputs":[{"name":"order","type":"tuple","components":[{"name":"id","type":"int64"},{"name":"owner","type":"address"},{"name":"data","type":"bytes"},{"name":"ok","type":"bool"}]}]},
+	{"name":"sum","inputs":[{"name":"a","type":"int64"},{"name":"b","type":"int64"}],"outputs":[]},
+	{"name":"store","inputs":[{"name":"data","type":"bytes"}],"outputs":[]}
+]`
+
+func newTestWasm(acc *account.DB, stateDB db.KV) dapp.Driver {
+	wasm := newWasm()
+	wasm.SetCoinsAccount(acc)
+	api := mocks.QueueProtocolAPI{}
+	api.On("GetConfig").Return(cfg)
+	wasm.SetAPI(&api)
+	wasm.SetStateDB(stateDB)
+	return wasm
+}
+
+func execWasmAction(t *testing.T, acc *account.DB, stateDB db.KV, action *types2.WasmAction) (*types.Receipt, error) {
+	return execWasmActionBy(t, newTestWasm(acc, stateDB), stateDB, action, PrivKeys[0])
+}
+
+func execWasmActionBy(t *testing.T, wasm dapp.Driver, stateDB db.KV, action *types2.WasmAction, privKey string) (*types.Receipt, error) {
+	tx, err := types.FormatTx(cfg, types2.WasmX, &types.Transaction{Payload: types.Encode(action)})
+	require.Nil(t, err)
+	require.Nil(t, signTx(tx, privKey))
+	receipt, err := wasm.Exec(tx, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, kv := range receipt.KV {
+		require.Nil(t, stateDB.Set(kv.Key, kv.Value))
+	}
+	return receipt, nil
+}
+
+func callTyped(t *testing.T, acc *account.DB, stateDB db.KV, method, args string) (*types2.CallContractLog, error) {
+	receipt, err := execWasmAction(t, acc, stateDB, &types2.WasmAction{
+		Ty:    types2.WasmActionCall,
+		Value: &types2.WasmAction_Call{Call: &types2.WasmCall{Contract: "typed", Method: method, JsonArgs: args}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var callLog types2.CallContractLog
+	require.Nil(t, types.Decode(receipt.Logs[0].Log, &callLog))
+	return &callLog, nil
+}
+
+func TestWasm_TypedCall(t *testing.T) {
+	dir, ldb, kvdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, ldb)
+	acc := initAccount(ldb)
+
+	create := func(name, abi string) error {
+		_, err := execWasmAction(t, acc, kvdb, &types2.WasmAction{
+			Ty:    types2.WasmActionCreate,
+			Value: &types2.WasmAction_Create{Create: &types2.WasmCreate{Name: name, Code: typedWasm, Abi: abi}},
+		})
+		return err
+	}
+	require.Equal(t, types2.ErrInvalidABI, create("typed", `[{"name":"echo","inputs":[{"type":"float"}]}]`))
+	require.Nil(t, create("typed", typedABI))
+	require.Nil(t, create("untyped", ""))
+
+	w := newWasm().(*Wasm)
+	api := mocks.QueueProtocolAPI{}
+	api.On("GetConfig").Return(cfg)
+	w.SetAPI(&api)
+	w.SetStateDB(kvdb)
+	reply, err := w.Query_GetABI(&types2.QueryCheckContract{Name: "typed"})
+	require.Nil(t, err)
+	require.Equal(t, typedABI, reply.(*types.ReplyString).Data)
+
+	callLog, err := callTyped(t, acc, kvdb, "echo", `["hello"]`)
+	require.Nil(t, err)
+	require.Equal(t, append([]byte{5, 0, 0, 0}, "hello"...), callLog.ReturnData)
+	require.Equal(t, `[{"name":"","type":"string","value":"hello"}]`, callLog.JsonResult)
+
+	callLog, err = callTyped(t, acc, kvdb, "raw", `[{"id":"9007199254740993","owner":"`+Addrs[0]+`","data":"0x0102","ok":true}]`)
+	require.Nil(t, err)
+	require.Equal(t, `[{"name":"order","type":"tuple","value":{"data":"0x0102","id":"9007199254740993","ok":true,"owner":"`+Addrs[0]+`"}}]`, callLog.JsonResult)
+
+	callLog, err = callTyped(t, acc, kvdb, "sum", `[40, "2"]`)
+	require.Nil(t, err)
+	require.Equal(t, int32(42), callLog.Result)
+	require.Empty(t, callLog.ReturnData)
+
+	_, err = callTyped(t, acc, kvdb, "sum", `[40]`)
+	require.Equal(t, types2.ErrInvalidParam, err)
+	_, err = callTyped(t, acc, kvdb, "raw", `[{"id":1,"owner":"invalid","data":"0x","ok":false}]`)
+	require.NotNil(t, err)
+	_, err = execWasmAction(t, acc, kvdb, &types2.WasmAction{
+		Ty:    types2.WasmActionCall,
+		Value: &types2.WasmAction_Call{Call: &types2.WasmCall{Contract: "untyped", Method: "echo", JsonArgs: `["hello"]`}},
+	})
+	require.Equal(t, types2.ErrNoABI, err)
+}
+
+func TestWasm_QueryCall(t *testing.T) {
+	dir, ldb, kvdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, ldb)
+	acc := initAccount(ldb)
+	_, err := execWasmAction(t, acc, kvdb, &types2.WasmAction{
+		Ty:    types2.WasmActionCreate,
+		Value: &types2.WasmAction_Create{Create: &types2.WasmCreate{Name: "typed", Code: typedWasm, Abi: typedABI}},
+	})
+	require.Nil(t, err)
+
+	query := func(q *types2.QueryCallContract) (*types2.QueryCallResult, error) {
+		w := newWasm().(*Wasm)
+		api := mocks.QueueProtocolAPI{}
+		api.On("GetConfig").Return(cfg)
+		w.SetAPI(&api)
+		w.SetStateDB(kvdb)
+		w.SetLocalDB(kvdb)
+		reply, err := w.Query_Call(q)
+		if err != nil {
+			return nil, err
+		}
+		return reply.(*types2.QueryCallResult), nil
+	}
+
+	res, err := query(&types2.QueryCallContract{Contract: "typed", Method: "echo", JsonArgs: `["hello"]`, Caller: Addrs[0]})
+	require.Nil(t, err)
+	require.Equal(t, `[{"name":"","type":"string","value":"hello"}]`, res.JsonResult)
+
+	// 并发的查询使用各自的执行上下文，返回数据互不影响
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			msg := strconv.Itoa(i)
+			res, err := query(&types2.QueryCallContract{Contract: "typed", Method: "echo", JsonArgs: `["` + msg + `"]`})
+			if assert.Nil(t, err) {
+				assert.Equal(t, `[{"name":"","type":"string","value":"`+msg+`"}]`, res.JsonResult)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// 返回值不截断为int32
+	res, err = query(&types2.QueryCallContract{Contract: "typed", Method: "sum", Parameters: []int64{5e9, 1}})
+	require.Nil(t, err)
+	require.Equal(t, int64(5e9+1), res.Result)
+
+	// 只读调用中不允许修改状态
+	_, err = query(&types2.QueryCallContract{Contract: "typed", Method: "store", JsonArgs: `["0x0102"]`})
+	require.Equal(t, types2.ErrReadOnly, err)
+	receipt, err := execWasmAction(t, acc, kvdb, &types2.WasmAction{
+		Ty:    types2.WasmActionCall,
+		Value: &types2.WasmAction_Call{Call: &types2.WasmCall{Contract: "typed", Method: "store", JsonArgs: `["0x0102"]`}},
+	})
+	require.Nil(t, err)
+	require.Equal(t, append(calcStatePrefix("typed"), 1, 2), receipt.KV[0].Key)
+
+	_, err = query(&types2.QueryCallContract{Contract: "typed", Method: "none"})
+	require.Equal(t, types2.ErrInvalidMethod, err)
+	_, err = query(&types2.QueryCallContract{Contract: "missing", Method: "echo"})
+	require.Equal(t, types.ErrNotFound, err)
+	_, err = query(&types2.QueryCallContract{Contract: "typed", Method: "sum", Parameters: []int64{1, 2}, Caller: "invalid"})
+	require.NotNil(t, err)
+}
+
+func TestWasm_Update(t *testing.T) {
+	dir, ldb, kvdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, ldb)
+	acc := initAccount(ldb)
+	diceCode, err := ioutil.ReadFile("../contracts/dice/dice.wasm")
+	require.Nil(t, err)
+
+	create := func(name, admin string) {
+		_, err := execWasmAction(t, acc, kvdb, &types2.WasmAction{
+			Ty:    types2.WasmActionCreate,
+			Value: &types2.WasmAction_Create{Create: &types2.WasmCreate{Name: name, Code: typedWasm, Abi: typedABI, Admin: admin}},
+		})
+		require.Nil(t, err)
+	}
+	update := func(wasm dapp.Driver, name string, code []byte, abi, privKey string) (*types.Receipt, error) {
+		return execWasmActionBy(t, wasm, kvdb, &types2.WasmAction{
+			Ty:    types2.WasmActionUpdate,
+			Value: &types2.WasmAction_Update{Update: &types2.WasmUpdate{Name: name, Code: code, Abi: abi}},
+		}, privKey)
+	}
+	call := func(wasm dapp.Driver) error {
+		_, err := execWasmActionBy(t, wasm, kvdb, &types2.WasmAction{
+			Ty:    types2.WasmActionCall,
+			Value: &types2.WasmAction_Call{Call: &types2.WasmCall{Contract: "typed", Method: "store", Parameters: []int64{1024, 4}}},
+		}, PrivKeys[0])
+		return err
+	}
+
+	create("typed", "")
+	create("managed", Addrs[1])
+	_, err = update(newTestWasm(acc, kvdb), "typed", diceCode, "", PrivKeys[1])
+	require.Equal(t, types2.ErrPermissionDenied, err)
+	_, err = update(newTestWasm(acc, kvdb), "typed", []byte{0x00, 0x61}, "", PrivKeys[0])
+	require.Equal(t, types2.ErrInvalidWasm, err)
+	_, err = update(newTestWasm(acc, kvdb), "missing", diceCode, "", PrivKeys[0])
+	require.Equal(t, types.ErrNotFound, err)
+
+	// 同一个执行器中升级后不再使用缓存的旧代码
+	wasm := newTestWasm(acc, kvdb)
+	require.Nil(t, call(wasm))
+	receipt, err := update(wasm, "typed", diceCode, "", PrivKeys[0])
+	require.Nil(t, err)
+	require.Equal(t, int32(types2.TyLogWasmUpdate), receipt.Logs[0].Ty)
+	require.Equal(t, types2.ErrInvalidMethod, call(wasm))
+	code, err := kvdb.Get(contractKey("typed"))
+	require.Nil(t, err)
+	require.Equal(t, diceCode, code)
+	// 合约的状态数据保持不变，新代码没有ABI时删除原有的ABI
+	_, err = kvdb.Get(append(calcStatePrefix("typed"), make([]byte, 4)...))
+	require.Nil(t, err)
+	_, err = newTestWasm(acc, kvdb).(*Wasm).contractABI("typed")
+	require.Equal(t, types2.ErrNoABI, err)
+
+	// 管理员和创建者都可以升级
+	_, err = update(newTestWasm(acc, kvdb), "managed", diceCode, "", PrivKeys[1])
+	require.Nil(t, err)
+	_, err = update(newTestWasm(acc, kvdb), "managed", typedWasm, typedABI, PrivKeys[0])
+	require.Nil(t, err)
+
+	w := newTestWasm(acc, kvdb).(*Wasm)
+	reply, err := w.Query_ListVersions(&types2.QueryCheckContract{Name: "managed"})
+	require.Nil(t, err)
+	versions := reply.(*types2.WasmContractVersions)
+	require.Equal(t, Addrs[0], versions.Info.Creator)
+	require.Equal(t, Addrs[1], versions.Info.Admin)
+	require.Equal(t, int32(3), versions.Info.Version)
+	require.Equal(t, 3, len(versions.Versions))
+	require.Equal(t, Addrs[1], versions.Versions[1].Operator)
+	require.Equal(t, hex.EncodeToString(common.Sha256(diceCode)), versions.Versions[1].CodeHash)
+	require.Equal(t, versions.Versions[0].CodeHash, versions.Versions[2].CodeHash)
+	abi, err := w.Query_GetABI(&types2.QueryCheckContract{Name: "managed"})
+	require.Nil(t, err)
+	require.Equal(t, typedABI, abi.(*types.ReplyString).Data)
+
+	// 升级功能之前创建的合约没有记录创建者，不能升级
+	require.Nil(t, kvdb.Set(contractKey("legacy"), typedWasm))
+	_, err = update(newTestWasm(acc, kvdb), "legacy", diceCode, "", PrivKeys[0])
+	require.Equal(t, types2.ErrPermissionDenied, err)
+	_, err = w.Query_ListVersions(&types2.QueryCheckContract{Name: "legacy"})
+	require.Equal(t, types.ErrNotFound, err)
+}
+
+// 手工编码的跨合约调用测试合约，导入callContract,setStateDB,getFrom,setReturnData和getReturnData，导出：
+// callsum(a,b) 调用typed合约的sum方法
+// from() 将getFrom的结果设置为返回数据
+// whoami() 调用自身的from方法，并将其返回数据设置为自己的返回数据
+// fail() 写入状态k=v后执行unreachable
+// callfail() 写入状态a=v后调用自身的fail方法，返回调用结果加1
+// recurse() 递归调用自身的recurse方法，返回调用结果加1
+var proxyWasm = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	// type
+	0x01, 0x27, 0x06,
+	0x60, 0x06, 0x7f, 0x7f, 0x7f, 0x7f, 0x7f, 0x7f, 0x01, 0x7e,
+	0x60, 0x04, 0x7f, 0x7f, 0x7f, 0x7f, 0x00,
+	0x60, 0x02, 0x7f, 0x7f, 0x00,
+	0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7e,
+	0x60, 0x00, 0x01, 0x7e,
+	0x60, 0x02, 0x7e, 0x7e, 0x01, 0x7e,
+	// import env.callContract, env.setStateDB, env.getFrom, env.setReturnData, env.getReturnData
+	0x02, 0x5b, 0x05,
+	0x03, 'e', 'n', 'v', 0x0c, 'c', 'a', 'l', 'l', 'C', 'o', 'n', 't', 'r', 'a', 'c', 't', 0x00, 0x00,
+	0x03, 'e', 'n', 'v', 0x0a, 's', 'e', 't', 'S', 't', 'a', 't', 'e', 'D', 'B', 0x00, 0x01,
+	0x03, 'e', 'n', 'v', 0x07, 'g', 'e', 't', 'F', 'r', 'o', 'm', 0x00, 0x02,
+	0x03, 'e', 'n', 'v', 0x0d, 's', 'e', 't', 'R', 'e', 't', 'u', 'r', 'n', 'D', 'a', 't', 'a', 0x00, 0x02,
+	0x03, 'e', 'n', 'v', 0x0d, 'g', 'e', 't', 'R', 'e', 't', 'u', 'r', 'n', 'D', 'a', 't', 'a', 0x00, 0x03,
+	// function
+	0x03, 0x07, 0x06, 0x05, 0x04, 0x04, 0x04, 0x04, 0x04,
+	// memory
+	0x05, 0x03, 0x01, 0x00, 0x01,
+	// export
+	0x07, 0x37, 0x06,
+	0x07, 'c', 'a', 'l', 'l', 's', 'u', 'm', 0x00, 0x05,
+	0x04, 'f', 'r', 'o', 'm', 0x00, 0x06,
+	0x06, 'w', 'h', 'o', 'a', 'm', 'i', 0x00, 0x07,
+	0x04, 'f', 'a', 'i', 'l', 0x00, 0x08,
+	0x08, 'c', 'a', 'l', 'l', 'f', 'a', 'i', 'l', 0x00, 0x09,
+	0x07, 'r', 'e', 'c', 'u', 'r', 's', 'e', 0x00, 0x0a,
+	// code
+	0x0a, 0x99, 0x01, 0x06,
+	0x21, 0x00, 0x41, 0xc0, 0x00, 0x20, 0x00, 0x37, 0x03, 0x00, 0x41, 0xc8, 0x00, 0x20, 0x01, 0x37, 0x03, 0x00, 0x41, 0x00, 0x41, 0x05, 0x41, 0x08, 0x41, 0x03, 0x41, 0xc0, 0x00, 0x41, 0x10, 0x10, 0x00, 0x0b,
+	0x12, 0x00, 0x41, 0x80, 0x01, 0x41, 0x22, 0x10, 0x02, 0x41, 0x80, 0x01, 0x41, 0x22, 0x10, 0x03, 0x42, 0x00, 0x0b,
+	0x22, 0x00, 0x41, 0x10, 0x41, 0x05, 0x41, 0x18, 0x41, 0x04, 0x41, 0x00, 0x41, 0x00, 0x10, 0x00, 0x1a, 0x41, 0x80, 0x02, 0x41, 0x22, 0x10, 0x04, 0x1a, 0x41, 0x80, 0x02, 0x41, 0x22, 0x10, 0x03, 0x42, 0x00, 0x0b,
+	0x0d, 0x00, 0x41, 0x28, 0x41, 0x01, 0x41, 0x29, 0x41, 0x01, 0x10, 0x01, 0x00, 0x0b,
+	0x1d, 0x00, 0x41, 0x2a, 0x41, 0x01, 0x41, 0x29, 0x41, 0x01, 0x10, 0x01, 0x41, 0x10, 0x41, 0x05, 0x41, 0x30, 0x41, 0x04, 0x41, 0x00, 0x41, 0x00, 0x10, 0x00, 0x42, 0x01, 0x7c, 0x0b,
+	0x13, 0x00, 0x41, 0x10, 0x41, 0x05, 0x41, 0x38, 0x41, 0x07, 0x41, 0x00, 0x41, 0x00, 0x10, 0x00, 0x42, 0x01, 0x7c, 0x0b,
+	// data
+	0x0b, 0x45, 0x01,
+	0x00, 0x41, 0x00, 0x0b, 0x3f,
+	't', 'y', 'p', 'e', 'd', 0x00, 0x00, 0x00,
+	's', 'u', 'm', 0x00, 0x00, 0x00, 0x00, 0x00,
+	'p', 'r', 'o', 'x', 'y', 0x00, 0x00, 0x00,
+	'f', 'r', 'o', 'm', 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	'k', 'v', 'a', 0x00, 0x00, 0x00, 0x00, 0x00,
+	'f', 'a', 'i', 'l', 0x00, 0x00, 0x00, 0x00,
+	'r', 'e', 'c', 'u', 'r', 's', 'e',
+}
+
+// 手工编码的跨合约写状态测试合约，导入callContract，导出：
+// callstore() 以bytes参数"hi"调用typed合约的store方法
+var storeProxyWasm = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	// type
+	0x01, 0x0f, 0x02,
+	0x60, 0x06, 0x7f, 0x7f, 0x7f, 0x7f, 0x7f, 0x7f, 0x01, 0x7e,
+	0x60, 0x00, 0x01, 0x7e,
+	// import env.callContract
+	0x02, 0x14, 0x01,
+	0x03, 'e', 'n', 'v', 0x0c, 'c', 'a', 'l', 'l', 'C', 'o', 'n', 't', 'r', 'a', 'c', 't', 0x00, 0x00,
+	// function
+	0x03, 0x02, 0x01, 0x01,
+	// memory
+	0x05, 0x03, 0x01, 0x00, 0x01,
+	// export
+	0x07, 0x0d, 0x01,
+	0x09, 'c', 'a', 'l', 'l', 's', 't', 'o', 'r', 'e', 0x00, 0x01,
+	// code
+	0x0a, 0x12, 0x01,
+	0x10, 0x00, 0x41, 0x00, 0x41, 0x05, 0x41, 0x05, 0x41, 0x05, 0x41, 0x0a, 0x41, 0x06, 0x10, 0x00, 0x0b,
+	// data
+	0x0b, 0x16, 0x01,
+	0x00, 0x41, 0x00, 0x0b, 0x10,
+	't', 'y', 'p', 'e', 'd',
+	's', 't', 'o', 'r', 'e',
+	0x02, 0x00, 0x00, 0x00, 'h', 'i',
+}
+
+// 手工编码的重入调用测试合约，导入callContract和setStateDB，导出：
+// outer() 写入状态k=1后调用bounce合约的back方法
+// inner() 写入状态k=2
+var reenterWasm = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	// type
+	0x01, 0x16, 0x03,
+	0x60, 0x06, 0x7f, 0x7f, 0x7f, 0x7f, 0x7f, 0x7f, 0x01, 0x7e,
+	0x60, 0x04, 0x7f, 0x7f, 0x7f, 0x7f, 0x00,
+	0x60, 0x00, 0x01, 0x7e,
+	// import env.callContract, env.setStateDB
+	0x02, 0x25, 0x02,
+	0x03, 'e', 'n', 'v', 0x0c, 'c', 'a', 'l', 'l', 'C', 'o', 'n', 't', 'r', 'a', 'c', 't', 0x00, 0x00,
+	0x03, 'e', 'n', 'v', 0x0a, 's', 'e', 't', 'S', 't', 'a', 't', 'e', 'D', 'B', 0x00, 0x01,
+	// function
+	0x03, 0x03, 0x02, 0x02, 0x02,
+	// memory
+	0x05, 0x03, 0x01, 0x00, 0x01,
+	// export
+	0x07, 0x11, 0x02,
+	0x05, 'o', 'u', 't', 'e', 'r', 0x00, 0x02,
+	0x05, 'i', 'n', 'n', 'e', 'r', 0x00, 0x03,
+	// code
+	0x0a, 0x2e, 0x02,
+	0x1d, 0x00, 0x41, 0x00, 0x41, 0x01, 0x41, 0x01, 0x41, 0x01, 0x10, 0x01, 0x41, 0x03, 0x41, 0x06, 0x41, 0x09, 0x41, 0x04, 0x41, 0x00, 0x41, 0x00, 0x10, 0x00, 0x1a, 0x42, 0x00, 0x0b,
+	0x0e, 0x00, 0x41, 0x00, 0x41, 0x01, 0x41, 0x02, 0x41, 0x01, 0x10, 0x01, 0x42, 0x00, 0x0b,
+	// data
+	0x0b, 0x13, 0x01,
+	0x00, 0x41, 0x00, 0x0b, 0x0d,
+	'k', '1', '2',
+	'b', 'o', 'u', 'n', 'c', 'e',
+	'b', 'a', 'c', 'k',
+}
+
+// 手工编码的重入调用测试合约，导入callContract，导出：
+// back() 调用reenter合约的inner方法
+var bounceWasm = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	// type
+	0x01, 0x0f, 0x02,
+	0x60, 0x06, 0x7f, 0x7f, 0x7f, 0x7f, 0x7f, 0x7f, 0x01, 0x7e,
+	0x60, 0x00, 0x01, 0x7e,
+	// import env.callContract
+	0x02, 0x14, 0x01,
+	0x03, 'e', 'n', 'v', 0x0c, 'c', 'a', 'l', 'l', 'C', 'o', 'n', 't', 'r', 'a', 'c', 't', 0x00, 0x00,
+	// function
+	0x03, 0x02, 0x01, 0x01,
+	// memory
+	0x05, 0x03, 0x01, 0x00, 0x01,
+	// export
+	0x07, 0x08, 0x01,
+	0x04, 'b', 'a', 'c', 'k', 0x00, 0x01,
+	// code
+	0x0a, 0x15, 0x01,
+	0x13, 0x00, 0x41, 0x00, 0x41, 0x07, 0x41, 0x07, 0x41, 0x05, 0x41, 0x00, 0x41, 0x00, 0x10, 0x00, 0x1a, 0x42, 0x00, 0x0b,
+	// data
+	0x0b, 0x12, 0x01,
+	0x00, 0x41, 0x00, 0x0b, 0x0c,
+	'r', 'e', 'e', 'n', 't', 'e', 'r',
+	'i', 'n', 'n', 'e', 'r',
+}
+
+// A->B->A重入调用时，回执中kv的顺序和执行顺序一致，按顺序写入后的状态是重入时写入的值
+func TestWasm_Reenter(t *testing.T) {
+	dir, ldb, kvdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, ldb)
+	acc := initAccount(ldb)
+	for _, create := range []*types2.WasmCreate{
+		{Name: "reenter", Code: reenterWasm},
+		{Name: "bounce", Code: bounceWasm},
+	} {
+		_, err := execWasmAction(t, acc, kvdb, &types2.WasmAction{
+			Ty:    types2.WasmActionCreate,
+			Value: &types2.WasmAction_Create{Create: create},
+		})
+		require.Nil(t, err)
+	}
+	receipt, err := execWasmAction(t, acc, kvdb, &types2.WasmAction{
+		Ty:    types2.WasmActionCall,
+		Value: &types2.WasmAction_Call{Call: &types2.WasmCall{Contract: "reenter", Method: "outer"}},
+	})
+	require.Nil(t, err)
+	require.Equal(t, int32(types.ExecOk), receipt.Ty)
+	key := append(calcStatePrefix("reenter"), 'k')
+	value, err := kvdb.Get(key)
+	require.Nil(t, err)
+	require.Equal(t, []byte("2"), value)
+	var last []byte
+	count := 0
+	for _, kv := range receipt.KV {
+		if bytes.Equal(kv.Key, key) {
+			last = kv.Value
+			count++
+		}
+	}
+	require.Equal(t, 2, count)
+	require.Equal(t, []byte("2"), last)
+}
+
+func TestWasm_ForkV2(t *testing.T) {
+	dir, ldb, kvdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, ldb)
+	acc := initAccount(ldb)
+	// 分叉高度使用默认的注册高度
+	preCfg := types.NewChain33Config(strings.Replace(types.GetDefaultCfgstring(), "Title=\"local\"", "Title=\"chain33\"", 1))
+	newPreFork := func() dapp.Driver {
+		wasm := newWasm()
+		wasm.SetCoinsAccount(acc)
+		api := mocks.QueueProtocolAPI{}
+		api.On("GetConfig").Return(preCfg)
+		wasm.SetAPI(&api)
+		wasm.SetStateDB(kvdb)
+		return wasm
+	}
+
+	// 分叉之前创建合约只记录代码
+	receipt, err := execWasmActionBy(t, newPreFork(), kvdb, &types2.WasmAction{
+		Ty:    types2.WasmActionCreate,
+		Value: &types2.WasmAction_Create{Create: &types2.WasmCreate{Name: "typed", Code: typedWasm, Abi: typedABI, Admin: Addrs[1]}},
+	}, PrivKeys[0])
+	require.Nil(t, err)
+	require.Equal(t, 1, len(receipt.KV))
+	require.Equal(t, contractKey("typed"), receipt.KV[0].Key)
+	_, err = execWasmActionBy(t, newPreFork(), kvdb, &types2.WasmAction{
+		Ty:    types2.WasmActionCreate,
+		Value: &types2.WasmAction_Create{Create: &types2.WasmCreate{Name: "proxy", Code: proxyWasm}},
+	}, PrivKeys[0])
+	require.Nil(t, err)
+
+	// 分叉之前不支持升级和跨合约调用
+	_, err = execWasmActionBy(t, newPreFork(), kvdb, &types2.WasmAction{
+		Ty:    types2.WasmActionUpdate,
+		Value: &types2.WasmAction_Update{Update: &types2.WasmUpdate{Name: "typed", Code: typedWasm}},
+	}, PrivKeys[0])
+	require.Equal(t, types.ErrActionNotSupport, err)
+	_, err = execWasmActionBy(t, newPreFork(), kvdb, &types2.WasmAction{
+		Ty:    types2.WasmActionCall,
+		Value: &types2.WasmAction_Call{Call: &types2.WasmCall{Contract: "proxy", Method: "callsum", Parameters: []int64{40, 2}}},
+	}, PrivKeys[0])
+	require.NotNil(t, err)
+
+	// 分叉之后可以调用
+	receipt, err = execWasmAction(t, acc, kvdb, &types2.WasmAction{
+		Ty:    types2.WasmActionCall,
+		Value: &types2.WasmAction_Call{Call: &types2.WasmCall{Contract: "proxy", Method: "callsum", Parameters: []int64{40, 2}}},
+	})
+	require.Nil(t, err)
+	var callLog types2.CallContractLog
+	require.Nil(t, types.Decode(receipt.Logs[0].Log, &callLog))
+	require.Equal(t, int32(42), callLog.Result)
+}
+
+func TestWasm_CrossCall(t *testing.T) {
+	dir, ldb, kvdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, ldb)
+	acc := initAccount(ldb)
+	for _, create := range []*types2.WasmCreate{
+		{Name: "typed", Code: typedWasm, Abi: typedABI},
+		{Name: "proxy", Code: proxyWasm},
+		{Name: "storeproxy", Code: storeProxyWasm},
+	} {
+		_, err := execWasmAction(t, acc, kvdb, &types2.WasmAction{
+			Ty:    types2.WasmActionCreate,
+			Value: &types2.WasmAction_Create{Create: create},
+		})
+		require.Nil(t, err)
+	}
+	call := func(method string, params ...int64) (*types.Receipt, *types2.CallContractLog) {
+		receipt, err := execWasmAction(t, acc, kvdb, &types2.WasmAction{
+			Ty:    types2.WasmActionCall,
+			Value: &types2.WasmAction_Call{Call: &types2.WasmCall{Contract: "proxy", Method: method, Parameters: params}},
+		})
+		require.Nil(t, err)
+		var callLog types2.CallContractLog
+		require.Nil(t, types.Decode(receipt.Logs[0].Log, &callLog))
+		return receipt, &callLog
+	}
+	query := func(method string) *types2.QueryCallResult {
+		w := newTestWasm(acc, kvdb).(*Wasm)
+		w.SetLocalDB(kvdb)
+		reply, err := w.Query_Call(&types2.QueryCallContract{Contract: "proxy", Method: method})
+		require.Nil(t, err)
+		return reply.(*types2.QueryCallResult)
+	}
+
+	// 按照被调用方法的ABI解析紧凑编码的参数，调用记录在回执中
+	receipt, callLog := call("callsum", 40, 2)
+	require.Equal(t, int32(42), callLog.Result)
+	require.Equal(t, int32(types2.TyLogWasmCall), receipt.Logs[1].Ty)
+	var nestedLog types2.CallContractLog
+	require.Nil(t, types.Decode(receipt.Logs[1].Log, &nestedLog))
+	require.Equal(t, "typed", nestedLog.Contract)
+	require.Equal(t, "sum", nestedLog.Method)
+
+	// 被调用合约中getFrom返回调用者合约的地址，调用者可以读取被调用合约的返回数据
+	res := query("whoami")
+	proxyAddr := address.ExecAddress("user.wasm.proxy")
+	require.Equal(t, proxyAddr, strings.TrimRight(string(res.ReturnData), "\x00"))
+
+	// 被调用合约执行失败时只回滚被调用合约修改的状态
+	receipt, callLog = call("callfail")
+	require.Equal(t, int32(0), callLog.Result)
+	_, err := kvdb.Get(append(calcStatePrefix("proxy"), 'a'))
+	require.Nil(t, err)
+	value, _ := kvdb.Get(append(calcStatePrefix("proxy"), 'k'))
+	require.Empty(t, value)
+	for _, kv := range receipt.KV {
+		require.NotEqual(t, append(calcStatePrefix("proxy"), 'k'), kv.Key)
+	}
+
+	// 被调用合约写入的状态只带有被调用合约的前缀
+	receipt, err = execWasmAction(t, acc, kvdb, &types2.WasmAction{
+		Ty:    types2.WasmActionCall,
+		Value: &types2.WasmAction_Call{Call: &types2.WasmCall{Contract: "storeproxy", Method: "callstore"}},
+	})
+	require.Nil(t, err)
+	require.Equal(t, int32(types.ExecOk), receipt.Ty)
+	key := append(calcStatePrefix("typed"), []byte("hi")...)
+	found := false
+	for _, kv := range receipt.KV {
+		require.False(t, bytes.HasPrefix(kv.Key, calcStatePrefix("storeproxy")))
+		if bytes.Equal(kv.Key, key) {
+			require.Equal(t, []byte("hi"), kv.Value)
+			found = true
+		}
+	}
+	require.True(t, found)
+	value, err = kvdb.Get(key)
+	require.Nil(t, err)
+	require.Equal(t, []byte("hi"), value)
+
+	// 超过最大调用深度的调用失败
+	require.Equal(t, int64(types2.MaxCallDepth), query("recurse").Result)
+
+	// 被调用合约消耗的gas计入调用者
+	w := newTestWasm(acc, kvdb).(*Wasm)
+	_, _, sumGas, err := w.execContract("typed", "sum", nil, []*types2.WasmArg{{Value: 40}, {Value: 2}}, types2.QueryGasLimit)
+	require.Nil(t, err)
+	_, _, callGas, err := w.execContract("proxy", "callsum", nil, []*types2.WasmArg{{Value: 40}, {Value: 2}}, types2.QueryGasLimit)
+	require.Nil(t, err)
+	require.True(t, callGas > sumGas)
+	vm := &exec.VirtualMachine{Config: exec.VMConfig{GasLimit: 10}, Gas: 10}
+	_, err = w.nestedCall(vm, "typed", "sum", nil)
+	require.Equal(t, types2.ErrOutOfGas, err)
+}
@@ -46,6 +46,19 @@ func (j *Jrpc) CreateContract(param *types2.WasmCreate, result *interface{}) err
 	return nil
 }
 
+func (j *Jrpc) UpdateContract(param *types2.WasmUpdate, result *interface{}) error {
+	if param == nil {
+		return types2.ErrInvalidParam
+	}
+	cfg := types.LoadExecutorType(types2.WasmX).GetConfig()
+	data, err := types.CallCreateTx(cfg, cfg.ExecName(types2.WasmX), "Update", param)
+	if err != nil {
+		return err
+	}
+	*result = common.ToHex(data)
+	return nil
+}
+
 func (j *Jrpc) CallContract(param *types2.WasmCall, result *interface{}) error {
 	if param == nil {
 		return types2.ErrInvalidParam
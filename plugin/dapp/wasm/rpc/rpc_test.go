@@ -45,6 +45,16 @@ func TestJrpc_CreateContract(t *testing.T) {
 	t.Log(result)
 }
 
+func TestJrpc_UpdateContract(t *testing.T) {
+	jrpc := &Jrpc{}
+	code, err := ioutil.ReadFile("../contracts/dice/dice.wasm")
+	assert.Nil(t, err, "read wasm file error")
+	var result interface{}
+	err = jrpc.UpdateContract(&types2.WasmUpdate{Name: "dice", Code: code}, &result)
+	assert.Nil(t, err, "update contract error")
+	t.Log(result)
+}
+
 func TestJrpc_CallContract(t *testing.T) {
 	jrpc := &Jrpc{}
 	var result interface{}
@@ -0,0 +1,401 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"strconv"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/common/address"
+)
+
+// ABI中支持的参数类型
+const (
+	ABIInt32   = "int32"
+	ABIInt64   = "int64"
+	ABIUint32  = "uint32"
+	ABIUint64  = "uint64"
+	ABIBool    = "bool"
+	ABIString  = "string"
+	ABIBytes   = "bytes"
+	ABIAddress = "address"
+	ABITuple   = "tuple"
+)
+
+// ABI 合约接口描述，格式为：
+// [{"name":"play","inputs":[{"name":"amount","type":"int64"},{"name":"memo","type":"string"}],"outputs":[{"name":"","type":"string"}]}]
+//
+// 调用约定：
+// 数值类型(int32,int64,uint32,uint64,bool)的参数直接作为函数参数传递；
+// string,bytes,address和tuple类型的参数由执行器调用合约导出的allocate方法分配内存并写入数据，以(ptr,len)两个参数传递；
+// tuple类型在内存中按成员顺序紧凑编码，数值类型为小端定长编码，bool为1字节，string,bytes,address为4字节小端长度加数据；
+// 合约通过setReturnData设置返回数据，返回数据按照outputs的紧凑编码解析。
+type ABI []*ABIMethod
+
+// ABIMethod 合约导出方法的描述
+type ABIMethod struct {
+	Name    string         `json:"name"`
+	Inputs  []*ABIArgument `json:"inputs"`
+	Outputs []*ABIArgument `json:"outputs"`
+}
+
+// ABIArgument 参数描述，tuple类型的参数通过components描述成员
+type ABIArgument struct {
+	Name       string         `json:"name"`
+	Type       string         `json:"type"`
+	Components []*ABIArgument `json:"components,omitempty"`
+}
+
+// WasmArg 转换后的调用参数，Buffer不为空时需要写入合约内存
+type WasmArg struct {
+	Value    int64
+	Buffer   []byte
+	IsBuffer bool
+}
+
+// ParseABI 解析并检查ABI描述
+func ParseABI(data string) (ABI, error) {
+	var abi ABI
+	if err := json.Unmarshal([]byte(data), &abi); err != nil {
+		return nil, ErrInvalidABI
+	}
+	names := make(map[string]bool)
+	for _, method := range abi {
+		if method == nil || method.Name == "" || names[method.Name] {
+			return nil, ErrInvalidABI
+		}
+		names[method.Name] = true
+		for _, arg := range append(method.Inputs, method.Outputs...) {
+			if !validArgument(arg) {
+				return nil, ErrInvalidABI
+			}
+		}
+	}
+	return abi, nil
+}
+
+func validArgument(arg *ABIArgument) bool {
+	if arg == nil {
+		return false
+	}
+	switch arg.Type {
+	case ABIInt32, ABIInt64, ABIUint32, ABIUint64, ABIBool, ABIString, ABIBytes, ABIAddress:
+		return len(arg.Components) == 0
+	case ABITuple:
+		if len(arg.Components) == 0 {
+			return false
+		}
+		for _, c := range arg.Components {
+			if !validArgument(c) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// Method 按名称查找方法
+func (abi ABI) Method(name string) (*ABIMethod, bool) {
+	for _, method := range abi {
+		if method.Name == name {
+			return method, true
+		}
+	}
+	return nil, false
+}
+
+// PackArgs 将json数组格式的参数按照ABI转换为合约函数的参数
+func (m *ABIMethod) PackArgs(jsonArgs string) ([]*WasmArg, error) {
+	var raws []json.RawMessage
+	if jsonArgs != "" {
+		if err := json.Unmarshal([]byte(jsonArgs), &raws); err != nil {
+			return nil, ErrInvalidParam
+		}
+	}
+	if len(raws) != len(m.Inputs) {
+		return nil, ErrInvalidParam
+	}
+	args := make([]*WasmArg, len(raws))
+	for i, input := range m.Inputs {
+		if isScalar(input.Type) {
+			v, err := packScalar(input.Type, raws[i])
+			if err != nil {
+				return nil, err
+			}
+			args[i] = &WasmArg{Value: v}
+			continue
+		}
+		var buf []byte
+		var err error
+		if input.Type == ABITuple {
+			buf, err = packTuple(nil, input.Components, raws[i])
+		} else {
+			buf, err = packDynamic(input.Type, raws[i])
+		}
+		if err != nil {
+			return nil, err
+		}
+		args[i] = &WasmArg{Buffer: buf, IsBuffer: true}
+	}
+	return args, nil
+}
+
+// UnpackArgs 将紧凑编码的参数按照ABI转换为合约函数的参数，用于跨合约调用
+func (m *ABIMethod) UnpackArgs(data []byte) ([]*WasmArg, error) {
+	r := bytes.NewReader(data)
+	args := make([]*WasmArg, len(m.Inputs))
+	for i, input := range m.Inputs {
+		start := len(data) - r.Len()
+		v, err := unpackValue(r, input)
+		if err != nil {
+			return nil, ErrInvalidParam
+		}
+		raw := data[start : len(data)-r.Len()]
+		switch {
+		case isScalar(input.Type):
+			args[i] = &WasmArg{Value: scalarValue(input.Type, raw)}
+		case input.Type == ABITuple:
+			args[i] = &WasmArg{Buffer: raw, IsBuffer: true}
+		default:
+			if input.Type == ABIAddress {
+				if err := address.CheckAddress(v.(string)); err != nil {
+					return nil, err
+				}
+			}
+			// 去掉长度前缀，与PackArgs的结果保持一致
+			args[i] = &WasmArg{Buffer: raw[4:], IsBuffer: true}
+		}
+	}
+	if r.Len() != 0 {
+		return nil, ErrInvalidParam
+	}
+	return args, nil
+}
+
+// UnpackOutputs 将合约的返回数据按照ABI解析为json格式
+func (m *ABIMethod) UnpackOutputs(data []byte) (string, error) {
+	if len(m.Outputs) == 0 {
+		return "", nil
+	}
+	r := bytes.NewReader(data)
+	values := make([]*Param, len(m.Outputs))
+	for i, output := range m.Outputs {
+		v, err := unpackValue(r, output)
+		if err != nil {
+			return "", err
+		}
+		values[i] = &Param{Name: output.Name, Type: output.Type, Value: v}
+	}
+	if r.Len() != 0 {
+		return "", ErrInvalidReturnData
+	}
+	jsonData, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+// Param 返回值参数结构定义
+type Param struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+func isScalar(typ string) bool {
+	switch typ {
+	case ABIInt32, ABIInt64, ABIUint32, ABIUint64, ABIBool:
+		return true
+	}
+	return false
+}
+
+// 数值可以是json数字或者十进制字符串，以支持超过json精度的64位整数
+func packScalar(typ string, raw json.RawMessage) (int64, error) {
+	if typ == ABIBool {
+		var b bool
+		if err := json.Unmarshal(raw, &b); err != nil {
+			return 0, ErrInvalidParam
+		}
+		if b {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		var n json.Number
+		if err := json.Unmarshal(raw, &n); err != nil {
+			return 0, ErrInvalidParam
+		}
+		s = n.String()
+	}
+	var bits int
+	switch typ {
+	case ABIInt32, ABIUint32:
+		bits = 32
+	default:
+		bits = 64
+	}
+	if typ == ABIUint32 || typ == ABIUint64 {
+		v, err := strconv.ParseUint(s, 10, bits)
+		if err != nil {
+			return 0, ErrInvalidParam
+		}
+		return int64(v), nil
+	}
+	v, err := strconv.ParseInt(s, 10, bits)
+	if err != nil {
+		return 0, ErrInvalidParam
+	}
+	return v, nil
+}
+
+// bytes类型使用十六进制字符串，address类型使用chain33地址
+func packDynamic(typ string, raw json.RawMessage) ([]byte, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, ErrInvalidParam
+	}
+	switch typ {
+	case ABIBytes:
+		b, err := common.FromHex(s)
+		if err != nil {
+			return nil, ErrInvalidParam
+		}
+		return b, nil
+	case ABIAddress:
+		if err := address.CheckAddress(s); err != nil {
+			return nil, err
+		}
+	}
+	return []byte(s), nil
+}
+
+// tuple类型的参数为以成员名称为key的json对象
+func packTuple(buf []byte, components []*ABIArgument, raw json.RawMessage) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil || len(fields) != len(components) {
+		return nil, ErrInvalidParam
+	}
+	for _, c := range components {
+		value, ok := fields[c.Name]
+		if !ok {
+			return nil, ErrInvalidParam
+		}
+		var err error
+		switch {
+		case isScalar(c.Type):
+			var v int64
+			v, err = packScalar(c.Type, value)
+			buf = appendScalar(buf, c.Type, v)
+		case c.Type == ABITuple:
+			buf, err = packTuple(buf, c.Components, value)
+		default:
+			var b []byte
+			b, err = packDynamic(c.Type, value)
+			buf = appendUint32(buf, uint32(len(b)))
+			buf = append(buf, b...)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+func appendUint32(buf []byte, v uint32) []byte {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	return append(buf, b[:]...)
+}
+
+func appendScalar(buf []byte, typ string, v int64) []byte {
+	switch typ {
+	case ABIBool:
+		return append(buf, byte(v))
+	case ABIInt32, ABIUint32:
+		return appendUint32(buf, uint32(v))
+	}
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], uint64(v))
+	return append(buf, b[:]...)
+}
+
+func scalarValue(typ string, raw []byte) int64 {
+	switch typ {
+	case ABIBool:
+		if raw[0] != 0 {
+			return 1
+		}
+		return 0
+	case ABIInt32:
+		return int64(int32(binary.LittleEndian.Uint32(raw)))
+	case ABIUint32:
+		return int64(binary.LittleEndian.Uint32(raw))
+	}
+	return int64(binary.LittleEndian.Uint64(raw))
+}
+
+func unpackValue(r *bytes.Reader, arg *ABIArgument) (interface{}, error) {
+	read := func(n int) ([]byte, error) {
+		if n < 0 || r.Len() < n {
+			return nil, ErrInvalidReturnData
+		}
+		b := make([]byte, n)
+		_, _ = r.Read(b)
+		return b, nil
+	}
+	switch arg.Type {
+	case ABIBool:
+		b, err := read(1)
+		if err != nil {
+			return nil, err
+		}
+		return b[0] != 0, nil
+	case ABIInt32, ABIUint32:
+		b, err := read(4)
+		if err != nil {
+			return nil, err
+		}
+		if arg.Type == ABIInt32 {
+			return int32(binary.LittleEndian.Uint32(b)), nil
+		}
+		return binary.LittleEndian.Uint32(b), nil
+	case ABIInt64, ABIUint64:
+		b, err := read(8)
+		if err != nil {
+			return nil, err
+		}
+		// 64位整数使用字符串表示，避免json解析时丢失精度
+		if arg.Type == ABIInt64 {
+			return strconv.FormatInt(int64(binary.LittleEndian.Uint64(b)), 10), nil
+		}
+		return strconv.FormatUint(binary.LittleEndian.Uint64(b), 10), nil
+	case ABITuple:
+		values := make(map[string]interface{})
+		for _, c := range arg.Components {
+			v, err := unpackValue(r, c)
+			if err != nil {
+				return nil, err
+			}
+			values[c.Name] = v
+		}
+		return values, nil
+	}
+	b, err := read(4)
+	if err != nil {
+		return nil, err
+	}
+	data, err := read(int(binary.LittleEndian.Uint32(b)))
+	if err != nil {
+		return nil, err
+	}
+	if arg.Type == ABIBytes {
+		return common.ToHex(data), nil
+	}
+	return string(data), nil
+}
@@ -10,4 +10,12 @@ var (
 	ErrInvalidContractName = errors.New("invalid contract name")
 	ErrInvalidParam        = errors.New("invalid parameters")
 	ErrUnknown             = errors.New("unknown error")
+	ErrInvalidABI          = errors.New("invalid abi")
+	ErrNoABI               = errors.New("contract has no abi")
+	ErrInvalidReturnData   = errors.New("invalid return data")
+	ErrOutOfMemory         = errors.New("contract memory access out of bounds")
+	ErrReadOnly            = errors.New("state modification in read-only call")
+	ErrPermissionDenied    = errors.New("permission denied")
+	ErrCallDepthExceeded   = errors.New("max call depth exceeded")
+	ErrOutOfGas            = errors.New("out of gas")
 )
@@ -14,12 +14,22 @@ const (
 	NameRegExp = "^[a-z0-9]+$"
 	//TODO: max size to define
 	MaxCodeSize = 1 << 20
+	// AllocateFunc 合约导出的内存分配方法，参数为需要分配的字节数，返回分配的内存地址
+	AllocateFunc = "allocate"
+	// QueryGasLimit 只读调用时合约可以执行的最大指令数
+	QueryGasLimit = 100000000
+	// MaxCallDepth 跨合约调用的最大嵌套深度
+	MaxCallDepth = 8
 )
 
+// ForkWasmV2 之后支持合约ABI，合约升级和跨合约调用
+const ForkWasmV2 = "ForkWasmV2"
+
 // action for executor
 const (
 	WasmActionCreate = iota + 1
 	WasmActionCall
+	WasmActionUpdate
 )
 
 // log ty for executor
@@ -28,6 +38,7 @@ const (
 	TyLogWasmCall
 	TyLogCustom
 	TyLogLocalData
+	TyLogWasmUpdate
 )
 
 func init() {
@@ -40,6 +51,7 @@ func init() {
 
 func InitFork(cfg *types.Chain33Config) {
 	cfg.RegisterDappFork(WasmX, "Enable", 0)
+	cfg.RegisterDappFork(WasmX, ForkWasmV2, types.MaxHeight)
 }
 
 func InitExecutor(cfg *types.Chain33Config) {
@@ -65,6 +77,7 @@ func (t *WasmType) GetTypeMap() map[string]int32 {
 	return map[string]int32{
 		"Create": WasmActionCreate,
 		"Call":   WasmActionCall,
+		"Update": WasmActionUpdate,
 	}
 }
 
@@ -74,5 +87,6 @@ func (t *WasmType) GetLogMap() map[int64]*types.LogInfo {
 		TyLogWasmCall:   {Ty: reflect.TypeOf(CallContractLog{}), Name: "LogWasmCall"},
 		TyLogCustom:     {Ty: reflect.TypeOf(CustomLog{}), Name: "LogWasmCustom"},
 		TyLogLocalData:  {Ty: reflect.TypeOf(LocalDataLog{}), Name: "LogWasmLocalData"},
+		TyLogWasmUpdate: {Ty: reflect.TypeOf(UpdateContractLog{}), Name: "LogWasmUpdate"},
 	}
 }
@@ -25,6 +25,7 @@ type WasmAction struct {
 	// Types that are valid to be assigned to Value:
 	//	*WasmAction_Create
 	//	*WasmAction_Call
+	//	*WasmAction_Update
 	Value                isWasmAction_Value `protobuf_oneof:"value"`
 	Ty                   int32              `protobuf:"varint,3,opt,name=ty,proto3" json:"ty,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
@@ -69,10 +70,16 @@ type WasmAction_Call struct {
 	Call *WasmCall `protobuf:"bytes,2,opt,name=call,proto3,oneof"`
 }
 
+type WasmAction_Update struct {
+	Update *WasmUpdate `protobuf:"bytes,4,opt,name=update,proto3,oneof"`
+}
+
 func (*WasmAction_Create) isWasmAction_Value() {}
 
 func (*WasmAction_Call) isWasmAction_Value() {}
 
+func (*WasmAction_Update) isWasmAction_Value() {}
+
 func (m *WasmAction) GetValue() isWasmAction_Value {
 	if m != nil {
 		return m.Value
@@ -94,6 +101,13 @@ func (m *WasmAction) GetCall() *WasmCall {
 	return nil
 }
 
+func (m *WasmAction) GetUpdate() *WasmUpdate {
+	if x, ok := m.GetValue().(*WasmAction_Update); ok {
+		return x.Update
+	}
+	return nil
+}
+
 func (m *WasmAction) GetTy() int32 {
 	if m != nil {
 		return m.Ty
@@ -106,12 +120,17 @@ func (*WasmAction) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*WasmAction_Create)(nil),
 		(*WasmAction_Call)(nil),
+		(*WasmAction_Update)(nil),
 	}
 }
 
 type WasmCreate struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Code                 []byte   `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Code []byte `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	// json格式的ABI描述，为空时只能使用数值参数调用合约
+	Abi string `protobuf:"bytes,3,opt,name=abi,proto3" json:"abi,omitempty"`
+	// 可选的管理员地址，管理员和合约创建者可以升级合约代码
+	Admin                string   `protobuf:"bytes,4,opt,name=admin,proto3" json:"admin,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -156,10 +175,83 @@ func (m *WasmCreate) GetCode() []byte {
 	return nil
 }
 
+func (m *WasmCreate) GetAbi() string {
+	if m != nil {
+		return m.Abi
+	}
+	return ""
+}
+
+func (m *WasmCreate) GetAdmin() string {
+	if m != nil {
+		return m.Admin
+	}
+	return ""
+}
+
+// 替换合约代码，合约的状态数据保持不变
+type WasmUpdate struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Code []byte `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	// 新版本代码的ABI，为空时删除原有的ABI
+	Abi                  string   `protobuf:"bytes,3,opt,name=abi,proto3" json:"abi,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WasmUpdate) Reset()         { *m = WasmUpdate{} }
+func (m *WasmUpdate) String() string { return proto.CompactTextString(m) }
+func (*WasmUpdate) ProtoMessage()    {}
+func (*WasmUpdate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7d78909ad64e3bbb, []int{2}
+}
+
+func (m *WasmUpdate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WasmUpdate.Unmarshal(m, b)
+}
+func (m *WasmUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WasmUpdate.Marshal(b, m, deterministic)
+}
+func (m *WasmUpdate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WasmUpdate.Merge(m, src)
+}
+func (m *WasmUpdate) XXX_Size() int {
+	return xxx_messageInfo_WasmUpdate.Size(m)
+}
+func (m *WasmUpdate) XXX_DiscardUnknown() {
+	xxx_messageInfo_WasmUpdate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WasmUpdate proto.InternalMessageInfo
+
+func (m *WasmUpdate) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *WasmUpdate) GetCode() []byte {
+	if m != nil {
+		return m.Code
+	}
+	return nil
+}
+
+func (m *WasmUpdate) GetAbi() string {
+	if m != nil {
+		return m.Abi
+	}
+	return ""
+}
+
 type WasmCall struct {
-	Contract             string   `protobuf:"bytes,1,opt,name=contract,proto3" json:"contract,omitempty"`
-	Method               string   `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
-	Parameters           []int64  `protobuf:"varint,3,rep,packed,name=parameters,proto3" json:"parameters,omitempty"`
+	Contract   string  `protobuf:"bytes,1,opt,name=contract,proto3" json:"contract,omitempty"`
+	Method     string  `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	Parameters []int64 `protobuf:"varint,3,rep,packed,name=parameters,proto3" json:"parameters,omitempty"`
+	// json数组格式的调用参数，按照合约ABI转换后传入合约
+	JsonArgs             string   `protobuf:"bytes,4,opt,name=jsonArgs,proto3" json:"jsonArgs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -169,7 +261,7 @@ func (m *WasmCall) Reset()         { *m = WasmCall{} }
 func (m *WasmCall) String() string { return proto.CompactTextString(m) }
 func (*WasmCall) ProtoMessage()    {}
 func (*WasmCall) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7d78909ad64e3bbb, []int{2}
+	return fileDescriptor_7d78909ad64e3bbb, []int{3}
 }
 
 func (m *WasmCall) XXX_Unmarshal(b []byte) error {
@@ -211,6 +303,13 @@ func (m *WasmCall) GetParameters() []int64 {
 	return nil
 }
 
+func (m *WasmCall) GetJsonArgs() string {
+	if m != nil {
+		return m.JsonArgs
+	}
+	return ""
+}
+
 type QueryCheckContract struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -222,7 +321,7 @@ func (m *QueryCheckContract) Reset()         { *m = QueryCheckContract{} }
 func (m *QueryCheckContract) String() string { return proto.CompactTextString(m) }
 func (*QueryCheckContract) ProtoMessage()    {}
 func (*QueryCheckContract) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7d78909ad64e3bbb, []int{3}
+	return fileDescriptor_7d78909ad64e3bbb, []int{4}
 }
 
 func (m *QueryCheckContract) XXX_Unmarshal(b []byte) error {
@@ -250,6 +349,325 @@ func (m *QueryCheckContract) GetName() string {
 	return ""
 }
 
+// 只读调用合约的导出方法，不生成交易
+type QueryCallContract struct {
+	Contract   string  `protobuf:"bytes,1,opt,name=contract,proto3" json:"contract,omitempty"`
+	Method     string  `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	Parameters []int64 `protobuf:"varint,3,rep,packed,name=parameters,proto3" json:"parameters,omitempty"`
+	JsonArgs   string  `protobuf:"bytes,4,opt,name=jsonArgs,proto3" json:"jsonArgs,omitempty"`
+	// 可选的调用者地址，合约中通过getFrom获取
+	Caller               string   `protobuf:"bytes,5,opt,name=caller,proto3" json:"caller,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryCallContract) Reset()         { *m = QueryCallContract{} }
+func (m *QueryCallContract) String() string { return proto.CompactTextString(m) }
+func (*QueryCallContract) ProtoMessage()    {}
+func (*QueryCallContract) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7d78909ad64e3bbb, []int{5}
+}
+
+func (m *QueryCallContract) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryCallContract.Unmarshal(m, b)
+}
+func (m *QueryCallContract) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryCallContract.Marshal(b, m, deterministic)
+}
+func (m *QueryCallContract) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryCallContract.Merge(m, src)
+}
+func (m *QueryCallContract) XXX_Size() int {
+	return xxx_messageInfo_QueryCallContract.Size(m)
+}
+func (m *QueryCallContract) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryCallContract.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryCallContract proto.InternalMessageInfo
+
+func (m *QueryCallContract) GetContract() string {
+	if m != nil {
+		return m.Contract
+	}
+	return ""
+}
+
+func (m *QueryCallContract) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *QueryCallContract) GetParameters() []int64 {
+	if m != nil {
+		return m.Parameters
+	}
+	return nil
+}
+
+func (m *QueryCallContract) GetJsonArgs() string {
+	if m != nil {
+		return m.JsonArgs
+	}
+	return ""
+}
+
+func (m *QueryCallContract) GetCaller() string {
+	if m != nil {
+		return m.Caller
+	}
+	return ""
+}
+
+type QueryCallResult struct {
+	Result               int64    `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	ReturnData           []byte   `protobuf:"bytes,2,opt,name=returnData,proto3" json:"returnData,omitempty"`
+	JsonResult           string   `protobuf:"bytes,3,opt,name=jsonResult,proto3" json:"jsonResult,omitempty"`
+	Logs                 []string `protobuf:"bytes,4,rep,name=logs,proto3" json:"logs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryCallResult) Reset()         { *m = QueryCallResult{} }
+func (m *QueryCallResult) String() string { return proto.CompactTextString(m) }
+func (*QueryCallResult) ProtoMessage()    {}
+func (*QueryCallResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7d78909ad64e3bbb, []int{6}
+}
+
+func (m *QueryCallResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryCallResult.Unmarshal(m, b)
+}
+func (m *QueryCallResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryCallResult.Marshal(b, m, deterministic)
+}
+func (m *QueryCallResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryCallResult.Merge(m, src)
+}
+func (m *QueryCallResult) XXX_Size() int {
+	return xxx_messageInfo_QueryCallResult.Size(m)
+}
+func (m *QueryCallResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryCallResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryCallResult proto.InternalMessageInfo
+
+func (m *QueryCallResult) GetResult() int64 {
+	if m != nil {
+		return m.Result
+	}
+	return 0
+}
+
+func (m *QueryCallResult) GetReturnData() []byte {
+	if m != nil {
+		return m.ReturnData
+	}
+	return nil
+}
+
+func (m *QueryCallResult) GetJsonResult() string {
+	if m != nil {
+		return m.JsonResult
+	}
+	return ""
+}
+
+func (m *QueryCallResult) GetLogs() []string {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
+type WasmContractInfo struct {
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Creator string `protobuf:"bytes,2,opt,name=creator,proto3" json:"creator,omitempty"`
+	Admin   string `protobuf:"bytes,3,opt,name=admin,proto3" json:"admin,omitempty"`
+	// 当前代码版本，创建时为1，每次升级加1
+	Version              int32    `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WasmContractInfo) Reset()         { *m = WasmContractInfo{} }
+func (m *WasmContractInfo) String() string { return proto.CompactTextString(m) }
+func (*WasmContractInfo) ProtoMessage()    {}
+func (*WasmContractInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7d78909ad64e3bbb, []int{7}
+}
+
+func (m *WasmContractInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WasmContractInfo.Unmarshal(m, b)
+}
+func (m *WasmContractInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WasmContractInfo.Marshal(b, m, deterministic)
+}
+func (m *WasmContractInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WasmContractInfo.Merge(m, src)
+}
+func (m *WasmContractInfo) XXX_Size() int {
+	return xxx_messageInfo_WasmContractInfo.Size(m)
+}
+func (m *WasmContractInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_WasmContractInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WasmContractInfo proto.InternalMessageInfo
+
+func (m *WasmContractInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *WasmContractInfo) GetCreator() string {
+	if m != nil {
+		return m.Creator
+	}
+	return ""
+}
+
+func (m *WasmContractInfo) GetAdmin() string {
+	if m != nil {
+		return m.Admin
+	}
+	return ""
+}
+
+func (m *WasmContractInfo) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type WasmCodeVersion struct {
+	Version int32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	// 代码的sha256哈希
+	CodeHash             string   `protobuf:"bytes,2,opt,name=codeHash,proto3" json:"codeHash,omitempty"`
+	TxHash               string   `protobuf:"bytes,3,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	Height               int64    `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	Operator             string   `protobuf:"bytes,5,opt,name=operator,proto3" json:"operator,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WasmCodeVersion) Reset()         { *m = WasmCodeVersion{} }
+func (m *WasmCodeVersion) String() string { return proto.CompactTextString(m) }
+func (*WasmCodeVersion) ProtoMessage()    {}
+func (*WasmCodeVersion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7d78909ad64e3bbb, []int{8}
+}
+
+func (m *WasmCodeVersion) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WasmCodeVersion.Unmarshal(m, b)
+}
+func (m *WasmCodeVersion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WasmCodeVersion.Marshal(b, m, deterministic)
+}
+func (m *WasmCodeVersion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WasmCodeVersion.Merge(m, src)
+}
+func (m *WasmCodeVersion) XXX_Size() int {
+	return xxx_messageInfo_WasmCodeVersion.Size(m)
+}
+func (m *WasmCodeVersion) XXX_DiscardUnknown() {
+	xxx_messageInfo_WasmCodeVersion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WasmCodeVersion proto.InternalMessageInfo
+
+func (m *WasmCodeVersion) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *WasmCodeVersion) GetCodeHash() string {
+	if m != nil {
+		return m.CodeHash
+	}
+	return ""
+}
+
+func (m *WasmCodeVersion) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+func (m *WasmCodeVersion) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *WasmCodeVersion) GetOperator() string {
+	if m != nil {
+		return m.Operator
+	}
+	return ""
+}
+
+type WasmContractVersions struct {
+	Info                 *WasmContractInfo  `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
+	Versions             []*WasmCodeVersion `protobuf:"bytes,2,rep,name=versions,proto3" json:"versions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *WasmContractVersions) Reset()         { *m = WasmContractVersions{} }
+func (m *WasmContractVersions) String() string { return proto.CompactTextString(m) }
+func (*WasmContractVersions) ProtoMessage()    {}
+func (*WasmContractVersions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7d78909ad64e3bbb, []int{9}
+}
+
+func (m *WasmContractVersions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WasmContractVersions.Unmarshal(m, b)
+}
+func (m *WasmContractVersions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WasmContractVersions.Marshal(b, m, deterministic)
+}
+func (m *WasmContractVersions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WasmContractVersions.Merge(m, src)
+}
+func (m *WasmContractVersions) XXX_Size() int {
+	return xxx_messageInfo_WasmContractVersions.Size(m)
+}
+func (m *WasmContractVersions) XXX_DiscardUnknown() {
+	xxx_messageInfo_WasmContractVersions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WasmContractVersions proto.InternalMessageInfo
+
+func (m *WasmContractVersions) GetInfo() *WasmContractInfo {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+func (m *WasmContractVersions) GetVersions() []*WasmCodeVersion {
+	if m != nil {
+		return m.Versions
+	}
+	return nil
+}
+
 type CustomLog struct {
 	Info                 []string `protobuf:"bytes,1,rep,name=info,proto3" json:"info,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -261,7 +679,7 @@ func (m *CustomLog) Reset()         { *m = CustomLog{} }
 func (m *CustomLog) String() string { return proto.CompactTextString(m) }
 func (*CustomLog) ProtoMessage()    {}
 func (*CustomLog) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7d78909ad64e3bbb, []int{4}
+	return fileDescriptor_7d78909ad64e3bbb, []int{10}
 }
 
 func (m *CustomLog) XXX_Unmarshal(b []byte) error {
@@ -301,7 +719,7 @@ func (m *CreateContractLog) Reset()         { *m = CreateContractLog{} }
 func (m *CreateContractLog) String() string { return proto.CompactTextString(m) }
 func (*CreateContractLog) ProtoMessage()    {}
 func (*CreateContractLog) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7d78909ad64e3bbb, []int{5}
+	return fileDescriptor_7d78909ad64e3bbb, []int{11}
 }
 
 func (m *CreateContractLog) XXX_Unmarshal(b []byte) error {
@@ -336,10 +754,69 @@ func (m *CreateContractLog) GetCode() string {
 	return ""
 }
 
+type UpdateContractLog struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Code                 string   `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Version              int32    `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateContractLog) Reset()         { *m = UpdateContractLog{} }
+func (m *UpdateContractLog) String() string { return proto.CompactTextString(m) }
+func (*UpdateContractLog) ProtoMessage()    {}
+func (*UpdateContractLog) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7d78909ad64e3bbb, []int{12}
+}
+
+func (m *UpdateContractLog) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateContractLog.Unmarshal(m, b)
+}
+func (m *UpdateContractLog) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateContractLog.Marshal(b, m, deterministic)
+}
+func (m *UpdateContractLog) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateContractLog.Merge(m, src)
+}
+func (m *UpdateContractLog) XXX_Size() int {
+	return xxx_messageInfo_UpdateContractLog.Size(m)
+}
+func (m *UpdateContractLog) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateContractLog.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateContractLog proto.InternalMessageInfo
+
+func (m *UpdateContractLog) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UpdateContractLog) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *UpdateContractLog) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 type CallContractLog struct {
-	Contract             string   `protobuf:"bytes,1,opt,name=contract,proto3" json:"contract,omitempty"`
-	Method               string   `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
-	Result               int32    `protobuf:"varint,3,opt,name=result,proto3" json:"result,omitempty"`
+	Contract string `protobuf:"bytes,1,opt,name=contract,proto3" json:"contract,omitempty"`
+	Method   string `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	Result   int32  `protobuf:"varint,3,opt,name=result,proto3" json:"result,omitempty"`
+	// 合约通过setReturnData设置的返回数据
+	ReturnData []byte `protobuf:"bytes,4,opt,name=returnData,proto3" json:"returnData,omitempty"`
+	// 按照合约ABI解析后的json格式返回数据
+	JsonResult           string   `protobuf:"bytes,5,opt,name=jsonResult,proto3" json:"jsonResult,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -349,7 +826,7 @@ func (m *CallContractLog) Reset()         { *m = CallContractLog{} }
 func (m *CallContractLog) String() string { return proto.CompactTextString(m) }
 func (*CallContractLog) ProtoMessage()    {}
 func (*CallContractLog) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7d78909ad64e3bbb, []int{6}
+	return fileDescriptor_7d78909ad64e3bbb, []int{13}
 }
 
 func (m *CallContractLog) XXX_Unmarshal(b []byte) error {
@@ -391,6 +868,20 @@ func (m *CallContractLog) GetResult() int32 {
 	return 0
 }
 
+func (m *CallContractLog) GetReturnData() []byte {
+	if m != nil {
+		return m.ReturnData
+	}
+	return nil
+}
+
+func (m *CallContractLog) GetJsonResult() string {
+	if m != nil {
+		return m.JsonResult
+	}
+	return ""
+}
+
 type LocalDataLog struct {
 	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	Value                []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
@@ -403,7 +894,7 @@ func (m *LocalDataLog) Reset()         { *m = LocalDataLog{} }
 func (m *LocalDataLog) String() string { return proto.CompactTextString(m) }
 func (*LocalDataLog) ProtoMessage()    {}
 func (*LocalDataLog) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7d78909ad64e3bbb, []int{7}
+	return fileDescriptor_7d78909ad64e3bbb, []int{14}
 }
 
 func (m *LocalDataLog) XXX_Unmarshal(b []byte) error {
@@ -441,10 +932,17 @@ func (m *LocalDataLog) GetValue() []byte {
 func init() {
 	proto.RegisterType((*WasmAction)(nil), "types.wasmAction")
 	proto.RegisterType((*WasmCreate)(nil), "types.wasmCreate")
+	proto.RegisterType((*WasmUpdate)(nil), "types.wasmUpdate")
 	proto.RegisterType((*WasmCall)(nil), "types.wasmCall")
 	proto.RegisterType((*QueryCheckContract)(nil), "types.queryCheckContract")
+	proto.RegisterType((*QueryCallContract)(nil), "types.queryCallContract")
+	proto.RegisterType((*QueryCallResult)(nil), "types.queryCallResult")
+	proto.RegisterType((*WasmContractInfo)(nil), "types.wasmContractInfo")
+	proto.RegisterType((*WasmCodeVersion)(nil), "types.wasmCodeVersion")
+	proto.RegisterType((*WasmContractVersions)(nil), "types.wasmContractVersions")
 	proto.RegisterType((*CustomLog)(nil), "types.customLog")
 	proto.RegisterType((*CreateContractLog)(nil), "types.createContractLog")
+	proto.RegisterType((*UpdateContractLog)(nil), "types.updateContractLog")
 	proto.RegisterType((*CallContractLog)(nil), "types.callContractLog")
 	proto.RegisterType((*LocalDataLog)(nil), "types.localDataLog")
 }
@@ -454,26 +952,44 @@ func init() {
 }
 
 var fileDescriptor_7d78909ad64e3bbb = []byte{
-	// 327 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x92, 0x4f, 0x4b, 0xc3, 0x40,
-	0x10, 0xc5, 0x9b, 0xa6, 0x8d, 0xcd, 0x58, 0xac, 0x5d, 0xa4, 0x04, 0x0f, 0x1a, 0x16, 0x84, 0x80,
-	0xd0, 0x83, 0x8a, 0x17, 0x4f, 0x5a, 0x0f, 0x3d, 0x78, 0xda, 0xbb, 0xc2, 0xba, 0x5d, 0x6d, 0xe9,
-	0x26, 0x5b, 0x37, 0x13, 0x25, 0xdf, 0x5e, 0xf6, 0x4f, 0x25, 0x07, 0xf1, 0xe0, 0x6d, 0x66, 0xf6,
-	0x37, 0xef, 0x65, 0x66, 0x02, 0xf0, 0xc5, 0xeb, 0x72, 0xbe, 0x33, 0x1a, 0x35, 0x19, 0x62, 0xbb,
-	0x93, 0x35, 0x6d, 0x7d, 0xf1, 0x5e, 0xe0, 0x46, 0x57, 0xe4, 0x12, 0x12, 0x61, 0x24, 0x47, 0x99,
-	0x45, 0x79, 0x54, 0x1c, 0x5e, 0x4d, 0xe7, 0x8e, 0x9a, 0x5b, 0x64, 0xe1, 0x1e, 0x96, 0x3d, 0x16,
-	0x10, 0x72, 0x01, 0x03, 0xc1, 0x95, 0xca, 0xfa, 0x0e, 0x9d, 0x74, 0x51, 0xae, 0xd4, 0xb2, 0xc7,
-	0xdc, 0x33, 0x39, 0x82, 0x3e, 0xb6, 0x59, 0x9c, 0x47, 0xc5, 0x90, 0xf5, 0xb1, 0x7d, 0x38, 0x80,
-	0xe1, 0x27, 0x57, 0x8d, 0xa4, 0x37, 0xde, 0xda, 0xeb, 0x12, 0x02, 0x83, 0x8a, 0x97, 0xde, 0x38,
-	0x65, 0x2e, 0xb6, 0x35, 0xa1, 0x57, 0xd2, 0x39, 0x8c, 0x99, 0x8b, 0xe9, 0x0b, 0x8c, 0xf6, 0x16,
-	0xe4, 0x14, 0x46, 0x42, 0x57, 0x68, 0xb8, 0xc0, 0xd0, 0xf7, 0x93, 0x93, 0x19, 0x24, 0xa5, 0xc4,
-	0xb5, 0x5e, 0xb9, 0xee, 0x94, 0x85, 0x8c, 0x9c, 0x01, 0xec, 0xb8, 0xe1, 0xa5, 0x44, 0x69, 0xea,
-	0x2c, 0xce, 0xe3, 0x22, 0x66, 0x9d, 0x0a, 0x2d, 0x80, 0x7c, 0x34, 0xd2, 0xb4, 0x8b, 0xb5, 0x14,
-	0xdb, 0xc5, 0x5e, 0xed, 0x97, 0xaf, 0xa3, 0xe7, 0x90, 0x8a, 0xa6, 0x46, 0x5d, 0x3e, 0xe9, 0x77,
-	0x0b, 0x6c, 0xaa, 0x37, 0x9d, 0x45, 0x79, 0x6c, 0x01, 0x1b, 0xd3, 0x3b, 0x98, 0xfa, 0x55, 0xed,
-	0x65, 0x02, 0xf8, 0xe7, 0x9c, 0x69, 0x98, 0xf3, 0x19, 0x26, 0x76, 0x7d, 0xdd, 0xd6, 0xff, 0x8c,
-	0x3b, 0x83, 0xc4, 0xc8, 0xba, 0x51, 0x18, 0x2e, 0x10, 0x32, 0x7a, 0x0b, 0x63, 0xa5, 0x05, 0x57,
-	0x8f, 0x1c, 0xb9, 0xd5, 0x3e, 0x86, 0x78, 0x2b, 0x5b, 0x27, 0x3b, 0x66, 0x36, 0x24, 0x27, 0xe1,
-	0x4e, 0x61, 0xfb, 0x3e, 0x79, 0x4d, 0xdc, 0xdf, 0x73, 0xfd, 0x1d, 0x00, 0x00, 0xff, 0xff, 0xc3,
-	0xc2, 0x9e, 0xbb, 0x4b, 0x02, 0x00, 0x00,
+	// 618 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x55, 0xcf, 0x6f, 0xd3, 0x3e,
+	0x14, 0x5f, 0xea, 0x66, 0x5b, 0xde, 0xa6, 0x6f, 0x57, 0x6b, 0xda, 0x37, 0xe2, 0x00, 0x95, 0x25,
+	0xa4, 0x4a, 0x93, 0x7a, 0x28, 0x12, 0x17, 0x4e, 0xa3, 0x08, 0x15, 0x89, 0x93, 0xa5, 0x71, 0xe2,
+	0xe2, 0x25, 0x5e, 0x1b, 0x96, 0xc4, 0xc1, 0x71, 0x36, 0x8a, 0xc4, 0x5f, 0xc1, 0x11, 0x71, 0xe5,
+	0xef, 0x44, 0xcf, 0x76, 0xd3, 0x14, 0x2a, 0x90, 0x7a, 0xe0, 0xf6, 0xde, 0xf3, 0x27, 0x9f, 0xf7,
+	0xf1, 0xfb, 0xe1, 0x00, 0x3c, 0x88, 0xba, 0x98, 0x54, 0x5a, 0x19, 0x45, 0x43, 0xb3, 0xaa, 0x64,
+	0xcd, 0x7e, 0x04, 0x2e, 0x7a, 0x95, 0x98, 0x4c, 0x95, 0xf4, 0x12, 0x0e, 0x13, 0x2d, 0x85, 0x91,
+	0x71, 0x30, 0x0a, 0xc6, 0x27, 0xd3, 0xe1, 0xc4, 0xc2, 0x26, 0x08, 0x99, 0xd9, 0x83, 0xf9, 0x01,
+	0xf7, 0x10, 0xfa, 0x14, 0xfa, 0x89, 0xc8, 0xf3, 0xb8, 0x67, 0xa1, 0x83, 0x2e, 0x54, 0xe4, 0xf9,
+	0xfc, 0x80, 0xdb, 0x63, 0xe4, 0x6c, 0xaa, 0x14, 0x39, 0xfb, 0xbf, 0x71, 0x5e, 0x57, 0xa9, 0xe7,
+	0x74, 0x10, 0xfa, 0x1f, 0xf4, 0xcc, 0x2a, 0x26, 0xa3, 0x60, 0x1c, 0xf2, 0x9e, 0x59, 0xbd, 0x3c,
+	0x82, 0xf0, 0x5e, 0xe4, 0x8d, 0x64, 0xef, 0x01, 0x36, 0x22, 0x28, 0x85, 0x7e, 0x29, 0x0a, 0xa7,
+	0x32, 0xe2, 0xd6, 0xc6, 0x58, 0xa2, 0x52, 0x69, 0xe5, 0x9c, 0x72, 0x6b, 0xd3, 0x33, 0x20, 0xe2,
+	0x26, 0xb3, 0x7c, 0x11, 0x47, 0x93, 0x9e, 0x43, 0x28, 0xd2, 0x22, 0x2b, 0xad, 0x98, 0x88, 0x3b,
+	0x87, 0xbd, 0x06, 0xd8, 0xc8, 0xd9, 0x9f, 0x9d, 0x7d, 0x86, 0xe3, 0xf5, 0xfd, 0xe9, 0x23, 0x38,
+	0x4e, 0x54, 0x69, 0xb4, 0x48, 0x8c, 0x67, 0x6a, 0x7d, 0x7a, 0x01, 0x87, 0x85, 0x34, 0x4b, 0x95,
+	0x5a, 0xbe, 0x88, 0x7b, 0x8f, 0x3e, 0x06, 0xa8, 0x84, 0x16, 0x85, 0x34, 0x52, 0xd7, 0x31, 0x19,
+	0x91, 0x31, 0xe1, 0x9d, 0x08, 0x72, 0x7e, 0xa8, 0x55, 0x79, 0xa5, 0x17, 0xb5, 0xbf, 0x40, 0xeb,
+	0xb3, 0x31, 0xd0, 0x8f, 0x8d, 0xd4, 0xab, 0xd9, 0x52, 0x26, 0x77, 0xb3, 0x75, 0xa6, 0x1d, 0x77,
+	0x61, 0xdf, 0x02, 0x18, 0x3a, 0xa8, 0xc8, 0xf3, 0x16, 0xf9, 0x8f, 0xf5, 0x22, 0x27, 0xce, 0x87,
+	0xd4, 0x71, 0xe8, 0x38, 0x9d, 0xc7, 0xbe, 0xc0, 0xa0, 0x15, 0xc7, 0x65, 0xdd, 0xe4, 0x36, 0xbd,
+	0xb6, 0x96, 0x15, 0x46, 0xb8, 0xf7, 0x30, 0xbd, 0x96, 0xa6, 0xd1, 0xe5, 0x2b, 0x61, 0x84, 0x6f,
+	0x4d, 0x27, 0x82, 0xe7, 0x98, 0xce, 0xb1, 0xf8, 0x3e, 0x75, 0x22, 0x58, 0x9c, 0x5c, 0x59, 0x69,
+	0x04, 0x8b, 0x83, 0x36, 0xab, 0xe0, 0xcc, 0xb6, 0xd0, 0x5f, 0xfd, 0x4d, 0x79, 0xab, 0x76, 0x0e,
+	0x44, 0x0c, 0x47, 0x76, 0x0f, 0x94, 0xf6, 0x35, 0x59, 0xbb, 0x9b, 0x11, 0x23, 0x9d, 0x11, 0x43,
+	0xfc, 0xbd, 0xd4, 0x75, 0xa6, 0xdc, 0xe8, 0x85, 0x7c, 0xed, 0xb2, 0xaf, 0x01, 0x0c, 0x5c, 0xca,
+	0x54, 0xbe, 0x73, 0xb1, 0x2e, 0x3a, 0xd8, 0x42, 0xbb, 0x36, 0xa5, 0x72, 0x2e, 0xea, 0xa5, 0x4f,
+	0xdc, 0xfa, 0x58, 0x27, 0xf3, 0xc9, 0x9e, 0xb8, 0xd4, 0xde, 0xc3, 0xf8, 0x52, 0x66, 0x8b, 0xa5,
+	0xb1, 0xa9, 0x09, 0xf7, 0x1e, 0x72, 0xa9, 0x4a, 0x6a, 0x7b, 0x09, 0xd7, 0x84, 0xd6, 0x67, 0x0f,
+	0x70, 0xde, 0xad, 0x83, 0x17, 0x56, 0xd3, 0x4b, 0xe8, 0x67, 0xe5, 0xad, 0xf2, 0x0f, 0xc4, 0xff,
+	0xdd, 0xad, 0xef, 0x94, 0x8c, 0x5b, 0x10, 0x9d, 0xc2, 0xb1, 0xd7, 0x5d, 0xc7, 0xbd, 0x11, 0x19,
+	0x9f, 0x4c, 0x2f, 0xb6, 0x3e, 0x68, 0x2f, 0xcc, 0x5b, 0x1c, 0x7b, 0x02, 0x51, 0xd2, 0xd4, 0x46,
+	0x15, 0x6f, 0xd5, 0x02, 0x2b, 0xef, 0xb3, 0xd9, 0x0e, 0xa1, 0xcd, 0x5e, 0xc0, 0xd0, 0xbd, 0x40,
+	0xeb, 0x84, 0x1e, 0xf8, 0xc7, 0x9d, 0x8d, 0xdc, 0xce, 0xb2, 0x6b, 0x18, 0xba, 0xa7, 0x66, 0x8f,
+	0x8f, 0xbb, 0x5d, 0x21, 0xdb, 0x3d, 0xfc, 0x1e, 0xc0, 0x20, 0xe9, 0x6c, 0x13, 0xb2, 0xee, 0xb3,
+	0x50, 0x9b, 0x49, 0x77, 0x09, 0x76, 0x4f, 0x7a, 0xff, 0x2f, 0x93, 0x1e, 0xfe, 0x3a, 0xe9, 0xec,
+	0x39, 0x9c, 0xe6, 0x2a, 0x11, 0x39, 0x82, 0x51, 0xdb, 0x19, 0x90, 0x3b, 0xb9, 0xb2, 0xb2, 0x4e,
+	0x39, 0x9a, 0xf4, 0xdc, 0xbf, 0xb4, 0x7e, 0x8d, 0x9c, 0x73, 0x73, 0x68, 0xff, 0x16, 0xcf, 0x7e,
+	0x0e, 0x00, 0x8c, 0x91, 0xb8, 0x21, 0x3b, 0x06, 0x00, 0x00,
 }
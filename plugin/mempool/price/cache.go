@@ -3,12 +3,13 @@ package price
 import (
 	"github.com/33cn/chain33/common/skiplist"
 	"github.com/33cn/chain33/system/mempool"
+	"github.com/33cn/plugin/plugin/mempool/skipqueue"
 	"github.com/golang/protobuf/proto"
 )
 
 // Queue 价格队列模式(价格=手续费/交易字节数,价格高者优先,同价则时间早优先)
 type Queue struct {
-	*skiplist.Queue
+	*skipqueue.Queue
 	subConfig subConfig
 }
 
@@ -44,29 +45,26 @@ func (item *priceScore) ByteSize() int64 {
 // NewQueue 创建队列
 func NewQueue(subcfg subConfig) *Queue {
 	return &Queue{
-		Queue:     skiplist.NewQueue(subcfg.PoolCacheSize),
+		Queue:     skipqueue.NewQueue(subcfg.Config),
 		subConfig: subcfg,
 	}
 }
 
-//GetItem 获取数据通过 key
-func (cache *Queue) GetItem(hash string) (*mempool.Item, error) {
-	item, err := cache.Queue.GetItem(hash)
-	if err != nil {
-		return nil, err
-	}
-	return item.(*priceScore).Item, nil
-}
-
 //Push 加入数据到队列
 func (cache *Queue) Push(item *mempool.Item) error {
-	return cache.Queue.Push(&priceScore{Item: item})
+	_, err := cache.PushItem(item)
+	return err
+}
+
+//PushItem 加入数据到队列, 如果替换了同一账户相同 nonce 的交易, 返回从本节点 mempool 中驱逐的原交易
+func (cache *Queue) PushItem(item *mempool.Item) (*skipqueue.LocalEviction, error) {
+	return cache.Queue.Push(item, &priceScore{Item: item})
 }
 
 //Walk 获取数据通过 key
 func (cache *Queue) Walk(count int, cb func(tx *mempool.Item) bool) {
-	cache.Queue.Walk(count, func(item skiplist.Scorer) bool {
-		return cb(item.(*priceScore).Item)
+	cache.Queue.Walk(count, func(item *mempool.Item, score skiplist.Scorer) bool {
+		return cb(item)
 	})
 }
 
@@ -78,22 +76,8 @@ func (cache *Queue) GetProperFee() int64 {
 		return cache.subConfig.ProperFee
 	}
 	i := 0
-	var feeRate int64
 	cache.Walk(100, func(item *mempool.Item) bool {
-		//总单元费率的个数, 单个交易根据txsize/1000 + 1计算
-		unitFeeNum := proto.Size(item.Value)/1000 + 1
-		//交易组计算
-		if count := item.Value.GetGroupCount(); count > 0 {
-			unitFeeNum = int(count)
-			txs, err := item.Value.GetTxGroup()
-			if err != nil {
-				for _, tx := range txs.GetTxs() {
-					unitFeeNum += proto.Size(tx) / 1000
-				}
-			}
-		}
-		feeRate = item.Value.Fee / int64(unitFeeNum)
-		sumFeeRate += feeRate
+		sumFeeRate += txFeeRate(item.Value)
 		i++
 		return true
 	})
@@ -3,6 +3,7 @@ package price
 import (
 	"log"
 	"testing"
+	"time"
 
 	"github.com/33cn/chain33/common"
 	"github.com/33cn/chain33/common/address"
@@ -12,7 +13,9 @@ import (
 	"github.com/33cn/chain33/types"
 	"github.com/33cn/chain33/util"
 	"github.com/33cn/chain33/util/testnode"
+	"github.com/33cn/plugin/plugin/mempool/skipqueue"
 	"github.com/golang/protobuf/proto"
+	metrics "github.com/rcrowley/go-metrics"
 	"github.com/stretchr/testify/assert"
 
 	_ "github.com/33cn/chain33/system"
@@ -251,4 +254,242 @@ func TestRealNodeMempool(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, len(peer.Peers), 0)
 	//assert.Equal(t, peer.Peers[0].MempoolSize, int32(0))
+
+	//手续费估算同步了链上的区块
+	header, err := mock33.GetAPI().GetLastHeader()
+	assert.Nil(t, err)
+	for i := 0; i < 50 && getEstimator().lastHeight() < header.Height; i++ {
+		time.Sleep(100 * time.Millisecond)
+	}
+	assert.True(t, getEstimator().lastHeight() >= header.Height)
+	var est interface{}
+	assert.Nil(t, new(Jrpc).EstimateFee(&ReqEstimateFee{Target: 1}, &est))
+	assert.True(t, est.(*FeeEstimate).High >= cfg.GetMinTxFeeRate())
+}
+
+func TestReplaceByFee(t *testing.T) {
+	cache := initEnv(2)
+	newItem := func(fee, nonce int64) *drivers.Item {
+		tx := &types.Transaction{Execer: []byte("coins"), Payload: types.Encode(transfer), Fee: fee, Nonce: nonce, To: toAddr}
+		tx.Sign(types.SECP256K1, privKey)
+		return &drivers.Item{Value: tx, Priority: tx.Fee, EnterTime: types.Now().Unix()}
+	}
+	old := newItem(1000000, 1)
+	replaced, err := cache.PushItem(old)
+	assert.Nil(t, err)
+	assert.Nil(t, replaced)
+	//不同的 nonce 不冲突
+	other := newItem(1000000, 2)
+	assert.Nil(t, cache.Push(other))
+
+	//手续费提高的比例不够
+	_, err = cache.PushItem(newItem(1050000, 1))
+	assert.Equal(t, skipqueue.ErrLocalReplaceFeeTooLow, err)
+	assert.Equal(t, 2, cache.Size())
+
+	//队列满的时候也可以替换
+	bump := newItem(1100000, 1)
+	replaced, err = cache.PushItem(bump)
+	assert.Nil(t, err)
+	assert.Equal(t, old, replaced.Item)
+	assert.Equal(t, 2, cache.Size())
+	assert.False(t, cache.Exist(string(old.Value.Hash())))
+	assert.True(t, cache.Exist(string(bump.Value.Hash())))
+	it, err := cache.GetItem(string(bump.Value.Hash()))
+	assert.Nil(t, err)
+	assert.Equal(t, bump, it)
+
+	//删除以后相同的 nonce 可以重新加入
+	assert.Nil(t, cache.Remove(string(bump.Value.Hash())))
+	assert.Nil(t, cache.Push(old))
+}
+
+func TestQuotaAndFairEvict(t *testing.T) {
+	newItem := func(priv crypto.PrivKey, execer string, fee, nonce int64) *drivers.Item {
+		tx := &types.Transaction{Execer: []byte(execer), Payload: types.Encode(transfer), Fee: fee, Nonce: nonce, To: toAddr}
+		tx.Sign(types.SECP256K1, priv)
+		return &drivers.Item{Value: tx, Priority: tx.Fee, EnterTime: types.Now().Unix()}
+	}
+	spammer, _ := c.GenKey()
+	user, _ := c.GenKey()
+
+	//地址和执行器的配额
+	cache := initEnv(10)
+	cache.Queue = skipqueue.NewQueue(skipqueue.Config{PoolCacheSize: 10, MaxTxPerAddr: 2, MaxTxPerExec: 3})
+	cache.RegisterMetrics("price-test")
+	assert.Nil(t, cache.Push(newItem(spammer, "coins", 1000000, 1)))
+	assert.Nil(t, cache.Push(newItem(spammer, "coins", 1000000, 2)))
+	assert.Equal(t, types.ErrManyTx, cache.Push(newItem(spammer, "coins", 1000000, 3)))
+	//替换交易不占用配额
+	assert.Nil(t, cache.Push(newItem(spammer, "coins", 2000000, 2)))
+	assert.Nil(t, cache.Push(newItem(user, "coins", 1000000, 11)))
+	assert.Equal(t, skipqueue.ErrManyExecTx, cache.Push(newItem(user, "coins", 1000000, 12)))
+	assert.Nil(t, cache.Push(newItem(user, "none", 1000000, 13)))
+	m := cache.Metrics()
+	assert.Equal(t, int64(1), m.RejectedAddr)
+	assert.Equal(t, int64(1), m.RejectedExec)
+	assert.Equal(t, int64(1), m.EvictedLocal)
+	gauge, ok := metrics.Get("mempool/price-test/rejectedAddr").(metrics.Gauge)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), gauge.Value())
+
+	//队列满的时候先驱逐排队交易最多的地址的交易, 即使它的手续费更高
+	cache = initEnv(4)
+	spam := make([]*drivers.Item, 3)
+	for i := range spam {
+		spam[i] = newItem(spammer, "coins", int64(2000000+i*100000), int64(i))
+		assert.Nil(t, cache.Push(spam[i]))
+	}
+	assert.Nil(t, cache.Push(newItem(user, "coins", 1000000, 11)))
+	assert.Nil(t, cache.Push(newItem(user, "coins", 1000000, 12)))
+	assert.Equal(t, 4, cache.Size())
+	assert.False(t, cache.Exist(string(spam[0].Value.Hash())))
+	assert.Equal(t, int64(1), cache.Metrics().EvictedSender)
+	//数量接近的时候按照优先级驱逐, 新交易优先级太低就拒绝
+	assert.Equal(t, types.ErrMemFull, cache.Push(newItem(user, "coins", 100000, 13)))
+	assert.Equal(t, int64(1), cache.Metrics().RejectedFull)
+}
+
+func TestFeeEstimator(t *testing.T) {
+	newBlock := func(height int64, fees ...int64) *types.Block {
+		block := &types.Block{Height: height}
+		//挖矿交易不收手续费, 不参与统计
+		block.Txs = append(block.Txs, &types.Transaction{Execer: []byte("coins"), Nonce: height})
+		for i, fee := range fees {
+			block.Txs = append(block.Txs, &types.Transaction{Execer: []byte("coins"), Payload: types.Encode(transfer), Fee: fee, Nonce: int64(i)})
+		}
+		return block
+	}
+	e := newFeeEstimator(10, 100000)
+	est := e.Estimate(1)
+	assert.Equal(t, int64(100000), est.Low)
+	assert.Equal(t, int64(100000), est.High)
+	assert.Equal(t, 0, est.Blocks)
+
+	e.addBlock(newBlock(1, 400000, 100000, 300000, 200000))
+	history := e.History()
+	assert.Equal(t, 1, len(history))
+	assert.Equal(t, 4, history[0].Count)
+	assert.Equal(t, int64(100000), history[0].Min)
+	assert.Equal(t, int64(100000), history[0].P25)
+	assert.Equal(t, int64(200000), history[0].P50)
+	assert.Equal(t, int64(300000), history[0].P75)
+	assert.Equal(t, int64(400000), history[0].Max)
+
+	//保留最近 10 个区块, 门槛手续费率分别是 3e5 ~ 1.2e6
+	for i := int64(2); i <= 12; i++ {
+		e.addBlock(newBlock(i, i*100000, i*100000+50000))
+	}
+	history = e.History()
+	assert.Equal(t, 10, len(history))
+	assert.Equal(t, int64(3), history[0].Height)
+	assert.Equal(t, int64(12), e.lastHeight())
+
+	est = e.Estimate(1)
+	assert.Equal(t, int64(700000), est.Low)
+	assert.Equal(t, int64(1000000), est.Medium)
+	assert.Equal(t, int64(1200000), est.High)
+	//目标区块数越多, 需要的手续费越低
+	est10 := e.Estimate(10)
+	assert.True(t, est10.Low <= est.Low)
+	assert.True(t, est10.High < est.High)
+	assert.Equal(t, int64(300000), est10.Low)
+	//空块的门槛是 0, 估算结果不会低于最低手续费率
+	for i := int64(13); i <= 22; i++ {
+		e.addBlock(newBlock(i))
+	}
+	est = e.Estimate(1)
+	assert.Equal(t, int64(100000), est.Low)
+	assert.Equal(t, int64(100000), est.High)
+
+	//回滚的区块重新加入
+	e.rollback(20)
+	assert.Equal(t, int64(20), e.lastHeight())
+	e.addBlock(newBlock(20, 900000))
+	history = e.History()
+	assert.Equal(t, int64(900000), history[len(history)-1].Min)
+	assert.Equal(t, int64(20), e.lastHeight())
+
+	//交易组按照组内交易的个数和每个交易的大小计算单元数
+	var gtxs []*types.Transaction
+	for i := 0; i < 3; i++ {
+		gtxs = append(gtxs, &types.Transaction{Execer: []byte("coins"), Payload: make([]byte, 1500), Fee: 1000000, Nonce: int64(i), To: toAddr})
+	}
+	group, err := types.CreateTxGroup(gtxs, 100000)
+	assert.Nil(t, err)
+	gtx := group.Tx()
+	unitFeeNum := 3
+	for _, tx := range group.Txs {
+		unitFeeNum += proto.Size(tx) / 1000
+	}
+	assert.Equal(t, 6, unitFeeNum)
+	assert.Equal(t, gtx.Fee/int64(unitFeeNum), txFeeRate(gtx))
+	e.addBlock(&types.Block{Height: 21, Txs: []*types.Transaction{gtx}})
+	history = e.History()
+	assert.Equal(t, gtx.Fee/6, history[len(history)-1].Min)
+}
+
+func TestDependencyOrder(t *testing.T) {
+	now := types.Now().Unix()
+	newItem := func(priv crypto.PrivKey, fee, nonce int64) *drivers.Item {
+		tx := &types.Transaction{Execer: []byte("coins"), Payload: types.Encode(transfer), Fee: fee, Nonce: nonce, To: toAddr}
+		tx.Sign(types.SECP256K1, priv)
+		return &drivers.Item{Value: tx, Priority: tx.Fee, EnterTime: now}
+	}
+	walk := func(cache *Queue, count int) (items []*drivers.Item) {
+		cache.Walk(count, func(item *drivers.Item) bool {
+			items = append(items, item)
+			return true
+		})
+		return items
+	}
+	alice, _ := c.GenKey()
+	bob, _ := c.GenKey()
+	//alice 先授权再交易, 交易的手续费比授权高
+	approve := newItem(alice, 1000000, 1)
+	trade := newItem(alice, 5000000, 2)
+	other := newItem(bob, 3000000, 11)
+
+	cache := initEnv(0)
+	assert.Nil(t, cache.Push(approve))
+	assert.Nil(t, cache.Push(trade))
+	assert.Nil(t, cache.Push(other))
+	assert.Equal(t, []*drivers.Item{other, approve, trade}, walk(cache, 0))
+	//只取一笔交易的时候不会越过授权先取出交易
+	assert.Equal(t, []*drivers.Item{other}, walk(cache, 1))
+
+	//替换的交易保持原来的顺序
+	bump := newItem(alice, 1100000, 1)
+	assert.Nil(t, cache.Push(bump))
+	assert.Equal(t, []*drivers.Item{other, bump, trade}, walk(cache, 0))
+
+	//授权打包以后交易按照手续费排在前面
+	assert.Nil(t, cache.Remove(string(bump.Value.Hash())))
+	assert.Equal(t, []*drivers.Item{trade, other}, walk(cache, 0))
+}
+
+func TestReplaceRemoveFromMempool(t *testing.T) {
+	cfg, sub := types.InitCfg("chain33.test.toml")
+	mem := New(cfg.Mempool, sub.Mempool["price"]).(*Mempool)
+	defer mem.Close()
+	newTx := func(fee int64) *types.Transaction {
+		tx := &types.Transaction{Execer: []byte("coins"), Payload: types.Encode(transfer), Fee: fee, Nonce: 1, To: toAddr}
+		tx.Sign(types.SECP256K1, privKey)
+		return tx
+	}
+	old := newTx(1000000)
+	assert.Nil(t, mem.PushTx(old))
+	bump := newTx(2000000)
+	assert.Nil(t, mem.PushTx(bump))
+	//被替换的交易也要从账户索引和最新交易中删除
+	for i := 0; i < 50 && mem.TxNumOfAccount(old.From()) > 1; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, int64(1), mem.TxNumOfAccount(old.From()))
+	assert.Equal(t, 1, mem.Size())
+	latest := mem.GetLatestTx()
+	assert.Equal(t, 1, len(latest))
+	assert.Equal(t, bump.Hash(), latest[0].Hash())
+	//原来的交易可以重新提交, 但是手续费不够替换
+	assert.Equal(t, skipqueue.ErrLocalReplaceFeeTooLow, mem.PushTx(old))
 }
@@ -0,0 +1,180 @@
+package price
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/33cn/chain33/types"
+	"github.com/golang/protobuf/proto"
+)
+
+// 默认记录最近多少个区块的手续费率
+const defaultFeeHistoryBlocks = 100
+
+// 估算 低/中/高 三档手续费率时, 在目标区块数内被打包的概率
+var feeConfidence = [3]float64{0.5, 0.8, 0.95}
+
+// FeeEstimate 按照目标确认区块数估算的手续费率, 手续费率的单位和 GetProperFee 一致
+type FeeEstimate struct {
+	Target int64 `json:"target"`
+	Low    int64 `json:"low"`
+	Medium int64 `json:"medium"`
+	High   int64 `json:"high"`
+	//参与估算的区块数量
+	Blocks int `json:"blocks"`
+}
+
+// BlockFee 一个区块中打包交易的手续费率分布
+type BlockFee struct {
+	Height int64 `json:"height"`
+	Count  int   `json:"count"`
+	Min    int64 `json:"min"`
+	P25    int64 `json:"p25"`
+	P50    int64 `json:"p50"`
+	P75    int64 `json:"p75"`
+	Max    int64 `json:"max"`
+}
+
+// feeEstimator 记录最近区块中打包交易的手续费率分布, 根据每个区块的最低手续费率估算手续费
+type feeEstimator struct {
+	mu      sync.RWMutex
+	size    int
+	minFee  int64
+	history []*BlockFee
+}
+
+func newFeeEstimator(size int, minFee int64) *feeEstimator {
+	if size <= 0 {
+		size = defaultFeeHistoryBlocks
+	}
+	return &feeEstimator{size: size, minFee: minFee}
+}
+
+// txFeeRate 交易的手续费率, 也就是每个单元(1000 字节)的手续费
+func txFeeRate(tx *types.Transaction) int64 {
+	//总单元费率的个数, 单个交易根据txsize/1000 + 1计算
+	unitFeeNum := proto.Size(tx)/1000 + 1
+	//交易组计算
+	if count := tx.GetGroupCount(); count > 0 {
+		unitFeeNum = int(count)
+		txs, err := tx.GetTxGroup()
+		if err == nil {
+			for _, tx := range txs.GetTxs() {
+				unitFeeNum += proto.Size(tx) / 1000
+			}
+		}
+	}
+	return tx.Fee / int64(unitFeeNum)
+}
+
+// newBlockFee 统计区块中交易的手续费率, 不收手续费的交易(比如挖矿交易)不参与统计
+func newBlockFee(block *types.Block) *BlockFee {
+	var rates []int64
+	for _, tx := range block.Txs {
+		if tx.Fee <= 0 {
+			continue
+		}
+		rates = append(rates, txFeeRate(tx))
+	}
+	bf := &BlockFee{Height: block.Height, Count: len(rates)}
+	if len(rates) == 0 {
+		return bf
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i] < rates[j] })
+	bf.Min = rates[0]
+	bf.P25 = percentile(rates, 0.25)
+	bf.P50 = percentile(rates, 0.5)
+	bf.P75 = percentile(rates, 0.75)
+	bf.Max = rates[len(rates)-1]
+	return bf
+}
+
+// percentile 从小到大排好序的数据中取百分位数
+func percentile(sorted []int64, p float64) int64 {
+	idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
+
+// addBlock 记录新的区块, 区块回滚的时候先删除高度不小于这个区块的记录
+func (e *feeEstimator) addBlock(block *types.Block) {
+	bf := newBlockFee(block)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.removeFrom(bf.Height)
+	e.history = append(e.history, bf)
+	if len(e.history) > e.size {
+		e.history = e.history[len(e.history)-e.size:]
+	}
+}
+
+func (e *feeEstimator) removeFrom(height int64) {
+	i := len(e.history)
+	for i > 0 && e.history[i-1].Height >= height {
+		i--
+	}
+	e.history = e.history[:i]
+}
+
+// rollback 区块回滚之后删除比当前高度高的记录
+func (e *feeEstimator) rollback(height int64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.removeFrom(height + 1)
+}
+
+// lastHeight 记录的最后一个区块的高度, 没有记录返回 -1
+func (e *feeEstimator) lastHeight() int64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if len(e.history) == 0 {
+		return -1
+	}
+	return e.history[len(e.history)-1].Height
+}
+
+// History 最近区块的手续费率分布
+func (e *feeEstimator) History() []*BlockFee {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	history := make([]*BlockFee, len(e.history))
+	copy(history, e.history)
+	return history
+}
+
+// Estimate 估算交易在 target 个区块内被打包需要的手续费率。
+// 把每个区块打包交易的最低手续费率(空块为 0)看作进入区块的门槛, 手续费率达到门槛分布的 p 分位时,
+// 在 target 个区块内被打包的概率约为 1-(1-p)^target, 按照 低/中/高 三档概率反推 p 取对应的分位数
+func (e *feeEstimator) Estimate(target int64) *FeeEstimate {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if target <= 0 {
+		target = 1
+	}
+	if target > int64(e.size) {
+		target = int64(e.size)
+	}
+	est := &FeeEstimate{Target: target, Low: e.minFee, Medium: e.minFee, High: e.minFee, Blocks: len(e.history)}
+	if len(e.history) == 0 {
+		return est
+	}
+	floors := make([]int64, len(e.history))
+	for i, bf := range e.history {
+		floors[i] = bf.Min
+	}
+	sort.Slice(floors, func(i, j int) bool { return floors[i] < floors[j] })
+	fees := []*int64{&est.Low, &est.Medium, &est.High}
+	for i, c := range feeConfidence {
+		p := 1 - math.Pow(1-c, 1/float64(target))
+		if fee := percentile(floors, p); fee > e.minFee {
+			*fees[i] = fee
+		}
+	}
+	return est
+}
@@ -1,23 +1,47 @@
 package price
 
 import (
+	"sync"
+	"time"
+
+	"github.com/33cn/chain33/client"
+	log "github.com/33cn/chain33/common/log/log15"
 	"github.com/33cn/chain33/queue"
 	drivers "github.com/33cn/chain33/system/mempool"
 	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/mempool/skipqueue"
 )
 
 //--------------------------------------------------------------------------------
 // Module Mempool
 
 type subConfig struct {
-	PoolCacheSize int64 `json:"poolCacheSize"`
-	ProperFee     int64 `json:"properFee"`
+	skipqueue.Config
+	ProperFee int64 `json:"properFee"`
+	//手续费估算记录最近多少个区块
+	FeeHistoryBlocks int `json:"feeHistoryBlocks"`
 }
 
+var mlog = log.New("module", "mempool.price")
+
+//每次从区块链获取的最大区块数量
+const maxFetchBlocks = 128
+
+//估算器同步区块的间隔
+var feePollInterval = time.Second
+
 func init() {
 	drivers.Reg("price", New)
 }
 
+// Mempool price 模式的 mempool, 在基础 mempool 上增加了根据最近区块的手续费率估算手续费
+type Mempool struct {
+	*drivers.Mempool
+	estimator *feeEstimator
+	done      chan struct{}
+	wg        sync.WaitGroup
+}
+
 //New 创建price cache 结构的 mempool
 func New(cfg *types.Mempool, sub []byte) queue.Module {
 	c := drivers.NewMempool(cfg)
@@ -29,6 +53,90 @@ func New(cfg *types.Mempool, sub []byte) queue.Module {
 	if subcfg.ProperFee == 0 {
 		subcfg.ProperFee = cfg.MinTxFeeRate
 	}
-	c.SetQueueCache(NewQueue(subcfg))
-	return c
+	q := NewQueue(subcfg)
+	q.BindMempool(c)
+	q.RegisterMetrics("price")
+	c.SetQueueCache(q)
+	mem := &Mempool{
+		Mempool:   c,
+		estimator: newFeeEstimator(subcfg.FeeHistoryBlocks, subcfg.ProperFee),
+		done:      make(chan struct{}),
+	}
+	setEstimator(mem.estimator)
+	return mem
+}
+
+//SetQueueClient 初始化mempool模块, 并开始同步区块的手续费率
+func (mem *Mempool) SetQueueClient(qclient queue.Client) {
+	mem.Mempool.SetQueueClient(qclient)
+	api, err := client.New(qclient, nil)
+	if err != nil {
+		panic(err)
+	}
+	mem.wg.Add(1)
+	go mem.pollBlocks(api)
+}
+
+//Close 关闭mempool
+func (mem *Mempool) Close() {
+	select {
+	case <-mem.done:
+		return
+	default:
+	}
+	close(mem.done)
+	mem.wg.Wait()
+	mem.Mempool.Close()
+}
+
+//pollBlocks 定时把新的区块加入手续费估算
+func (mem *Mempool) pollBlocks(api client.QueueProtocolAPI) {
+	defer mem.wg.Done()
+	ticker := time.NewTicker(feePollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-mem.done:
+			return
+		case <-ticker.C:
+			err := mem.syncBlocks(api)
+			if err != nil {
+				mlog.Debug("pollBlocks", "err", err)
+			}
+		}
+	}
+}
+
+//syncBlocks 同步估算器没有记录的区块, 第一次同步的时候只取最近 FeeHistoryBlocks 个区块
+func (mem *Mempool) syncBlocks(api client.QueueProtocolAPI) error {
+	header, err := api.GetLastHeader()
+	if err != nil {
+		return err
+	}
+	e := mem.estimator
+	e.rollback(header.Height)
+	start := e.lastHeight() + 1
+	if min := header.Height - int64(e.size) + 1; start < min {
+		start = min
+	}
+	if start < 0 {
+		start = 0
+	}
+	for start <= header.Height {
+		end := start + maxFetchBlocks - 1
+		if end > header.Height {
+			end = header.Height
+		}
+		details, err := api.GetBlocks(&types.ReqBlocks{Start: start, End: end})
+		if err != nil {
+			return err
+		}
+		for _, detail := range details.GetItems() {
+			if detail.GetBlock() != nil {
+				e.addBlock(detail.Block)
+			}
+		}
+		start = end + 1
+	}
+	return nil
 }
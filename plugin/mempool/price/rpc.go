@@ -0,0 +1,60 @@
+package price
+
+import (
+	"sync/atomic"
+
+	rpctypes "github.com/33cn/chain33/rpc/types"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/common/rpcplugin"
+)
+
+//当前节点使用的手续费估算器, 只有 mempool 使用 price 模式的时候才有
+var estimatorValue atomic.Value
+
+func setEstimator(e *feeEstimator) {
+	estimatorValue.Store(e)
+}
+
+func getEstimator() *feeEstimator {
+	e, _ := estimatorValue.Load().(*feeEstimator)
+	return e
+}
+
+func init() {
+	rpcplugin.Register("mempool-price", func(s rpctypes.RPCServer) {
+		s.JRPC().RegisterName("mempool", &Jrpc{})
+	}, nil)
+}
+
+// Jrpc 手续费估算的 json rpc 接口。估算的是区块打包交易的手续费率;
+// 用更高的手续费发送同一账户相同 nonce 的交易只会从本节点的 mempool 中驱逐原交易(见 skipqueue.LocalEviction),
+// 原交易已经广播出去的时候仍然可能被打包, 不能用来取消交易
+type Jrpc struct{}
+
+// ReqEstimateFee 估算手续费的请求, Target 是希望在多少个区块内被打包
+type ReqEstimateFee struct {
+	Target int64 `json:"target"`
+}
+
+// EstimateFee 根据最近区块打包交易的手续费率, 估算在 Target 个区块内被打包的 低/中/高 三档手续费率
+func (c *Jrpc) EstimateFee(in *ReqEstimateFee, result *interface{}) error {
+	e := getEstimator()
+	if e == nil {
+		return types.ErrNotSupport
+	}
+	if in == nil {
+		return types.ErrInvalidParam
+	}
+	*result = e.Estimate(in.Target)
+	return nil
+}
+
+// FeeHistory 最近区块打包交易的手续费率分布
+func (c *Jrpc) FeeHistory(in *types.ReqNil, result *interface{}) error {
+	e := getEstimator()
+	if e == nil {
+		return types.ErrNotSupport
+	}
+	*result = e.History()
+	return nil
+}
@@ -5,12 +5,13 @@ import (
 
 	"github.com/33cn/chain33/common/skiplist"
 	"github.com/33cn/chain33/system/mempool"
+	"github.com/33cn/plugin/plugin/mempool/skipqueue"
 	"github.com/golang/protobuf/proto"
 )
 
 // Queue 分数队列模式(分数=定量a*常量b*手续费/交易字节数-常量c*时间,按分数排队,高的优先,定量a和常量b,c可配置)
 type Queue struct {
-	*skiplist.Queue
+	*skipqueue.Queue
 	subConfig subConfig
 }
 
@@ -49,7 +50,7 @@ func (item *scoreScore) ByteSize() int64 {
 // NewQueue 创建队列
 func NewQueue(subcfg subConfig) *Queue {
 	return &Queue{
-		Queue:     skiplist.NewQueue(subcfg.PoolCacheSize),
+		Queue:     skipqueue.NewQueue(subcfg.Config),
 		subConfig: subcfg,
 	}
 }
@@ -60,24 +61,21 @@ func NewQueue(subcfg subConfig) *Queue {
 //		cache.subConfig.PricePower - cache.subConfig.TimeParam*item.EnterTime, Value: item}, nil
 //}
 
-//GetItem 获取数据通过 key
-func (cache *Queue) GetItem(hash string) (*mempool.Item, error) {
-	item, err := cache.Queue.GetItem(hash)
-	if err != nil {
-		return nil, err
-	}
-	return item.(*scoreScore).Item, nil
-}
-
 // Push 把给定tx添加到Queue；如果tx已经存在Queue中或Mempool已满则返回对应error
 func (cache *Queue) Push(item *mempool.Item) error {
-	return cache.Queue.Push(&scoreScore{Item: item, subConfig: cache.subConfig})
+	_, err := cache.PushItem(item)
+	return err
+}
+
+// PushItem 把给定tx添加到Queue, 如果替换了同一账户相同 nonce 的交易, 返回从本节点 mempool 中驱逐的原交易
+func (cache *Queue) PushItem(item *mempool.Item) (*skipqueue.LocalEviction, error) {
+	return cache.Queue.Push(item, &scoreScore{Item: item, subConfig: cache.subConfig})
 }
 
 // Walk 遍历整个队列
 func (cache *Queue) Walk(count int, cb func(value *mempool.Item) bool) {
-	cache.Queue.Walk(count, func(item skiplist.Scorer) bool {
-		return cb(item.(*scoreScore).Item)
+	cache.Queue.Walk(count, func(item *mempool.Item, score skiplist.Scorer) bool {
+		return cb(item)
 	})
 }
 
@@ -89,7 +87,7 @@ func (cache *Queue) GetProperFee() int64 {
 		return cache.subConfig.ProperFee
 	}
 	i := 0
-	cache.Queue.Walk(0, func(score skiplist.Scorer) bool {
+	cache.Queue.Walk(0, func(item *mempool.Item, score skiplist.Scorer) bool {
 		if i == 100 {
 			return false
 		}
@@ -13,6 +13,7 @@ import (
 	"github.com/33cn/chain33/types"
 	"github.com/33cn/chain33/util"
 	"github.com/33cn/chain33/util/testnode"
+	"github.com/33cn/plugin/plugin/mempool/skipqueue"
 	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 
@@ -244,3 +245,121 @@ func TestGetProperFee(t *testing.T) {
 		(cache.subConfig.PriceConstant * cache.subConfig.PricePower)
 	assert.Equal(t, int64(1), properFee/cache.GetProperFee())
 }
+
+func TestReplaceByFee(t *testing.T) {
+	cache := initEnv(2)
+	newItem := func(fee, nonce int64) *drivers.Item {
+		tx := &types.Transaction{Execer: []byte("coins"), Payload: types.Encode(transfer), Fee: fee, Nonce: nonce, To: toAddr}
+		tx.Sign(types.SECP256K1, privKey)
+		return &drivers.Item{Value: tx, Priority: tx.Fee, EnterTime: types.Now().Unix()}
+	}
+	old := newItem(1000000, 1)
+	replaced, err := cache.PushItem(old)
+	assert.Nil(t, err)
+	assert.Nil(t, replaced)
+	//不同的 nonce 不冲突
+	other := newItem(1000000, 2)
+	assert.Nil(t, cache.Push(other))
+
+	//手续费提高的比例不够
+	_, err = cache.PushItem(newItem(1050000, 1))
+	assert.Equal(t, skipqueue.ErrLocalReplaceFeeTooLow, err)
+	assert.Equal(t, 2, cache.Size())
+
+	//队列满的时候也可以替换
+	bump := newItem(1100000, 1)
+	replaced, err = cache.PushItem(bump)
+	assert.Nil(t, err)
+	assert.Equal(t, old, replaced.Item)
+	assert.Equal(t, 2, cache.Size())
+	assert.False(t, cache.Exist(string(old.Value.Hash())))
+	assert.True(t, cache.Exist(string(bump.Value.Hash())))
+	it, err := cache.GetItem(string(bump.Value.Hash()))
+	assert.Nil(t, err)
+	assert.Equal(t, bump, it)
+
+	//删除以后相同的 nonce 可以重新加入
+	assert.Nil(t, cache.Remove(string(bump.Value.Hash())))
+	assert.Nil(t, cache.Push(old))
+}
+
+func TestQuotaAndFairEvict(t *testing.T) {
+	newItem := func(priv crypto.PrivKey, execer string, fee, nonce int64) *drivers.Item {
+		tx := &types.Transaction{Execer: []byte(execer), Payload: types.Encode(transfer), Fee: fee, Nonce: nonce, To: toAddr}
+		tx.Sign(types.SECP256K1, priv)
+		return &drivers.Item{Value: tx, Priority: tx.Fee, EnterTime: types.Now().Unix()}
+	}
+	spammer, _ := c.GenKey()
+	user, _ := c.GenKey()
+
+	//地址和执行器的配额
+	cache := initEnv(10)
+	cache.Queue = skipqueue.NewQueue(skipqueue.Config{PoolCacheSize: 10, MaxTxPerAddr: 2, MaxTxPerExec: 3})
+	assert.Nil(t, cache.Push(newItem(spammer, "coins", 1000000, 1)))
+	assert.Nil(t, cache.Push(newItem(spammer, "coins", 1000000, 2)))
+	assert.Equal(t, types.ErrManyTx, cache.Push(newItem(spammer, "coins", 1000000, 3)))
+	//替换交易不占用配额
+	assert.Nil(t, cache.Push(newItem(spammer, "coins", 2000000, 2)))
+	assert.Nil(t, cache.Push(newItem(user, "coins", 1000000, 11)))
+	assert.Equal(t, skipqueue.ErrManyExecTx, cache.Push(newItem(user, "coins", 1000000, 12)))
+	assert.Nil(t, cache.Push(newItem(user, "none", 1000000, 13)))
+	m := cache.Metrics()
+	assert.Equal(t, int64(1), m.RejectedAddr)
+	assert.Equal(t, int64(1), m.RejectedExec)
+	assert.Equal(t, int64(1), m.EvictedLocal)
+
+	//队列满的时候先驱逐排队交易最多的地址的交易, 即使它的手续费更高
+	cache = initEnv(4)
+	spam := make([]*drivers.Item, 3)
+	for i := range spam {
+		spam[i] = newItem(spammer, "coins", int64(2000000+i*100000), int64(i))
+		assert.Nil(t, cache.Push(spam[i]))
+	}
+	assert.Nil(t, cache.Push(newItem(user, "coins", 1000000, 11)))
+	assert.Nil(t, cache.Push(newItem(user, "coins", 1000000, 12)))
+	assert.Equal(t, 4, cache.Size())
+	assert.False(t, cache.Exist(string(spam[0].Value.Hash())))
+	assert.Equal(t, int64(1), cache.Metrics().EvictedSender)
+	//数量接近的时候按照优先级驱逐, 新交易优先级太低就拒绝
+	assert.Equal(t, types.ErrMemFull, cache.Push(newItem(user, "coins", 100000, 13)))
+	assert.Equal(t, int64(1), cache.Metrics().RejectedFull)
+}
+
+func TestDependencyOrder(t *testing.T) {
+	now := types.Now().Unix()
+	newItem := func(priv crypto.PrivKey, fee, nonce int64) *drivers.Item {
+		tx := &types.Transaction{Execer: []byte("coins"), Payload: types.Encode(transfer), Fee: fee, Nonce: nonce, To: toAddr}
+		tx.Sign(types.SECP256K1, priv)
+		return &drivers.Item{Value: tx, Priority: tx.Fee, EnterTime: now}
+	}
+	walk := func(cache *Queue, count int) (items []*drivers.Item) {
+		cache.Walk(count, func(item *drivers.Item) bool {
+			items = append(items, item)
+			return true
+		})
+		return items
+	}
+	alice, _ := c.GenKey()
+	bob, _ := c.GenKey()
+	//alice 先授权再交易, 交易的手续费比授权高
+	approve := newItem(alice, 1000000, 1)
+	trade := newItem(alice, 5000000, 2)
+	other := newItem(bob, 3000000, 11)
+
+	cache := initEnv(0)
+	assert.Nil(t, cache.Push(approve))
+	assert.Nil(t, cache.Push(trade))
+	assert.Nil(t, cache.Push(other))
+	assert.Equal(t, []*drivers.Item{other, approve, trade}, walk(cache, 0))
+	//只取一笔交易的时候不会越过授权先取出交易
+	assert.Equal(t, []*drivers.Item{other}, walk(cache, 1))
+
+	//替换的交易保持原来的顺序
+	bump := newItem(alice, 1100000, 1)
+	assert.Nil(t, cache.Push(bump))
+	assert.Equal(t, []*drivers.Item{other, bump, trade}, walk(cache, 0))
+
+	//授权打包以后交易按照手续费排在前面
+	assert.Nil(t, cache.Remove(string(bump.Value.Hash())))
+	assert.Equal(t, []*drivers.Item{trade, other}, walk(cache, 0))
+}
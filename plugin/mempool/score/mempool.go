@@ -4,13 +4,14 @@ import (
 	"github.com/33cn/chain33/queue"
 	drivers "github.com/33cn/chain33/system/mempool"
 	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/mempool/skipqueue"
 )
 
 //--------------------------------------------------------------------------------
 // Module Mempool
 
 type subConfig struct {
-	PoolCacheSize int64 `json:"poolCacheSize"`
+	skipqueue.Config
 	TimeParam     int64 `json:"timeParam"`
 	PriceConstant int64 `json:"priceConstant"`
 	PricePower    int64 `json:"pricePower"`
@@ -32,6 +33,9 @@ func New(cfg *types.Mempool, sub []byte) queue.Module {
 	if subcfg.ProperFee == 0 {
 		subcfg.ProperFee = cfg.MinTxFeeRate
 	}
-	c.SetQueueCache(NewQueue(subcfg))
+	q := NewQueue(subcfg)
+	q.BindMempool(c)
+	q.RegisterMetrics("score")
+	c.SetQueueCache(q)
 	return c
 }
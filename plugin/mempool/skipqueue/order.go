@@ -0,0 +1,85 @@
+package skipqueue
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/33cn/chain33/common/skiplist"
+	"github.com/33cn/chain33/system/mempool"
+)
+
+//chain 一个地址在队列中的交易, 按照进入队列的顺序排列, 前面的交易没有取出之前后面的交易不能取出
+type chain []*entry
+
+//chainHeap 按照每个地址第一笔交易的优先级排序, 优先级高的在前面, 优先级相同的时候先进入队列的在前面
+type chainHeap []chain
+
+func (h chainHeap) Len() int { return len(h) }
+
+func (h chainHeap) Less(i, j int) bool {
+	a, b := h[i][0], h[j][0]
+	if less(b.score, a.score) {
+		return true
+	}
+	if less(a.score, b.score) {
+		return false
+	}
+	return a.seq < b.seq
+}
+
+func (h chainHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *chainHeap) Push(x interface{}) { *h = append(*h, x.(chain)) }
+
+func (h *chainHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+//chains 把队列中的交易按地址分成交易序列, 没有签名的交易无法确定地址, 每笔交易单独作为一个序列
+func (cache *Queue) chains() *chainHeap {
+	h := make(chainHeap, 0, len(cache.senders))
+	for _, txs := range cache.senders {
+		var c chain
+		for _, e := range txs {
+			if e.item.Value.GetSignature() == nil {
+				h = append(h, chain{e})
+				continue
+			}
+			c = append(c, e)
+		}
+		if len(c) == 0 {
+			continue
+		}
+		sort.Slice(c, func(i, j int) bool { return c[i].seq < c[j].seq })
+		h = append(h, c)
+	}
+	heap.Init(&h)
+	return &h
+}
+
+// Walk 遍历队列, 同一个地址的交易按照进入队列的顺序返回, 比如先授权再交易的两笔交易不会颠倒顺序;
+// 在这个前提下每次取出所有地址的下一笔交易中优先级最高的, 尽量让手续费高的交易先被打包
+func (cache *Queue) Walk(count int, cb func(item *mempool.Item, score skiplist.Scorer) bool) {
+	h := cache.chains()
+	i := 0
+	for h.Len() > 0 {
+		c := (*h)[0]
+		if !cb(c[0].item, c[0].score) {
+			return
+		}
+		i++
+		if i == count {
+			return
+		}
+		if len(c) == 1 {
+			heap.Pop(h)
+			continue
+		}
+		(*h)[0] = c[1:]
+		heap.Fix(h, 0)
+	}
+}
@@ -0,0 +1,245 @@
+// Package skipqueue price 和 score 两种排队策略共用的队列
+package skipqueue
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/33cn/chain33/common"
+	log "github.com/33cn/chain33/common/log/log15"
+	"github.com/33cn/chain33/common/skiplist"
+	"github.com/33cn/chain33/system/mempool"
+	"github.com/33cn/chain33/types"
+)
+
+var mlog = log.New("module", "mempool.skipqueue")
+
+// ErrLocalReplaceFeeTooLow 本节点的 mempool 中已经有同一账户相同 nonce 的交易, 新交易的手续费不够替换它。
+// 替换只是把原交易从本节点的 mempool 中驱逐, 链上不检查 nonce, 两笔交易都可能被打包
+var ErrLocalReplaceFeeTooLow = errors.New("ErrLocalReplaceFeeTooLow: fee too low to evict the tx with the same sender and nonce from the local mempool, nonce is not enforced on chain")
+
+// LocalEviction 新交易在本节点的 mempool 中替换掉的同一账户相同 nonce 的交易。
+// chain33 执行交易的时候不检查 nonce, 被替换的交易只是从本节点的 mempool 中删除, 并没有失效,
+// 已经广播到其他节点的仍然可能被打包, 两笔交易都可能上链
+type LocalEviction struct {
+	Item *mempool.Item
+}
+
+// DefaultReplaceFeeBump 替换交易默认需要提高的手续费百分比
+const DefaultReplaceFeeBump = 10
+
+// Config 队列的公共配置
+type Config struct {
+	PoolCacheSize int64 `json:"poolCacheSize"`
+	//替换同一账户相同 nonce 的交易, 手续费至少需要提高的百分比
+	ReplaceFeeBump int64 `json:"replaceFeeBump"`
+	//每个地址在队列中最多的交易数量, 0 表示不限制。
+	//mempool 在交易进入队列之前已经按照 [mempool] maxTxNumPerAccount(默认100) 检查过账户在 mempool 中的交易数量,
+	//所以实际的限制是两者中较小的一个, 只有比 maxTxNumPerAccount 小的时候这个配置才有作用
+	MaxTxPerAddr int64 `json:"maxTxPerAddr"`
+	//每个执行器在队列中最多的交易数量, 0 表示不限制
+	MaxTxPerExec int64 `json:"maxTxPerExec"`
+}
+
+// Queue 在 skiplist.Queue 的基础上增加了按照账户 nonce 替换交易(replace-by-fee),
+// 按地址和执行器的配额, 以及队列满的时候优先驱逐排队交易最多的地址的交易。
+// chain33 交易的 nonce 是随机生成的, 执行的时候不检查, 替换只对本节点的 mempool 有效,
+// 已经广播出去的原交易仍然可能被其他节点打包
+type Queue struct {
+	*skiplist.Queue
+	cfg     Config
+	entries map[string]*entry
+	nonces  map[string]string
+	senders map[string]map[string]*entry
+	execs   map[string]int64
+	metrics Metrics
+	//交易进入队列的序号, 同一个地址的交易按照序号排序
+	seq int64
+	//被替换或者驱逐的交易, 已经不在队列中, 但是还没有从 mempool 的账户索引等缓存中删除
+	dropped map[string]*mempool.Item
+	onDrop  func(hash string)
+}
+
+//entry 队列中的交易以及它的索引信息
+type entry struct {
+	item   *mempool.Item
+	score  skiplist.Scorer
+	sender string
+	exec   string
+	nonce  string
+	seq    int64
+}
+
+// NewQueue 创建队列
+func NewQueue(cfg Config) *Queue {
+	if cfg.ReplaceFeeBump <= 0 {
+		cfg.ReplaceFeeBump = DefaultReplaceFeeBump
+	}
+	return &Queue{
+		Queue:   skiplist.NewQueue(cfg.PoolCacheSize),
+		cfg:     cfg,
+		entries: make(map[string]*entry),
+		nonces:  make(map[string]string),
+		senders: make(map[string]map[string]*entry),
+		execs:   make(map[string]int64),
+		dropped: make(map[string]*mempool.Item),
+	}
+}
+
+// BindMempool 队列中被替换或者驱逐的交易, 通过 mem.RemoveTxs 从 mempool 的账户索引, 最新交易和手续费统计中删除。
+// Push 的时候 mempool 已经加锁了, 所以在单独的 goroutine 中删除
+func (cache *Queue) BindMempool(mem *mempool.Mempool) {
+	cache.onDrop = func(hash string) {
+		go mem.RemoveTxs(&types.TxHashList{Hashes: [][]byte{[]byte(hash)}})
+	}
+}
+
+//drop 从队列中删除被替换或者驱逐的交易, 在 mempool 的其他缓存删除它之前, 仍然可以通过 GetItem 获取
+func (cache *Queue) drop(hash string) error {
+	e := cache.entries[hash]
+	err := cache.Remove(hash)
+	if err != nil {
+		return err
+	}
+	if cache.onDrop != nil {
+		cache.dropped[hash] = e.item
+		cache.onDrop(hash)
+	}
+	return nil
+}
+
+// Exist 交易是否在队列中, 还没有从 mempool 中删除干净的交易也算存在
+func (cache *Queue) Exist(hash string) bool {
+	if _, ok := cache.dropped[hash]; ok {
+		return true
+	}
+	return cache.Queue.Exist(hash)
+}
+
+//nonceKey 同一个账户相同 nonce 的交易是相互冲突的, 没有签名的交易无法确定账户, 也不参与替换
+func nonceKey(tx *types.Transaction, sender string) string {
+	if tx.GetSignature() == nil || tx.GetGroupCount() > 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s-%d", sender, tx.Nonce)
+}
+
+func newEntry(item *mempool.Item, score skiplist.Scorer) *entry {
+	sender := item.Value.From()
+	return &entry{
+		item:   item,
+		score:  score,
+		sender: sender,
+		exec:   string(item.Value.Execer),
+		nonce:  nonceKey(item.Value, sender),
+	}
+}
+
+//GetItem 获取数据通过 key
+func (cache *Queue) GetItem(hash string) (*mempool.Item, error) {
+	e, ok := cache.entries[hash]
+	if !ok {
+		if item, ok := cache.dropped[hash]; ok {
+			return item, nil
+		}
+		return nil, types.ErrNotFound
+	}
+	return e.item, nil
+}
+
+// Push 把交易加入队列, score 是交易在队列中的排序方式。
+// 如果队列中有同一个账户相同 nonce 的交易, 新交易的手续费需要比原来的高出 ReplaceFeeBump%, 否则返回 ErrLocalReplaceFeeTooLow,
+// 满足条件的时候从本节点的 mempool 中驱逐原来的交易, 返回 LocalEviction
+func (cache *Queue) Push(item *mempool.Item, score skiplist.Scorer) (*LocalEviction, error) {
+	hash := string(item.Value.Hash())
+	if cache.Exist(hash) {
+		return nil, types.ErrTxExist
+	}
+	e := newEntry(item, score)
+	var replaced *entry
+	if e.nonce != "" {
+		if old, ok := cache.nonces[e.nonce]; ok {
+			replaced = cache.entries[old]
+			if !cache.canReplace(replaced.item.Value, item.Value) {
+				atomic.AddInt64(&cache.metrics.RejectedFee, 1)
+				return nil, ErrLocalReplaceFeeTooLow
+			}
+		}
+	}
+	if err := cache.checkQuota(e, replaced); err != nil {
+		return nil, err
+	}
+	if replaced != nil {
+		err := cache.drop(string(replaced.item.Value.Hash()))
+		if err != nil {
+			return nil, err
+		}
+		//替换的交易占用原来交易在地址交易序列中的位置
+		e.seq = replaced.seq
+		atomic.AddInt64(&cache.metrics.EvictedLocal, 1)
+		mlog.Info("Push evict tx with same nonce from local mempool", "old", common.ToHex(replaced.item.Value.Hash()), "new", common.ToHex(item.Value.Hash()),
+			"oldfee", replaced.item.Value.Fee, "newfee", item.Value.Fee)
+	} else if err := cache.evict(e); err != nil {
+		return nil, err
+	} else {
+		cache.seq++
+		e.seq = cache.seq
+	}
+	cache.insert(hash, e)
+	if replaced != nil {
+		return &LocalEviction{Item: replaced.item}, nil
+	}
+	return nil, nil
+}
+
+//canReplace 新交易的手续费至少要比原来的高出 ReplaceFeeBump%
+func (cache *Queue) canReplace(old, tx *types.Transaction) bool {
+	if tx.Fee <= old.Fee {
+		return false
+	}
+	return tx.Fee*100 >= old.Fee*(100+cache.cfg.ReplaceFeeBump)
+}
+
+func (cache *Queue) insert(hash string, e *entry) {
+	cache.Insert(hash, e.score)
+	cache.entries[hash] = e
+	if e.nonce != "" {
+		cache.nonces[e.nonce] = hash
+	}
+	txs, ok := cache.senders[e.sender]
+	if !ok {
+		txs = make(map[string]*entry)
+		cache.senders[e.sender] = txs
+	}
+	txs[hash] = e
+	cache.execs[e.exec]++
+}
+
+// Remove 删除数据
+func (cache *Queue) Remove(hash string) error {
+	if _, ok := cache.dropped[hash]; ok {
+		delete(cache.dropped, hash)
+		return nil
+	}
+	err := cache.Queue.Remove(hash)
+	if err != nil {
+		return err
+	}
+	e := cache.entries[hash]
+	delete(cache.entries, hash)
+	if e.nonce != "" && cache.nonces[e.nonce] == hash {
+		delete(cache.nonces, e.nonce)
+	}
+	if txs := cache.senders[e.sender]; txs != nil {
+		delete(txs, hash)
+		if len(txs) == 0 {
+			delete(cache.senders, e.sender)
+		}
+	}
+	cache.execs[e.exec]--
+	if cache.execs[e.exec] <= 0 {
+		delete(cache.execs, e.exec)
+	}
+	return nil
+}
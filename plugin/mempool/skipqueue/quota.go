@@ -0,0 +1,148 @@
+package skipqueue
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"github.com/33cn/chain33/common/skiplist"
+	"github.com/33cn/chain33/types"
+	metrics "github.com/rcrowley/go-metrics"
+)
+
+// ErrManyExecTx 执行器在队列中的交易数量超过了配额
+var ErrManyExecTx = errors.New("ErrManyExecTx")
+
+// Metrics 队列拒绝和驱逐交易的统计
+type Metrics struct {
+	//地址的交易数量超过配额被拒绝
+	RejectedAddr int64 `json:"rejectedAddr"`
+	//执行器的交易数量超过配额被拒绝
+	RejectedExec int64 `json:"rejectedExec"`
+	//替换同一账户相同 nonce 的交易的手续费不够被拒绝
+	RejectedFee int64 `json:"rejectedFee"`
+	//队列满了被拒绝
+	RejectedFull int64 `json:"rejectedFull"`
+	//驱逐排队交易最多的地址的交易
+	EvictedSender int64 `json:"evictedSender"`
+	//驱逐队列中优先级最低的交易
+	EvictedPriority int64 `json:"evictedPriority"`
+	//被同一账户相同 nonce 的交易替换, 只是从本节点的 mempool 中驱逐, 原交易仍然可能上链
+	EvictedLocal int64 `json:"evictedLocal"`
+}
+
+// Metrics 返回队列拒绝和驱逐交易的统计
+func (cache *Queue) Metrics() Metrics {
+	m := &cache.metrics
+	return Metrics{
+		RejectedAddr:    atomic.LoadInt64(&m.RejectedAddr),
+		RejectedExec:    atomic.LoadInt64(&m.RejectedExec),
+		RejectedFee:     atomic.LoadInt64(&m.RejectedFee),
+		RejectedFull:    atomic.LoadInt64(&m.RejectedFull),
+		EvictedSender:   atomic.LoadInt64(&m.EvictedSender),
+		EvictedPriority: atomic.LoadInt64(&m.EvictedPriority),
+		EvictedLocal:    atomic.LoadInt64(&m.EvictedLocal),
+	}
+}
+
+// RegisterMetrics 把队列的统计注册到 go-metrics 的默认 registry, 名称为 mempool/{name}/{统计项},
+// 配置了 [metrics] enableMetrics=true 之后和其他模块的统计一起定时发送
+func (cache *Queue) RegisterMetrics(name string) {
+	m := &cache.metrics
+	counters := map[string]*int64{
+		"rejectedAddr":    &m.RejectedAddr,
+		"rejectedExec":    &m.RejectedExec,
+		"rejectedFee":     &m.RejectedFee,
+		"rejectedFull":    &m.RejectedFull,
+		"evictedSender":   &m.EvictedSender,
+		"evictedPriority": &m.EvictedPriority,
+		"evictedLocal":    &m.EvictedLocal,
+	}
+	for key, counter := range counters {
+		counter := counter
+		metricName := "mempool/" + name + "/" + key
+		//同名的统计只保留最新创建的队列
+		metrics.Unregister(metricName)
+		metrics.NewRegisteredFunctionalGauge(metricName, nil, func() int64 { return atomic.LoadInt64(counter) })
+	}
+}
+
+//checkQuota 检查地址和执行器的配额, 被替换的交易不占用配额
+func (cache *Queue) checkQuota(e *entry, replaced *entry) error {
+	if limit := cache.cfg.MaxTxPerAddr; limit > 0 {
+		n := int64(len(cache.senders[e.sender]))
+		if replaced != nil && replaced.sender == e.sender {
+			n--
+		}
+		if n >= limit {
+			atomic.AddInt64(&cache.metrics.RejectedAddr, 1)
+			return types.ErrManyTx
+		}
+	}
+	if limit := cache.cfg.MaxTxPerExec; limit > 0 {
+		n := cache.execs[e.exec]
+		if replaced != nil && replaced.exec == e.exec {
+			n--
+		}
+		if n >= limit {
+			atomic.AddInt64(&cache.metrics.RejectedExec, 1)
+			return ErrManyExecTx
+		}
+	}
+	return nil
+}
+
+//evict 队列满的时候腾出一个位置:
+//如果排队交易最多的地址比新交易的地址至少多两笔交易, 驱逐这个地址优先级最低的交易;
+//否则新交易的优先级比队列中最低的高, 弹出优先级最低的交易, 不然就报错
+func (cache *Queue) evict(e *entry) error {
+	if int64(cache.Size()) < cache.MaxSize() {
+		return nil
+	}
+	sender, count := cache.topSender()
+	if count > int64(len(cache.senders[e.sender]))+1 {
+		tail := lowest(cache.senders[sender])
+		atomic.AddInt64(&cache.metrics.EvictedSender, 1)
+		mlog.Debug("evict tx of top sender", "sender", sender, "count", count)
+		return cache.drop(string(tail.Hash()))
+	}
+	tail := cache.Last()
+	if less(tail, e.score) {
+		atomic.AddInt64(&cache.metrics.EvictedPriority, 1)
+		return cache.drop(string(tail.Hash()))
+	}
+	atomic.AddInt64(&cache.metrics.RejectedFull, 1)
+	return types.ErrMemFull
+}
+
+//topSender 排队交易最多的地址, 数量相同的时候取地址小的, 保证结果是确定的
+func (cache *Queue) topSender() (string, int64) {
+	var top string
+	var count int64
+	for sender, txs := range cache.senders {
+		n := int64(len(txs))
+		if n > count || (n == count && sender < top) {
+			top, count = sender, n
+		}
+	}
+	return top, count
+}
+
+//lowest 一组交易中优先级最低的交易
+func lowest(txs map[string]*entry) skiplist.Scorer {
+	var tail skiplist.Scorer
+	for _, e := range txs {
+		if tail == nil || less(e.score, tail) {
+			tail = e.score
+		}
+	}
+	return tail
+}
+
+//less a 的优先级是否比 b 低, 和 skiplist 中的排序一致: 分数低的优先级低, 分数相同的时候后到的优先级低
+func less(a, b skiplist.Scorer) bool {
+	sa, sb := a.GetScore(), b.GetScore()
+	if sa != sb {
+		return sa < sb
+	}
+	return b.Compare(a) == skiplist.Big
+}
@@ -0,0 +1,296 @@
+// Package bench 用相同的负载比较不同 store 插件的性能
+package bench
+
+import (
+	"errors"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	dbm "github.com/33cn/chain33/common/db"
+	log "github.com/33cn/chain33/common/log/log15"
+	"github.com/33cn/chain33/queue"
+	drivers "github.com/33cn/chain33/system/store"
+	"github.com/33cn/chain33/types"
+	"github.com/syndtr/goleveldb/leveldb"
+
+	//比较的 store 插件
+	_ "github.com/33cn/plugin/plugin/store/kvdb"
+	_ "github.com/33cn/plugin/plugin/store/kvmvcc"
+	_ "github.com/33cn/plugin/plugin/store/kvmvccmavl"
+	_ "github.com/33cn/plugin/plugin/store/mpt"
+)
+
+var blog = log.New("module", "store.bench")
+
+// ErrBenchDirExist 每次测试需要新的数据目录, 已有的数据会影响结果
+var ErrBenchDirExist = errors.New("ErrBenchDirExist")
+
+// DefaultDrivers 默认比较的 store 插件
+var DefaultDrivers = []string{"kvdb", "kvmvcc", "kvmvccmavl", "mpt"}
+
+// Options 回放负载的参数
+type Options struct {
+	//每个 store 的数据目录在 Dir 下面, 目录名是 store 的名称
+	Dir string
+	//数据库类型, 默认 leveldb
+	DbDriver string
+	DbCache  int32
+	//store 的名称到 store 子配置的映射
+	Sub        map[string][]byte
+	Chain33Cfg *types.Chain33Config
+	//每个区块提交以后随机读取已经写入的 key 的次数
+	ReadsPerBlock int
+	//每隔多少个区块先写入一个分叉区块再回滚, 0 表示不回滚
+	RollbackEvery int
+	Sync          bool
+	//读取和分叉区块使用的随机数种子
+	Seed int64
+}
+
+// Latency 一种操作的延迟统计
+type Latency struct {
+	Count int64         `json:"count"`
+	Total time.Duration `json:"total"`
+	Mean  time.Duration `json:"mean"`
+	P50   time.Duration `json:"p50"`
+	P90   time.Duration `json:"p90"`
+	P99   time.Duration `json:"p99"`
+	Max   time.Duration `json:"max"`
+}
+
+// Result 一个 store 回放负载的结果
+type Result struct {
+	Driver string `json:"driver"`
+	Blocks int64  `json:"blocks"`
+	KVs    int64  `json:"kvs"`
+	Reads  int64  `json:"reads"`
+	//读取已经写入的 key 返回空值的次数, 正常应该是 0
+	Misses  int64         `json:"misses"`
+	Elapsed time.Duration `json:"elapsed"`
+	//写入吞吐按照 MemSet, Commit 和 Rollback 的时间计算, 读取吞吐按照 Get 的时间计算
+	BlocksPerSec float64  `json:"blocksPerSec"`
+	KVsPerSec    float64  `json:"kvsPerSec"`
+	ReadsPerSec  float64  `json:"readsPerSec"`
+	MemSet       *Latency `json:"memSet"`
+	Commit       *Latency `json:"commit"`
+	Rollback     *Latency `json:"rollback"`
+	Get          *Latency `json:"get"`
+	//负载写入的 key 和 value 的字节数
+	LogicalBytes int64 `json:"logicalBytes"`
+	//数据库实际写入磁盘的字节数, 包括日志和压缩, 只有 leveldb 支持统计
+	DiskWrite int64 `json:"diskWrite"`
+	//测试结束以后数据目录占用的空间
+	DiskUse            int64   `json:"diskUse"`
+	WriteAmplification float64 `json:"writeAmplification"`
+}
+
+type benchStore interface {
+	queue.Module
+	drivers.SubStore
+	GetDB() dbm.DB
+}
+
+//recorder 记录一种操作每次的耗时
+type recorder []time.Duration
+
+func (r *recorder) add(start time.Time) {
+	*r = append(*r, time.Since(start))
+}
+
+func (r recorder) latency() *Latency {
+	l := &Latency{Count: int64(len(r))}
+	if len(r) == 0 {
+		return l
+	}
+	sorted := make([]time.Duration, len(r))
+	copy(sorted, r)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	for _, d := range sorted {
+		l.Total += d
+	}
+	l.Mean = l.Total / time.Duration(len(sorted))
+	l.P50 = percentile(sorted, 0.50)
+	l.P90 = percentile(sorted, 0.90)
+	l.P99 = percentile(sorted, 0.99)
+	l.Max = sorted[len(sorted)-1]
+	return l
+}
+
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	return sorted[int(float64(len(sorted)-1)*p)]
+}
+
+// Compare 用相同的负载依次测试多个 store, newWorkload 为每个 store 创建一个新的负载
+func Compare(names []string, opt *Options, newWorkload func() (Workload, error)) ([]*Result, error) {
+	var results []*Result
+	for _, name := range names {
+		wl, err := newWorkload()
+		if err != nil {
+			return results, err
+		}
+		res, err := Run(name, opt, wl)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// Run 在新的数据目录中创建 store, 按照区块的顺序 MemSet 和 Commit 负载,
+// 按照参数插入回滚的分叉区块和随机读取, 统计吞吐, 延迟, 写放大和磁盘占用
+func Run(name string, opt *Options, wl Workload) (*Result, error) {
+	create, err := drivers.Load(name)
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(opt.Dir, name)
+	if _, err := os.Stat(dir); err == nil {
+		return nil, ErrBenchDirExist
+	}
+	cfg := &types.Store{Name: name, Driver: opt.DbDriver, DbPath: dir, DbCache: opt.DbCache}
+	if cfg.Driver == "" {
+		cfg.Driver = "leveldb"
+	}
+	if cfg.DbCache == 0 {
+		cfg.DbCache = 128
+	}
+	s, ok := create(cfg, opt.Sub[name], opt.Chain33Cfg).(benchStore)
+	if !ok {
+		return nil, types.ErrActionNotSupport
+	}
+	res, err := replay(s, opt, wl)
+	if err != nil {
+		s.Close()
+		return nil, err
+	}
+	res.Driver = name
+	res.DiskWrite = diskWrite(s.GetDB())
+	s.Close()
+	res.DiskUse, err = dirSize(dir)
+	if err != nil {
+		return nil, err
+	}
+	if res.LogicalBytes > 0 {
+		res.WriteAmplification = float64(res.DiskWrite) / float64(res.LogicalBytes)
+	}
+	blog.Info("bench", "driver", name, "blocks", res.Blocks, "kvs", res.KVs, "elapsed", res.Elapsed)
+	return res, nil
+}
+
+func replay(s benchStore, opt *Options, wl Workload) (*Result, error) {
+	res := &Result{}
+	rnd := rand.New(rand.NewSource(opt.Seed))
+	var memset, commit, rollback, get recorder
+	//已经写入的 key, 用来随机读取
+	var keys [][]byte
+	seen := make(map[string]bool)
+	prev := drivers.EmptyRoot[:]
+	begin := time.Now()
+	for height := int64(0); ; height++ {
+		kvs, err := wl.Next()
+		if err != nil {
+			return nil, err
+		}
+		if kvs == nil {
+			break
+		}
+		if opt.RollbackEvery > 0 && height > 0 && height%int64(opt.RollbackEvery) == 0 {
+			fork := make([]*types.KeyValue, len(kvs))
+			for i, kv := range kvs {
+				value := make([]byte, len(kv.Value)+1)
+				rnd.Read(value)
+				fork[i] = &types.KeyValue{Key: kv.Key, Value: value}
+			}
+			start := time.Now()
+			hash, err := s.MemSet(&types.StoreSet{StateHash: prev, KV: fork, Height: height}, opt.Sync)
+			if err != nil {
+				return nil, err
+			}
+			memset.add(start)
+			start = time.Now()
+			_, err = s.Rollback(&types.ReqHash{Hash: hash})
+			if err != nil {
+				return nil, err
+			}
+			rollback.add(start)
+		}
+		start := time.Now()
+		hash, err := s.MemSet(&types.StoreSet{StateHash: prev, KV: kvs, Height: height}, opt.Sync)
+		if err != nil {
+			return nil, err
+		}
+		memset.add(start)
+		start = time.Now()
+		_, err = s.Commit(&types.ReqHash{Hash: hash})
+		if err != nil {
+			return nil, err
+		}
+		commit.add(start)
+		prev = hash
+		res.Blocks++
+		for _, kv := range kvs {
+			res.KVs++
+			res.LogicalBytes += int64(len(kv.Key) + len(kv.Value))
+			if !seen[string(kv.Key)] {
+				seen[string(kv.Key)] = true
+				keys = append(keys, kv.Key)
+			}
+		}
+		for i := 0; i < opt.ReadsPerBlock && len(keys) > 0; i++ {
+			key := keys[rnd.Intn(len(keys))]
+			start := time.Now()
+			values := s.Get(&types.StoreGet{StateHash: hash, Keys: [][]byte{key}})
+			get.add(start)
+			res.Reads++
+			if len(values) == 0 || values[0] == nil {
+				res.Misses++
+			}
+		}
+	}
+	res.Elapsed = time.Since(begin)
+	res.MemSet = memset.latency()
+	res.Commit = commit.latency()
+	res.Rollback = rollback.latency()
+	res.Get = get.latency()
+	write := res.MemSet.Total + res.Commit.Total + res.Rollback.Total
+	if write > 0 {
+		res.BlocksPerSec = float64(res.Blocks) / write.Seconds()
+		res.KVsPerSec = float64(res.KVs) / write.Seconds()
+	}
+	if res.Get.Total > 0 {
+		res.ReadsPerSec = float64(res.Reads) / res.Get.Total.Seconds()
+	}
+	return res, nil
+}
+
+//diskWrite 数据库打开以后写入磁盘的字节数, 不是 leveldb 的时候返回 0
+func diskWrite(db dbm.DB) int64 {
+	gdb, ok := db.(*dbm.GoLevelDB)
+	if !ok {
+		return 0
+	}
+	var stats leveldb.DBStats
+	err := gdb.DB().Stats(&stats)
+	if err != nil {
+		return 0
+	}
+	return int64(stats.IOWrite)
+}
+
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
@@ -0,0 +1,146 @@
+package bench
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/33cn/chain33/common/log"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/kvmvccmavl"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	log.SetLogLevel("err")
+}
+
+func TestRecordWorkload(t *testing.T) {
+	cfg := SyntheticConfig{Blocks: 5, KVsPerBlock: 3, KeySpace: 10, ValueSize: 8, Seed: 1}
+	wl, err := NewSynthetic(cfg)
+	assert.Nil(t, err)
+	var buf bytes.Buffer
+	blocks, err := RecordWorkload(&buf, wl)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), blocks)
+
+	//相同的种子产生相同的负载
+	wl, err = NewSynthetic(cfg)
+	assert.Nil(t, err)
+	rec := NewRecorded(&buf)
+	for {
+		kvs, err := wl.Next()
+		assert.Nil(t, err)
+		recKVs, err := rec.Next()
+		assert.Nil(t, err)
+		assert.Equal(t, kvs, recKVs)
+		if kvs == nil {
+			break
+		}
+		assert.Equal(t, 3, len(kvs))
+	}
+
+	_, err = NewRecorded(bytes.NewReader([]byte{10, 1})).Next()
+	assert.Equal(t, ErrWorkloadFormat, err)
+	_, err = NewSynthetic(SyntheticConfig{Blocks: 1, KVsPerBlock: 3, KeySpace: 2, ValueSize: 8})
+	assert.NotNil(t, err)
+}
+
+func TestRecordNode(t *testing.T) {
+	//模拟节点的 kvmvccmavl.GetBlockKV 接口, 高度 2 是空块
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string         `json:"method"`
+			Params []types.ReqInt `json:"params"`
+			ID     uint64         `json:"id"`
+		}
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "kvmvccmavl.GetBlockKV", req.Method)
+		height := req.Params[0].Height
+		reply := &kvmvccmavl.BlockKV{Height: height}
+		if height != 2 {
+			reply.KV = []*types.KeyValue{{Key: []byte("mavl-coins-bty-exec"), Value: []byte{byte(height)}}}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": req.ID, "result": reply, "error": nil})
+	}))
+	defer server.Close()
+
+	_, err := NewNode(server.URL, 3, 1)
+	assert.Equal(t, types.ErrInvalidParam, err)
+	wl, err := NewNode(server.URL, 1, 3)
+	assert.Nil(t, err)
+	var buf bytes.Buffer
+	blocks, err := RecordWorkload(&buf, wl)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), blocks)
+	rec := NewRecorded(&buf)
+	for _, height := range []int64{1, 2, 3} {
+		kvs, err := rec.Next()
+		assert.Nil(t, err)
+		if height == 2 {
+			assert.Equal(t, 0, len(kvs))
+			continue
+		}
+		assert.Equal(t, []*types.KeyValue{{Key: []byte("mavl-coins-bty-exec"), Value: []byte{byte(height)}}}, kvs)
+	}
+	kvs, err := rec.Next()
+	assert.Nil(t, err)
+	assert.Nil(t, kvs)
+}
+
+func TestCompare(t *testing.T) {
+	dir, err := ioutil.TempDir("", "storebench")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir) // clean up
+
+	opt := &Options{Dir: dir, ReadsPerBlock: 5, RollbackEvery: 4, Seed: 1}
+	newWorkload := func() (Workload, error) {
+		return NewSynthetic(SyntheticConfig{Blocks: 20, KVsPerBlock: 10, KeySpace: 50, ValueSize: 32, Seed: 1})
+	}
+	results, err := Compare(DefaultDrivers, opt, newWorkload)
+	assert.Nil(t, err)
+	assert.Equal(t, len(DefaultDrivers), len(results))
+	for i, res := range results {
+		assert.Equal(t, DefaultDrivers[i], res.Driver)
+		assert.Equal(t, int64(20), res.Blocks)
+		assert.Equal(t, int64(200), res.KVs)
+		assert.Equal(t, int64(100), res.Reads)
+		assert.Equal(t, int64(0), res.Misses, res.Driver)
+		assert.Equal(t, int64(24), res.MemSet.Count)
+		assert.Equal(t, int64(4), res.Rollback.Count)
+		assert.True(t, res.Commit.P50 <= res.Commit.P99)
+		assert.True(t, res.DiskUse > 0)
+		assert.True(t, res.DiskWrite > 0)
+	}
+
+	//数据目录已经存在
+	_, err = Compare(DefaultDrivers[:1], opt, newWorkload)
+	assert.Equal(t, ErrBenchDirExist, err)
+}
+
+func BenchmarkKvdb(b *testing.B)       { benchmarkStore(b, "kvdb") }
+func BenchmarkKvmvcc(b *testing.B)     { benchmarkStore(b, "kvmvcc") }
+func BenchmarkKvmvccmavl(b *testing.B) { benchmarkStore(b, "kvmvccmavl") }
+func BenchmarkMpt(b *testing.B)        { benchmarkStore(b, "mpt") }
+
+//benchmarkStore 每个区块是一次操作, 包括 MemSet, Commit 和读取
+func benchmarkStore(b *testing.B, name string) {
+	dir, err := ioutil.TempDir("", "storebench")
+	assert.Nil(b, err)
+	defer os.RemoveAll(dir) // clean up
+
+	cfg := SyntheticConfig{Blocks: b.N, KVsPerBlock: 100, KeySpace: 100000, ValueSize: 128, Seed: 1}
+	wl, err := NewSynthetic(cfg)
+	assert.Nil(b, err)
+	b.SetBytes(int64(cfg.KVsPerBlock * (len("mavl-bench-0000000000") + cfg.ValueSize)))
+	b.ResetTimer()
+	res, err := Run(name, &Options{Dir: dir, ReadsPerBlock: 100, Seed: 1}, wl)
+	assert.Nil(b, err)
+	b.StopTimer()
+	b.Logf("%s blocks %d kvs/s %.1f reads/s %.1f write amp %.2f disk use %d", name, res.Blocks,
+		res.KVsPerSec, res.ReadsPerSec, res.WriteAmplification, res.DiskUse)
+}
@@ -0,0 +1,234 @@
+package bench
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/common/rpcplugin"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rpcplugin.Register("store-bench", nil, Cmd)
+}
+
+// Cmd store 性能测试的命令
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "storebench",
+		Short: "Benchmark store plugins with synthetic or recorded workloads",
+		Args:  cobra.MinimumNArgs(1),
+	}
+	cmd.AddCommand(
+		RunCmd(),
+		RecordCmd(),
+	)
+	return cmd
+}
+
+func addSyntheticFlags(cmd *cobra.Command) {
+	cmd.Flags().IntP("blocks", "b", 1000, "number of blocks of the synthetic workload")
+	cmd.Flags().IntP("kvs", "k", 100, "number of kvs in a block")
+	cmd.Flags().IntP("keyspace", "n", 100000, "number of distinct keys, smaller keyspace means more updates")
+	cmd.Flags().IntP("value_size", "v", 128, "value size in bytes")
+	cmd.Flags().Int64("seed", 1, "random seed")
+}
+
+func syntheticConfig(cmd *cobra.Command) SyntheticConfig {
+	blocks, _ := cmd.Flags().GetInt("blocks")
+	kvs, _ := cmd.Flags().GetInt("kvs")
+	keyspace, _ := cmd.Flags().GetInt("keyspace")
+	valueSize, _ := cmd.Flags().GetInt("value_size")
+	seed, _ := cmd.Flags().GetInt64("seed")
+	return SyntheticConfig{Blocks: blocks, KVsPerBlock: kvs, KeySpace: keyspace, ValueSize: valueSize, Seed: seed}
+}
+
+// RunCmd 用相同的负载依次测试多个 store
+func RunCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Replay a workload through MemSet/Commit/Rollback/Get of each store and compare",
+		Long: "Replay a workload through MemSet/Commit/Rollback/Get of each store and compare.\n" +
+			"Each store is created in a new sub dir of the data dir. Store sub configs and forks\n" +
+			"are read from the config file if given, otherwise the defaults of each store are used.",
+		Run: runBench,
+	}
+	addSyntheticFlags(cmd)
+	cmd.Flags().StringP("stores", "s", strings.Join(DefaultDrivers, ","), "stores to compare, separated by comma")
+	cmd.Flags().StringP("workload", "w", "", "recorded workload file, replace the synthetic workload")
+	cmd.Flags().StringP("config", "c", "", "chain33 config file for store sub configs and forks")
+	cmd.Flags().StringP("datadir", "d", "", "data dir, default a temp dir")
+	cmd.Flags().Bool("keep", false, "keep the data dir after benchmark")
+	cmd.Flags().IntP("reads", "r", 100, "random reads of written keys after each block")
+	cmd.Flags().IntP("rollback", "R", 0, "write and rollback a fork block every n blocks, 0 for no rollback")
+	cmd.Flags().Bool("sync", false, "sync write")
+	cmd.Flags().Bool("json", false, "print results as json")
+	return cmd
+}
+
+func runBench(cmd *cobra.Command, args []string) {
+	stores, _ := cmd.Flags().GetString("stores")
+	workload, _ := cmd.Flags().GetString("workload")
+	config, _ := cmd.Flags().GetString("config")
+	datadir, _ := cmd.Flags().GetString("datadir")
+	keep, _ := cmd.Flags().GetBool("keep")
+	reads, _ := cmd.Flags().GetInt("reads")
+	rollback, _ := cmd.Flags().GetInt("rollback")
+	sync, _ := cmd.Flags().GetBool("sync")
+	asJSON, _ := cmd.Flags().GetBool("json")
+	seed, _ := cmd.Flags().GetInt64("seed")
+
+	opt := &Options{ReadsPerBlock: reads, RollbackEvery: rollback, Sync: sync, Seed: seed}
+	if config != "" {
+		data, err := ioutil.ReadFile(config)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return
+		}
+		opt.Chain33Cfg = types.NewChain33Config(string(data))
+		opt.Sub = opt.Chain33Cfg.GetSubConfig().Store
+		opt.DbDriver = opt.Chain33Cfg.GetModuleConfig().Store.Driver
+	}
+	if datadir == "" {
+		dir, err := ioutil.TempDir("", "storebench")
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return
+		}
+		datadir = dir
+	}
+	opt.Dir = datadir
+	if !keep {
+		defer os.RemoveAll(datadir)
+	}
+
+	synCfg := syntheticConfig(cmd)
+	var files []*os.File
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+	newWorkload := func() (Workload, error) {
+		if workload == "" {
+			return NewSynthetic(synCfg)
+		}
+		//每个 store 从头读取录制的负载
+		f, err := os.Open(workload)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+		return NewRecorded(f), nil
+	}
+	results, err := Compare(strings.Split(stores, ","), opt, newWorkload)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	if asJSON {
+		data, err := json.MarshalIndent(results, "", "    ")
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return
+		}
+		fmt.Println(string(data))
+		return
+	}
+	printResults(results)
+}
+
+//printResults 按照表格打印每个 store 的结果
+func printResults(results []*Result) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "store\tblocks\tkvs\tblocks/s\tkvs/s\treads/s\tmisses\twrite amp\tdisk write\tdisk use")
+	for _, r := range results {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%.1f\t%.1f\t%.1f\t%d\t%.2f\t%s\t%s\n", r.Driver, r.Blocks, r.KVs,
+			r.BlocksPerSec, r.KVsPerSec, r.ReadsPerSec, r.Misses, r.WriteAmplification, formatBytes(r.DiskWrite), formatBytes(r.DiskUse))
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "store\top\tcount\tmean\tp50\tp90\tp99\tmax")
+	for _, r := range results {
+		ops := []struct {
+			name string
+			l    *Latency
+		}{{"memset", r.MemSet}, {"commit", r.Commit}, {"rollback", r.Rollback}, {"get", r.Get}}
+		for _, op := range ops {
+			if op.l.Count == 0 {
+				continue
+			}
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\t%s\t%s\n", r.Driver, op.name, op.l.Count, formatDuration(op.l.Mean),
+				formatDuration(op.l.P50), formatDuration(op.l.P90), formatDuration(op.l.P99), formatDuration(op.l.Max))
+		}
+	}
+	w.Flush()
+}
+
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Microsecond).String()
+}
+
+func formatBytes(n int64) string {
+	return fmt.Sprintf("%.2fMB", float64(n)/(1024*1024))
+}
+
+// RecordCmd 把合成负载或者节点上真实区块写入 store 的 kv 写入文件, 用于在不同机器上回放相同的负载
+func RecordCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "record",
+		Short: "Write a synthetic workload, or the store kvs of blocks on a node, to a file",
+		Long: "Write a synthetic workload, or the store kvs of blocks on a node, to a file.\n" +
+			"With --node the kvs of heights [start, end] are read from the node at rpc_laddr\n" +
+			"through kvmvccmavl.GetBlockKV, the node store must be kvmvccmavl and the heights not pruned.",
+		Run: recordWorkload,
+	}
+	addSyntheticFlags(cmd)
+	cmd.Flags().StringP("output", "o", "", "workload file")
+	cmd.MarkFlagRequired("output")
+	cmd.Flags().Bool("node", false, "record the store kvs of blocks on the node at rpc_laddr")
+	cmd.Flags().Int64("start", 0, "start height to record from the node")
+	cmd.Flags().Int64("end", 0, "end height to record from the node")
+	return cmd
+}
+
+func recordWorkload(cmd *cobra.Command, args []string) {
+	output, _ := cmd.Flags().GetString("output")
+	fromNode, _ := cmd.Flags().GetBool("node")
+	var wl Workload
+	var err error
+	if fromNode {
+		rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+		start, _ := cmd.Flags().GetInt64("start")
+		end, _ := cmd.Flags().GetInt64("end")
+		wl, err = NewNode(rpcLaddr, start, end)
+	} else {
+		wl, err = NewSynthetic(syntheticConfig(cmd))
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	f, err := os.Create(output)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	defer f.Close()
+	w := bufio.NewWriter(f)
+	blocks, err := RecordWorkload(w, wl)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	fmt.Println("blocks:", blocks)
+}
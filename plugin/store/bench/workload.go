@@ -0,0 +1,189 @@
+package bench
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+
+	"github.com/33cn/chain33/rpc/jsonclient"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/kvmvccmavl"
+)
+
+// ErrWorkloadFormat 录制的负载文件格式错误
+var ErrWorkloadFormat = errors.New("ErrWorkloadFormat")
+
+//一个区块的 StoreSet 编码以后的最大长度, 防止读到错误的文件时分配过大的内存
+const maxRecordSize = 256 * 1024 * 1024
+
+// Workload 按照区块的顺序产生写入 store 的 kv, 没有更多区块的时候返回 nil, nil
+type Workload interface {
+	Next() ([]*types.KeyValue, error)
+}
+
+// SyntheticConfig 合成负载的参数
+type SyntheticConfig struct {
+	//区块数量
+	Blocks int `json:"blocks"`
+	//每个区块写入的 kv 数量
+	KVsPerBlock int `json:"kvsPerBlock"`
+	//key 的总数, 写入的 key 在这个范围内随机选择, 越小更新已有 key 的比例越高
+	KeySpace  int   `json:"keySpace"`
+	ValueSize int   `json:"valueSize"`
+	Seed      int64 `json:"seed"`
+}
+
+type synthetic struct {
+	cfg    SyntheticConfig
+	rand   *rand.Rand
+	height int
+}
+
+// NewSynthetic 按照参数随机产生负载, 相同的 Seed 产生相同的负载
+func NewSynthetic(cfg SyntheticConfig) (Workload, error) {
+	if cfg.Blocks < 0 || cfg.KVsPerBlock <= 0 || cfg.KeySpace < cfg.KVsPerBlock || cfg.ValueSize <= 0 {
+		return nil, types.ErrInvalidParam
+	}
+	return &synthetic{cfg: cfg, rand: rand.New(rand.NewSource(cfg.Seed))}, nil
+}
+
+func (s *synthetic) Next() ([]*types.KeyValue, error) {
+	if s.height >= s.cfg.Blocks {
+		return nil, nil
+	}
+	s.height++
+	//同一个区块中的 key 不重复
+	picked := make(map[int]bool, s.cfg.KVsPerBlock)
+	kvs := make([]*types.KeyValue, 0, s.cfg.KVsPerBlock)
+	for len(kvs) < s.cfg.KVsPerBlock {
+		n := s.rand.Intn(s.cfg.KeySpace)
+		if picked[n] {
+			continue
+		}
+		picked[n] = true
+		value := make([]byte, s.cfg.ValueSize)
+		s.rand.Read(value)
+		kvs = append(kvs, &types.KeyValue{Key: []byte(fmt.Sprintf("mavl-bench-%010d", n)), Value: value})
+	}
+	return kvs, nil
+}
+
+//录制的负载文件是连续的记录, 每个记录是 uvarint 编码的长度加上一个区块的 StoreSet
+
+type recorded struct {
+	r *bufio.Reader
+}
+
+// NewRecorded 读取录制的负载, 回放的时候只使用 StoreSet 中的 KV, 状态 hash 和高度由回放的 store 决定
+func NewRecorded(r io.Reader) Workload {
+	return &recorded{r: bufio.NewReader(r)}
+}
+
+func (rec *recorded) Next() ([]*types.KeyValue, error) {
+	size, err := binary.ReadUvarint(rec.r)
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if size > maxRecordSize {
+		return nil, ErrWorkloadFormat
+	}
+	data := make([]byte, size)
+	_, err = io.ReadFull(rec.r, data)
+	if err != nil {
+		return nil, ErrWorkloadFormat
+	}
+	var set types.StoreSet
+	err = types.Decode(data, &set)
+	if err != nil {
+		return nil, ErrWorkloadFormat
+	}
+	//空块也是一个区块, 返回空的列表而不是 nil
+	if set.KV == nil {
+		set.KV = []*types.KeyValue{}
+	}
+	return set.KV, nil
+}
+
+type node struct {
+	client *jsonclient.JSONClient
+	height int64
+	end    int64
+}
+
+// NewNode 通过运行中的节点的 kvmvccmavl.GetBlockKV 接口, 按高度读取 [start, end] 每个区块写入 store 的 kv。
+// 节点的 store 需要使用 kvmvccmavl, 开启裁剪的时候这些高度的数据需要还没有被裁剪
+func NewNode(rpcAddr string, start, end int64) (Workload, error) {
+	if start < 0 || end < start {
+		return nil, types.ErrInvalidParam
+	}
+	client, err := jsonclient.NewJSONClient(rpcAddr)
+	if err != nil {
+		return nil, err
+	}
+	return &node{client: client, height: start, end: end}, nil
+}
+
+func (n *node) Next() ([]*types.KeyValue, error) {
+	if n.height > n.end {
+		return nil, nil
+	}
+	var res kvmvccmavl.BlockKV
+	err := n.client.Call("kvmvccmavl.GetBlockKV", &types.ReqInt{Height: n.height}, &res)
+	if err != nil {
+		return nil, err
+	}
+	n.height++
+	if res.KV == nil {
+		res.KV = []*types.KeyValue{}
+	}
+	return res.KV, nil
+}
+
+// Recorder 把区块的 StoreSet 写成录制的负载
+type Recorder struct {
+	w   io.Writer
+	buf [binary.MaxVarintLen64]byte
+}
+
+// NewRecorder 创建录制负载的 Recorder
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{w: w}
+}
+
+// Record 写入一个区块的 StoreSet
+func (rec *Recorder) Record(set *types.StoreSet) error {
+	data := types.Encode(set)
+	n := binary.PutUvarint(rec.buf[:], uint64(len(data)))
+	_, err := rec.w.Write(rec.buf[:n])
+	if err != nil {
+		return err
+	}
+	_, err = rec.w.Write(data)
+	return err
+}
+
+// RecordWorkload 把负载全部写成录制的负载, 返回区块数量
+func RecordWorkload(w io.Writer, wl Workload) (int64, error) {
+	rec := NewRecorder(w)
+	var height int64
+	for {
+		kvs, err := wl.Next()
+		if err != nil {
+			return height, err
+		}
+		if kvs == nil {
+			return height, nil
+		}
+		err = rec.Record(&types.StoreSet{KV: kvs, Height: height})
+		if err != nil {
+			return height, err
+		}
+		height++
+	}
+}
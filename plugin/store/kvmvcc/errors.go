@@ -9,4 +9,6 @@ import "errors"
 var (
 	//ErrStateHashLost means err happened when query with StateHash
 	ErrStateHashLost = errors.New("ErrStateHashLost")
+	//ErrStateHashPruned means the state of StateHash has been pruned
+	ErrStateHashPruned = errors.New("ErrStateHashPruned")
 )
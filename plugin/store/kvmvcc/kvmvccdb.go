@@ -12,6 +12,7 @@ import (
 	"github.com/33cn/chain33/queue"
 	drivers "github.com/33cn/chain33/system/store"
 	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/snapshot"
 	"github.com/golang/protobuf/proto"
 )
 
@@ -38,26 +39,36 @@ type KVMVCCStore struct {
 	kvsetmap       map[string][]*types.KeyValue
 	enableMVCCIter bool
 	sync           bool
+	pruner         *pruner
 }
 
 type subConfig struct {
 	EnableMVCCIter bool `json:"enableMVCCIter"`
+	// 是否裁剪历史状态, 归档节点不裁剪
+	EnableMVCCPrune bool `json:"enableMVCCPrune"`
+	// 保留最近多少个高度的状态, 在这个范围内可以回滚
+	PruneMVCCHeight int64 `json:"pruneMVCCHeight"`
+	// 检查点的间隔, 高度是它的整数倍的状态不裁剪, 0 表示不保留检查点
+	PruneCheckpoint int64 `json:"pruneCheckpoint"`
+	// 每一轮裁剪的高度数量
+	PruneBatchHeight int64 `json:"pruneBatchHeight"`
 }
 
 // New construct KVMVCCStore module
 func New(cfg *types.Store, sub []byte, chain33cfg *types.Chain33Config) queue.Module {
 	bs := drivers.NewBaseStore(cfg)
 	var kvs *KVMVCCStore
-	enable := false
+	var subcfg subConfig
 	if sub != nil {
-		var subcfg subConfig
 		types.MustDecode(sub, &subcfg)
-		enable = subcfg.EnableMVCCIter
 	}
-	if enable {
-		kvs = &KVMVCCStore{bs, dbm.NewMVCCIter(bs.GetDB()), make(map[string][]*types.KeyValue), true, false}
+	if subcfg.EnableMVCCIter {
+		kvs = &KVMVCCStore{bs, dbm.NewMVCCIter(bs.GetDB()), make(map[string][]*types.KeyValue), true, false, nil}
 	} else {
-		kvs = &KVMVCCStore{bs, dbm.NewMVCC(bs.GetDB()), make(map[string][]*types.KeyValue), false, false}
+		kvs = &KVMVCCStore{bs, dbm.NewMVCC(bs.GetDB()), make(map[string][]*types.KeyValue), false, false, nil}
+	}
+	if subcfg.EnableMVCCPrune {
+		kvs.pruner = newPruner(bs.GetDB(), kvs.mvcc, &subcfg)
 	}
 	bs.SetChild(kvs)
 	return kvs
@@ -65,6 +76,9 @@ func New(cfg *types.Store, sub []byte, chain33cfg *types.Chain33Config) queue.Mo
 
 // Close the KVMVCCStore module
 func (mvccs *KVMVCCStore) Close() {
+	if mvccs.pruner != nil {
+		mvccs.pruner.close()
+	}
 	mvccs.BaseStore.Close()
 	klog.Info("store kvdb closed")
 }
@@ -77,16 +91,28 @@ func (mvccs *KVMVCCStore) Set(datas *types.StoreSet, sync bool) ([]byte, error)
 		return nil, err
 	}
 	mvccs.saveKVSets(kvlist, sync)
+	mvccs.prune()
 	return hash, nil
 }
 
 // Get kvs with statehash from KVMVCCStore
 func (mvccs *KVMVCCStore) Get(datas *types.StoreGet) [][]byte {
+	values, err := mvccs.get(datas)
+	if err != nil {
+		klog.Error("Get by hash failed.", "hash", common.ToHex(datas.StateHash), "err", err)
+	}
+	return values
+}
+
+//get 查询已经被裁剪的状态返回 ErrStateHashPruned
+func (mvccs *KVMVCCStore) get(datas *types.StoreGet) ([][]byte, error) {
 	values := make([][]byte, len(datas.Keys))
 	version, err := mvccs.mvcc.GetVersion(datas.StateHash)
 	if err != nil {
-		klog.Error("Get version by hash failed.", "hash", common.ToHex(datas.StateHash))
-		return values
+		return values, err
+	}
+	if mvccs.pruner != nil && mvccs.pruner.isPruned(version) {
+		return values, ErrStateHashPruned
 	}
 	for i := 0; i < len(datas.Keys); i++ {
 		value, err := mvccs.mvcc.GetV(datas.Keys[i], version)
@@ -96,7 +122,7 @@ func (mvccs *KVMVCCStore) Get(datas *types.StoreGet) [][]byte {
 			values[i] = value
 		}
 	}
-	return values
+	return values, nil
 }
 
 // MemSet set kvs to the mem of KVMVCCStore module and return the StateHash
@@ -137,6 +163,7 @@ func (mvccs *KVMVCCStore) Commit(req *types.ReqHash) ([]byte, error) {
 	//klog.Debug("KVMVCCStore Commit saveKVSets", "hash", common.ToHex(req.Hash))
 	mvccs.saveKVSets(mvccs.kvsetmap[string(req.Hash)], mvccs.sync)
 	delete(mvccs.kvsetmap, string(req.Hash))
+	mvccs.prune()
 	return req.Hash, nil
 }
 
@@ -199,11 +226,19 @@ func (mvccs *KVMVCCStore) ProcEvent(msg *queue.Message) {
 	if msg == nil {
 		return
 	}
+	if msg.Ty == snapshot.EventStoreExportSnapshot {
+		snapshot.ProcExport(mvccs.GetQueueClient(), msg, mvccs)
+		return
+	}
 	msg.ReplyErr("KVStore", types.ErrActionNotSupport)
 }
 
 // Del set kvs to nil with StateHash
 func (mvccs *KVMVCCStore) Del(req *types.StoreDel) ([]byte, error) {
+	if mvccs.pruner != nil && !mvccs.pruner.canRollback(req.Height) {
+		klog.Error("store kvmvcc del", "height", req.Height, "err", ErrStateHashPruned)
+		return nil, ErrStateHashPruned
+	}
 	kvset, err := mvccs.mvcc.DelMVCC(req.StateHash, req.Height, true)
 	if err != nil {
 		klog.Error("store kvmvcc del", "err", err)
@@ -252,6 +287,9 @@ func (mvccs *KVMVCCStore) checkVersion(height int64) ([]*types.KeyValue, error)
 		return nil, ErrStateHashLost
 	} else if maxVersion == height-1 {
 		return nil, nil
+	} else if mvccs.pruner != nil && !mvccs.pruner.canRollback(height) {
+		klog.Error("store kvmvcc checkVersion can not rollback to pruned height", "maxVersion", maxVersion, "height", height)
+		return nil, ErrStateHashPruned
 	} else {
 		for i := maxVersion; i >= height; i-- {
 			hash, err := mvccs.mvcc.GetVersionHash(i)
@@ -273,6 +311,13 @@ func (mvccs *KVMVCCStore) checkVersion(height int64) ([]*types.KeyValue, error)
 	return kvset, nil
 }
 
+//prune 开启裁剪的时候, 状态写入数据库以后通知后台裁剪
+func (mvccs *KVMVCCStore) prune() {
+	if mvccs.pruner != nil {
+		mvccs.pruner.trigger()
+	}
+}
+
 func calcHash(datas proto.Message) []byte {
 	b := types.Encode(datas)
 	return common.Sha256(b)
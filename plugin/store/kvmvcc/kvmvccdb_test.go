@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/33cn/chain33/common"
 	drivers "github.com/33cn/chain33/system/store"
 	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/snapshot"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -274,6 +276,160 @@ func TestKvmvccdbRollbackBatch(t *testing.T) {
 	assert.Equal(t, int64(2), maxVersion)
 }
 
+func TestKvmvccdbPrune(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir) // clean up
+	var storeCfg = newStoreCfg(dir)
+	sub, _ := json.Marshal(&subConfig{EnableMVCCPrune: true, PruneMVCCHeight: 5, PruneCheckpoint: 10, PruneBatchHeight: 3})
+	store := New(storeCfg, sub, nil).(*KVMVCCStore)
+	assert.NotNil(t, store)
+	defer store.Close()
+
+	//k 每个高度都修改, s 在高度 0 和 12 修改, c 只在高度 0 写入
+	hashes := make([][]byte, 30)
+	prev := drivers.EmptyRoot[:]
+	for i := int64(0); i < 30; i++ {
+		kv := []*types.KeyValue{{Key: []byte("k"), Value: []byte(fmt.Sprintf("v%d", i))}}
+		if i == 0 || i == 12 {
+			kv = append(kv, &types.KeyValue{Key: []byte("s"), Value: []byte(fmt.Sprintf("s%d", i))})
+		}
+		if i == 0 {
+			kv = append(kv, &types.KeyValue{Key: []byte("c"), Value: []byte("c0")})
+		}
+		hash, err := store.MemSet(&types.StoreSet{StateHash: prev, KV: kv, Height: i}, true)
+		assert.Nil(t, err)
+		_, err = store.Commit(&types.ReqHash{Hash: hash})
+		assert.Nil(t, err)
+		hashes[i] = hash
+		prev = hash
+	}
+	//后台分批裁剪到窗口的起点 25
+	for i := 0; i < 100 && store.pruner.isPruned(24) == false; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	for i := 0; i < 100; i++ {
+		if more, err := store.pruner.pruneOnce(); err == nil && !more {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	value, err := store.GetDB().Get(pruneNextKey)
+	assert.Nil(t, err)
+	assert.Equal(t, types.Encode(&types.Int64{Data: 25}), value)
+
+	keys := [][]byte{[]byte("k"), []byte("s"), []byte("c")}
+	get := func(height int64) ([][]byte, error) {
+		return store.get(&types.StoreGet{StateHash: hashes[height], Keys: keys})
+	}
+	//窗口内和检查点的状态都可以查询
+	for _, h := range []int64{0, 10, 20, 25, 29} {
+		values, err := get(h)
+		assert.Nil(t, err)
+		assert.Equal(t, []byte(fmt.Sprintf("v%d", h)), values[0])
+		assert.Equal(t, []byte("c0"), values[2])
+	}
+	values, _ := get(10)
+	assert.Equal(t, []byte("s0"), values[1])
+	values, _ = get(20)
+	assert.Equal(t, []byte("s12"), values[1])
+	values, _ = get(25)
+	assert.Equal(t, []byte("s12"), values[1])
+	//被裁剪的状态返回明确的错误
+	for _, h := range []int64{1, 13, 24} {
+		_, err := get(h)
+		assert.Equal(t, ErrStateHashPruned, err)
+	}
+	//旧版本的数据被删除了, 检查点和没有被覆盖的值保留
+	db := store.GetDB()
+	for h, exist := range map[int64]bool{0: true, 1: false, 13: false, 20: true, 24: true, 25: true} {
+		v, _ := db.Get(genKeyVersion([]byte("k"), h))
+		assert.Equal(t, exist, v != nil, "height %d", h)
+	}
+	v, _ := db.Get(genKeyVersion([]byte("c"), 0))
+	assert.NotNil(t, v)
+
+	//窗口内可以回滚
+	hash, err := store.MemSet(&types.StoreSet{StateHash: hashes[26], KV: []*types.KeyValue{{Key: []byte("k"), Value: []byte("r27")}}, Height: 27}, true)
+	assert.Nil(t, err)
+	_, err = store.Commit(&types.ReqHash{Hash: hash})
+	assert.Nil(t, err)
+	values, err = store.get(&types.StoreGet{StateHash: hash, Keys: keys})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("r27"), values[0])
+	assert.Equal(t, []byte("s12"), values[1])
+	//不能回滚到窗口之外
+	_, err = store.MemSet(&types.StoreSet{StateHash: hashes[24], KV: []*types.KeyValue{{Key: []byte("k"), Value: []byte("r25")}}, Height: 25}, true)
+	assert.Equal(t, ErrStateHashPruned, err)
+}
+
+func TestKvmvccdbSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir) // clean up
+	storeCfg, sub := newStoreCfgIter(filepath.Join(dir, "src"))
+	store := New(storeCfg, sub, nil).(*KVMVCCStore)
+	assert.NotNil(t, store)
+	defer store.Close()
+
+	//b 在高度 1 被删除, d 在高度 2 才写入
+	sets := [][]*types.KeyValue{
+		{{Key: []byte("a"), Value: []byte("a0")}, {Key: []byte("b"), Value: []byte("b0")}, {Key: []byte("c"), Value: []byte("c0")}},
+		{{Key: []byte("a"), Value: []byte("a1")}, {Key: []byte("b"), Value: []byte{}}},
+		{{Key: []byte("a"), Value: []byte("a2")}, {Key: []byte("d"), Value: []byte("d2")}},
+	}
+	var hashes [][]byte
+	prev := drivers.EmptyRoot[:]
+	for i, kv := range sets {
+		hash, err := store.Set(&types.StoreSet{StateHash: prev, KV: kv, Height: int64(i)}, true)
+		assert.Nil(t, err)
+		hashes = append(hashes, hash)
+		prev = hash
+	}
+	snapdir := filepath.Join(dir, "snapshot")
+	m, err := snapshot.Export(store, &snapshot.ReqExport{Dir: snapdir, Driver: "kvmvcc", Height: 1, StateHash: hashes[1], ChunkSize: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), m.Total)
+	assert.Equal(t, 2, len(m.Chunks))
+
+	storeCfg, sub = newStoreCfgIter(filepath.Join(dir, "dst"))
+	dst := New(storeCfg, sub, nil).(*KVMVCCStore)
+	defer dst.Close()
+	//kvmvcc 不能校验状态 hash, 必须信任快照
+	_, err = snapshot.Import(dst, "kvmvcc", snapdir, hashes[1], false)
+	assert.Equal(t, snapshot.ErrUntrustedSnapshot, err)
+	_, err = snapshot.Import(dst, "mpt", snapdir, nil, true)
+	assert.Equal(t, snapshot.ErrDriverMismatch, err)
+	_, err = snapshot.Import(dst, "kvmvcc", snapdir, hashes[0], true)
+	assert.Equal(t, snapshot.ErrStateHashMismatch, err)
+	_, err = snapshot.Import(dst, "kvmvcc", snapdir, hashes[1], true)
+	assert.Nil(t, err)
+	_, err = snapshot.Import(dst, "kvmvcc", snapdir, nil, true)
+	assert.Equal(t, snapshot.ErrStoreNotEmpty, err)
+
+	keys := [][]byte{[]byte("a"), []byte("b"), []byte("c"), []byte("d")}
+	values := dst.Get(&types.StoreGet{StateHash: hashes[1], Keys: keys})
+	assert.Equal(t, []byte("a1"), values[0])
+	assert.Nil(t, values[1])
+	assert.Equal(t, []byte("c0"), values[2])
+	assert.Nil(t, values[3])
+	var iter [][]byte
+	dst.IterateRangeByStateHash(hashes[1], nil, nil, true, func(key, value []byte) bool {
+		iter = append(iter, key)
+		return false
+	})
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("c")}, iter)
+
+	//导入以后可以从快照的高度继续执行区块
+	hash, err := dst.Set(&types.StoreSet{StateHash: hashes[1], KV: sets[2], Height: 2}, true)
+	assert.Nil(t, err)
+	assert.Equal(t, hashes[2], hash)
+	values = dst.Get(&types.StoreGet{StateHash: hash, Keys: keys})
+	assert.Equal(t, []byte("a2"), values[0])
+	assert.Equal(t, []byte("c0"), values[2])
+	assert.Equal(t, []byte("d2"), values[3])
+}
+
 func enableConfig() []byte {
 	data, _ := json.Marshal(&subConfig{EnableMVCCIter: true})
 	return data
@@ -0,0 +1,268 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kvmvccdb
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+)
+
+const (
+	defaultPruneHeight      = 10000 // 默认保留最近10000个高度的状态
+	defaultPruneBatchHeight = 100   // 默认每轮裁剪100个高度
+)
+
+var (
+	//同common/db中的mvcc相关的定义保持一致
+	mvccPrefix             = []byte(".-mvcc-.")
+	mvccMeta               = append(mvccPrefix, []byte("m.")...)
+	mvccData               = append(mvccPrefix, []byte("d.")...)
+	mvccLast               = append(mvccPrefix, []byte("l.")...)
+	mvccMetaVersion        = append(mvccMeta, []byte("version.")...)
+	mvccMetaVersionKeyList = append(mvccMeta, []byte("versionkl.")...)
+
+	//裁剪进度, 比这个高度低的状态都已经裁剪过了
+	pruneNextKey = append(mvccPrefix, []byte("prune.next")...)
+)
+
+//pruner 按照高度顺序增量裁剪历史状态:
+//保留最近 PruneHeight 个高度, 以及高度是 PruneCheckpoint 整数倍的检查点;
+//其他高度的状态在后台分批删除, 每轮最多处理 PruneBatchHeight 个高度。
+//一个 key 在高度 p 写入的值, 在它下一次被修改(高度 q)之前都是有效的,
+//裁剪到高度 q 的时候, 如果 [p, q) 之间没有检查点就删除这个值, 还没有被覆盖的值一直保留。
+type pruner struct {
+	db         dbm.DB
+	mvcc       dbm.MVCC
+	keep       int64
+	checkpoint int64
+	batch      int64
+
+	mu sync.Mutex
+	//比 next 低的高度都已经裁剪过或者正在裁剪
+	next   int64
+	notify chan struct{}
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+func newPruner(db dbm.DB, mvcc dbm.MVCC, sub *subConfig) *pruner {
+	p := &pruner{
+		db:         db,
+		mvcc:       mvcc,
+		keep:       sub.PruneMVCCHeight,
+		checkpoint: sub.PruneCheckpoint,
+		batch:      sub.PruneBatchHeight,
+		notify:     make(chan struct{}, 1),
+		done:       make(chan struct{}),
+	}
+	if p.keep <= 0 {
+		p.keep = defaultPruneHeight
+	}
+	if p.batch <= 0 {
+		p.batch = defaultPruneBatchHeight
+	}
+	value, err := db.Get(pruneNextKey)
+	if err == nil && value != nil {
+		var next types.Int64
+		if types.Decode(value, &next) == nil {
+			p.next = next.Data
+		}
+	}
+	p.wg.Add(1)
+	go p.run()
+	return p
+}
+
+//trigger 有新的状态写入以后通知后台裁剪, 不会阻塞
+func (p *pruner) trigger() {
+	select {
+	case p.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (p *pruner) close() {
+	close(p.done)
+	p.wg.Wait()
+}
+
+func (p *pruner) closed() bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *pruner) run() {
+	defer p.wg.Done()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-p.notify:
+			for !p.closed() {
+				more, err := p.pruneOnce()
+				if err != nil {
+					klog.Error("store kvmvcc prune", "err", err)
+					break
+				}
+				if !more {
+					break
+				}
+			}
+		}
+	}
+}
+
+//isCheckpoint 检查点的状态不会被裁剪
+func (p *pruner) isCheckpoint(version int64) bool {
+	return p.checkpoint > 0 && version%p.checkpoint == 0
+}
+
+//isPruned 这个高度的状态是否已经被裁剪
+func (p *pruner) isPruned(version int64) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return version < p.next && !p.isCheckpoint(version)
+}
+
+//canRollback 回滚到 height 会删除 height 及以上的状态, 被裁剪的状态可能依赖这些高度的值
+func (p *pruner) canRollback(height int64) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return height > p.next
+}
+
+//pruneOnce 裁剪一轮, 返回是否还有需要裁剪的高度
+func (p *pruner) pruneOnce() (bool, error) {
+	maxVersion, err := p.mvcc.GetMaxVersion()
+	if err != nil {
+		if err == types.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	target := maxVersion - p.keep + 1
+	p.mu.Lock()
+	start := p.next
+	end := start + p.batch
+	if end > target {
+		end = target
+	}
+	if end <= start {
+		p.mu.Unlock()
+		return false, nil
+	}
+	//先占住这一轮要裁剪的高度, 避免裁剪的同时回滚到这些高度
+	p.next = end
+	p.mu.Unlock()
+
+	batch := p.db.NewBatch(true)
+	for version := start; version < end; version++ {
+		err := p.pruneVersion(batch, version)
+		if err != nil {
+			p.mu.Lock()
+			p.next = start
+			p.mu.Unlock()
+			return false, err
+		}
+	}
+	batch.Set(pruneNextKey, types.Encode(&types.Int64{Data: end}))
+	err = batch.Write()
+	if err != nil {
+		p.mu.Lock()
+		p.next = start
+		p.mu.Unlock()
+		return false, err
+	}
+	klog.Debug("store kvmvcc prune", "start", start, "end", end, "maxVersion", maxVersion)
+	return end < target, nil
+}
+
+//pruneVersion 处理高度 version 修改过的 key: 这些 key 在 version 之前的值只在它们写入的高度到 version 之间有效,
+//如果中间没有检查点就删除。最后删除这个高度的版本信息, 但是保留 statehash 到高度的映射,
+//查询被裁剪的状态时可以返回明确的错误
+func (p *pruner) pruneVersion(batch dbm.Batch, version int64) error {
+	kvs, err := p.getVersionKeyList(version)
+	if err == types.ErrNotFound {
+		//已经裁剪过了
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, kv := range kvs {
+		prev, ok := p.prevVersion(kv.Key, version)
+		if !ok || p.isCheckpoint(prev) {
+			continue
+		}
+		if p.checkpoint > 0 && (prev/p.checkpoint+1)*p.checkpoint < version {
+			//被覆盖之前有检查点
+			continue
+		}
+		batch.Delete(genKeyVersion(kv.Key, prev))
+	}
+	if p.isCheckpoint(version) {
+		return nil
+	}
+	batch.Delete(append(append([]byte{}, mvccMetaVersion...), pad(version)...))
+	batch.Delete(append(append([]byte{}, mvccMetaVersionKeyList...), pad(version)...))
+	return nil
+}
+
+func (p *pruner) getVersionKeyList(version int64) ([]*types.KeyValue, error) {
+	value, err := p.db.Get(append(append([]byte{}, mvccMetaVersionKeyList...), pad(version)...))
+	if err != nil || value == nil {
+		return nil, types.ErrNotFound
+	}
+	var kvlist types.LocalDBSet
+	err = types.Decode(value, &kvlist)
+	if err != nil {
+		return nil, err
+	}
+	return kvlist.KV, nil
+}
+
+//prevVersion key 在 version 之前最后一次被修改的高度
+func (p *pruner) prevVersion(key []byte, version int64) (int64, bool) {
+	prefix := genKeyPrefix(key)
+	it := p.db.Iterator(prefix, genKeyVersion(key, version), true)
+	defer it.Close()
+	for it.Rewind(); it.Valid(); it.Next() {
+		//前缀相同的其他 key 也可能被遍历到, 比如 a 和 a.0b
+		rest := it.Key()[len(prefix):]
+		if len(rest) != 20 {
+			continue
+		}
+		prev, err := strconv.ParseInt(string(rest), 10, 64)
+		if err != nil || prev >= version {
+			continue
+		}
+		return prev, true
+	}
+	return 0, false
+}
+
+func genKeyPrefix(key []byte) []byte {
+	b := append([]byte{}, mvccData...)
+	newkey := append(b, key...)
+	return append(newkey, []byte(".")...)
+}
+
+func genKeyVersion(key []byte, version int64) []byte {
+	return append(genKeyPrefix(key), pad(version)...)
+}
+
+func pad(version int64) []byte {
+	s := fmt.Sprintf("%020d", version)
+	return []byte(s)
+}
+
@@ -0,0 +1,109 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kvmvccdb
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/33cn/chain33/types"
+)
+
+//kvmvcc 的状态 hash 是区块修改的状态的 hash, 不能根据全部状态计算出来,
+//所以快照只能通过分块的 hash 校验, 状态 hash 需要和可信节点的区块头核对, 只有指定信任快照的时候才能导入
+
+// ExportState 遍历 stateHash 对应的全部状态, 每个 key 取不超过这个高度的最新的值, 值为空的 key 已经被删除了
+func (mvccs *KVMVCCStore) ExportState(stateHash []byte, fn func(key, value []byte) error) error {
+	version, err := mvccs.mvcc.GetVersion(stateHash)
+	if err != nil {
+		return err
+	}
+	if mvccs.pruner != nil && mvccs.pruner.isPruned(version) {
+		return ErrStateHashPruned
+	}
+	var key, value []byte
+	emit := func() error {
+		if len(value) == 0 {
+			return nil
+		}
+		return fn(key, value)
+	}
+	it := mvccs.GetDB().Iterator(mvccData, nil, false)
+	defer it.Close()
+	for it.Rewind(); it.Valid(); it.Next() {
+		k, v, ok := splitKeyVersion(it.Key())
+		if !ok {
+			continue
+		}
+		if !bytes.Equal(k, key) {
+			if err := emit(); err != nil {
+				return err
+			}
+			key = append([]byte{}, k...)
+			value = nil
+		}
+		//同一个 key 的版本从小到大排列
+		if v <= version {
+			value = it.ValueCopy()
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return emit()
+}
+
+// ImportState 把快照中的状态写入空的 store, 所有的状态都作为 height 高度写入, 不校验 stateHash
+func (mvccs *KVMVCCStore) ImportState(height int64, stateHash []byte, next func() ([]*types.KeyValue, error)) error {
+	db := mvccs.GetDB()
+	for {
+		kvs, err := next()
+		if err != nil {
+			return err
+		}
+		if kvs == nil {
+			break
+		}
+		batch := db.NewBatch(false)
+		for _, kv := range kvs {
+			batch.Set(genKeyVersion(kv.Key, height), kv.Value)
+			if mvccs.enableMVCCIter {
+				batch.Set(append(append([]byte{}, mvccLast...), kv.Key...), kv.Value)
+			}
+		}
+		err = batch.Write()
+		if err != nil {
+			return err
+		}
+	}
+	//版本信息最后写入, 导入中断的时候 store 中没有可以查询的状态
+	kvlist, err := mvccs.mvcc.SetVersionKV(stateHash, height)
+	if err != nil {
+		return err
+	}
+	batch := db.NewBatch(true)
+	for _, kv := range kvlist {
+		batch.Set(kv.Key, kv.Value)
+	}
+	//快照之前的高度没有状态, 开启裁剪的时候不能回滚到快照的高度
+	batch.Set(pruneNextKey, types.Encode(&types.Int64{Data: height}))
+	return batch.Write()
+}
+
+//splitKeyVersion 把数据的 key 拆分成原始的 key 和高度
+func splitKeyVersion(dbkey []byte) ([]byte, int64, bool) {
+	if !bytes.HasPrefix(dbkey, mvccData) {
+		return nil, 0, false
+	}
+	rest := dbkey[len(mvccData):]
+	if len(rest) < 21 || rest[len(rest)-21] != '.' {
+		return nil, 0, false
+	}
+	version, err := strconv.ParseInt(string(rest[len(rest)-20:]), 10, 64)
+	if err != nil {
+		return nil, 0, false
+	}
+	return rest[:len(rest)-21], version, true
+}
@@ -0,0 +1,64 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kvmvccmavl
+
+import (
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/types"
+)
+
+const (
+	// EventStoreGetBlockKV 获取一个高度写入 kvmvcc 的 kv
+	EventStoreGetBlockKV = 10007
+	// EventStoreGetBlockKVReply EventStoreGetBlockKV 的返回
+	EventStoreGetBlockKVReply = 10008
+)
+
+// BlockKV 一个高度写入 kvmvcc 的 kv, 顺序和区块写入 store 的时候一致, Value 为空表示删除
+type BlockKV struct {
+	Height int64             `json:"height"`
+	KV     []*types.KeyValue `json:"kv"`
+}
+
+// GetBlockKV 按照 AddMVCC 记录的 key 列表取出这个高度写入的 kv, 用于从运行中的节点录制 store 的负载。
+// 开启裁剪的时候, 比最新高度低 PruneHeight 以上的高度的数据可能已经不存在了
+func (mvccs *KVMVCCStore) GetBlockKV(height int64) (*BlockKV, error) {
+	keys, err := mvccs.getVersionKeyList(height)
+	if err == dbm.ErrNotFoundInDb {
+		return nil, types.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &BlockKV{Height: height, KV: mvccs.getVersionKV(keys, height)}, nil
+}
+
+//getVersionKV 取出 key 在这个高度写入的值
+func (mvccs *KVMVCCStore) getVersionKV(keys []*types.KeyValue, height int64) []*types.KeyValue {
+	kvs := make([]*types.KeyValue, 0, len(keys))
+	for _, kv := range keys {
+		value, err := mvccs.db.Get(genKeyVersion(kv.Key, height))
+		if err != nil {
+			value = nil
+		}
+		kvs = append(kvs, &types.KeyValue{Key: kv.Key, Value: value})
+	}
+	return kvs
+}
+
+func (kvmMavls *KVmMavlStore) procGetBlockKV(msg *queue.Message) {
+	req, ok := msg.GetData().(*types.ReqInt)
+	if !ok {
+		msg.ReplyErr("KVmMavlStore", types.ErrInvalidParam)
+		return
+	}
+	reply, err := kvmMavls.KVMVCCStore.GetBlockKV(req.GetHeight())
+	if err != nil {
+		msg.ReplyErr("KVmMavlStore", err)
+		return
+	}
+	msg.Reply(kvmMavls.GetQueueClient().NewMessage("", EventStoreGetBlockKVReply, reply))
+}
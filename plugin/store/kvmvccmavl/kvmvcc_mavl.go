@@ -76,6 +76,8 @@ type KVmMavlStore struct {
 	*KVMVCCStore
 	*MavlStore
 	cache *lru.Cache
+	//写入 kvmvcc 版本信息的操作和修复版本信息互斥, 避免修复写入正在回滚的高度
+	versionLock sync.Mutex
 }
 
 type subKVMVCCConfig struct {
@@ -145,7 +147,7 @@ func New(cfg *types.Store, sub []byte, chain33cfg *types.Chain33Config) queue.Mo
 	}
 
 	kvms = &KVmMavlStore{bs, NewKVMVCC(&subKVMVCCcfg, bs.GetDB()),
-		NewMavl(&subMavlcfg, bs.GetDB()), cache}
+		NewMavl(&subMavlcfg, bs.GetDB()), cache, sync.Mutex{}}
 	// 查询是否已经删除mavl
 	_, err = bs.GetDB().Get(genDelMavlKey(mvccPrefix))
 	if err == nil {
@@ -179,6 +181,8 @@ func (kvmMavls *KVmMavlStore) Close() {
 
 // Set kvs with statehash to KVmMavlStore
 func (kvmMavls *KVmMavlStore) Set(datas *types.StoreSet, sync bool) ([]byte, error) {
+	kvmMavls.versionLock.Lock()
+	defer kvmMavls.versionLock.Unlock()
 	if datas.Height < kvmvccMavlFork {
 		hash, err := kvmMavls.MavlStore.Set(datas, sync)
 		if err != nil {
@@ -235,6 +239,8 @@ func (kvmMavls *KVmMavlStore) Get(datas *types.StoreGet) [][]byte {
 
 // MemSet set kvs to the mem of KVmMavlStore module and return the StateHash
 func (kvmMavls *KVmMavlStore) MemSet(datas *types.StoreSet, sync bool) ([]byte, error) {
+	kvmMavls.versionLock.Lock()
+	defer kvmMavls.versionLock.Unlock()
 	if datas.Height < kvmvccMavlFork {
 		hash, err := kvmMavls.MavlStore.MemSet(datas, sync)
 		if err != nil {
@@ -298,6 +304,8 @@ func (kvmMavls *KVmMavlStore) MemSet(datas *types.StoreSet, sync bool) ([]byte,
 
 // Commit kvs in the mem of KVmMavlStore module to state db and return the StateHash
 func (kvmMavls *KVmMavlStore) Commit(req *types.ReqHash) ([]byte, error) {
+	kvmMavls.versionLock.Lock()
+	defer kvmMavls.versionLock.Unlock()
 	if value, ok := kvmMavls.cache.Get(string(req.Hash)); ok {
 		if value.(int64) < kvmvccMavlFork {
 			hash, err := kvmMavls.MavlStore.Commit(req)
@@ -362,11 +370,21 @@ func (kvmMavls *KVmMavlStore) ProcEvent(msg *queue.Message) {
 	if msg == nil {
 		return
 	}
+	switch msg.Ty {
+	case EventStoreVerify:
+		kvmMavls.procVerify(msg)
+		return
+	case EventStoreGetBlockKV:
+		kvmMavls.procGetBlockKV(msg)
+		return
+	}
 	msg.ReplyErr("KVmMavlStore", types.ErrActionNotSupport)
 }
 
 // MemSetUpgrade set kvs to the mem of KVmMavlStore module  not cache the tree and return the StateHash
 func (kvmMavls *KVmMavlStore) MemSetUpgrade(datas *types.StoreSet, sync bool) ([]byte, error) {
+	kvmMavls.versionLock.Lock()
+	defer kvmMavls.versionLock.Unlock()
 	if datas.Height < kvmvccMavlFork {
 		var hash []byte
 		var err error
@@ -425,13 +443,17 @@ func (kvmMavls *KVmMavlStore) CommitUpgrade(req *types.ReqHash) ([]byte, error)
 			go deletePrunedMavl(kvmMavls.GetDB())
 		}
 	} else {
+		kvmMavls.versionLock.Lock()
 		hash, err = kvmMavls.KVMVCCStore.CommitUpgrade(req)
+		kvmMavls.versionLock.Unlock()
 	}
 	return hash, err
 }
 
 // Del set kvs to nil with StateHash
 func (kvmMavls *KVmMavlStore) Del(req *types.StoreDel) ([]byte, error) {
+	kvmMavls.versionLock.Lock()
+	defer kvmMavls.versionLock.Unlock()
 	if req.Height < kvmvccMavlFork {
 		hash, err := kvmMavls.MavlStore.Del(req)
 		if err != nil {
@@ -1798,3 +1798,114 @@ func benchmarkIterCommit(b *testing.B, isResetForkHeight bool) {
 	fmt.Println("kvmvcc BenchmarkCommit cost time is", end.Sub(start), "num is", b.N)
 	b.StopTimer()
 }
+
+func TestVerify(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir) // clean up
+	os.RemoveAll(dir)       //删除已存在目录
+	var storeCfg = newStoreCfg(dir)
+	store := New(storeCfg, nil, nil).(*KVmMavlStore)
+	assert.NotNil(t, store)
+	defer store.Close()
+
+	kvmvccMavlFork = 5
+	defer func() {
+		kvmvccMavlFork = 200 * 10000
+	}()
+
+	var headers []*types.Header
+	prev := drivers.EmptyRoot[:]
+	for i := 0; i < 10; i++ {
+		datas := &types.StoreSet{
+			StateHash: prev,
+			KV:        []*types.KeyValue{{Key: []byte("mk1"), Value: []byte(fmt.Sprintf("v%d", i))}, {Key: []byte(fmt.Sprintf("mk%d", i+2)), Value: []byte("v")}},
+			Height:    int64(i)}
+		hash, err := store.Set(datas, true)
+		assert.Nil(t, err)
+		headers = append(headers, &types.Header{Height: int64(i), StateHash: hash})
+		prev = hash
+	}
+	req := &ReqVerify{PrevStateHash: drivers.EmptyRoot[:], Headers: headers}
+	report, err := store.Verify(req)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), report.Checked)
+	assert.Equal(t, int64(5), report.Recomputed)
+	assert.Equal(t, 0, len(report.Issues))
+
+	//删除版本信息, 分叉之后的高度可以重新计算状态 hash 后修复, 分叉之前的高度需要另一个方向的映射确认
+	db := store.KVMVCCStore.db
+	assert.Nil(t, db.Delete(append(append([]byte{}, mvccMetaVersion...), pad(7)...)))
+	assert.Nil(t, db.Delete(append(append([]byte{}, mvccMeta...), headers[8].StateHash...)))
+	assert.Nil(t, db.Delete(append(append([]byte{}, mvccMetaVersion...), pad(2)...)))
+	assert.Nil(t, db.Delete(append(append([]byte{}, mvccMeta...), headers[3].StateHash...)))
+	assert.Nil(t, db.Delete(append(append([]byte{}, mvccMetaVersion...), pad(1)...)))
+	assert.Nil(t, db.Delete(append(append([]byte{}, mvccMeta...), headers[1].StateHash...)))
+	report, err = store.Verify(req)
+	assert.Nil(t, err)
+	assert.Equal(t, 6, len(report.Issues))
+	assert.Equal(t, int64(0), report.Repaired)
+
+	req.Repair = true
+	report, err = store.Verify(req)
+	assert.Nil(t, err)
+	assert.Equal(t, 6, len(report.Issues))
+	assert.Equal(t, int64(4), report.Repaired)
+	req.Repair = false
+	report, err = store.Verify(req)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(report.Issues))
+	for _, issue := range report.Issues {
+		assert.Equal(t, int64(1), issue.Height)
+	}
+
+	//篡改数据
+	assert.Nil(t, db.Set(genKeyVersion([]byte("mk1"), 6), []byte("bad")))
+	report, err = store.Verify(&ReqVerify{PrevStateHash: headers[4].StateHash, Headers: headers[5:]})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(report.Issues))
+	assert.Equal(t, IssueStateHashMismatch, report.Issues[0].Kind)
+	assert.Equal(t, int64(6), report.Issues[0].Height)
+
+	//修复和区块的写入以及回滚互斥
+	store.versionLock.Lock()
+	done := make(chan *VerifyReport)
+	go func() {
+		report, _ := store.Verify(&ReqVerify{PrevStateHash: headers[8].StateHash, Headers: headers[9:], Repair: true})
+		done <- report
+	}()
+	select {
+	case <-done:
+		t.Error("repair should wait for the version lock")
+	case <-time.After(100 * time.Millisecond):
+	}
+	//持有锁期间删除最后一个高度的版本信息, 模拟回滚, 之后修复不会给超过最大版本的高度写入版本信息
+	assert.Nil(t, db.Delete(append(append([]byte{}, mvccMetaVersion...), pad(9)...)))
+	assert.Nil(t, db.Delete(append(append([]byte{}, mvccMeta...), headers[9].StateHash...)))
+	store.versionLock.Unlock()
+	report = <-done
+	assert.Equal(t, int64(0), report.Repaired)
+	_, err = store.KVMVCCStore.mvcc.GetVersionHash(9)
+	assert.NotNil(t, err)
+
+	//通过消息检查
+	q := queue.New("channel")
+	store.SetQueueClient(q.Client())
+	client := q.Client()
+	msg := client.NewMessage("store", EventStoreVerify, &ReqVerify{PrevStateHash: headers[1].StateHash, Headers: headers[2:4]})
+	assert.Nil(t, client.Send(msg, true))
+	resp, err := client.Wait(msg)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), resp.GetData().(*VerifyReport).Checked)
+
+	//录制负载用的每个高度写入的 kv
+	msg = client.NewMessage("store", EventStoreGetBlockKV, &types.ReqInt{Height: 3})
+	assert.Nil(t, client.Send(msg, true))
+	resp, err = client.Wait(msg)
+	assert.Nil(t, err)
+	blockKV := resp.GetData().(*BlockKV)
+	assert.Equal(t, int64(3), blockKV.Height)
+	assert.Equal(t, []*types.KeyValue{{Key: []byte("mk1"), Value: []byte("v3")}, {Key: []byte("mk5"), Value: []byte("v")}}, blockKV.KV)
+	_, err = store.KVMVCCStore.GetBlockKV(10)
+	assert.Equal(t, types.ErrNotFound, err)
+}
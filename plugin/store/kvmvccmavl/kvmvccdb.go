@@ -36,11 +36,11 @@ var (
 var (
 	//同common/db中的mvcc相关的定义保持一致
 	mvccPrefix = []byte(".-mvcc-.")
-	//mvccMeta               = append(mvccPrefix, []byte("m.")...)
-	mvccData = append(mvccPrefix, []byte("d.")...)
+	mvccMeta   = append(mvccPrefix, []byte("m.")...)
+	mvccData   = append(mvccPrefix, []byte("d.")...)
 	//mvccLast               = append(mvccPrefix, []byte("l.")...)
-	//mvccMetaVersion        = append(mvccMeta, []byte("version.")...)
-	//mvccMetaVersionKeyList = append(mvccMeta, []byte("versionkl.")...)
+	mvccMetaVersion        = append(mvccMeta, []byte("version.")...)
+	mvccMetaVersionKeyList = append(mvccMeta, []byte("versionkl.")...)
 
 	// for empty block
 	rdmHashPrefix = append(mvccPrefix, []byte("rdm.")...)
@@ -0,0 +1,180 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kvmvccmavl
+
+import (
+	"errors"
+
+	"github.com/33cn/chain33/client"
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/rpc/jsonclient"
+	rpctypes "github.com/33cn/chain33/rpc/types"
+	drivers "github.com/33cn/chain33/system/store"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/common/rpcplugin"
+	"github.com/spf13/cobra"
+)
+
+//每次发给 store 检查的高度数量, 修复的时候 store 在检查期间不写入新的区块, 所以分批检查
+const verifyBatchHeight = 1000
+
+func init() {
+	rpcplugin.Register("store-kvmvccmavl", func(s rpctypes.RPCServer) {
+		s.JRPC().RegisterName("kvmvccmavl", &Jrpc{client: s.GetQueueClient()})
+	}, Cmd)
+}
+
+// Jrpc kvmvccmavl 的 json rpc 接口
+type Jrpc struct {
+	client queue.Client
+}
+
+// ReqVerifyStore 检查 [Start, End] 高度的数据, Repair 的时候修复能够确认的缺失的版本信息
+type ReqVerifyStore struct {
+	Start  int64 `json:"start"`
+	End    int64 `json:"end"`
+	Repair bool  `json:"repair"`
+}
+
+// Verify 按照区块头分批检查 kvmvcc 的数据, 只有 store 使用 kvmvccmavl 的时候支持
+func (c *Jrpc) Verify(in *ReqVerifyStore, result *interface{}) error {
+	if in == nil || in.Start < 0 || in.End < in.Start {
+		return types.ErrInvalidParam
+	}
+	api, err := client.New(c.client, nil)
+	if err != nil {
+		return err
+	}
+	report := &VerifyReport{}
+	for start := in.Start; start <= in.End; start += verifyBatchHeight {
+		end := start + verifyBatchHeight - 1
+		if end > in.End {
+			end = in.End
+		}
+		req, err := getVerifyHeaders(api, start, end)
+		if err != nil {
+			return err
+		}
+		req.Repair = in.Repair
+		reply, err := c.verify(req)
+		if err != nil {
+			return err
+		}
+		report.Merge(reply)
+	}
+	*result = report
+	return nil
+}
+
+//getVerifyHeaders 获取 [start, end] 的区块头, 以及 start 前一个区块的状态 hash
+func getVerifyHeaders(api client.QueueProtocolAPI, start, end int64) (*ReqVerify, error) {
+	req := &ReqVerify{PrevStateHash: drivers.EmptyRoot[:]}
+	from := start
+	if start > 0 {
+		from = start - 1
+	}
+	headers, err := api.GetHeaders(&types.ReqBlocks{Start: from, End: end})
+	if err != nil {
+		return nil, err
+	}
+	items := headers.GetItems()
+	if int64(len(items)) != end-from+1 {
+		return nil, types.ErrBlockNotFound
+	}
+	if start > 0 {
+		req.PrevStateHash = items[0].StateHash
+		items = items[1:]
+	}
+	req.Headers = items
+	return req, nil
+}
+
+func (c *Jrpc) verify(req *ReqVerify) (*VerifyReport, error) {
+	data, err := c.request(EventStoreVerify, req)
+	if err != nil {
+		return nil, err
+	}
+	report, ok := data.(*VerifyReport)
+	if !ok {
+		return nil, types.ErrTypeAsset
+	}
+	return report, nil
+}
+
+// GetBlockKV 获取一个高度写入 kvmvcc 的 kv, 用于录制 store 的性能测试负载, 只有 store 使用 kvmvccmavl 的时候支持
+func (c *Jrpc) GetBlockKV(in *types.ReqInt, result *interface{}) error {
+	if in == nil || in.Height < 0 {
+		return types.ErrInvalidParam
+	}
+	data, err := c.request(EventStoreGetBlockKV, in)
+	if err != nil {
+		return err
+	}
+	reply, ok := data.(*BlockKV)
+	if !ok {
+		return types.ErrTypeAsset
+	}
+	*result = reply
+	return nil
+}
+
+//request 给 store 发送消息并等待返回, store 返回的错误转换成 error
+func (c *Jrpc) request(ty int64, req interface{}) (interface{}, error) {
+	msg := c.client.NewMessage("store", ty, req)
+	err := c.client.Send(msg, true)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Wait(msg)
+	if err != nil {
+		return nil, err
+	}
+	switch data := resp.GetData().(type) {
+	case *types.Reply:
+		return nil, errors.New(string(data.GetMsg()))
+	case error:
+		return nil, data
+	default:
+		return data, nil
+	}
+}
+
+// Cmd kvmvccmavl store 的命令
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "kvmvccmavl",
+		Short: "Check kvmvccmavl store",
+		Args:  cobra.MinimumNArgs(1),
+	}
+	cmd.AddCommand(
+		VerifyCmd(),
+	)
+	return cmd
+}
+
+// VerifyCmd 检查一段高度的 kvmvcc 数据
+func VerifyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify kvmvcc versions against block headers, and repair missing version entries",
+		Run:   verifyStore,
+	}
+	cmd.Flags().Int64P("start", "s", 0, "start height")
+	cmd.Flags().Int64P("end", "e", 0, "end height")
+	cmd.MarkFlagRequired("end")
+	cmd.Flags().BoolP("repair", "r", false, "repair missing version entries which can be confirmed")
+	return cmd
+}
+
+func verifyStore(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	start, _ := cmd.Flags().GetInt64("start")
+	end, _ := cmd.Flags().GetInt64("end")
+	repair, _ := cmd.Flags().GetBool("repair")
+	params := &ReqVerifyStore{Start: start, End: end, Repair: repair}
+	var res VerifyReport
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "kvmvccmavl.Verify", params, &res)
+	ctx.Run()
+}
@@ -0,0 +1,242 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kvmvccmavl
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/33cn/chain33/common"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/types"
+)
+
+// store 模块的事件编号, 和系统以及其他 store 插件的事件编号分开
+const (
+	// EventStoreVerify 检查一段高度的 kvmvcc 数据
+	EventStoreVerify = 10005
+	// EventStoreVerifyReply EventStoreVerify 的返回
+	EventStoreVerifyReply = 10006
+)
+
+// 检查发现的问题类型
+const (
+	// IssueVersionMissing 高度到状态 hash 的映射不存在
+	IssueVersionMissing = "versionMissing"
+	// IssueVersionMismatch 高度映射的状态 hash 和区块头不一致
+	IssueVersionMismatch = "versionMismatch"
+	// IssueHashMissing 状态 hash 到高度的映射不存在
+	IssueHashMissing = "hashMissing"
+	// IssueHashMismatch 状态 hash 映射的高度不对
+	IssueHashMismatch = "hashMismatch"
+	// IssueKeyListMissing 高度修改的 key 列表不存在, 无法重新计算状态 hash
+	IssueKeyListMissing = "keyListMissing"
+	// IssueStateHashMismatch 根据高度修改的 kv 重新计算的状态 hash 和区块头不一致
+	IssueStateHashMismatch = "stateHashMismatch"
+	// IssueRdmMissing 空块处理时 mavl 状态 hash 到 kvmvcc 状态 hash 的映射不存在
+	IssueRdmMissing = "rdmMissing"
+)
+
+// ReqVerify 检查 Headers 对应高度的 kvmvcc 数据, Headers 的高度必须是连续的
+type ReqVerify struct {
+	//Headers[0] 前一个区块的状态 hash, 高度 0 的时候是空的状态 hash
+	PrevStateHash []byte
+	Headers       []*types.Header
+	//修复能够确认的缺失的版本信息
+	Repair bool
+}
+
+// VerifyIssue 一个高度上发现的问题
+type VerifyIssue struct {
+	Height   int64  `json:"height"`
+	Kind     string `json:"kind"`
+	Detail   string `json:"detail,omitempty"`
+	Repaired bool   `json:"repaired"`
+}
+
+// VerifyReport 检查的结果
+type VerifyReport struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+	//检查的高度数量
+	Checked int64 `json:"checked"`
+	//重新计算了状态 hash 的高度数量
+	Recomputed int64 `json:"recomputed"`
+	Repaired   int64 `json:"repaired"`
+	//后台删除 mavl 数据以及压缩是否已经完成
+	DelMavlData    bool           `json:"delMavlData"`
+	CompactDelMavl bool           `json:"compactDelMavl"`
+	Issues         []*VerifyIssue `json:"issues"`
+}
+
+// Merge 合并分批检查的结果
+func (r *VerifyReport) Merge(other *VerifyReport) {
+	if r.Checked == 0 {
+		r.Start = other.Start
+	}
+	r.End = other.End
+	r.Checked += other.Checked
+	r.Recomputed += other.Recomputed
+	r.Repaired += other.Repaired
+	r.DelMavlData = other.DelMavlData
+	r.CompactDelMavl = other.CompactDelMavl
+	r.Issues = append(r.Issues, other.Issues...)
+}
+
+// Verify 按照区块头检查 kvmvcc 的版本信息:
+// 分叉高度之后, 以及开启空块处理的时候, kvmvcc 的状态 hash 是区块修改的 kv 的 hash,
+// 根据这个高度的 key 列表和数据重新计算以后和区块头比较; 分叉高度之前的状态 hash 是 mavl 的根节点 hash, 只检查版本信息。
+// Repair 的时候只补上缺失的版本信息, 并且要求状态 hash 经过重新计算或者另一个方向的映射确认, 数据不一致的高度只报告不修复。
+// store 在单独的协程中处理这个请求, Repair 的时候整个检查过程持有 versionLock, 期间区块的写入和回滚会等待检查结束,
+// 并且只修复不超过当前最大版本的高度, 不会给已经回滚的高度写入版本信息
+func (kvmMavls *KVmMavlStore) Verify(req *ReqVerify) (*VerifyReport, error) {
+	if req == nil || len(req.Headers) == 0 {
+		return nil, types.ErrInvalidParam
+	}
+	for i := 1; i < len(req.Headers); i++ {
+		if req.Headers[i].Height != req.Headers[i-1].Height+1 {
+			return nil, types.ErrInvalidParam
+		}
+	}
+	if req.Repair {
+		kvmMavls.versionLock.Lock()
+		defer kvmMavls.versionLock.Unlock()
+	}
+	mvccs := kvmMavls.KVMVCCStore
+	maxVersion, err := mvccs.mvcc.GetMaxVersion()
+	if err != nil {
+		if err != types.ErrNotFound {
+			return nil, err
+		}
+		maxVersion = -1
+	}
+	//后台删除和压缩的协程会修改全局的标记, 这里从数据库中读取它们完成时写入的 key
+	_, delErr := mvccs.db.Get(genDelMavlKey(mvccPrefix))
+	_, compactErr := mvccs.db.Get(genCompactDelMavlKey(mvccPrefix))
+	report := &VerifyReport{
+		Start:          req.Headers[0].Height,
+		End:            req.Headers[len(req.Headers)-1].Height,
+		DelMavlData:    delErr == nil,
+		CompactDelMavl: compactErr == nil,
+	}
+	batch := mvccs.db.NewBatch(true)
+	prev := req.PrevStateHash
+	for _, header := range req.Headers {
+		mvccs.verifyHeight(header, prev, maxVersion, req.Repair && header.Height <= maxVersion, batch, report)
+		prev = header.StateHash
+	}
+	if report.Repaired > 0 {
+		err = batch.Write()
+		if err != nil {
+			return nil, err
+		}
+		kmlog.Info("store kvmvccmavl repair", "start", report.Start, "end", report.End, "repaired", report.Repaired)
+	}
+	return report, nil
+}
+
+func (mvccs *KVMVCCStore) verifyHeight(header *types.Header, prev []byte, maxVersion int64, repair bool, batch dbm.Batch, report *VerifyReport) {
+	height := header.Height
+	report.Checked++
+	addIssue := func(kind, detail string, repaired bool) {
+		if repaired {
+			report.Repaired++
+		}
+		report.Issues = append(report.Issues, &VerifyIssue{Height: height, Kind: kind, Detail: detail, Repaired: repaired})
+	}
+	emptyBlock := mvccs.kvmvccCfg.EnableEmptyBlockHandle
+	var recomputed []byte
+	kvs, err := mvccs.getVersionKeyList(height)
+	if err != nil {
+		addIssue(IssueKeyListMissing, "", false)
+	} else if (height >= kvmvccMavlFork || emptyBlock) && mvccs.canRecompute(height, maxVersion) {
+		recomputed = mvccs.recomputeHash(kvs, prev, height)
+		report.Recomputed++
+	}
+
+	mvccHash := header.StateHash
+	if height < kvmvccMavlFork && emptyBlock {
+		rdm, err := mvccs.GetHashRdm(header.StateHash, height)
+		if err != nil || len(rdm) == 0 {
+			repaired := repair && recomputed != nil
+			if repaired {
+				batch.Set(calcRdmKey(header.StateHash, height), recomputed)
+			}
+			addIssue(IssueRdmMissing, "", repaired)
+			if recomputed == nil {
+				return
+			}
+			rdm = recomputed
+		}
+		mvccHash = rdm
+	}
+	if recomputed != nil && !bytes.Equal(recomputed, mvccHash) {
+		addIssue(IssueStateHashMismatch, "recomputed "+common.ToHex(recomputed), false)
+		return
+	}
+
+	vhash, verr := mvccs.mvcc.GetVersionHash(height)
+	version, herr := mvccs.mvcc.GetVersion(mvccHash)
+	if verr != nil {
+		repaired := repair && (recomputed != nil || (herr == nil && version == height))
+		if repaired {
+			batch.Set(append(append([]byte{}, mvccMetaVersion...), pad(height)...), mvccHash)
+		}
+		addIssue(IssueVersionMissing, "", repaired)
+	} else if !bytes.Equal(vhash, mvccHash) {
+		addIssue(IssueVersionMismatch, common.ToHex(vhash), false)
+	}
+	if herr != nil {
+		repaired := repair && (recomputed != nil || (verr == nil && bytes.Equal(vhash, mvccHash)))
+		if repaired {
+			batch.Set(append(append([]byte{}, mvccMeta...), mvccHash...), types.Encode(&types.Int64{Data: height}))
+		}
+		addIssue(IssueHashMissing, "", repaired)
+	} else if version != height {
+		addIssue(IssueHashMismatch, fmt.Sprintf("version %d", version), false)
+	}
+}
+
+//canRecompute 开启裁剪的时候, 比最新高度低 PruneHeight 以上的高度的数据可能已经被裁剪了
+func (mvccs *KVMVCCStore) canRecompute(height, maxVersion int64) bool {
+	if !mvccs.kvmvccCfg.EnableMVCCPrune {
+		return true
+	}
+	return height+int64(mvccs.kvmvccCfg.PruneHeight) > maxVersion
+}
+
+//recomputeHash 按照 AddMVCC 记录的 key 的顺序取出这个高度写入的值, 还原出 StoreSet 计算状态 hash
+func (mvccs *KVMVCCStore) recomputeHash(kvs []*types.KeyValue, prev []byte, height int64) []byte {
+	datas := &types.StoreSet{StateHash: prev, Height: height, KV: mvccs.getVersionKV(kvs, height)}
+	return calcHash(datas)
+}
+
+func (mvccs *KVMVCCStore) getVersionKeyList(height int64) ([]*types.KeyValue, error) {
+	value, err := mvccs.db.Get(append(append([]byte{}, mvccMetaVersionKeyList...), pad(height)...))
+	if err != nil {
+		return nil, err
+	}
+	var kvlist types.LocalDBSet
+	err = types.Decode(value, &kvlist)
+	if err != nil {
+		return nil, err
+	}
+	return kvlist.KV, nil
+}
+
+func (kvmMavls *KVmMavlStore) procVerify(msg *queue.Message) {
+	req, ok := msg.GetData().(*ReqVerify)
+	if !ok {
+		msg.ReplyErr("KVmMavlStore", types.ErrInvalidParam)
+		return
+	}
+	report, err := kvmMavls.Verify(req)
+	if err != nil {
+		msg.ReplyErr("KVmMavlStore", err)
+		return
+	}
+	msg.Reply(kvmMavls.GetQueueClient().NewMessage("", EventStoreVerifyReply, report))
+}
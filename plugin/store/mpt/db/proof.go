@@ -29,6 +29,21 @@ import (
 	proto "github.com/golang/protobuf/proto"
 )
 
+// KeyValueWriter wraps the Set method of a backing data store, Prove only
+// needs to write the proof nodes.
+type KeyValueWriter interface {
+	Set(key []byte, value []byte) error
+}
+
+// ProofList 按照从根节点到叶子节点的顺序记录证明节点
+type ProofList [][]byte
+
+// Set 记录证明节点, 节点的 key 是节点编码的 hash, 验证的时候可以重新计算
+func (l *ProofList) Set(key []byte, value []byte) error {
+	*l = append(*l, value)
+	return nil
+}
+
 // Prove constructs a merkle proof for key. The result contains all encoded nodes
 // on the path to the value at key. The value itself is also included in the last
 // node and can be retrieved by verifying the proof.
@@ -36,7 +51,7 @@ import (
 // If the trie does not contain a value for key, the returned proof contains all
 // nodes of the longest existing prefix of the key (at least the root node), ending
 // with the node that proves the absence of the key.
-func (t *Trie) Prove(key []byte, fromLevel uint, proofDb dbm.DB) error {
+func (t *Trie) Prove(key []byte, fromLevel uint, proofDb KeyValueWriter) error {
 	// Collect all nodes on the path to key.
 	key = keybytesToHex(key)
 	nodes := []node{}
@@ -97,7 +112,7 @@ func (t *Trie) Prove(key []byte, fromLevel uint, proofDb dbm.DB) error {
 // If the trie does not contain a value for key, the returned proof contains all
 // nodes of the longest existing prefix of the key (at least the root node), ending
 // with the node that proves the absence of the key.
-func (t *SecureTrie) Prove(key []byte, fromLevel uint, proofDb dbm.DB) error {
+func (t *SecureTrie) Prove(key []byte, fromLevel uint, proofDb KeyValueWriter) error {
 	return t.trie.Prove(key, fromLevel, proofDb)
 }
 
@@ -130,6 +145,19 @@ func VerifyProof(rootHash common.Hash, key []byte, proofDb dbm.DB) (value []byte
 	}
 }
 
+// VerifyProofList 验证 ProofList 格式的证明, 返回 key 对应的值, 值为 nil 表示证明了 key 不存在
+func VerifyProofList(rootHash common.Hash, key []byte, proof [][]byte) ([]byte, error) {
+	proofDb, err := dbm.NewGoMemDB("proof", "", 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, enc := range proof {
+		proofDb.Set(common.Sha3(enc), enc)
+	}
+	value, _, err := VerifyProof(rootHash, key, proofDb)
+	return value, err
+}
+
 func get(tn node, key []byte) ([]byte, node) {
 	for {
 		switch n := tn.(type) {
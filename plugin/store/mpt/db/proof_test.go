@@ -139,6 +139,31 @@ func TestBadProof(t *testing.T) {
 	}
 }
 
+func TestProofList(t *testing.T) {
+	trie, vals := randomTrie(500)
+	root := trie.Hash()
+	for _, kv := range vals {
+		var proof ProofList
+		if err := trie.Prove(kv.k, 0, &proof); err != nil {
+			t.Fatalf("missing key %x while constructing proof: %v", kv.k, err)
+		}
+		val, err := VerifyProofList(root, kv.k, proof)
+		if err != nil {
+			t.Fatalf("failed to verify proof for key %x: %v", kv.k, err)
+		}
+		if !bytes.Equal(val, kv.v) {
+			t.Fatalf("verified value mismatch for key %x: have %x, want %x", kv.k, val, kv.v)
+		}
+		//修改任何一个证明节点都会导致验证失败
+		last := len(proof) - 1
+		proof[last] = append([]byte{}, proof[last]...)
+		mutateByte(proof[last])
+		if _, err := VerifyProofList(root, kv.k, proof); err == nil {
+			t.Fatalf("expected proof to fail for key %x", kv.k)
+		}
+	}
+}
+
 // Tests that missing keys can also be proven. The test explicitly uses a single
 // entry trie and checks for missing keys both before and after the single entry.
 func TestMissingKeyProof(t *testing.T) {
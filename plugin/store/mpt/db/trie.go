@@ -542,6 +542,27 @@ func (t *TrieEx) TryDelete(key []byte) error {
 	return t.Trie.TryDelete(key)
 }
 
+// Prove 返回 key 从根节点到叶子节点路径上所有节点的编码
+func (t *TrieEx) Prove(key []byte) ([][]byte, error) {
+	if enableSecure {
+		key = common.Sha3(key)
+	}
+	var proof ProofList
+	err := t.Trie.Prove(key, 0, &proof)
+	if err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// VerifyKeyProof 验证 TrieEx.Prove 返回的证明, 返回 key 对应的值, 值为 nil 表示证明了 key 不存在
+func VerifyKeyProof(roothash []byte, key []byte, proof [][]byte) ([]byte, error) {
+	if enableSecure {
+		key = common.Sha3(key)
+	}
+	return VerifyProofList(common.BytesToHash(roothash), key, proof)
+}
+
 // Commit writes all nodes to the trie's memory database
 func (t *TrieEx) Commit(onleaf LeafCallback) (root common.Hash, err error) {
 	return t.Trie.Commit(onleaf)
@@ -12,6 +12,7 @@ import (
 	drivers "github.com/33cn/chain33/system/store"
 	"github.com/33cn/chain33/types"
 	mpt "github.com/33cn/plugin/plugin/store/mpt/db"
+	"github.com/33cn/plugin/plugin/store/snapshot"
 	lru "github.com/hashicorp/golang-lru"
 )
 
@@ -169,10 +170,18 @@ func (mpts *Store) IterateRangeByStateHash(statehash []byte, start []byte, end [
 	mpt.IterateRangeByStateHash(mpts.GetDB(), statehash, start, end, ascending, fn)
 }
 
-// ProcEvent not support message
+// ProcEvent handles supported events
 func (mpts *Store) ProcEvent(msg *queue.Message) {
 	if msg == nil {
 		return
 	}
+	if msg.Ty == EventStoreGetWithProof {
+		mpts.procGetWithProof(msg)
+		return
+	}
+	if msg.Ty == snapshot.EventStoreExportSnapshot {
+		snapshot.ProcExport(mpts.GetQueueClient(), msg, mpts)
+		return
+	}
 	msg.ReplyErr("Store", types.ErrActionNotSupport)
 }
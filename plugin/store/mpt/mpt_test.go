@@ -7,14 +7,17 @@ package mpt
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"fmt"
 	"time"
 
 	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/queue"
 	drivers "github.com/33cn/chain33/system/store"
 	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/snapshot"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -83,6 +86,112 @@ func TestKvddbSetGet(t *testing.T) {
 	assert.Equal(t, []byte(nil), values3[0])
 }
 
+func TestGetWithProof(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir) // clean up
+	var storeCfg = newStoreCfg(dir)
+	store := New(storeCfg, nil, nil).(*Store)
+	assert.NotNil(t, store)
+	q := queue.New("channel")
+	store.SetQueueClient(q.Client())
+	defer store.Close()
+
+	var kv []*types.KeyValue
+	for i := 0; i < 100; i++ {
+		kv = append(kv, &types.KeyValue{Key: []byte(fmt.Sprintf("k%d", i)), Value: []byte(fmt.Sprintf("v%d", i))})
+	}
+	datas := &types.StoreSet{StateHash: drivers.EmptyRoot[:], KV: kv, Height: 0}
+	hash, err := store.Set(datas, true)
+	assert.Nil(t, err)
+
+	keys := [][]byte{[]byte("k1"), []byte("k99"), []byte("none")}
+	reply, err := store.GetWithProof(&ReqGetWithProof{StateHash: hash, Keys: keys})
+	assert.Nil(t, err)
+	assert.Len(t, reply.Proofs, 3)
+	assert.Equal(t, []byte("v1"), reply.Proofs[0].Value)
+	assert.Equal(t, []byte("v99"), reply.Proofs[1].Value)
+	//不存在的 key 也可以证明
+	assert.Nil(t, reply.Proofs[2].Value)
+	for _, p := range reply.Proofs {
+		assert.Nil(t, VerifyProof(hash, p))
+	}
+	//篡改值或者换一个状态都不能通过验证
+	forged := *reply.Proofs[0]
+	forged.Value = []byte("v2")
+	assert.Equal(t, ErrProofValueMismatch, VerifyProof(hash, &forged))
+	assert.NotNil(t, VerifyProof(drivers.EmptyRoot[:], reply.Proofs[0]))
+	_, err = store.GetWithProof(&ReqGetWithProof{StateHash: drivers.EmptyRoot[:1]})
+	assert.Equal(t, types.ErrInvalidParam, err)
+
+	//通过 rpc 获取证明
+	rpc := &Jrpc{client: q.Client()}
+	var result interface{}
+	err = rpc.GetWithProof(&ReqStateProof{StateHash: common.ToHex(hash), Keys: []string{"k1", "none"}}, &result)
+	assert.Nil(t, err)
+	proof := result.(*StateProof)
+	assert.Len(t, proof.Proofs, 2)
+	for i, kv := range proof.Proofs {
+		p, err := DecodeKeyProof(kv)
+		assert.Nil(t, err)
+		assert.Equal(t, reply.Proofs[i*2].Value, p.Value)
+		assert.Nil(t, VerifyProof(hash, p))
+	}
+	unknown := common.Sha256([]byte("unknown"))
+	err = rpc.GetWithProof(&ReqStateProof{StateHash: common.ToHex(unknown), Keys: []string{"k1"}}, &result)
+	assert.Equal(t, types.ErrHashNotFound.Error(), err.Error())
+}
+
+func TestSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir) // clean up
+	store := New(newStoreCfg(filepath.Join(dir, "src")), nil, nil).(*Store)
+	assert.NotNil(t, store)
+	q := queue.New("channel")
+	store.SetQueueClient(q.Client())
+	defer store.Close()
+
+	var kv []*types.KeyValue
+	for i := 0; i < 100; i++ {
+		kv = append(kv, &types.KeyValue{Key: []byte(fmt.Sprintf("k%d", i)), Value: []byte(fmt.Sprintf("v%d", i))})
+	}
+	hash, err := store.Set(&types.StoreSet{StateHash: drivers.EmptyRoot[:], KV: kv, Height: 0}, true)
+	assert.Nil(t, err)
+	hash, err = store.Set(&types.StoreSet{StateHash: hash, KV: []*types.KeyValue{{Key: []byte("k1"), Value: []byte("new")}}, Height: 1}, true)
+	assert.Nil(t, err)
+
+	//通过 store 的事件导出
+	snapdir := filepath.Join(dir, "snapshot")
+	client := q.Client()
+	msg := client.NewMessage("store", snapshot.EventStoreExportSnapshot, &snapshot.ReqExport{Dir: snapdir, Driver: "mpt", Height: 1, StateHash: hash, ChunkSize: 30})
+	assert.Nil(t, client.Send(msg, true))
+	resp, err := client.Wait(msg)
+	assert.Nil(t, err)
+	m := resp.GetData().(*snapshot.Manifest)
+	assert.Equal(t, int64(100), m.Total)
+	assert.Equal(t, 4, len(m.Chunks))
+	assert.Equal(t, common.ToHex(hash), m.StateHash)
+	_, err = snapshot.Verify(snapdir)
+	assert.Nil(t, err)
+
+	//导入的时候重新构建树, 根节点 hash 必须和快照的状态 hash 一致
+	dst := New(newStoreCfg(filepath.Join(dir, "dst")), nil, nil).(*Store)
+	defer dst.Close()
+	r, err := snapshot.Open(snapdir)
+	assert.Nil(t, err)
+	assert.Equal(t, snapshot.ErrStateHashMismatch, dst.ImportState(1, drivers.EmptyRoot[:], r.Next))
+	_, err = snapshot.Import(dst, "mpt", snapdir, nil, false)
+	assert.Equal(t, snapshot.ErrStoreNotEmpty, err)
+
+	dst2 := New(newStoreCfg(filepath.Join(dir, "dst2")), nil, nil).(*Store)
+	defer dst2.Close()
+	_, err = snapshot.Import(dst2, "mpt", snapdir, hash, false)
+	assert.Nil(t, err)
+	values := dst2.Get(&types.StoreGet{StateHash: hash, Keys: [][]byte{[]byte("k0"), []byte("k1"), []byte("k99")}})
+	assert.Equal(t, [][]byte{[]byte("v0"), []byte("new"), []byte("v99")}, values)
+}
+
 func TestKvdbMemSet(t *testing.T) {
 	dir, err := ioutil.TempDir("", "example")
 	assert.Nil(t, err)
@@ -0,0 +1,96 @@
+package mpt
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/types"
+	mpt "github.com/33cn/plugin/plugin/store/mpt/db"
+)
+
+// store 模块的事件编号, 和系统的事件编号分开
+const (
+	// EventStoreGetWithProof 获取状态的值以及 merkle 证明
+	EventStoreGetWithProof = 10001
+	// EventStoreGetWithProofReply EventStoreGetWithProof 的返回
+	EventStoreGetWithProofReply = 10002
+)
+
+// ErrProofValueMismatch 证明中的值和证明节点验证出来的值不一致
+var ErrProofValueMismatch = errors.New("ErrProofValueMismatch")
+
+// ReqGetWithProof 获取 StateHash 对应状态中 Keys 的值以及证明
+type ReqGetWithProof struct {
+	StateHash []byte
+	Keys      [][]byte
+}
+
+// KeyProof 一个 key 的值和证明, Proof 是从根节点到叶子节点路径上的节点编码, Value 为 nil 表示 key 不存在
+type KeyProof struct {
+	Key   []byte
+	Value []byte
+	Proof [][]byte
+}
+
+// ReplyGetWithProof 状态的值以及证明
+type ReplyGetWithProof struct {
+	StateHash []byte
+	Proofs    []*KeyProof
+}
+
+// GetWithProof 获取已经提交的状态中 keys 的值以及证明
+func (mpts *Store) GetWithProof(req *ReqGetWithProof) (*ReplyGetWithProof, error) {
+	if req == nil || len(req.StateHash) != common.Sha256Len {
+		return nil, types.ErrInvalidParam
+	}
+	//证明只针对已经提交的状态, 每次重新加载, 不和 cache 中的 tree 共用节点
+	tree, err := mpt.NewEx(common.BytesToHash(req.StateHash), mpt.NewDatabase(mpts.GetDB()))
+	if err != nil {
+		mlog.Error("GetWithProof can not find a trie", "StateHash", common.ToHex(req.StateHash), "err", err)
+		return nil, types.ErrHashNotFound
+	}
+	reply := &ReplyGetWithProof{StateHash: req.StateHash}
+	for _, key := range req.Keys {
+		value, err := tree.TryGet(key)
+		if err != nil {
+			return nil, err
+		}
+		proof, err := tree.Prove(key)
+		if err != nil {
+			return nil, err
+		}
+		reply.Proofs = append(reply.Proofs, &KeyProof{Key: key, Value: value, Proof: proof})
+	}
+	return reply, nil
+}
+
+// VerifyProof 根据区块的 StateHash 验证 key 的值和证明, 不需要访问节点的数据库
+func VerifyProof(stateHash []byte, proof *KeyProof) error {
+	if proof == nil {
+		return types.ErrInvalidParam
+	}
+	value, err := mpt.VerifyKeyProof(stateHash, proof.Key, proof.Proof)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(value, proof.Value) {
+		return ErrProofValueMismatch
+	}
+	return nil
+}
+
+func (mpts *Store) procGetWithProof(msg *queue.Message) {
+	req, ok := msg.GetData().(*ReqGetWithProof)
+	if !ok {
+		msg.ReplyErr("Store", types.ErrInvalidParam)
+		return
+	}
+	reply, err := mpts.GetWithProof(req)
+	if err != nil {
+		msg.ReplyErr("Store", err)
+		return
+	}
+	msg.Reply(mpts.GetQueueClient().NewMessage("", EventStoreGetWithProofReply, reply))
+}
@@ -0,0 +1,113 @@
+package mpt
+
+import (
+	"errors"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/queue"
+	rpctypes "github.com/33cn/chain33/rpc/types"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/common/rpcplugin"
+)
+
+func init() {
+	rpcplugin.Register("store-mpt", func(s rpctypes.RPCServer) {
+		s.JRPC().RegisterName("mpt", &Jrpc{client: s.GetQueueClient()})
+	}, nil)
+}
+
+// Jrpc 状态证明的 json rpc 接口
+type Jrpc struct {
+	client queue.Client
+}
+
+// ReqStateProof 获取状态证明的请求, StateHash 是区块头中的状态 hash
+type ReqStateProof struct {
+	StateHash string   `json:"stateHash"`
+	Keys      []string `json:"keys"`
+}
+
+// KeyValueProof json 格式的 KeyProof, 值和证明节点都是 hex 编码
+type KeyValueProof struct {
+	Key   string   `json:"key"`
+	Value string   `json:"value"`
+	Proof []string `json:"proof"`
+}
+
+// StateProof json 格式的 ReplyGetWithProof
+type StateProof struct {
+	StateHash string           `json:"stateHash"`
+	Proofs    []*KeyValueProof `json:"proofs"`
+}
+
+// GetWithProof 获取状态中 keys 的值以及 merkle 证明, 只有 store 使用 mpt 的时候支持
+func (c *Jrpc) GetWithProof(in *ReqStateProof, result *interface{}) error {
+	if in == nil || len(in.Keys) == 0 {
+		return types.ErrInvalidParam
+	}
+	hash, err := common.FromHex(in.StateHash)
+	if err != nil {
+		return types.ErrInvalidParam
+	}
+	req := &ReqGetWithProof{StateHash: hash}
+	for _, key := range in.Keys {
+		req.Keys = append(req.Keys, []byte(key))
+	}
+	msg := c.client.NewMessage("store", EventStoreGetWithProof, req)
+	err = c.client.Send(msg, true)
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.Wait(msg)
+	if err != nil {
+		return err
+	}
+	switch data := resp.GetData().(type) {
+	case *ReplyGetWithProof:
+		*result = encodeStateProof(data)
+		return nil
+	case *types.Reply:
+		return errors.New(string(data.GetMsg()))
+	case error:
+		return data
+	}
+	return types.ErrTypeAsset
+}
+
+func encodeStateProof(reply *ReplyGetWithProof) *StateProof {
+	proof := &StateProof{StateHash: common.ToHex(reply.StateHash)}
+	for _, p := range reply.Proofs {
+		kv := &KeyValueProof{Key: string(p.Key)}
+		if p.Value != nil {
+			kv.Value = common.ToHex(p.Value)
+		}
+		for _, node := range p.Proof {
+			kv.Proof = append(kv.Proof, common.ToHex(node))
+		}
+		proof.Proofs = append(proof.Proofs, kv)
+	}
+	return proof
+}
+
+// DecodeKeyProof 把 rpc 返回的证明转换成 KeyProof, 用来调用 VerifyProof
+func DecodeKeyProof(kv *KeyValueProof) (*KeyProof, error) {
+	if kv == nil {
+		return nil, types.ErrInvalidParam
+	}
+	p := &KeyProof{Key: []byte(kv.Key)}
+	if kv.Value != "" {
+		value, err := common.FromHex(kv.Value)
+		if err != nil {
+			return nil, err
+		}
+		p.Value = value
+	}
+	for _, node := range kv.Proof {
+		enc, err := common.FromHex(node)
+		if err != nil {
+			return nil, err
+		}
+		p.Proof = append(p.Proof, enc)
+	}
+	return p, nil
+}
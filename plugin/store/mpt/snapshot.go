@@ -0,0 +1,69 @@
+package mpt
+
+import (
+	"bytes"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/types"
+	mpt "github.com/33cn/plugin/plugin/store/mpt/db"
+	"github.com/33cn/plugin/plugin/store/snapshot"
+)
+
+// ExportState 按照 key 的顺序遍历 stateHash 对应的树中全部的叶子节点
+func (mpts *Store) ExportState(stateHash []byte, fn func(key, value []byte) error) error {
+	tree, err := mpt.NewEx(common.BytesToHash(stateHash), mpt.NewDatabase(mpts.GetDB()))
+	if err != nil {
+		mlog.Error("ExportState can not find a trie", "StateHash", common.ToHex(stateHash), "err", err)
+		return types.ErrHashNotFound
+	}
+	it := mpt.NewIterator(tree.NodeIterator(nil))
+	for it.Next() {
+		err := fn(it.Key, it.Value)
+		if err != nil {
+			return err
+		}
+	}
+	return it.Err
+}
+
+// VerifyStateHash 导入的时候重新构建树, 可以校验状态 hash
+func (mpts *Store) VerifyStateHash() bool {
+	return true
+}
+
+// ImportState 用快照中的状态重新构建树, 每个分块提交一次, 最后的根节点 hash 必须等于 stateHash
+func (mpts *Store) ImportState(height int64, stateHash []byte, next func() ([]*types.KeyValue, error)) error {
+	root := common.Hash{}
+	for {
+		kvs, err := next()
+		if err != nil {
+			return err
+		}
+		if kvs == nil {
+			break
+		}
+		tree, err := mpt.NewEx(root, mpt.NewDatabase(mpts.GetDB()))
+		if err != nil {
+			return err
+		}
+		for _, kv := range kvs {
+			err := tree.TryUpdate(kv.Key, kv.Value)
+			if err != nil {
+				return err
+			}
+		}
+		root, err = tree.Commit(nil)
+		if err != nil {
+			return err
+		}
+		err = tree.Commit2Db(root, true)
+		if err != nil {
+			return err
+		}
+	}
+	if !bytes.Equal(root[:], stateHash) {
+		mlog.Error("ImportState state hash not match", "root", common.ToHex(root[:]), "StateHash", common.ToHex(stateHash), "height", height)
+		return snapshot.ErrStateHashMismatch
+	}
+	return nil
+}
@@ -0,0 +1,322 @@
+package snapshot
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/33cn/chain33/blockchain"
+	"github.com/33cn/chain33/common"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/common/db/table"
+	drivers "github.com/33cn/chain33/system/store"
+	"github.com/33cn/chain33/types"
+)
+
+// 区块链数据的快照, 和 store 的状态一起导入以后节点从快照的高度开始同步区块。
+// 包括快照高度以及之前 blockchain.InitBlockNum 个高度的区块头, 节点启动的时候要把这些区块头加载到内存中;
+// 之前 chainBlockNum 个高度的完整区块和回执, 节点启动的时候会缓存最近的区块;
+// 以及 blockchain 数据库中区块数据以外的全部数据, 包括执行器的 localdb, 交易索引和数据库的版本信息。
+const (
+	// ChainDir 快照目录中区块链数据的子目录
+	ChainDir = "chain"
+	// ChainDriver 区块链数据快照的类型
+	ChainDriver = "blockchain"
+	//导出完整区块的数量, 需要不小于节点缓存的区块数量 [blockchain] defCacheSize
+	chainBlockNum int64 = 1024
+)
+
+// 同 blockchain 中定义保持一致
+var (
+	blockLastHeight    = []byte("blockLastHeight")
+	heightToHashPrefix = []byte("Height:")
+	hashToHeightPrefix = []byte("Hash:")
+	hashToTdPrefix     = []byte("TD:")
+)
+
+var (
+	// ErrChainNotEmpty 只能把区块链数据导入空的 blockchain 数据库
+	ErrChainNotEmpty = errors.New("ErrChainNotEmpty")
+	// ErrChainSequence 记录区块序列号的节点和平行链节点需要从创世区块开始的区块数据, 不能从快照启动
+	ErrChainSequence = errors.New("ErrChainSequence")
+	// ErrChainMismatch 区块链数据和状态快照的高度, 区块 hash 或者状态 hash 不一致
+	ErrChainMismatch = errors.New("ErrChainMismatch")
+)
+
+// ExportChain 把 blockchain 数据库中最新高度的区块链数据导出到 dir, 节点需要已经停止
+func ExportChain(db dbm.DB, dir string, chunkSize int) (*Manifest, error) {
+	height, err := blockchain.LoadBlockStoreHeight(db)
+	if err != nil {
+		return nil, err
+	}
+	w, err := NewWriter(dir, chunkSize)
+	if err != nil {
+		return nil, err
+	}
+	err = exportChainLocal(db, w)
+	if err != nil {
+		return nil, err
+	}
+	start := height - blockchain.InitBlockNum
+	if start < 0 {
+		start = 0
+	}
+	var header *types.Header
+	for h := start; h <= height; h++ {
+		header, err = exportChainBlock(db, w, h, h > height-chainBlockNum)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = w.Add(blockLastHeight, types.Encode(&types.Int64{Data: height}))
+	if err != nil {
+		return nil, err
+	}
+	m, err := w.Close(&Manifest{
+		Driver:    ChainDriver,
+		Height:    height,
+		BlockHash: common.ToHex(header.Hash),
+		StateHash: common.ToHex(header.StateHash),
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("export chain", "dir", dir, "height", m.Height, "total", m.Total)
+	return m, nil
+}
+
+//exportChainLocal 导出区块数据以外的数据, 区块数据的 key 前缀和 blockchain.GetLocalDBKeyList 一致
+func exportChainLocal(db dbm.DB, w *Writer) error {
+	prefixes := blockchain.GetLocalDBKeyList()
+	it := db.Iterator(nil, nil, false)
+	defer it.Close()
+next:
+	for it.Rewind(); it.Valid(); it.Next() {
+		for _, prefix := range prefixes {
+			if bytes.HasPrefix(it.Key(), prefix) {
+				continue next
+			}
+		}
+		err := w.Add(it.Key(), it.Value())
+		if err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+//exportChainBlock 导出主链上一个高度的区块头以及高度, hash 和总难度的索引, full 的时候同时导出区块体和回执
+func exportChainBlock(db dbm.DB, w *Writer, height int64, full bool) (*types.Header, error) {
+	key := append(append([]byte{}, heightToHashPrefix...), []byte(fmt.Sprintf("%v", height))...)
+	hash, err := db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	err = w.Add(key, hash)
+	if err != nil {
+		return nil, err
+	}
+	for _, prefix := range [][]byte{hashToHeightPrefix, hashToTdPrefix} {
+		key = append(append([]byte{}, prefix...), hash...)
+		value, err := db.Get(key)
+		if err != nil {
+			return nil, err
+		}
+		err = w.Add(key, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	primary, err := (&blockchain.HeaderRow{Header: &types.Header{Height: height, Hash: hash}}).Get("heighthash")
+	if err != nil {
+		return nil, err
+	}
+	row, err := exportChainRow(db, w, blockchain.NewHeaderTable, primary)
+	if err != nil {
+		return nil, err
+	}
+	if full {
+		_, err = exportChainRow(db, w, blockchain.NewBodyTable, primary)
+		if err != nil {
+			return nil, err
+		}
+		//精简 localdb 的节点可能已经删除了回执
+		_, err = exportChainRow(db, w, blockchain.NewReceiptTable, primary)
+		if err != nil && err != types.ErrNotFound {
+			return nil, err
+		}
+	}
+	return row.(*types.Header), nil
+}
+
+//exportChainRow 读出表中的一行, 通过同样的表生成这一行的数据和索引的 kv
+func exportChainRow(db dbm.DB, w *Writer, newTable func(kvdb dbm.KV) *table.Table, primary []byte) (types.Message, error) {
+	rows, err := newTable(dbm.NewKVDB(db)).ListIndex("", primary, nil, 0, dbm.ListASC)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) != 1 {
+		return nil, types.ErrNotFound
+	}
+	mdb, err := dbm.NewGoMemDB("snapshot", "", 0)
+	if err != nil {
+		return nil, err
+	}
+	t := newTable(dbm.NewKVDB(mdb))
+	err = t.Replace(rows[0].Data)
+	if err != nil {
+		return nil, err
+	}
+	kvs, err := t.Save()
+	if err != nil {
+		return nil, err
+	}
+	for _, kv := range kvs {
+		err = w.Add(kv.Key, kv.Value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return rows[0].Data, nil
+}
+
+// ImportChain 把 dir 中的区块链数据导入空的 blockchain 数据库
+func ImportChain(db dbm.DB, dir string) (*Manifest, error) {
+	r, err := Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	m := r.Manifest()
+	if m.Driver != ChainDriver {
+		return nil, ErrDriverMismatch
+	}
+	if !isEmpty(db) {
+		return nil, ErrChainNotEmpty
+	}
+	var last []byte
+	for {
+		kvs, err := r.Next()
+		if err != nil {
+			return nil, err
+		}
+		if kvs == nil {
+			break
+		}
+		batch := db.NewBatch(false)
+		for _, kv := range kvs {
+			if bytes.Equal(kv.Key, blockLastHeight) {
+				last = kv.Value
+				continue
+			}
+			batch.Set(kv.Key, kv.Value)
+		}
+		err = batch.Write()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if last == nil {
+		return nil, ErrChainMismatch
+	}
+	//最新高度最后写入, 导入中断的时候节点不会从不完整的数据启动
+	err = db.SetSync(blockLastHeight, last)
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("import chain", "dir", dir, "height", m.Height, "total", m.Total)
+	return m, nil
+}
+
+// ExportNode 从停止的节点导出区块链数据和最新高度的 store 状态, 区块链数据写入 dir 下面的 ChainDir 子目录
+func ExportNode(cfg *types.Config, sub *types.ConfigSubModule, dir string, chunkSize int) (*Manifest, error) {
+	if _, err := os.Stat(filepath.Join(dir, ManifestFile)); err == nil {
+		return nil, ErrSnapshotExist
+	}
+	db := dbm.NewDB("blockchain", cfg.BlockChain.Driver, cfg.BlockChain.DbPath, cfg.BlockChain.DbCache)
+	cm, err := ExportChain(db, filepath.Join(dir, ChainDir), chunkSize)
+	db.Close()
+	if err != nil {
+		return nil, err
+	}
+	create, err := drivers.Load(cfg.Store.Name)
+	if err != nil {
+		return nil, err
+	}
+	s := create(cfg.Store, sub.Store[cfg.Store.Name], nil)
+	defer s.Close()
+	exporter, ok := s.(Exporter)
+	if !ok {
+		return nil, types.ErrActionNotSupport
+	}
+	blockHash, err := common.FromHex(cm.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+	stateHash, err := common.FromHex(cm.StateHash)
+	if err != nil {
+		return nil, err
+	}
+	return Export(exporter, &ReqExport{
+		Dir:       dir,
+		Driver:    cfg.Store.Name,
+		Height:    cm.Height,
+		BlockHash: blockHash,
+		StateHash: stateHash,
+		ChunkSize: chunkSize,
+		Chain:     ChainDir,
+	})
+}
+
+// ImportNode 把快照导入停止的节点的空数据目录, 快照包含区块链数据并且 storeOnly 为 false 的时候同时导入区块链数据,
+// 节点启动以后从快照的高度开始同步。区块链数据中的 localdb 不能根据区块头校验, 只有 trusted 为 true 的时候才能导入;
+// 记录区块序列号的节点和平行链节点需要从创世区块开始的区块数据, 不能导入区块链数据
+func ImportNode(cfg *types.Config, sub *types.ConfigSubModule, dir string, stateHash []byte, trusted bool, storeOnly bool) (*Manifest, error) {
+	r, err := Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	m := r.Manifest()
+	if m.Chain == "" || storeOnly {
+		return ImportStore(cfg.Store, sub.Store[cfg.Store.Name], dir, stateHash, trusted)
+	}
+	if !trusted {
+		return nil, ErrUntrustedSnapshot
+	}
+	if cfg.BlockChain.IsRecordBlockSequence || cfg.BlockChain.IsParaChain {
+		return nil, ErrChainSequence
+	}
+	chainDir, err := verifyChain(dir, m)
+	if err != nil {
+		return nil, err
+	}
+	db := dbm.NewDB("blockchain", cfg.BlockChain.Driver, cfg.BlockChain.DbPath, cfg.BlockChain.DbCache)
+	defer db.Close()
+	//先检查再导入 store, 避免导入了 store 以后才发现 blockchain 数据库不是空的
+	if !isEmpty(db) {
+		return nil, ErrChainNotEmpty
+	}
+	m, err = ImportStore(cfg.Store, sub.Store[cfg.Store.Name], dir, stateHash, trusted)
+	if err != nil {
+		return nil, err
+	}
+	_, err = ImportChain(db, chainDir)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+//verifyChain 校验区块链数据的分块, 以及区块链数据和状态快照是同一个区块的
+func verifyChain(dir string, m *Manifest) (string, error) {
+	chainDir := filepath.Join(dir, m.Chain)
+	cm, err := verifyChunks(chainDir)
+	if err != nil {
+		return "", err
+	}
+	if cm.Driver != ChainDriver || cm.Height != m.Height || cm.BlockHash != m.BlockHash || cm.StateHash != m.StateHash {
+		return "", ErrChainMismatch
+	}
+	return chainDir, nil
+}
@@ -0,0 +1,214 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/rpc/jsonclient"
+	drivers "github.com/33cn/chain33/system/store"
+	"github.com/33cn/chain33/types"
+	"github.com/spf13/cobra"
+)
+
+// Cmd 状态快照的命令
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Export, verify and import store state snapshot",
+		Args:  cobra.MinimumNArgs(1),
+	}
+	cmd.AddCommand(
+		ExportCmd(),
+		ExportNodeCmd(),
+		VerifyCmd(),
+		ImportCmd(),
+	)
+	return cmd
+}
+
+// ExportCmd 在运行中的节点上导出快照, 只包含 store 的状态
+func ExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the state after the block at height to a dir on the node",
+		Long: "Export the state after the block at height to a dir on the running node.\n" +
+			"The snapshot only contains the store state, use export-node on a stopped node to export\n" +
+			"a snapshot with the blockchain data, which a node can start syncing from.",
+		Run: exportSnapshot,
+	}
+	cmd.Flags().Int64P("height", "t", 0, "block height")
+	cmd.MarkFlagRequired("height")
+	cmd.Flags().StringP("dir", "d", "", "snapshot dir on the node, relative to the snapshot root (snapshotRoot in [store], default snapshot dir next to the store dbPath)")
+	cmd.MarkFlagRequired("dir")
+	cmd.Flags().IntP("chunk", "s", DefaultChunkSize, "number of kvs in a chunk")
+	return cmd
+}
+
+func exportSnapshot(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	height, _ := cmd.Flags().GetInt64("height")
+	dir, _ := cmd.Flags().GetString("dir")
+	chunk, _ := cmd.Flags().GetInt("chunk")
+	params := &ReqExportSnapshot{Height: height, Dir: dir, ChunkSize: chunk}
+	var res Manifest
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "snapshot.Export", params, &res)
+	ctx.SetResultCb(func(res interface{}) (interface{}, error) {
+		//分块列表比较长, 不打印
+		info := *res.(*Manifest)
+		info.Chunks = nil
+		return &info, nil
+	})
+	ctx.Run()
+}
+
+// ExportNodeCmd 在停止的节点上导出区块链数据和最新高度的状态
+func ExportNodeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-node",
+		Short: "Export the blockchain data and the state at the last height of a stopped node",
+		Run:   exportNode,
+	}
+	cmd.Flags().StringP("dir", "d", "", "snapshot dir")
+	cmd.MarkFlagRequired("dir")
+	cmd.Flags().StringP("config", "c", "chain33.toml", "config file of the node")
+	cmd.Flags().StringP("datadir", "p", "", "data dir of the node, override the blockchain and store dbPath in config")
+	cmd.Flags().IntP("chunk", "s", DefaultChunkSize, "number of kvs in a chunk")
+	return cmd
+}
+
+func exportNode(cmd *cobra.Command, args []string) {
+	dir, _ := cmd.Flags().GetString("dir")
+	chunk, _ := cmd.Flags().GetInt("chunk")
+	cfg, sub, err := loadNodeConfig(cmd)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	m, err := ExportNode(cfg, sub, dir, chunk)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	printManifest(m)
+}
+
+// VerifyCmd 校验本地快照目录中所有分块的 hash
+func VerifyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify chunks of a local snapshot",
+		Run:   verifySnapshot,
+	}
+	cmd.Flags().StringP("dir", "d", "", "snapshot dir")
+	cmd.MarkFlagRequired("dir")
+	return cmd
+}
+
+func verifySnapshot(cmd *cobra.Command, args []string) {
+	dir, _ := cmd.Flags().GetString("dir")
+	m, err := Verify(dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	printManifest(m)
+}
+
+// ImportCmd 把快照导入节点的空数据目录, 节点不能在运行
+func ImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import a snapshot into the empty data dir of a stopped node",
+		Long: "Import a snapshot into the empty data dir of a stopped node.\n" +
+			"A snapshot exported by export-node also contains the blockchain data, after importing it the node\n" +
+			"starts syncing from the snapshot height. The blocks before the last 1024 heights are not imported,\n" +
+			"the node can not serve them to peers or archive them into chunks, so set disableShard in [blockchain].\n" +
+			"Nodes with isRecordBlockSequence or isParaChain need all blocks from genesis and can only use --store_only.\n" +
+			"The blockchain data (executor local db) can not be verified, so it can only be imported with --trusted.\n" +
+			"mpt state is verified against the state hash, kvmvcc state only by chunk hashes,\n" +
+			"so it can only be imported with --trusted, when the snapshot comes from a trusted node.",
+		Run: importSnapshot,
+	}
+	cmd.Flags().StringP("dir", "d", "", "snapshot dir")
+	cmd.MarkFlagRequired("dir")
+	cmd.Flags().StringP("config", "c", "chain33.toml", "config file of the node")
+	cmd.Flags().StringP("datadir", "p", "", "data dir of the node, override the blockchain and store dbPath in config")
+	cmd.Flags().StringP("state_hash", "s", "", "expected state hash of the snapshot, from a trusted block header")
+	cmd.Flags().Bool("trusted", false, "trust the snapshot, required by the blockchain data and the stores which can not verify the state hash")
+	cmd.Flags().Bool("store_only", false, "only import the store state, the blockchain data must be restored separately")
+	return cmd
+}
+
+func importSnapshot(cmd *cobra.Command, args []string) {
+	dir, _ := cmd.Flags().GetString("dir")
+	stateHash, _ := cmd.Flags().GetString("state_hash")
+	trusted, _ := cmd.Flags().GetBool("trusted")
+	storeOnly, _ := cmd.Flags().GetBool("store_only")
+	var hash []byte
+	if stateHash != "" {
+		var err error
+		hash, err = common.FromHex(stateHash)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, types.ErrInvalidParam)
+			return
+		}
+	}
+	cfg, sub, err := loadNodeConfig(cmd)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	m, err := ImportNode(cfg, sub, dir, hash, trusted, storeOnly)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	printManifest(m)
+}
+
+//loadNodeConfig 读取节点的配置, datadir 不为空的时候 blockchain 和 store 的数据目录放在 datadir 下面
+func loadNodeConfig(cmd *cobra.Command) (*types.Config, *types.ConfigSubModule, error) {
+	config, _ := cmd.Flags().GetString("config")
+	datadir, _ := cmd.Flags().GetString("datadir")
+	data, err := ioutil.ReadFile(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	cfg, sub := types.InitCfgString(string(data))
+	if datadir != "" {
+		cfg.BlockChain.DbPath = filepath.Join(datadir, cfg.BlockChain.DbPath)
+		cfg.Store.DbPath = filepath.Join(datadir, cfg.Store.DbPath)
+	}
+	return cfg, sub, nil
+}
+
+// ImportStore 按照 store 的配置打开 store, 把快照导入以后关闭
+func ImportStore(cfg *types.Store, sub []byte, dir string, stateHash []byte, trusted bool) (*Manifest, error) {
+	create, err := drivers.Load(cfg.Name)
+	if err != nil {
+		return nil, err
+	}
+	s := create(cfg, sub, nil)
+	defer s.Close()
+	importer, ok := s.(Importer)
+	if !ok {
+		return nil, types.ErrActionNotSupport
+	}
+	return Import(importer, cfg.Name, dir, stateHash, trusted)
+}
+
+//printManifest 打印快照的信息, 不打印分块列表
+func printManifest(m *Manifest) {
+	info := *m
+	info.Chunks = nil
+	data, err := json.MarshalIndent(&info, "", "    ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	fmt.Println(string(data))
+}
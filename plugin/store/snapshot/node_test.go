@@ -0,0 +1,97 @@
+package snapshot_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/33cn/chain33/common"
+	_ "github.com/33cn/chain33/system"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/chain33/util"
+	"github.com/33cn/chain33/util/testnode"
+	_ "github.com/33cn/plugin/plugin/store/kvmvcc"
+	"github.com/33cn/plugin/plugin/store/snapshot"
+	"github.com/stretchr/testify/assert"
+)
+
+//newNodeConfig 节点的数据库放在 dir 中, testnode 关闭的时候只删除自己的临时目录, 不删除 dir
+func newNodeConfig(dir string) (*types.Chain33Config, *types.Config, *types.ConfigSubModule) {
+	cfgstr := strings.Replace(types.GetDefaultCfgstring(), `name="mavl"`, `name="kvmvcc"`, 1)
+	cfg := types.NewChain33Config(cfgstr)
+	//testnode 的数据目录和 dir 都在系统的临时目录下面
+	mcfg := cfg.GetModuleConfig()
+	mcfg.BlockChain.DbPath = filepath.Join("..", filepath.Base(dir), "blockchain")
+	mcfg.Store.DbPath = filepath.Join("..", filepath.Base(dir), "store")
+	mcfg.BlockChain.IsRecordBlockSequence = false
+	mcfg.BlockChain.DisableShard = true
+	//导入和导出的时候不启动节点, 直接使用 dir 中的数据库
+	ncfg, sub := types.InitCfgString(cfgstr)
+	ncfg.BlockChain.DbPath = filepath.Join(dir, "blockchain")
+	ncfg.Store.DbPath = filepath.Join(dir, "store")
+	ncfg.BlockChain.IsRecordBlockSequence = false
+	return cfg, ncfg, sub
+}
+
+func TestExportImportNode(t *testing.T) {
+	dirA, err := ioutil.TempDir("", "snapshot-node-a")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dirA)
+	dirB, err := ioutil.TempDir("", "snapshot-node-b")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dirB)
+	snapdir, err := ioutil.TempDir("", "snapshot-node")
+	assert.Nil(t, err)
+	defer os.RemoveAll(snapdir)
+
+	cfgA, ncfgA, subA := newNodeConfig(dirA)
+	mockA := testnode.NewWithConfig(cfgA, nil)
+	to := "1JRNjdEqp4LJ5fqycUBm9ayCKSeeskgMKR"
+	var hashes [][]byte
+	for i := 0; i < 3; i++ {
+		tx := util.CreateCoinsTx(cfgA, mockA.GetGenesisKey(), to, types.Coin)
+		hashes = append(hashes, mockA.SendTx(tx))
+		assert.Nil(t, mockA.WaitHeight(int64(i+1)))
+	}
+	last := mockA.GetLastBlock()
+	balance := mockA.GetAccount(last.StateHash, to).Balance
+	assert.Equal(t, 3*types.Coin, balance)
+	mockA.Close()
+
+	m, err := snapshot.ExportNode(ncfgA, subA, snapdir, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, last.Height, m.Height)
+	assert.Equal(t, common.ToHex(last.Hash(cfgA)), m.BlockHash)
+	assert.Equal(t, snapshot.ChainDir, m.Chain)
+	_, err = snapshot.Verify(snapdir)
+	assert.Nil(t, err)
+
+	cfgB, ncfgB, subB := newNodeConfig(dirB)
+	_, err = snapshot.ImportNode(ncfgB, subB, snapdir, nil, false, false)
+	assert.Equal(t, snapshot.ErrUntrustedSnapshot, err)
+	ncfgB.BlockChain.IsRecordBlockSequence = true
+	_, err = snapshot.ImportNode(ncfgB, subB, snapdir, nil, true, false)
+	assert.Equal(t, snapshot.ErrChainSequence, err)
+	ncfgB.BlockChain.IsRecordBlockSequence = false
+	_, err = snapshot.ImportNode(ncfgB, subB, snapdir, last.StateHash, true, false)
+	assert.Nil(t, err)
+	_, err = snapshot.ImportNode(ncfgB, subB, snapdir, nil, true, false)
+	assert.Equal(t, snapshot.ErrChainNotEmpty, err)
+
+	//导入以后的节点从快照的高度开始, 能够查询快照之前的交易, 并且在快照的状态上继续打包区块
+	mockB := testnode.NewWithConfig(cfgB, nil)
+	defer mockB.Close()
+	assert.Equal(t, last.Height, mockB.GetBlockChain().GetBlockHeight())
+	assert.Equal(t, balance, mockB.GetAccount(last.StateHash, to).Balance)
+	detail, err := mockB.GetAPI().QueryTx(&types.ReqHash{Hash: hashes[0]})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(types.ExecOk), detail.Receipt.Ty)
+	tx := util.CreateCoinsTx(cfgB, mockB.GetGenesisKey(), to, types.Coin)
+	mockB.SendTx(tx)
+	assert.Nil(t, mockB.WaitHeight(last.Height+1))
+	block := mockB.GetLastBlock()
+	assert.Equal(t, last.Height+1, block.Height)
+	assert.Equal(t, balance+types.Coin, mockB.GetAccount(block.StateHash, to).Balance)
+}
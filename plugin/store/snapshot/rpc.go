@@ -0,0 +1,111 @@
+package snapshot
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"github.com/33cn/chain33/client"
+	"github.com/33cn/chain33/queue"
+	rpctypes "github.com/33cn/chain33/rpc/types"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/common/rpcplugin"
+)
+
+func init() {
+	rpcplugin.Register("store-snapshot", func(s rpctypes.RPCServer) {
+		s.JRPC().RegisterName("snapshot", &Jrpc{client: s.GetQueueClient()})
+	}, Cmd)
+}
+
+// Jrpc 快照的 json rpc 接口
+type Jrpc struct {
+	client queue.Client
+}
+
+// ErrSnapshotDir 快照目录必须是快照根目录下面的相对路径
+var ErrSnapshotDir = errors.New("ErrSnapshotDir")
+
+// ReqExportSnapshot 导出 Height 高度的状态到节点上快照根目录下面的 Dir 目录
+type ReqExportSnapshot struct {
+	Height    int64  `json:"height"`
+	Dir       string `json:"dir"`
+	ChunkSize int    `json:"chunkSize"`
+}
+
+// Export 导出区块 Height 执行以后的状态, 返回快照的 manifest, 只有 store 支持快照的时候可以导出。
+// 快照只包含 store 的状态, 包含区块链数据的快照需要在停止的节点上用 snapshot export-node 导出
+func (c *Jrpc) Export(in *ReqExportSnapshot, result *interface{}) error {
+	if in == nil || in.Height < 0 || in.Dir == "" {
+		return types.ErrInvalidParam
+	}
+	api, err := client.New(c.client, nil)
+	if err != nil {
+		return err
+	}
+	headers, err := api.GetHeaders(&types.ReqBlocks{Start: in.Height, End: in.Height})
+	if err != nil {
+		return err
+	}
+	if len(headers.GetItems()) == 0 {
+		return types.ErrBlockNotFound
+	}
+	header := headers.Items[0]
+	dir, err := snapshotDir(c.client.GetConfig(), in.Dir)
+	if err != nil {
+		return err
+	}
+	req := &ReqExport{
+		Dir:       dir,
+		Driver:    c.client.GetConfig().GetModuleConfig().Store.Name,
+		Height:    header.Height,
+		BlockHash: header.Hash,
+		StateHash: header.StateHash,
+		ChunkSize: in.ChunkSize,
+	}
+	msg := c.client.NewMessage("store", EventStoreExportSnapshot, req)
+	err = c.client.Send(msg, true)
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.Wait(msg)
+	if err != nil {
+		return err
+	}
+	switch data := resp.GetData().(type) {
+	case *Manifest:
+		*result = data
+		return nil
+	case *types.Reply:
+		return errors.New(string(data.GetMsg()))
+	case error:
+		return data
+	}
+	return types.ErrTypeAsset
+}
+
+//snapshotRoot 快照根目录, 通过 [store] 的 snapshotRoot 配置, 默认是 store 数据目录旁边的 snapshot 目录
+func snapshotRoot(cfg *types.Chain33Config) string {
+	root := types.Conf(cfg, "config.store").GStr("snapshotRoot")
+	if root == "" {
+		root = filepath.Join(filepath.Dir(cfg.GetModuleConfig().Store.DbPath), "snapshot")
+	}
+	return root
+}
+
+//snapshotDir rpc 只能把快照导出到快照根目录下面, 不接受绝对路径和包含 .. 的路径
+func snapshotDir(cfg *types.Chain33Config, dir string) (string, error) {
+	if dir == "" || filepath.IsAbs(dir) || strings.HasPrefix(dir, "/") || strings.HasPrefix(dir, "\\") {
+		return "", ErrSnapshotDir
+	}
+	for _, name := range strings.FieldsFunc(dir, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if name == ".." {
+			return "", ErrSnapshotDir
+		}
+	}
+	dir = filepath.Clean(dir)
+	if dir == "." {
+		return "", ErrSnapshotDir
+	}
+	return filepath.Join(snapshotRoot(cfg), dir), nil
+}
@@ -0,0 +1,236 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package snapshot store 的状态快照, 用来恢复 store 中某个高度的全部状态, 不需要重放整条链来重建状态。
+// 快照是一个目录, 包含 manifest.json 和若干个分块文件,
+// 每个分块是按照 key 顺序排列的一批状态(types.LocalDBSet 的编码), manifest 中记录每个分块的 sha256。
+// 在运行中的节点上通过 rpc 导出的快照只包含 store 的状态;
+// 在停止的节点上导出的快照还在 chain 子目录中包含区块链数据(见 ExportNode), 导入以后节点从快照的高度开始同步
+package snapshot
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	log "github.com/33cn/chain33/common/log/log15"
+	"github.com/33cn/chain33/types"
+)
+
+var slog = log.New("module", "store.snapshot")
+
+const (
+	// Version 快照格式的版本
+	Version = 1
+	// ManifestFile 快照目录中描述快照的文件
+	ManifestFile = "manifest.json"
+	// DefaultChunkSize 默认每个分块中状态的数量
+	DefaultChunkSize = 10000
+)
+
+var (
+	// ErrSnapshotExist 快照目录中已经有快照了
+	ErrSnapshotExist = errors.New("ErrSnapshotExist")
+	// ErrSnapshotVersion 不支持的快照格式版本
+	ErrSnapshotVersion = errors.New("ErrSnapshotVersion")
+	// ErrChunkHash 分块的 hash 和 manifest 中记录的不一致
+	ErrChunkHash = errors.New("ErrChunkHash")
+	// ErrChunkCount 分块或者快照中状态的数量和 manifest 中记录的不一致
+	ErrChunkCount = errors.New("ErrChunkCount")
+)
+
+// Chunk manifest 中一个分块的信息
+type Chunk struct {
+	File  string `json:"file"`
+	Count int    `json:"count"`
+	//分块文件内容的 sha256, hex 编码
+	Hash string `json:"hash"`
+}
+
+// Manifest 快照的描述, StateHash 是区块头中的状态 hash, 导入的时候用来校验
+type Manifest struct {
+	Version   int      `json:"version"`
+	Driver    string   `json:"driver"`
+	Height    int64    `json:"height"`
+	BlockHash string   `json:"blockHash"`
+	StateHash string   `json:"stateHash"`
+	Total     int64    `json:"total"`
+	Chunks    []*Chunk `json:"chunks"`
+	//区块链数据快照的子目录, 只有 store 状态的快照为空
+	Chain string `json:"chain,omitempty"`
+}
+
+// Writer 把状态按顺序分块写入快照目录, 最后写入 manifest, 没有 manifest 的目录不是完整的快照
+type Writer struct {
+	dir       string
+	chunkSize int
+	kvs       []*types.KeyValue
+	chunks    []*Chunk
+	total     int64
+}
+
+// NewWriter 创建快照目录, 目录中已经有快照的时候报错
+func NewWriter(dir string, chunkSize int) (*Writer, error) {
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	if _, err := os.Stat(filepath.Join(dir, ManifestFile)); err == nil {
+		return nil, ErrSnapshotExist
+	}
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{dir: dir, chunkSize: chunkSize}, nil
+}
+
+// Add 添加一个状态, 满一个分块的时候写入文件
+func (w *Writer) Add(key, value []byte) error {
+	kv := &types.KeyValue{Key: append([]byte{}, key...), Value: append([]byte{}, value...)}
+	w.kvs = append(w.kvs, kv)
+	if len(w.kvs) >= w.chunkSize {
+		return w.flush()
+	}
+	return nil
+}
+
+func (w *Writer) flush() error {
+	if len(w.kvs) == 0 {
+		return nil
+	}
+	data := types.Encode(&types.LocalDBSet{KV: w.kvs})
+	sum := sha256.Sum256(data)
+	chunk := &Chunk{File: fmt.Sprintf("chunk-%06d.dat", len(w.chunks)), Count: len(w.kvs), Hash: hex.EncodeToString(sum[:])}
+	err := ioutil.WriteFile(filepath.Join(w.dir, chunk.File), data, 0644)
+	if err != nil {
+		return err
+	}
+	w.chunks = append(w.chunks, chunk)
+	w.total += int64(len(w.kvs))
+	w.kvs = nil
+	return nil
+}
+
+// Close 写入剩下的状态和 manifest, m 中的 Driver, Height, BlockHash, StateHash 由调用者填写
+func (w *Writer) Close(m *Manifest) (*Manifest, error) {
+	err := w.flush()
+	if err != nil {
+		return nil, err
+	}
+	m.Version = Version
+	m.Total = w.total
+	m.Chunks = w.chunks
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	//先写临时文件再改名, 避免留下不完整的 manifest
+	tmp := filepath.Join(w.dir, ManifestFile+".tmp")
+	err = ioutil.WriteFile(tmp, data, 0644)
+	if err != nil {
+		return nil, err
+	}
+	err = os.Rename(tmp, filepath.Join(w.dir, ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Reader 按顺序读取快照中的分块, 每个分块都会校验 hash
+type Reader struct {
+	dir      string
+	manifest *Manifest
+	next     int
+	total    int64
+}
+
+// Open 打开快照目录, 读取 manifest
+func Open(dir string) (*Reader, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	err = json.Unmarshal(data, &m)
+	if err != nil {
+		return nil, err
+	}
+	if m.Version != Version {
+		return nil, ErrSnapshotVersion
+	}
+	return &Reader{dir: dir, manifest: &m}, nil
+}
+
+// Manifest 快照的描述
+func (r *Reader) Manifest() *Manifest {
+	return r.manifest
+}
+
+// Next 返回下一个分块中的状态, 所有分块都读完以后返回 nil
+func (r *Reader) Next() ([]*types.KeyValue, error) {
+	if r.next >= len(r.manifest.Chunks) {
+		if r.total != r.manifest.Total {
+			return nil, ErrChunkCount
+		}
+		return nil, nil
+	}
+	chunk := r.manifest.Chunks[r.next]
+	data, err := ioutil.ReadFile(filepath.Join(r.dir, chunk.File))
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != chunk.Hash {
+		slog.Error("snapshot chunk hash not match", "file", chunk.File)
+		return nil, ErrChunkHash
+	}
+	var kvs types.LocalDBSet
+	err = types.Decode(data, &kvs)
+	if err != nil {
+		return nil, err
+	}
+	if len(kvs.KV) != chunk.Count {
+		return nil, ErrChunkCount
+	}
+	r.next++
+	r.total += int64(len(kvs.KV))
+	return kvs.KV, nil
+}
+
+// Verify 校验快照中所有分块的 hash 和数量, 包含区块链数据的时候同时校验区块链数据
+func Verify(dir string) (*Manifest, error) {
+	m, err := verifyChunks(dir)
+	if err != nil {
+		return nil, err
+	}
+	if m.Chain != "" {
+		_, err = verifyChain(dir, m)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func verifyChunks(dir string) (*Manifest, error) {
+	r, err := Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		kvs, err := r.Next()
+		if err != nil {
+			return nil, err
+		}
+		if kvs == nil {
+			return r.Manifest(), nil
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package snapshot
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/33cn/chain33/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriterReader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir) // clean up
+
+	w, err := NewWriter(dir, 2)
+	assert.Nil(t, err)
+	for i := 0; i < 5; i++ {
+		assert.Nil(t, w.Add([]byte(fmt.Sprintf("k%d", i)), []byte(fmt.Sprintf("v%d", i))))
+	}
+	m, err := w.Close(&Manifest{Driver: "mpt", Height: 10, StateHash: "0x01"})
+	assert.Nil(t, err)
+	assert.Equal(t, Version, m.Version)
+	assert.Equal(t, int64(5), m.Total)
+	assert.Equal(t, 3, len(m.Chunks))
+	//已经有快照的目录不能再写入
+	_, err = NewWriter(dir, 2)
+	assert.Equal(t, ErrSnapshotExist, err)
+
+	r, err := Open(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), r.Manifest().Height)
+	var kvs []*types.KeyValue
+	for {
+		chunk, err := r.Next()
+		assert.Nil(t, err)
+		if chunk == nil {
+			break
+		}
+		kvs = append(kvs, chunk...)
+	}
+	assert.Equal(t, 5, len(kvs))
+	assert.Equal(t, []byte("k4"), kvs[4].Key)
+	assert.Equal(t, []byte("v4"), kvs[4].Value)
+
+	//篡改分块
+	_, err = Verify(dir)
+	assert.Nil(t, err)
+	file := filepath.Join(dir, m.Chunks[1].File)
+	data, err := ioutil.ReadFile(file)
+	assert.Nil(t, err)
+	data[len(data)-1]++
+	assert.Nil(t, ioutil.WriteFile(file, data, 0644))
+	_, err = Verify(dir)
+	assert.Equal(t, ErrChunkHash, err)
+}
+
+func TestSnapshotDir(t *testing.T) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	root := filepath.Join(filepath.Dir(cfg.GetModuleConfig().Store.DbPath), "snapshot")
+	dir, err := snapshotDir(cfg, "h100")
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(root, "h100"), dir)
+	dir, err = snapshotDir(cfg, "a/./b/")
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(root, "a", "b"), dir)
+	for _, dir := range []string{"", ".", "/tmp/s", "../s", "a/../../s", "a/..", "a\\..\\..\\s"} {
+		_, err = snapshotDir(cfg, dir)
+		assert.Equal(t, ErrSnapshotDir, err, dir)
+	}
+
+	cfg = types.NewChain33Config(strings.Replace(types.GetDefaultCfgstring(), "[store]", "[store]\nsnapshotRoot=\"/data/snapshot\"", 1))
+	dir, err = snapshotDir(cfg, "h100")
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join("/data/snapshot", "h100"), dir)
+}
@@ -0,0 +1,146 @@
+package snapshot
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/33cn/chain33/common"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/types"
+)
+
+// store 模块的事件编号, 和系统以及 mpt 的事件编号分开
+const (
+	// EventStoreExportSnapshot 导出状态快照
+	EventStoreExportSnapshot = 10003
+	// EventStoreExportSnapshotReply EventStoreExportSnapshot 的返回
+	EventStoreExportSnapshotReply = 10004
+)
+
+var (
+	// ErrDriverMismatch 快照和 store 的类型不一致
+	ErrDriverMismatch = errors.New("ErrDriverMismatch")
+	// ErrStateHashMismatch 快照的状态 hash 和期望的不一致
+	ErrStateHashMismatch = errors.New("ErrStateHashMismatch")
+	// ErrStoreNotEmpty 只能把快照导入空的 store
+	ErrStoreNotEmpty = errors.New("ErrStoreNotEmpty")
+	// ErrUntrustedSnapshot 不能根据状态校验状态 hash 的快照, 需要明确指定信任快照才能导入
+	ErrUntrustedSnapshot = errors.New("ErrUntrustedSnapshot")
+)
+
+// Exporter 支持导出快照的 store
+type Exporter interface {
+	// ExportState 按照 key 的顺序遍历 stateHash 对应的全部状态, fn 返回错误的时候停止遍历
+	ExportState(stateHash []byte, fn func(key, value []byte) error) error
+}
+
+// Importer 支持从快照恢复状态的 store
+type Importer interface {
+	GetDB() dbm.DB
+	// ImportState 把快照中的状态写入空的 store, 作为高度 height, 状态 hash 为 stateHash 的状态;
+	// next 每次返回一批状态, 返回 nil 表示结束。能够根据状态计算状态 hash 的 store 需要校验 stateHash
+	ImportState(height int64, stateHash []byte, next func() ([]*types.KeyValue, error)) error
+}
+
+// StateVerifier 导入的时候会根据全部状态重新计算并校验状态 hash 的 store
+type StateVerifier interface {
+	VerifyStateHash() bool
+}
+
+// ReqExport 导出 StateHash 对应的状态到节点上的 Dir 目录
+type ReqExport struct {
+	Dir       string
+	Driver    string
+	Height    int64
+	BlockHash []byte
+	StateHash []byte
+	ChunkSize int
+	//已经导出到快照目录中的区块链数据的子目录
+	Chain string
+}
+
+// Export 导出快照
+func Export(s Exporter, req *ReqExport) (*Manifest, error) {
+	if req == nil || req.Dir == "" || len(req.StateHash) == 0 {
+		return nil, types.ErrInvalidParam
+	}
+	w, err := NewWriter(req.Dir, req.ChunkSize)
+	if err != nil {
+		return nil, err
+	}
+	err = s.ExportState(req.StateHash, w.Add)
+	if err != nil {
+		return nil, err
+	}
+	m, err := w.Close(&Manifest{
+		Driver:    req.Driver,
+		Height:    req.Height,
+		BlockHash: common.ToHex(req.BlockHash),
+		StateHash: common.ToHex(req.StateHash),
+		Chain:     req.Chain,
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("export snapshot", "dir", req.Dir, "height", m.Height, "total", m.Total, "chunks", len(m.Chunks))
+	return m, nil
+}
+
+// Import 把 dir 中的快照导入类型为 driver 的空 store, stateHash 不为空的时候要求和快照的状态 hash 一致。
+// 不能校验状态 hash 的 store(比如 kvmvcc), 分块 hash 只能发现快照损坏, 不能证明状态是正确的,
+// 只有 trusted 为 true, 也就是快照来自可信的节点的时候才能导入
+func Import(s Importer, driver string, dir string, stateHash []byte, trusted bool) (*Manifest, error) {
+	if v, ok := s.(StateVerifier); !trusted && (!ok || !v.VerifyStateHash()) {
+		return nil, ErrUntrustedSnapshot
+	}
+	r, err := Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	m := r.Manifest()
+	if m.Driver != driver {
+		return nil, ErrDriverMismatch
+	}
+	hash, err := common.FromHex(m.StateHash)
+	if err != nil {
+		return nil, err
+	}
+	if len(stateHash) > 0 && !bytes.Equal(stateHash, hash) {
+		return nil, ErrStateHashMismatch
+	}
+	if !isEmpty(s.GetDB()) {
+		return nil, ErrStoreNotEmpty
+	}
+	err = s.ImportState(m.Height, hash, r.Next)
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("import snapshot", "dir", dir, "height", m.Height, "total", m.Total)
+	return m, nil
+}
+
+func isEmpty(db dbm.DB) bool {
+	it := db.Iterator(nil, nil, false)
+	defer it.Close()
+	it.Rewind()
+	return !it.Valid()
+}
+
+// ProcExport 处理 EventStoreExportSnapshot 事件, 导出的时间可能比较长, 在单独的 goroutine 中执行, 不阻塞 store 的其他事件
+func ProcExport(client queue.Client, msg *queue.Message, s Exporter) {
+	req, ok := msg.GetData().(*ReqExport)
+	if !ok {
+		msg.ReplyErr("Store", types.ErrInvalidParam)
+		return
+	}
+	go func() {
+		m, err := Export(s, req)
+		if err != nil {
+			slog.Error("export snapshot", "dir", req.Dir, "height", req.Height, "err", err)
+			msg.ReplyErr("Store", err)
+			return
+		}
+		msg.Reply(client.NewMessage("", EventStoreExportSnapshotReply, m))
+	}()
+}